@@ -9,11 +9,14 @@ import (
 	"regexp"
 )
 
+// CIDRPattern is the regular expression which values validated by CIDR must match
+const CIDRPattern = `^([0-9]{1,3}\.){3}[0-9]{1,3}(/([0-9]|[1-2][0-9]|3[0-2]))?$`
+
 // CIDR is a SchemaValidateFunc which tests if the provided value is a valid IPv4 CIDR
 func CIDR(i interface{}, k string) (warnings []string, errors []error) {
 	cidr := i.(string)
 
-	re := regexp.MustCompile(`^([0-9]{1,3}\.){3}[0-9]{1,3}(/([0-9]|[1-2][0-9]|3[0-2]))?$`)
+	re := regexp.MustCompile(CIDRPattern)
 	if re != nil && !re.MatchString(cidr) {
 		errors = append(errors, fmt.Errorf("%s must start with IPV4 address and/or slash, number of bits (0-32) as prefix. Example: 127.0.0.1/8. Got %q.", k, cidr))
 	}
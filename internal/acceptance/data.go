@@ -64,6 +64,8 @@ type TestData struct {
 
 // BuildTestData generates some test data for the given resource
 func BuildTestData(t *testing.T, resourceType string, resourceLabel string) TestData {
+	applyReplayEnvironmentDefaults()
+
 	env, err := Environment()
 	if err != nil {
 		t.Fatalf("Error retrieving Environment: %+v", err)
@@ -96,6 +98,8 @@ func BuildTestData(t *testing.T, resourceType string, resourceLabel string) Test
 		Secondary: os.Getenv("ARM_TEST_SUBSCRIPTION_ID_ALT"),
 	}
 
+	startRecording(t, testData)
+
 	return testData
 }
 
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakearm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	authWrapper "github.com/hashicorp/go-azure-sdk/sdk/auth/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"golang.org/x/oauth2"
+)
+
+// Client returns a clients.Client which sends Resource Manager requests to the fake, for use with the
+// Create/Read/Update/Delete functions of a Resource
+//
+// Only the Resource Manager endpoint is replaced, as such requests made to Data Plane APIs (e.g. Key Vault)
+// aren't supported.
+func (s *Server) Client(ctx context.Context) (*clients.Client, error) {
+	env := environments.AzurePublic()
+	env.ResourceManager = environments.ResourceManagerAPI(s.URL)
+
+	// TODO: remove when `Azure/go-autorest` is no longer used
+	azureEnvironment := azure.PublicCloud
+	azureEnvironment.ResourceManagerEndpoint = s.URL + "/"
+
+	authorizer := staticAuthorizer{}
+	client := clients.Client{
+		Account: &clients.ResourceManagerAccount{
+			Environment:                      *env,
+			ClientId:                         defaultPrincipalId,
+			ObjectId:                         defaultPrincipalId,
+			SubscriptionId:                   s.SubscriptionId,
+			TenantId:                         s.TenantId,
+			AuthenticatedAsAServicePrincipal: true,
+			SkipResourceProviderRegistration: true,
+			AzureEnvironment:                 azureEnvironment,
+		},
+	}
+
+	o := &common.ClientOptions{
+		Authorizers: &common.Authorizers{
+			BatchManagement: authorizer,
+			KeyVault:        authorizer,
+			ManagedHSM:      authorizer,
+			ResourceManager: authorizer,
+			Storage:         authorizer,
+			Synapse:         authorizer,
+			AuthorizerFunc: func(api environments.Api) (auth.Authorizer, error) {
+				return authorizer, nil
+			},
+		},
+
+		Environment: *env,
+		Features:    features.Default(),
+
+		SubscriptionId:   s.SubscriptionId,
+		TenantId:         s.TenantId,
+		TerraformVersion: "0.0.0",
+
+		DisableTerraformPartnerID: true,
+		SkipProviderReg:           true,
+
+		BatchManagementAuthorizer: authWrapper.AutorestAuthorizer(authorizer),
+		KeyVaultAuthorizer:        authWrapper.AutorestAuthorizer(authorizer).BearerAuthorizerCallback(),
+		ManagedHSMAuthorizer:      authWrapper.AutorestAuthorizer(authorizer).BearerAuthorizerCallback(),
+		ResourceManagerAuthorizer: authWrapper.AutorestAuthorizer(authorizer),
+		SynapseAuthorizer:         authWrapper.AutorestAuthorizer(authorizer),
+
+		// TODO: remove when `Azure/go-autorest` is no longer used
+		AzureEnvironment:        azureEnvironment,
+		ResourceManagerEndpoint: azureEnvironment.ResourceManagerEndpoint,
+	}
+
+	if err := client.Build(ctx, o); err != nil {
+		return nil, fmt.Errorf("building Client for the fake Resource Manager API: %+v", err)
+	}
+
+	return &client, nil
+}
+
+// staticAuthorizer is an auth.Authorizer returning a static access token, since the fake doesn't validate these
+type staticAuthorizer struct{}
+
+func (staticAuthorizer) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	return &oauth2.Token{
+		AccessToken: "fakearm",
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(time.Hour),
+	}, nil
+}
+
+func (staticAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return []*oauth2.Token{}, nil
+}
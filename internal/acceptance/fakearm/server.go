@@ -0,0 +1,562 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package fakearm provides an in-process fake of the Azure Resource Manager API, which allows the Create, Read,
+// Update and Delete functions of a Resource (and the Resource Provider registration logic) to be exercised using
+// `go test` - without connecting to (or creating resources in) Azure.
+//
+// The fake is intentionally generic, rather than emulating any specific Resource Provider: the body of a PUT is
+// stored as-is (with the `id`, `name`, `type` and `properties.provisioningState` fields populated) and returned
+// from subsequent GET requests, PATCH requests are merged into the stored resource, and DELETE requests remove
+// the resource (and any nested resources). Requests for a resource which doesn't exist return a 404 using the
+// same error format as Resource Manager.
+//
+// NOTE: the pollers within `hashicorp/go-azure-sdk` wait 10s prior to checking whether a Delete has completed
+// (or polling the `provisioningState` of a resource) regardless of any `Retry-After` header - as such tests using
+// these pollers will take at least this long to run.
+package fakearm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	// operationsPath is the path used for the long-running operations returned by the fake
+	operationsPath = "/fakearm/operations/"
+
+	defaultPrincipalId    = "00000000-0000-0000-0000-000000000000"
+	defaultSubscriptionId = "00000000-0000-0000-0000-000000000000"
+	defaultTenantId       = "00000000-0000-0000-0000-000000000000"
+)
+
+type Options struct {
+	// SubscriptionId is the ID of the Subscription exposed by the fake, which defaults to an empty UUID
+	SubscriptionId string
+
+	// TenantId is the ID of the Tenant exposed by the fake, which defaults to an empty UUID
+	TenantId string
+
+	// LongRunningOperations specifies whether PUT, PATCH and DELETE requests should return an `Azure-AsyncOperation`
+	// (and `Location`) header to be polled - the status codes returned match those of synchronous requests, other than
+	// for DELETE requests which return a 202 Accepted
+	LongRunningOperations bool
+
+	// ResourceProviders maps the namespace of each Resource Provider exposed by the fake (e.g. `Microsoft.Compute`)
+	// to its initial registration state (e.g. `Registered` or `NotRegistered`)
+	ResourceProviders map[string]string
+
+	// ForbiddenResourceProviders are the namespaces of the Resource Providers (within ResourceProviders) which
+	// the caller doesn't have permission to register, where registering these returns a 403 Forbidden
+	ForbiddenResourceProviders []string
+}
+
+// Request is a request received by the fake, which can be used to assert the requests made by a Resource
+type Request struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+type Server struct {
+	// URL is the base URL of the fake, which is used as the Resource Manager endpoint
+	URL string
+
+	SubscriptionId string
+	TenantId       string
+
+	longRunningOperations bool
+	server                *httptest.Server
+
+	lock sync.Mutex
+
+	// operations maps the ID of each long-running operation to the number of times it's been polled
+	operations         map[string]int
+	operationSeq       int
+	providers          map[string]string
+	forbiddenProviders map[string]struct{}
+	requests           []Request
+	resources          map[string]map[string]interface{}
+	resourceOrder      []string
+}
+
+// New starts a fake Resource Manager API, which is stopped once the test has completed
+func New(t *testing.T, options Options) *Server {
+	t.Helper()
+
+	s := &Server{
+		SubscriptionId:        options.SubscriptionId,
+		TenantId:              options.TenantId,
+		longRunningOperations: options.LongRunningOperations,
+		operations:            map[string]int{},
+		providers:             map[string]string{},
+		forbiddenProviders:    map[string]struct{}{},
+		requests:              make([]Request, 0),
+		resources:             map[string]map[string]interface{}{},
+	}
+	if s.SubscriptionId == "" {
+		s.SubscriptionId = defaultSubscriptionId
+	}
+	if s.TenantId == "" {
+		s.TenantId = defaultTenantId
+	}
+	for k, v := range options.ResourceProviders {
+		s.providers[k] = v
+	}
+	for _, v := range options.ForbiddenResourceProviders {
+		s.forbiddenProviders[strings.ToLower(v)] = struct{}{}
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	s.URL = s.server.URL
+	t.Cleanup(s.server.Close)
+
+	return s
+}
+
+// Requests returns the requests received by the fake, in the order they were received
+func (s *Server) Requests() []Request {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	out := make([]Request, len(s.requests))
+	copy(out, s.requests)
+	return out
+}
+
+// Resource returns the resource stored for the specified Resource ID, if it exists
+func (s *Server) Resource(id string) (map[string]interface{}, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	resource, ok := s.resources[strings.ToLower(id)]
+	if !ok {
+		return nil, false
+	}
+	return copyObject(resource), true
+}
+
+// PutResource stores the resource for the specified Resource ID, for example to test that a Resource which
+// already exists must be imported
+func (s *Server) PutResource(id string, resource map[string]interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.putResource(id, copyObject(resource))
+}
+
+// ResourceProviderRegistrationState returns the registration state of the specified Resource Provider
+func (s *Server) ResourceProviderRegistrationState(namespace string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if name, ok := s.providerName(namespace); ok {
+		return s.providers[name]
+	}
+	return ""
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", fmt.Sprintf("reading the request body: %+v", err))
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Body:   string(body),
+	})
+
+	if r.Header.Get("Authorization") == "" {
+		writeError(w, http.StatusUnauthorized, "AuthenticationFailed", "Authentication failed. The 'Authorization' header is missing.")
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, operationsPath) {
+		s.handleOperation(w, r)
+		return
+	}
+
+	if r.URL.Query().Get("api-version") == "" {
+		writeError(w, http.StatusBadRequest, "MissingApiVersionParameter", "The api-version query parameter (?api-version=) is required for all requests.")
+		return
+	}
+
+	segments := pathSegments(r.URL.Path)
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "subscriptions") {
+		writeError(w, http.StatusNotFound, "InvalidResourceType", fmt.Sprintf("The resource type could not be found for the path %q.", r.URL.Path))
+		return
+	}
+	if !strings.EqualFold(segments[1], s.SubscriptionId) {
+		writeError(w, http.StatusNotFound, "SubscriptionNotFound", fmt.Sprintf("The subscription '%s' could not be found.", segments[1]))
+		return
+	}
+
+	// Resource Providers are scoped to the Subscription, e.g. `/subscriptions/{id}/providers/{namespace}`
+	if len(segments) >= 3 && len(segments) <= 5 && strings.EqualFold(segments[2], "providers") {
+		s.handleResourceProvider(w, r, segments)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if len(segments)%2 == 1 {
+			s.handleList(w, r)
+			return
+		}
+		s.handleGet(w, r)
+
+	case http.MethodPut:
+		s.handlePut(w, r, body)
+
+	case http.MethodPatch:
+		s.handlePatch(w, r, body)
+
+	case http.MethodDelete:
+		s.handleDelete(w, r)
+
+	default:
+		writeError(w, http.StatusNotImplemented, "NotImplemented", fmt.Sprintf("The fake Resource Manager API doesn't support %s requests to %q.", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	resource, ok := s.resources[strings.ToLower(r.URL.Path)]
+	if !ok {
+		writeNotFound(w, r.URL.Path)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resource)
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	prefix := strings.ToLower(r.URL.Path) + "/"
+
+	items := make([]interface{}, 0)
+	for _, key := range s.resourceOrder {
+		if strings.HasPrefix(key, prefix) && !strings.Contains(strings.TrimPrefix(key, prefix), "/") {
+			items = append(items, s.resources[key])
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"value": items,
+	})
+}
+
+func (s *Server) handlePut(w http.ResponseWriter, r *http.Request, body []byte) {
+	resource := map[string]interface{}{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &resource); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestContent", fmt.Sprintf("The request content was invalid and could not be deserialized: %+v", err))
+			return
+		}
+	}
+
+	if parentId := parentResourceId(r.URL.Path); parentId != "" {
+		if _, ok := s.resources[strings.ToLower(parentId)]; !ok {
+			writeError(w, http.StatusNotFound, "ParentResourceNotFound", fmt.Sprintf("Can not perform requested operation on nested resource. Parent resource '%s' not found.", parentId))
+			return
+		}
+	}
+
+	_, exists := s.resources[strings.ToLower(r.URL.Path)]
+	s.putResource(r.URL.Path, resource)
+
+	statusCode := http.StatusCreated
+	if exists {
+		statusCode = http.StatusOK
+	}
+	if s.longRunningOperations {
+		s.writeOperationHeaders(w, r)
+	}
+	writeJSON(w, statusCode, s.resources[strings.ToLower(r.URL.Path)])
+}
+
+func (s *Server) handlePatch(w http.ResponseWriter, r *http.Request, body []byte) {
+	existing, ok := s.resources[strings.ToLower(r.URL.Path)]
+	if !ok {
+		writeNotFound(w, r.URL.Path)
+		return
+	}
+
+	patch := map[string]interface{}{}
+	if err := json.Unmarshal(body, &patch); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", fmt.Sprintf("The request content was invalid and could not be deserialized: %+v", err))
+		return
+	}
+	s.putResource(r.URL.Path, mergePatch(existing, patch))
+
+	if s.longRunningOperations {
+		s.writeOperationHeaders(w, r)
+	}
+	writeJSON(w, http.StatusOK, s.resources[strings.ToLower(r.URL.Path)])
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	key := strings.ToLower(r.URL.Path)
+	if _, ok := s.resources[key]; !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// nested resources are deleted alongside their parent
+	order := make([]string, 0)
+	for _, v := range s.resourceOrder {
+		if v == key || strings.HasPrefix(v, key+"/") {
+			delete(s.resources, v)
+			continue
+		}
+		order = append(order, v)
+	}
+	s.resourceOrder = order
+
+	if s.longRunningOperations {
+		s.writeOperationHeaders(w, r)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleOperation returns the status of a long-running operation, which is `InProgress` the first time it's
+// polled and `Succeeded` thereafter
+func (s *Server) handleOperation(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, operationsPath)
+	count, ok := s.operations[id]
+	if !ok {
+		writeNotFound(w, r.URL.Path)
+		return
+	}
+	s.operations[id] = count + 1
+
+	status := "Succeeded"
+	if count == 0 {
+		status = "InProgress"
+		w.Header().Set("Retry-After", "0")
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":     r.URL.Path,
+		"name":   id,
+		"status": status,
+	})
+}
+
+func (s *Server) handleResourceProvider(w http.ResponseWriter, r *http.Request, segments []string) {
+	// /subscriptions/{id}/providers
+	if len(segments) == 3 {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("The %s method isn't supported for %q.", r.Method, r.URL.Path))
+			return
+		}
+
+		names := make([]string, 0, len(s.providers))
+		for name := range s.providers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		items := make([]interface{}, 0, len(names))
+		for _, name := range names {
+			items = append(items, s.resourceProvider(name))
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"value": items,
+		})
+		return
+	}
+
+	name, ok := s.providerName(segments[3])
+	if !ok {
+		writeError(w, http.StatusNotFound, "InvalidResourceNamespace", fmt.Sprintf("The resource namespace '%s' is invalid.", segments[3]))
+		return
+	}
+
+	// /subscriptions/{id}/providers/{namespace}
+	if len(segments) == 4 {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("The %s method isn't supported for %q.", r.Method, r.URL.Path))
+			return
+		}
+		writeJSON(w, http.StatusOK, s.resourceProvider(name))
+		return
+	}
+
+	// /subscriptions/{id}/providers/{namespace}/{action}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("The %s method isn't supported for %q.", r.Method, r.URL.Path))
+		return
+	}
+	if _, forbidden := s.forbiddenProviders[strings.ToLower(name)]; forbidden {
+		writeError(w, http.StatusForbidden, "AuthorizationFailed", fmt.Sprintf("The client does not have authorization to perform action '%s/%s/action' over scope '/subscriptions/%s'.", name, segments[4], s.SubscriptionId))
+		return
+	}
+	switch strings.ToLower(segments[4]) {
+	case "register":
+		s.providers[name] = "Registered"
+	case "unregister":
+		s.providers[name] = "NotRegistered"
+	default:
+		writeError(w, http.StatusNotFound, "InvalidResourceType", fmt.Sprintf("The action '%s' isn't supported for Resource Providers.", segments[4]))
+		return
+	}
+	writeJSON(w, http.StatusOK, s.resourceProvider(name))
+}
+
+func (s *Server) providerName(namespace string) (string, bool) {
+	for name := range s.providers {
+		if strings.EqualFold(name, namespace) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func (s *Server) resourceProvider(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                fmt.Sprintf("/subscriptions/%s/providers/%s", s.SubscriptionId, name),
+		"namespace":         name,
+		"registrationState": s.providers[name],
+	}
+}
+
+func (s *Server) putResource(id string, resource map[string]interface{}) {
+	segments := pathSegments(id)
+
+	resource["id"] = id
+	resource["name"] = segments[len(segments)-1]
+	resource["type"] = resourceType(segments)
+
+	properties, ok := resource["properties"].(map[string]interface{})
+	if !ok {
+		properties = map[string]interface{}{}
+	}
+	properties["provisioningState"] = "Succeeded"
+	resource["properties"] = properties
+
+	key := strings.ToLower(id)
+	if _, exists := s.resources[key]; !exists {
+		s.resourceOrder = append(s.resourceOrder, key)
+	}
+	s.resources[key] = resource
+}
+
+// writeOperationHeaders creates a long-running operation and returns the headers used to poll it
+func (s *Server) writeOperationHeaders(w http.ResponseWriter, r *http.Request) {
+	s.operationSeq++
+	id := fmt.Sprintf("%d", s.operationSeq)
+	s.operations[id] = 0
+
+	operationUrl := fmt.Sprintf("%s%s%s?api-version=%s", s.URL, operationsPath, id, r.URL.Query().Get("api-version"))
+	w.Header().Set("Azure-AsyncOperation", operationUrl)
+	w.Header().Set("Location", operationUrl)
+	w.Header().Set("Retry-After", "0")
+}
+
+func pathSegments(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// parentResourceId returns the ID of the parent resource for a nested resource (such as a Subnet within a
+// Virtual Network), or an empty string for a top-level resource
+func parentResourceId(id string) string {
+	segments := pathSegments(id)
+
+	// the type and name segments following the last `providers` segment
+	providersIndex := -1
+	for i, v := range segments {
+		if strings.EqualFold(v, "providers") {
+			providersIndex = i
+		}
+	}
+	if providersIndex == -1 || len(segments)-providersIndex <= 4 {
+		return ""
+	}
+
+	return "/" + strings.Join(segments[:len(segments)-2], "/")
+}
+
+// resourceType returns the Resource Type (e.g. `Microsoft.Network/virtualNetworks/subnets`) for the Resource ID
+func resourceType(segments []string) string {
+	providersIndex := -1
+	for i, v := range segments {
+		if strings.EqualFold(v, "providers") {
+			providersIndex = i
+		}
+	}
+
+	if providersIndex == -1 || providersIndex+1 >= len(segments) {
+		if len(segments) >= 4 && strings.EqualFold(segments[2], "resourceGroups") {
+			return "Microsoft.Resources/resourceGroups"
+		}
+		return "Microsoft.Resources/subscriptions"
+	}
+
+	types := []string{segments[providersIndex+1]}
+	for i := providersIndex + 2; i < len(segments); i += 2 {
+		types = append(types, segments[i])
+	}
+	return strings.Join(types, "/")
+}
+
+// mergePatch applies a JSON Merge Patch (RFC 7386) to the resource
+func mergePatch(target map[string]interface{}, patch map[string]interface{}) map[string]interface{} {
+	out := copyObject(target)
+	for k, v := range patch {
+		if v == nil {
+			delete(out, k)
+			continue
+		}
+
+		patchObject, isObject := v.(map[string]interface{})
+		targetObject, targetIsObject := out[k].(map[string]interface{})
+		if isObject && targetIsObject {
+			out[k] = mergePatch(targetObject, patchObject)
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+func copyObject(input map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	contents, err := json.Marshal(input)
+	if err != nil {
+		return out
+	}
+	_ = json.Unmarshal(contents, &out)
+	return out
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeNotFound(w http.ResponseWriter, id string) {
+	writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The Resource '%s' was not found.", id))
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code string, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
@@ -0,0 +1,281 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakearm_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/managedidentity/2023-01-31/managedidentities"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2020-10-01/deploymentscripts"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/fakearm"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managedidentity"
+)
+
+func TestTypedResourceLifecycle(t *testing.T) {
+	server := fakearm.New(t, fakearm.Options{})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client, err := server.Client(ctx)
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	wrapper := sdk.NewResourceWrapper(managedidentity.UserAssignedIdentityResource{})
+	resource, err := wrapper.Resource()
+	if err != nil {
+		t.Fatalf("building resource: %+v", err)
+	}
+
+	id := commonids.NewUserAssignedIdentityID(server.SubscriptionId, "example-resources", "example")
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name":                id.UserAssignedIdentityName,
+		"resource_group_name": id.ResourceGroupName,
+		"location":            "West Europe",
+		"tags": map[string]interface{}{
+			"environment": "test",
+		},
+	})
+
+	if diags := resource.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("creating: %+v", diags)
+	}
+	if d.Id() != id.ID() {
+		t.Fatalf("expected the ID to be %q but got %q", id.ID(), d.Id())
+	}
+
+	stored, ok := server.Resource(id.ID())
+	if !ok {
+		t.Fatalf("expected %s to exist", id)
+	}
+	if stored["location"] != "westeurope" {
+		t.Fatalf("expected the location to be normalized but got %q", stored["location"])
+	}
+	if stored["type"] != "Microsoft.ManagedIdentity/userAssignedIdentities" {
+		t.Fatalf("expected the type to be populated but got %q", stored["type"])
+	}
+
+	// creating the resource a second time should require it to be imported
+	if diags := resource.CreateContext(ctx, schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name":                id.UserAssignedIdentityName,
+		"resource_group_name": id.ResourceGroupName,
+		"location":            "West Europe",
+	}), client); !diags.HasError() || !strings.Contains(diags[0].Summary, "already exists") {
+		t.Fatalf("expected an error requiring the resource to be imported but got %+v", diags)
+	}
+
+	if err := d.Set("tags", map[string]interface{}{"environment": "production"}); err != nil {
+		t.Fatalf("setting tags: %+v", err)
+	}
+	if diags := resource.UpdateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("updating: %+v", diags)
+	}
+	stored, _ = server.Resource(id.ID())
+	if v := stored["tags"].(map[string]interface{})["environment"]; v != "production" {
+		t.Fatalf("expected the tags to be updated but got %q", v)
+	}
+
+	if diags := resource.DeleteContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("deleting: %+v", diags)
+	}
+	if _, ok := server.Resource(id.ID()); ok {
+		t.Fatalf("expected %s to be deleted", id)
+	}
+
+	// reading a resource which no longer exists should remove it from the state
+	if diags := resource.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("reading: %+v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected the resource to be removed from the state but got %q", d.Id())
+	}
+}
+
+func TestLongRunningOperations(t *testing.T) {
+	server := fakearm.New(t, fakearm.Options{
+		LongRunningOperations: true,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client, err := server.Client(ctx)
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	id := deploymentscripts.NewDeploymentScriptID(server.SubscriptionId, "example-resources", "example")
+	payload := deploymentscripts.AzurePowerShellScript{
+		Location: "westeurope",
+		Properties: deploymentscripts.AzurePowerShellScriptProperties{
+			AzPowerShellVersion: "10.0",
+			RetentionInterval:   "P1D",
+		},
+	}
+	if err := client.Resource.DeploymentScriptsClient.CreateThenPoll(ctx, id, payload); err != nil {
+		t.Fatalf("creating %s: %+v", id, err)
+	}
+
+	operationPolls := 0
+	for _, v := range server.Requests() {
+		if v.Method == http.MethodGet && strings.HasPrefix(v.Path, "/fakearm/operations/") {
+			operationPolls++
+		}
+	}
+	if operationPolls != 2 {
+		t.Fatalf("expected the long-running operation to be polled twice but got %d", operationPolls)
+	}
+
+	stored, ok := server.Resource(id.ID())
+	if !ok {
+		t.Fatalf("expected %s to exist", id)
+	}
+	if stored["kind"] != "AzurePowerShell" {
+		t.Fatalf("expected the request body to be stored but got %+v", stored)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	server := fakearm.New(t, fakearm.Options{})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client, err := server.Client(ctx)
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	id := commonids.NewUserAssignedIdentityID(server.SubscriptionId, "example-resources", "example")
+	resp, err := client.ManagedIdentity.V20230131.ManagedIdentities.UserAssignedIdentitiesGet(ctx, id)
+	if err == nil {
+		t.Fatalf("expected an error retrieving %s", id)
+	}
+	if resp.HttpResponse == nil || resp.HttpResponse.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected a 404 retrieving %s", id)
+	}
+	if !strings.Contains(err.Error(), "ResourceNotFound") {
+		t.Fatalf("expected the error to contain the Resource Manager error code but got %+v", err)
+	}
+
+	// nested resources can't be created unless the parent resource exists
+	credentialId := managedidentities.NewFederatedIdentityCredentialID(server.SubscriptionId, id.ResourceGroupName, id.UserAssignedIdentityName, "example")
+	credentialResp, err := client.ManagedIdentity.V20230131.ManagedIdentities.FederatedIdentityCredentialsCreateOrUpdate(ctx, credentialId, managedidentities.FederatedIdentityCredential{})
+	if err == nil || credentialResp.HttpResponse == nil || credentialResp.HttpResponse.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected a 404 creating %s without the parent resource but got %+v", credentialId, err)
+	}
+
+	// and are deleted alongside their parent resource
+	server.PutResource(id.ID(), map[string]interface{}{"location": "westeurope"})
+	if _, err := client.ManagedIdentity.V20230131.ManagedIdentities.FederatedIdentityCredentialsCreateOrUpdate(ctx, credentialId, managedidentities.FederatedIdentityCredential{}); err != nil {
+		t.Fatalf("creating %s: %+v", credentialId, err)
+	}
+	if _, err := client.ManagedIdentity.V20230131.ManagedIdentities.UserAssignedIdentitiesDelete(ctx, id); err != nil {
+		t.Fatalf("deleting %s: %+v", id, err)
+	}
+	if _, ok := server.Resource(credentialId.ID()); ok {
+		t.Fatalf("expected %s to be deleted alongside its parent", credentialId)
+	}
+}
+
+func TestResourceProviderRegistration(t *testing.T) {
+	server := fakearm.New(t, fakearm.Options{
+		ResourceProviders: map[string]string{
+			"Microsoft.Compute": "NotRegistered",
+			"Microsoft.Network": "Registered",
+		},
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client, err := server.Client(ctx)
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	resourceproviders.ClearCache()
+	defer resourceproviders.ClearCache()
+
+	required := map[string]struct{}{
+		"Microsoft.Compute": {},
+		"Microsoft.Network": {},
+	}
+	subscriptionId := commonids.NewSubscriptionID(server.SubscriptionId)
+	warnings, err := resourceproviders.EnsureRegistered(ctx, client.Resource.ResourceProvidersClient, subscriptionId, required)
+	if err != nil {
+		t.Fatalf("registering Resource Providers: %+v", err)
+	}
+	if len(warnings) > 0 {
+		t.Fatalf("expected no warnings but got %+v", warnings)
+	}
+
+	if v := server.ResourceProviderRegistrationState("Microsoft.Compute"); v != "Registered" {
+		t.Fatalf("expected Microsoft.Compute to be registered but got %q", v)
+	}
+	for _, v := range server.Requests() {
+		if v.Method == http.MethodPost && strings.Contains(v.Path, "Microsoft.Network") {
+			t.Fatalf("expected Microsoft.Network not to be registered since it was already registered")
+		}
+	}
+}
+
+func TestResourceProviderRegistrationForbidden(t *testing.T) {
+	server := fakearm.New(t, fakearm.Options{
+		ResourceProviders: map[string]string{
+			"Microsoft.Compute":    "NotRegistered",
+			"Microsoft.Databricks": "NotRegistered",
+		},
+		ForbiddenResourceProviders: []string{
+			"Microsoft.Databricks",
+		},
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client, err := server.Client(ctx)
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	resourceproviders.ClearCache()
+	defer resourceproviders.ClearCache()
+
+	required := map[string]struct{}{
+		"Microsoft.Compute":    {},
+		"Microsoft.Databricks": {},
+	}
+	subscriptionId := commonids.NewSubscriptionID(server.SubscriptionId)
+
+	// a Resource Provider which can't be registered due to insufficient permissions is returned as a warning
+	warnings, err := resourceproviders.EnsureRegistered(ctx, client.Resource.ResourceProvidersClient, subscriptionId, required)
+	if err != nil {
+		t.Fatalf("registering Resource Providers: %+v", err)
+	}
+	if len(warnings) != 1 || warnings[0].ResourceProvider != "Microsoft.Databricks" {
+		t.Fatalf("expected a single warning for Microsoft.Databricks but got %+v", warnings)
+	}
+	if v := server.ResourceProviderRegistrationState("Microsoft.Compute"); v != "Registered" {
+		t.Fatalf("expected Microsoft.Compute to be registered but got %q", v)
+	}
+
+	// and the Resource Providers which have been registered are cached, so aren't registered again
+	if _, err := resourceproviders.EnsureRegistered(ctx, client.Resource.ResourceProvidersClient, subscriptionId, required); err != nil {
+		t.Fatalf("registering Resource Providers: %+v", err)
+	}
+	registrations := 0
+	for _, v := range server.Requests() {
+		if v.Method == http.MethodPost && strings.Contains(v.Path, "Microsoft.Compute") {
+			registrations++
+		}
+	}
+	if registrations != 1 {
+		t.Fatalf("expected Microsoft.Compute to be registered once but got %d registrations", registrations)
+	}
+}
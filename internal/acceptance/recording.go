@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+const (
+	// recordingModeEnvVar specifies whether the interactions with Azure are recorded (`record`) to, or
+	// replayed (`replay`) from, a Cassette for each test
+	recordingModeEnvVar = "ARM_TEST_RECORDING_MODE"
+
+	// recordingDirectoryEnvVar optionally overrides the directory containing the Cassettes, which defaults
+	// to `testdata/recordings` within the directory of the package being tested
+	recordingDirectoryEnvVar = "ARM_TEST_RECORDING_DIRECTORY"
+)
+
+// replayEnvironmentDefaults are the values used for the environment variables required by the acceptance
+// tests when replaying recorded interactions, since these aren't used to connect to Azure
+var replayEnvironmentDefaults = map[string]string{
+	"ARM_CLIENT_ID":                "00000000-0000-0000-0000-000000000000",
+	"ARM_CLIENT_SECRET":            "replay",
+	"ARM_SUBSCRIPTION_ID":          "00000000-0000-0000-0000-000000000000",
+	"ARM_TENANT_ID":                "00000000-0000-0000-0000-000000000000",
+	"ARM_TEST_LOCATION":            "westeurope",
+	"ARM_TEST_LOCATION_ALT":        "eastus2",
+	"ARM_TEST_LOCATION_ALT2":       "westus2",
+	"ARM_TEST_SUBSCRIPTION_ID_ALT": "00000000-0000-0000-0000-000000000001",
+}
+
+var replayEnvironmentOnce = &sync.Once{}
+
+func recordingMode() common.RecordingMode {
+	return common.RecordingMode(os.Getenv(recordingModeEnvVar))
+}
+
+// applyReplayEnvironmentDefaults sets any of the environment variables required by the acceptance tests which
+// aren't already set when replaying recorded interactions, so that the tests can be run without credentials
+func applyReplayEnvironmentDefaults() {
+	if recordingMode() != common.RecordingModeReplay {
+		return
+	}
+
+	replayEnvironmentOnce.Do(func() {
+		for k, v := range replayEnvironmentDefaults {
+			if os.Getenv(k) == "" {
+				os.Setenv(k, v)
+			}
+		}
+	})
+}
+
+// startRecording starts recording (or replaying) the interactions with Azure for the current test, when enabled
+// using the `ARM_TEST_RECORDING_MODE` environment variable
+func startRecording(t *testing.T, data TestData) {
+	// the acceptance tests are skipped unless `TF_ACC` is set, in which case there's nothing to record
+	mode := recordingMode()
+	if mode == common.RecordingModeNone || os.Getenv(resource.EnvTfAcc) == "" {
+		return
+	}
+
+	directory := os.Getenv(recordingDirectoryEnvVar)
+	if directory == "" {
+		directory = "testdata/recordings"
+	}
+
+	stop, err := common.StartRecording(mode, directory, t.Name(), data.recordingReplacements())
+	if err != nil {
+		t.Fatalf("starting the %s of the interactions for %q: %+v", mode, t.Name(), err)
+	}
+
+	t.Cleanup(func() {
+		if err := stop(); err != nil {
+			t.Errorf("saving the interactions for %q: %+v", t.Name(), err)
+		}
+	})
+}
+
+// recordingReplacements returns the values which are specific to this run of the test, mapped to the placeholder
+// used for them within the Cassette - the placeholders for the random values are used to attribute requests to
+// the test, so values generated by RandomStringOfLength (which aren't known) must be used alongside one of these.
+func (td *TestData) recordingReplacements() map[string]string {
+	replacements := map[string]string{
+		"{{RandomInteger}}":     strconv.Itoa(td.RandomInteger),
+		"{{RandomString}}":      td.RandomString,
+		"{{LocationPrimary}}":   td.Locations.Primary,
+		"{{LocationSecondary}}": td.Locations.Secondary,
+		"{{LocationTernary}}":   td.Locations.Ternary,
+		"{{SubscriptionIdAlt}}": td.Subscriptions.Secondary,
+	}
+
+	// RandomInteger is a time-based value of 18 digits, so the shorter values can only be derived from it
+	// when it's this length
+	if len(strconv.Itoa(td.RandomInteger)) == 18 {
+		for i := 8; i < 18; i++ {
+			replacements[fmt.Sprintf("{{RandomIntOfLength%d}}", i)] = strconv.Itoa(td.RandomIntOfLength(i))
+		}
+	}
+
+	return replacements
+}
@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestBuildTestDataMultipleTimesWhenRecording(t *testing.T) {
+	directory := t.TempDir()
+	t.Setenv(resource.EnvTfAcc, "1")
+	t.Setenv(recordingModeEnvVar, "record")
+	t.Setenv(recordingDirectoryEnvVar, directory)
+
+	// tests commonly build test data for a related resource, which must share the Cassette for the test
+	ok := t.Run("related", func(t *testing.T) {
+		server := BuildTestData(t, "azurerm_mariadb_server", "test")
+		data := BuildTestData(t, "azurerm_mariadb_configuration", "test")
+		if server.RandomInteger == data.RandomInteger {
+			t.Fatalf("expected each set of test data to use a different random integer")
+		}
+	})
+	if !ok {
+		t.Fatalf("expected building the test data multiple times to succeed when recording")
+	}
+
+	if _, err := os.Stat(filepath.Join(directory, "TestBuildTestDataMultipleTimesWhenRecording_related.json")); err != nil {
+		t.Fatalf("expected the Cassette for the test to be saved: %+v", err)
+	}
+}
@@ -44,9 +44,13 @@ func TestAccEnsureRequiredResourceProvidersAreRegistered(t *testing.T) {
 	requiredResourceProviders := resourceproviders.Required()
 	subscriptionId := commonids.NewSubscriptionID(armClient.Account.SubscriptionId)
 
-	if err = resourceproviders.EnsureRegistered(ctx, client, subscriptionId, requiredResourceProviders); err != nil {
+	warnings, err := resourceproviders.EnsureRegistered(ctx, client, subscriptionId, requiredResourceProviders)
+	if err != nil {
 		t.Fatalf("Error registering Resource Providers: %+v", err)
 	}
+	for _, warning := range warnings {
+		t.Fatalf("Insufficient permissions to register the Resource Provider %q: %+v", warning.ResourceProvider, warning.Error)
+	}
 
 	// refresh the cache now things have been re-registered
 	resourceproviders.ClearCache()
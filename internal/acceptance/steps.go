@@ -139,6 +139,16 @@ func (td TestData) ImportStepFor(resourceName string, ignore ...string) resource
 	return step
 }
 
+// ImportIdentityStep returns a Test Step which Imports the Resource using the Resource Identity
+// (rather than the Resource ID) within an `import` block - this requires Terraform 1.12 or later
+func (td TestData) ImportIdentityStep() resource.TestStep {
+	return resource.TestStep{
+		ResourceName:    td.ResourceName,
+		ImportState:     true,
+		ImportStateKind: resource.ImportBlockWithResourceIdentity,
+	}
+}
+
 // RequiresImportErrorStep returns a Test Step which expects a Requires Import
 // error to be returned when running this step
 func (td TestData) RequiresImportErrorStep(configBuilder func(data TestData) string) resource.TestStep {
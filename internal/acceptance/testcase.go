@@ -4,12 +4,17 @@
 package acceptance
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/helpers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/testclient"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/types"
@@ -56,6 +61,78 @@ func (td TestData) ResourceTest(t *testing.T, testResource types.TestResource, s
 	td.runAcceptanceTest(t, testCase)
 }
 
+// ResourceTestWriteOnly runs the resource test when the version of Terraform Core supports write-only attributes
+// (Terraform 1.11 and later), and skips it otherwise.
+func (td TestData) ResourceTestWriteOnly(t *testing.T, testResource types.TestResource, steps []TestStep) {
+	testCase := resource.TestCase{
+		PreCheck: func() { PreCheck(t) },
+		CheckDestroy: func(s *terraform.State) error {
+			client, err := testclient.Build()
+			if err != nil {
+				return fmt.Errorf("building client: %+v", err)
+			}
+			return helpers.CheckDestroyedFunc(client, testResource, td.ResourceType, td.ResourceName)(s)
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: steps,
+	}
+	td.runAcceptanceTest(t, testCase)
+}
+
+// EphemeralResourceTest runs the test for an Ephemeral Resource when the version of Terraform Core supports
+// ephemeral resources (Terraform 1.10 and later), and skips it otherwise.
+//
+// Ephemeral Resources are served by the Plugin Framework, so this uses the muxed Provider Server. Since the values
+// of an Ephemeral Resource are never persisted, these can be checked by passing them into the `echo` provider,
+// which exposes them through the `data` attribute of the `echo` resource.
+func (td TestData) EphemeralResourceTest(t *testing.T, steps []TestStep) {
+	// Ephemeral Resources are never persisted into the state, so there's nothing to check has been destroyed
+
+	//lintignore:AT001
+	testCase := resource.TestCase{
+		PreCheck: func() { PreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ExternalProviders:        td.externalProviders(),
+		ProtoV5ProviderFactories: td.protoV5Providers(),
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"echo": echoprovider.NewProviderServer(),
+		},
+		Steps: steps,
+	}
+
+	resource.ParallelTest(t, testCase)
+}
+
+// ListResourceTest runs the test for a List Resource when the version of Terraform Core supports list resources
+// (Terraform 1.14 and later), and skips it otherwise.
+//
+// List Resources are served by the Plugin Framework, so this uses the muxed Provider Server. The Resources to be
+// listed are provisioned in a regular Config step, which is followed by a Query step containing the `list` blocks.
+func (td TestData) ListResourceTest(t *testing.T, testResource types.TestResource, steps []TestStep) {
+	testCase := resource.TestCase{
+		PreCheck: func() { PreCheck(t) },
+		CheckDestroy: func(s *terraform.State) error {
+			client, err := testclient.Build()
+			if err != nil {
+				return fmt.Errorf("building client: %+v", err)
+			}
+			return helpers.CheckDestroyedFunc(client, testResource, td.ResourceType, td.ResourceName)(s)
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		ExternalProviders:        td.externalProviders(),
+		ProtoV5ProviderFactories: td.protoV5Providers(),
+		Steps:                    steps,
+	}
+
+	resource.ParallelTest(t, testCase)
+}
+
 // ResourceTestIgnoreCheckDestroyed skips the check to confirm the resource test has been destroyed.
 // This is done because certain resources can't actually be deleted.
 func (td TestData) ResourceTestSkipCheckDestroyed(t *testing.T, steps []TestStep) {
@@ -133,6 +210,18 @@ func (td TestData) providers() map[string]func() (*schema.Provider, error) {
 	}
 }
 
+func (td TestData) protoV5Providers() map[string]func() (tfprotov5.ProviderServer, error) {
+	return map[string]func() (tfprotov5.ProviderServer, error){
+		"azurerm": func() (tfprotov5.ProviderServer, error) {
+			factory, err := provider.ProtoV5TestProviderServerFactory(context.Background())
+			if err != nil {
+				return nil, err
+			}
+			return factory(), nil
+		},
+	}
+}
+
 func (td TestData) externalProviders() map[string]resource.ExternalProvider {
 	return map[string]resource.ExternalProvider{
 		"azuread": {
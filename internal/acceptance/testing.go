@@ -18,6 +18,8 @@ import (
 )
 
 func PreCheck(t *testing.T) {
+	applyReplayEnvironmentDefaults()
+
 	variables := []string{
 		"ARM_CLIENT_ID",
 		"ARM_CLIENT_SECRET",
@@ -28,6 +28,9 @@ type ResourceManagerAccount struct {
 	AuthenticatedAsAServicePrincipal bool
 	SkipResourceProviderRegistration bool
 
+	// ResourceProvidersToRegister are the Resource Providers which are automatically registered by the Provider
+	ResourceProvidersToRegister map[string]struct{}
+
 	// TODO: delete these when no longer needed by older clients
 	AzureEnvironment azure.Environment
 }
@@ -143,3 +146,34 @@ func NewResourceManagerAccount(ctx context.Context, config auth.Credentials, sub
 
 	return &account, nil
 }
+
+// replayObjectId is the Object ID used for the authenticated principal when replaying recorded interactions
+const replayObjectId = "00000000-0000-0000-0000-000000000000"
+
+// newReplayResourceManagerAccount returns a ResourceManagerAccount built from the configuration alone, since the
+// access token can't be inspected when replaying recorded interactions (see common.RecordingModeReplay)
+func newReplayResourceManagerAccount(config auth.Credentials, subscriptionId string, skipResourceProviderRegistration bool, azureEnvironment azure.Environment) (*ResourceManagerAccount, error) {
+	if config.TenantID == "" {
+		return nil, fmt.Errorf("unable to configure ResourceManagerAccount: a tenant ID must be specified when replaying recorded interactions")
+	}
+	if subscriptionId == "" {
+		return nil, fmt.Errorf("unable to configure ResourceManagerAccount: a subscription ID must be specified when replaying recorded interactions")
+	}
+
+	account := ResourceManagerAccount{
+		Environment: config.Environment,
+
+		ClientId:       config.ClientID,
+		ObjectId:       replayObjectId,
+		SubscriptionId: subscriptionId,
+		TenantId:       config.TenantID,
+
+		AuthenticatedAsAServicePrincipal: true,
+		SkipResourceProviderRegistration: skipResourceProviderRegistration,
+
+		// TODO: delete these when no longer needed by older clients
+		AzureEnvironment: azureEnvironment,
+	}
+
+	return &account, nil
+}
@@ -18,11 +18,17 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 )
 
 type ClientBuilder struct {
-	AuthConfig *auth.Credentials
-	Features   features.UserFeatures
+	AuthConfig      *auth.Credentials
+	DefaultTimeouts timeouts.ProviderDefaults
+	Features        features.UserFeatures
+	ProviderTags    tags.ProviderConfig
+	Throttling      common.ThrottlingOptions
+	Tracing         common.TracingOptions
 
 	DisableCorrelationRequestID bool
 	DisableTerraformPartnerID   bool
@@ -34,6 +40,10 @@ type ClientBuilder struct {
 	PartnerID                  string
 	SubscriptionID             string
 	TerraformVersion           string
+
+	// ResourceProvidersToRegister are the Resource Providers which are automatically registered by the Provider,
+	// determined from the `resource_provider_registrations` and `resource_providers_to_register` fields
+	ResourceProvidersToRegister map[string]struct{}
 }
 
 const azureStackEnvironmentError = `
@@ -53,25 +63,38 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return nil, fmt.Errorf(azureStackEnvironmentError)
 	}
 
+	if err := common.ConfigureTracing(ctx, builder.Tracing); err != nil {
+		return nil, fmt.Errorf("configuring tracing: %+v", err)
+	}
+
+	// requests aren't sent to Azure when replaying recorded interactions, so there's no need to authenticate
+	recordingMode := common.ActiveRecordingMode()
+	newAuthorizer := func(api environments.Api) (auth.Authorizer, error) {
+		if recordingMode == common.RecordingModeReplay {
+			return common.ReplayAuthorizer{}, nil
+		}
+		return auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, api)
+	}
+
 	var resourceManagerAuth, storageAuth, synapseAuth, batchManagementAuth, keyVaultAuth auth.Authorizer
 
-	resourceManagerAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.ResourceManager)
+	resourceManagerAuth, err = newAuthorizer(builder.AuthConfig.Environment.ResourceManager)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Resource Manager API: %+v", err)
 	}
 
-	storageAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Storage)
+	storageAuth, err = newAuthorizer(builder.AuthConfig.Environment.Storage)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Storage API: %+v", err)
 	}
 
-	keyVaultAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.KeyVault)
+	keyVaultAuth, err = newAuthorizer(builder.AuthConfig.Environment.KeyVault)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Key Vault API: %+v", err)
 	}
 
 	if builder.AuthConfig.Environment.Synapse.Available() {
-		synapseAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Synapse)
+		synapseAuth, err = newAuthorizer(builder.AuthConfig.Environment.Synapse)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Synapse API: %+v", err)
 		}
@@ -80,7 +103,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 	}
 
 	if builder.AuthConfig.Environment.Batch.Available() {
-		batchManagementAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Batch)
+		batchManagementAuth, err = newAuthorizer(builder.AuthConfig.Environment.Batch)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Batch Management API: %+v", err)
 		}
@@ -90,7 +113,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 
 	// Helper for obtaining endpoint-specific tokens
 	authorizerFunc := common.ApiAuthorizerFunc(func(api environments.Api) (auth.Authorizer, error) {
-		authorizer, err := auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, api)
+		authorizer, err := newAuthorizer(api)
 		if err != nil {
 			return nil, fmt.Errorf("building custom authorizer for API %q: %+v", api.Name(), err)
 		}
@@ -105,14 +128,23 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 	}
 	resourceManagerEndpoint, _ := builder.AuthConfig.Environment.ResourceManager.Endpoint()
 
-	account, err := NewResourceManagerAccount(ctx, *builder.AuthConfig, builder.SubscriptionID, builder.SkipProviderRegistration, *azureEnvironment)
+	var account *ResourceManagerAccount
+	if recordingMode == common.RecordingModeReplay {
+		account, err = newReplayResourceManagerAccount(*builder.AuthConfig, builder.SubscriptionID, builder.SkipProviderRegistration, *azureEnvironment)
+	} else {
+		account, err = NewResourceManagerAccount(ctx, *builder.AuthConfig, builder.SubscriptionID, builder.SkipProviderRegistration, *azureEnvironment)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("building account: %+v", err)
 	}
+	account.ResourceProvidersToRegister = builder.ResourceProvidersToRegister
+	if recordingMode != common.RecordingModeNone {
+		common.SetRecordingAccount(account.SubscriptionId, account.TenantId, account.ClientId, account.ObjectId)
+	}
 
 	var managedHSMAuth auth.Authorizer
 	if builder.AuthConfig.Environment.ManagedHSM.Available() {
-		managedHSMAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.ManagedHSM)
+		managedHSMAuth, err = newAuthorizer(builder.AuthConfig.Environment.ManagedHSM)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Managed HSM API: %+v", err)
 		}
@@ -121,7 +153,9 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 	}
 
 	client := Client{
-		Account: account,
+		Account:         account,
+		DefaultTimeouts: builder.DefaultTimeouts,
+		ProviderTags:    builder.ProviderTags,
 	}
 
 	o := &common.ClientOptions{
@@ -156,6 +190,9 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		SkipProviderReg:             builder.SkipProviderRegistration,
 		StorageUseAzureAD:           builder.StorageUseAzureAD,
 
+		Throttling: builder.Throttling,
+		Tracing:    builder.Tracing,
+
 		// TODO: remove when `Azure/go-autorest` is no longer used
 		AzureEnvironment:        *azureEnvironment,
 		ResourceManagerEndpoint: *resourceManagerEndpoint,
@@ -171,7 +208,10 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		ctx2, cancel := context.WithTimeout(ctx, 10*time.Minute)
 		defer cancel()
 
-		location.CacheSupportedLocations(ctx2, *resourceManagerEndpoint)
+		// the supported locations are retrieved without using the clients, so can't be replayed
+		if recordingMode != common.RecordingModeReplay {
+			location.CacheSupportedLocations(ctx2, *resourceManagerEndpoint)
+		}
 		if err := resourceproviders.CacheSupportedProviders(ctx2, client.Resource.ResourceProvidersClient, subscriptionId); err != nil {
 			log.Printf("[DEBUG] error retrieving providers: %s. Enhanced validation will be unavailable", err)
 		}
@@ -151,6 +151,8 @@ import (
 	voiceServices "github.com/hashicorp/terraform-provider-azurerm/internal/services/voiceservices/client"
 	web "github.com/hashicorp/terraform-provider-azurerm/internal/services/web/client"
 	workloads "github.com/hashicorp/terraform-provider-azurerm/internal/services/workloads/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 )
 
 type Client struct {
@@ -162,6 +164,12 @@ type Client struct {
 	Account  *ResourceManagerAccount
 	Features features.UserFeatures
 
+	// DefaultTimeouts contains the `default_timeouts` defined in the Provider block
+	DefaultTimeouts timeouts.ProviderDefaults
+
+	// ProviderTags contains the `default_tags` and `ignore_tags` defined in the Provider block
+	ProviderTags tags.ProviderConfig
+
 	AadB2c                            *aadb2c_v2021_04_01_preview.Client
 	Advisor                           *advisor.Client
 	AnalysisServices                  *analysisservices_v2017_08_01.Client
@@ -475,7 +483,9 @@ func (client *Client) Build(ctx context.Context, o *common.ClientOptions) error
 	if client.IoTTimeSeriesInsights, err = timeseriesinsights.NewClient(o); err != nil {
 		return fmt.Errorf("building clients for IoT TimeSeries Insights: %+v", err)
 	}
-	client.KeyVault = keyvault.NewClient(o)
+	if client.KeyVault, err = keyvault.NewClient(o); err != nil {
+		return fmt.Errorf("building clients for KeyVault: %+v", err)
+	}
 	if client.Kusto, err = kusto.NewClient(o); err != nil {
 		return fmt.Errorf("building clients for Kusto: %+v", err)
 	}
@@ -667,3 +677,12 @@ func (client *Client) Build(ctx context.Context, o *common.ClientOptions) error
 
 	return nil
 }
+
+// DefaultSubscriptionId returns the Subscription ID configured in the Provider block, which is used when a
+// Resource is imported using a Resource Identity which doesn't specify the `subscription_id`
+func (client *Client) DefaultSubscriptionId() string {
+	if client.Account == nil {
+		return ""
+	}
+	return client.Account.SubscriptionId
+}
@@ -10,7 +10,6 @@ import (
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
-	"github.com/hashicorp/go-azure-helpers/sender"
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/client"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
@@ -51,6 +50,9 @@ type ClientOptions struct {
 	SkipProviderReg           bool
 	StorageUseAzureAD         bool
 
+	Throttling ThrottlingOptions
+	Tracing    TracingOptions
+
 	// Keep these around for convenience with Autorest based clients, remove when we are no longer using autorest
 	AzureEnvironment        azure.Environment
 	ResourceManagerEndpoint string
@@ -76,8 +78,22 @@ func (o ClientOptions) Configure(c client.BaseClient, authorizer auth.Authorizer
 		c.AppendRequestMiddleware(correlationRequestIDMiddleware(id))
 	}
 
-	c.AppendRequestMiddleware(requestLoggerMiddleware("AzureRM"))
-	c.AppendResponseMiddleware(responseLoggerMiddleware("AzureRM"))
+	recordingMode := ActiveRecordingMode()
+	if o.Throttling.Enabled && recordingMode != RecordingModeReplay {
+		policy := newThrottlingPolicy(o.Throttling)
+		c.AppendRequestMiddleware(throttlingRequestMiddleware(policy))
+		c.AppendResponseMiddleware(throttlingResponseMiddleware(policy))
+	}
+
+	redactor := newRedactor(o.Tracing.RedactedFields)
+	c.AppendRequestMiddleware(tracingRequestMiddleware(redactor))
+	c.AppendResponseMiddleware(tracingResponseMiddleware(redactor))
+
+	// the recording middleware runs last, so that the requests are recorded as they're sent to Azure
+	if recordingMode != RecordingModeNone {
+		c.AppendRequestMiddleware(recordingRequestMiddleware(sharedRecorder))
+		c.AppendResponseMiddleware(recordingResponseMiddleware(sharedRecorder))
+	}
 }
 
 // ConfigureClient sets up an autorest.Client using an autorest.Authorizer
@@ -85,7 +101,16 @@ func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.A
 	c.UserAgent = userAgent(c.UserAgent, o.TerraformVersion, o.PartnerId, o.DisableTerraformPartnerID)
 
 	c.Authorizer = authorizer
-	c.Sender = sender.BuildSender("AzureRM")
+	c.Sender = buildSender()
+	recordingMode := ActiveRecordingMode()
+	if recordingMode != RecordingModeNone {
+		c.Sender = autorest.DecorateSender(c.Sender, withRecording(sharedRecorder))
+	}
+	if o.Throttling.Enabled && recordingMode != RecordingModeReplay {
+		// throttled requests are retried outside of the throttling policy, so that each retry is paced
+		c.Sender = autorest.DecorateSender(c.Sender, withThrottling(newThrottlingPolicy(o.Throttling)), withRetries(newRetryPolicy(o.Throttling)))
+	}
+	c.Sender = autorest.DecorateSender(c.Sender, withTracing(newRedactor(o.Tracing.RedactedFields)))
 	c.SkipResourceProviderRegistration = o.SkipProviderReg
 	if !o.DisableCorrelationRequestID {
 		id := o.CustomCorrelationRequestID
@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+)
+
+func TestBuildSenderRequiresTLS12(t *testing.T) {
+	transport, ok := buildSender().(*http.Client).Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected the sender to use a *http.Transport")
+	}
+	if transport.TLSClientConfig == nil || transport.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Fatalf("expected the minimum TLS version to be 1.2")
+	}
+}
@@ -0,0 +1,664 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"golang.org/x/oauth2"
+)
+
+// RecordingMode specifies whether the requests made to Azure are recorded to, or replayed from, Cassettes
+type RecordingMode string
+
+const (
+	// RecordingModeNone sends requests to Azure without recording them
+	RecordingModeNone RecordingMode = ""
+
+	// RecordingModeRecord sends requests to Azure, recording the interactions to a Cassette for each test
+	RecordingModeRecord RecordingMode = "record"
+
+	// RecordingModeReplay doesn't send requests to Azure (nor authenticate), instead replaying the
+	// interactions previously recorded to the Cassette for each test
+	RecordingModeReplay RecordingMode = "replay"
+)
+
+const (
+	// headerReplayOriginalUrl is used to pass the URL of a request to the replay server
+	headerReplayOriginalUrl = "X-Azurerm-Replay-Original-Url"
+
+	// sharedCassetteName is the name of the Cassette containing the interactions which can't be attributed
+	// to a single test, such as those made when configuring the Provider
+	sharedCassetteName = "shared"
+)
+
+// recordingIgnoredResponseHeaders are response headers which are specific to a request (or which would change
+// the behaviour of the replay) and so aren't recorded
+var recordingIgnoredResponseHeaders = []string{
+	"Date",
+	"Set-Cookie",
+	"Strict-Transport-Security",
+	"X-Ms-Client-Request-Id",
+	"X-Ms-Correlation-Request-Id",
+	"X-Ms-Request-Id",
+	"X-Ms-Routing-Request-Id",
+}
+
+// Cassette contains the interactions with Azure recorded during a test
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+
+	name string
+	path string
+
+	// replacements maps the placeholders used within the Cassette to the values used by the current test
+	// (e.g. the random integer and string generated for the test), so that the Cassette can be replayed
+	replacements     map[string]string
+	replacementsLock sync.RWMutex
+
+	// sources is the number of sets of replacements added to the Cassette, since a test can build multiple
+	// sets of test data (e.g. for a related resource) which share the Cassette for the test
+	sources int
+
+	// users is the number of sets of replacements which are still in use, the Cassette is saved once this is zero
+	users int
+
+	lock   sync.Mutex
+	played map[string]int
+}
+
+// Interaction is a request made to Azure, and the response which was returned
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Url    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int                 `json:"status_code"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	Body       string              `json:"body,omitempty"`
+}
+
+// recorder is shared across all clients (and Provider instances) within the process, since the Provider
+// is served in-process by the acceptance tests
+type recorder struct {
+	lock sync.Mutex
+	mode RecordingMode
+
+	// directory is the directory containing the Cassettes
+	directory string
+
+	// cassettes are the Cassettes for the tests which are currently running
+	cassettes map[string]*Cassette
+
+	// shared is the Cassette for interactions which can't be attributed to a single test
+	shared *Cassette
+
+	// replayUrl is the URL of the replay server, which requests are redirected to in RecordingModeReplay
+	replayUrl *url.URL
+
+	// account maps the placeholders for the account used to run the tests (e.g. the Subscription ID) to their values
+	account map[string]string
+
+	redactor *redactor
+}
+
+var sharedRecorder = &recorder{
+	cassettes: map[string]*Cassette{},
+	redactor:  newRedactor(nil),
+}
+
+// ActiveRecordingMode returns the RecordingMode for the process, which is set by StartRecording
+func ActiveRecordingMode() RecordingMode {
+	sharedRecorder.lock.Lock()
+	defer sharedRecorder.lock.Unlock()
+
+	return sharedRecorder.mode
+}
+
+// SetRecordingAccount specifies the details of the account used to run the tests (e.g. the Subscription ID), which
+// are replaced with placeholders within the Cassettes - so that these aren't committed, and so that Cassettes can be
+// replayed using a different account.
+func SetRecordingAccount(subscriptionId, tenantId, clientId, objectId string) {
+	sharedRecorder.lock.Lock()
+	defer sharedRecorder.lock.Unlock()
+
+	sharedRecorder.account = map[string]string{
+		"{{SubscriptionId}}": subscriptionId,
+		"{{TenantId}}":       tenantId,
+		"{{ClientId}}":       clientId,
+		"{{ObjectId}}":       objectId,
+	}
+}
+
+func (r *recorder) accountReplacements() map[string]string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.account
+}
+
+// StartRecording starts recording (or replaying) the interactions for the test `name` to the Cassette within
+// `directory`. The `replacements` map a placeholder to a value which is specific to this run of the test (such
+// as the random integer generated for the test) - which are replaced with the placeholder when recording and
+// replaced with the current value when replaying, and used to attribute requests to the test.
+//
+// Where the Cassette for the test `name` is already in use (e.g. when a test builds multiple sets of test data)
+// the `replacements` are added to it - with any placeholders which are already used for a different value
+// suffixed with the number of the set of replacements (e.g. `{{RandomInteger_2}}`).
+//
+// The returned function must be called once the test has completed, to save the Cassette when recording.
+func StartRecording(mode RecordingMode, directory string, name string, replacements map[string]string) (func() error, error) {
+	if mode != RecordingModeRecord && mode != RecordingModeReplay {
+		return nil, fmt.Errorf("unsupported Recording Mode %q", mode)
+	}
+
+	r := sharedRecorder
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.mode != RecordingModeNone && (r.mode != mode || r.directory != directory) {
+		return nil, fmt.Errorf("the Recording Mode %q for %q doesn't match the Recording Mode %q for %q already in use", mode, directory, r.mode, r.directory)
+	}
+	if cassette, exists := r.cassettes[name]; exists {
+		cassette.addReplacements(replacements)
+		return r.stopRecording(mode, cassette), nil
+	}
+
+	if r.mode == RecordingModeNone {
+		r.mode = mode
+		r.directory = directory
+
+		shared, err := newCassette(mode, directory, sharedCassetteName, nil)
+		if err != nil {
+			return nil, err
+		}
+		r.shared = shared
+
+		if mode == RecordingModeReplay {
+			if err := r.startReplayServer(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	cassette, err := newCassette(mode, directory, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	cassette.addReplacements(replacements)
+	r.cassettes[name] = cassette
+
+	return r.stopRecording(mode, cassette), nil
+}
+
+// stopRecording returns a function which releases a set of replacements for the Cassette, saving the Cassette
+// (when recording) once this was the last set of replacements in use
+func (r *recorder) stopRecording(mode RecordingMode, cassette *Cassette) func() error {
+	cassette.users++
+
+	return func() error {
+		r.lock.Lock()
+		cassette.users--
+		inUse := cassette.users > 0
+		if !inUse {
+			delete(r.cassettes, cassette.name)
+		}
+		r.lock.Unlock()
+
+		if inUse || mode != RecordingModeRecord {
+			return nil
+		}
+		if err := cassette.save(); err != nil {
+			return err
+		}
+		return r.shared.save()
+	}
+}
+
+func cassettePath(directory string, name string) string {
+	return filepath.Join(directory, fmt.Sprintf("%s.json", strings.ReplaceAll(name, "/", "_")))
+}
+
+func newCassette(mode RecordingMode, directory string, name string, replacements map[string]string) (*Cassette, error) {
+	cassette := &Cassette{
+		Interactions: make([]Interaction, 0),
+		name:         name,
+		path:         cassettePath(directory, name),
+		replacements: make(map[string]string),
+		played:       map[string]int{},
+	}
+
+	if mode == RecordingModeReplay {
+		contents, err := os.ReadFile(cassette.path)
+		if err != nil {
+			if name == sharedCassetteName && os.IsNotExist(err) {
+				return cassette, nil
+			}
+			return nil, fmt.Errorf("reading the Cassette for %q: %+v", name, err)
+		}
+		if err := json.Unmarshal(contents, cassette); err != nil {
+			return nil, fmt.Errorf("parsing the Cassette for %q: %+v", name, err)
+		}
+	}
+
+	return cassette, nil
+}
+
+// addReplacements adds a set of replacements to the Cassette, suffixing any placeholders which are already used
+// for a different value with the number of this set of replacements - which is consistent between recording and
+// replaying, since the test data is built in the same order each time the test is run
+func (c *Cassette) addReplacements(replacements map[string]string) {
+	c.replacementsLock.Lock()
+	defer c.replacementsLock.Unlock()
+
+	c.sources++
+	for k, v := range replacements {
+		existing, ok := c.replacements[k]
+		if !ok {
+			c.replacements[k] = v
+			continue
+		}
+		if existing != v {
+			c.replacements[fmt.Sprintf("%s_%d}}", strings.TrimSuffix(k, "}}"), c.sources)] = v
+		}
+	}
+}
+
+// testReplacements returns a copy of the replacements for this test
+func (c *Cassette) testReplacements() map[string]string {
+	c.replacementsLock.RLock()
+	defer c.replacementsLock.RUnlock()
+
+	out := make(map[string]string, len(c.replacements))
+	for k, v := range c.replacements {
+		out[k] = v
+	}
+	return out
+}
+
+func (c *Cassette) save() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(c.Interactions) == 0 && c.name == sharedCassetteName {
+		return nil
+	}
+
+	contents, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serializing the Cassette for %q: %+v", c.name, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("creating the directory for the Cassette %q: %+v", c.name, err)
+	}
+	if err := os.WriteFile(c.path, append(contents, '\n'), 0o600); err != nil {
+		return fmt.Errorf("writing the Cassette for %q: %+v", c.name, err)
+	}
+
+	return nil
+}
+
+// allReplacements returns the replacements for this test, alongside those for the account used to run the tests
+func (c *Cassette) allReplacements() map[string]string {
+	out := make(map[string]string)
+	for k, v := range sharedRecorder.accountReplacements() {
+		out[k] = v
+	}
+	for k, v := range c.testReplacements() {
+		out[k] = v
+	}
+	return out
+}
+
+// placeholder returns the input with the values specific to this run of the test replaced with their placeholders
+func (c *Cassette) placeholder(input string) string {
+	replacements := c.allReplacements()
+	for _, k := range sortedPlaceholders(replacements) {
+		input = strings.ReplaceAll(input, replacements[k], k)
+	}
+	return input
+}
+
+// restore returns the input with the placeholders replaced with the values specific to this run of the test
+func (c *Cassette) restore(input string) string {
+	replacements := c.allReplacements()
+	for _, k := range sortedPlaceholders(replacements) {
+		input = strings.ReplaceAll(input, k, replacements[k])
+	}
+	return input
+}
+
+// sortedPlaceholders returns the placeholders ordered by the length of their value (longest first), so that
+// values which contain other values (e.g. a random integer and a shorter version of it) are replaced correctly
+func sortedPlaceholders(replacements map[string]string) []string {
+	out := make([]string, 0, len(replacements))
+	for k, v := range replacements {
+		if v != "" {
+			out = append(out, k)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if len(replacements[out[i]]) != len(replacements[out[j]]) {
+			return len(replacements[out[i]]) > len(replacements[out[j]])
+		}
+		return out[i] < out[j]
+	})
+	return out
+}
+
+// matchesRequest returns whether the request contains any of the values specific to this run of the test
+func (c *Cassette) matchesRequest(requestUrl string, body string) bool {
+	for k, v := range c.testReplacements() {
+		if !strings.HasPrefix(k, "{{Random") || v == "" {
+			continue
+		}
+		if strings.Contains(requestUrl, v) || strings.Contains(body, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchKey returns the key used to match a request to a recorded interaction, which ignores the casing of the
+// URL and the order of the query string
+func matchKey(method string, requestUrl string) string {
+	u, err := url.Parse(requestUrl)
+	if err != nil {
+		return strings.ToLower(fmt.Sprintf("%s %s", method, requestUrl))
+	}
+
+	return strings.ToLower(fmt.Sprintf("%s %s%s?%s", method, u.Host, strings.TrimSuffix(u.Path, "/"), u.Query().Encode()))
+}
+
+func (c *Cassette) record(request *http.Request, requestBody []byte, response *http.Response, responseBody []byte, redactor *redactor) {
+	headers := make(map[string][]string)
+	for k, v := range response.Header {
+		ignored := false
+		for _, header := range recordingIgnoredResponseHeaders {
+			if strings.EqualFold(k, header) {
+				ignored = true
+			}
+		}
+		if strings.HasPrefix(strings.ToLower(k), "x-ms-ratelimit-") || ignored || redactor.isRedactedHeader(k) {
+			continue
+		}
+
+		values := make([]string, 0, len(v))
+		for _, value := range v {
+			values = append(values, c.placeholder(value))
+		}
+		headers[k] = values
+	}
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: request.Method,
+			Url:    c.placeholder(redactor.url(request.URL)),
+			Body:   c.placeholder(redactor.recordedBody(request.URL, requestBody)),
+		},
+		Response: RecordedResponse{
+			StatusCode: response.StatusCode,
+			Headers:    headers,
+			Body:       c.placeholder(redactor.recordedBody(request.URL, responseBody)),
+		},
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.Interactions = append(c.Interactions, interaction)
+}
+
+// replay returns the recorded response for the request - requests with the same method and URL are replayed in
+// the order they were recorded, with the last response being repeated once these are exhausted (e.g. polling)
+func (c *Cassette) replay(method string, requestUrl string) (*http.Response, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := matchKey(method, c.placeholder(requestUrl))
+	matches := make([]Interaction, 0)
+	for _, v := range c.Interactions {
+		if matchKey(v.Request.Method, v.Request.Url) == key {
+			matches = append(matches, v)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, false
+	}
+
+	index := c.played[key]
+	if index >= len(matches) {
+		index = len(matches) - 1
+	}
+	c.played[key] = index + 1
+	interaction := matches[index]
+
+	headers := http.Header{}
+	for k, v := range interaction.Response.Headers {
+		for _, value := range v {
+			headers.Add(k, c.restore(value))
+		}
+	}
+	// there's no need to wait between polling a recorded long-running operation
+	if headers.Get("Retry-After") != "" {
+		headers.Set("Retry-After", "1")
+	}
+
+	body := c.restore(interaction.Response.Body)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        headers,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}, true
+}
+
+// cassetteForRequest returns the Cassette for the test which made the request, or the shared Cassette when
+// the request can't be attributed to a single test
+func (r *recorder) cassetteForRequest(requestUrl string, body string) *Cassette {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	names := make([]string, 0, len(r.cassettes))
+	for name := range r.cassettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if cassette := r.cassettes[name]; cassette.matchesRequest(requestUrl, body) {
+			return cassette
+		}
+	}
+
+	return r.shared
+}
+
+func (r *recorder) recordResponse(request *http.Request, response *http.Response) {
+	requestBody := readRequestBody(request)
+	responseBody := readResponseBody(request, response)
+
+	cassette := r.cassetteForRequest(request.URL.String(), string(requestBody))
+	cassette.record(request, requestBody, response, responseBody, r.redactor)
+}
+
+func (r *recorder) replayResponse(request *http.Request) *http.Response {
+	body := readRequestBody(request)
+	requestUrl := request.URL.String()
+
+	cassette := r.cassetteForRequest(requestUrl, string(body))
+	response, ok := cassette.replay(request.Method, requestUrl)
+	if !ok {
+		message := fmt.Sprintf("no interaction was recorded in the Cassette %q for %s %s", cassette.name, request.Method, cassette.placeholder(requestUrl))
+		log.Printf("[DEBUG] %s", message)
+
+		response = errorResponse(http.StatusNotImplemented, "NoRecordedInteraction", message)
+	}
+
+	response.Request = request
+	return response
+}
+
+// errorResponse returns a response containing a Resource Manager error, for a request which isn't sent to Azure
+func errorResponse(statusCode int, code string, message string) *http.Response {
+	body, _ := json.Marshal(map[string]interface{}{
+		"error": map[string]string{
+			"code":    code,
+			"message": message,
+		},
+	})
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode: statusCode,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+		},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+}
+
+// startReplayServer starts a local HTTP server which returns the recorded responses, since the clients from
+// hashicorp/go-azure-sdk don't allow the HTTP Transport to be replaced
+func (r *recorder) startReplayServer() error {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("starting the replay server: %+v", err)
+	}
+
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+			originalUrl, err := url.Parse(request.Header.Get(headerReplayOriginalUrl))
+			if err != nil {
+				http.Error(w, fmt.Sprintf("parsing the original URL: %+v", err), http.StatusBadRequest)
+				return
+			}
+			request.URL = originalUrl
+
+			response := r.replayResponse(request)
+			defer response.Body.Close()
+
+			for k, v := range response.Header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(response.StatusCode)
+			_, _ = io.Copy(w, response.Body)
+		}),
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Printf("[DEBUG] Replay server stopped: %+v", err)
+		}
+	}()
+
+	r.replayUrl = &url.URL{
+		Scheme: "http",
+		Host:   listener.Addr().String(),
+	}
+	return nil
+}
+
+func recordingRequestMiddleware(r *recorder) client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		if ActiveRecordingMode() != RecordingModeReplay {
+			return request, nil
+		}
+
+		// redirect the request to the replay server, which returns the recorded response
+		replayed := request.Clone(request.Context())
+		replayed.Header.Set(headerReplayOriginalUrl, request.URL.String())
+		replayed.URL.Scheme = r.replayUrl.Scheme
+		replayed.URL.Host = r.replayUrl.Host
+		replayed.Host = r.replayUrl.Host
+		return replayed, nil
+	}
+}
+
+func recordingResponseMiddleware(r *recorder) client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		switch ActiveRecordingMode() {
+		case RecordingModeRecord:
+			r.recordResponse(request, response)
+
+		case RecordingModeReplay:
+			// restore the original URL, since this is used to poll some long-running operations
+			if originalUrl, err := url.Parse(request.Header.Get(headerReplayOriginalUrl)); err == nil && originalUrl.Host != "" {
+				original := request.Clone(request.Context())
+				original.Header.Del(headerReplayOriginalUrl)
+				original.URL = originalUrl
+				original.Host = originalUrl.Host
+				response.Request = original
+			}
+		}
+		return response, nil
+	}
+}
+
+// withRecording returns an autorest.SendDecorator applying the recording (or replay) to the legacy autorest clients
+func withRecording(r *recorder) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(request *http.Request) (*http.Response, error) {
+			switch ActiveRecordingMode() {
+			case RecordingModeReplay:
+				return r.replayResponse(request), nil
+
+			case RecordingModeRecord:
+				resp, err := s.Do(request)
+				if err == nil && resp != nil {
+					r.recordResponse(request, resp)
+				}
+				return resp, err
+			}
+
+			return s.Do(request)
+		})
+	}
+}
+
+// ReplayAuthorizer is an auth.Authorizer returning a static access token, which is used in RecordingModeReplay
+// since the requests aren't sent to Azure
+type ReplayAuthorizer struct{}
+
+func (ReplayAuthorizer) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	return &oauth2.Token{
+		AccessToken: "replay",
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(time.Hour),
+	}, nil
+}
+
+func (ReplayAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return []*oauth2.Token{}, nil
+}
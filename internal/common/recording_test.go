@@ -0,0 +1,278 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// testRecorder replaces the shared recorder with a new one, for the duration of the test
+func testRecorder(t *testing.T) {
+	t.Helper()
+
+	previous := sharedRecorder
+	sharedRecorder = &recorder{
+		cassettes: map[string]*Cassette{},
+		redactor:  newRedactor(nil),
+	}
+	t.Cleanup(func() {
+		sharedRecorder = previous
+	})
+}
+
+func TestRecordingRoundTrip(t *testing.T) {
+	directory := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(headerRequestID, "00000000-0000-0000-0000-000000000001")
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(strings.Replace(string(body), "}", `,"properties":{"primaryKey":"abc123"}}`, 1)))
+	}))
+	defer server.Close()
+
+	// record the interactions using the values for the first run of the test
+	testRecorder(t)
+	SetRecordingAccount("11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222", "client", "object")
+	stop, err := StartRecording(RecordingModeRecord, directory, "TestAccExample_basic", map[string]string{
+		"{{RandomInteger}}": "230101120000001234",
+		"{{RandomString}}":  "abcde",
+	})
+	if err != nil {
+		t.Fatalf("starting the recording: %+v", err)
+	}
+
+	sender := autorest.DecorateSender(server.Client(), withRecording(sharedRecorder))
+	requestUrl := server.URL + "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/acctestRG-230101120000001234?api-version=2023-01-01"
+	request, _ := http.NewRequest(http.MethodPut, requestUrl, strings.NewReader(`{"name":"acctestabcde"}`))
+	request.Header.Set(HeaderCorrelationRequestID, "11111111-1111-1111-1111-111111111111")
+	response, err := sender.Do(request)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	body, _ := io.ReadAll(response.Body)
+	if !strings.Contains(string(body), "abc123") {
+		t.Fatalf("expected the response body to be unchanged but got %q", string(body))
+	}
+	if err := stop(); err != nil {
+		t.Fatalf("saving the recording: %+v", err)
+	}
+
+	contents, err := os.ReadFile(cassettePath(directory, "TestAccExample_basic"))
+	if err != nil {
+		t.Fatalf("reading the Cassette: %+v", err)
+	}
+	// the random values, account details, secrets and request-specific headers shouldn't be recorded
+	for _, v := range []string{"230101120000001234", "abcde", "11111111-1111-1111-1111-111111111111", "abc123", "00000000-0000-0000-0000-000000000001"} {
+		if strings.Contains(string(contents), v) {
+			t.Fatalf("expected %q to be removed from the Cassette but got %s", v, string(contents))
+		}
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(contents, &cassette); err != nil {
+		t.Fatalf("parsing the Cassette: %+v", err)
+	}
+	if len(cassette.Interactions) != 1 {
+		t.Fatalf("expected 1 interaction but got %d", len(cassette.Interactions))
+	}
+	if v := cassette.Interactions[0].Request.Body; v != `{"name":"acctest{{RandomString}}"}` {
+		t.Fatalf("expected the request body to use placeholders but got %q", v)
+	}
+
+	// replay the interactions using the values for a second run of the test, with the server unavailable
+	server.Close()
+	testRecorder(t)
+	SetRecordingAccount("33333333-3333-3333-3333-333333333333", "44444444-4444-4444-4444-444444444444", "client", "object")
+	stop, err = StartRecording(RecordingModeReplay, directory, "TestAccExample_basic", map[string]string{
+		"{{RandomInteger}}": "240202130000005678",
+		"{{RandomString}}":  "fghij",
+	})
+	if err != nil {
+		t.Fatalf("starting the replay: %+v", err)
+	}
+	defer stop()
+
+	sender = autorest.DecorateSender(server.Client(), withRecording(sharedRecorder))
+	replayUrl := server.URL + "/subscriptions/33333333-3333-3333-3333-333333333333/resourceGroups/acctestRG-240202130000005678?api-version=2023-01-01"
+	for i := 0; i < 2; i++ {
+		request, _ = http.NewRequest(http.MethodPut, replayUrl, strings.NewReader(`{"name":"acctestfghij"}`))
+		request.Header.Set(HeaderCorrelationRequestID, "55555555-5555-5555-5555-555555555555")
+		response, err = sender.Do(request)
+		if err != nil {
+			t.Fatalf("replaying request: %+v", err)
+		}
+		if response.StatusCode != http.StatusOK {
+			t.Fatalf("expected a 200 but got %d", response.StatusCode)
+		}
+		if v := response.Header.Get("Retry-After"); v != "1" {
+			t.Fatalf("expected the Retry-After header to be shortened but got %q", v)
+		}
+		body, _ = io.ReadAll(response.Body)
+		if v := string(body); v != `{"name":"acctestfghij","properties":{"primaryKey":"REDACTED"}}` {
+			t.Fatalf("expected the response body to use the values for this run but got %q", v)
+		}
+	}
+
+	request, _ = http.NewRequest(http.MethodDelete, replayUrl, nil)
+	response, err = sender.Do(request)
+	if err != nil {
+		t.Fatalf("replaying request: %+v", err)
+	}
+	if response.StatusCode != http.StatusNotImplemented {
+		t.Fatalf("expected a 501 for a request which wasn't recorded but got %d", response.StatusCode)
+	}
+}
+
+func TestRecordingReplayMiddleware(t *testing.T) {
+	directory := t.TempDir()
+	cassette := Cassette{
+		Interactions: []Interaction{
+			{
+				Request: RecordedRequest{
+					Method: http.MethodGet,
+					Url:    "https://management.azure.com/subscriptions/{{SubscriptionId}}/resourceGroups/acctestRG-{{RandomInteger}}?api-version=2023-01-01",
+				},
+				Response: RecordedResponse{
+					StatusCode: http.StatusOK,
+					Body:       `{"name":"acctestRG-{{RandomInteger}}"}`,
+				},
+			},
+		},
+	}
+	contents, _ := json.Marshal(&cassette)
+	if err := os.WriteFile(cassettePath(directory, "TestAccExample_basic"), contents, 0o600); err != nil {
+		t.Fatalf("writing the Cassette: %+v", err)
+	}
+
+	testRecorder(t)
+	SetRecordingAccount("33333333-3333-3333-3333-333333333333", "44444444-4444-4444-4444-444444444444", "client", "object")
+	stop, err := StartRecording(RecordingModeReplay, directory, "TestAccExample_basic", map[string]string{
+		"{{RandomInteger}}": "240202130000005678",
+	})
+	if err != nil {
+		t.Fatalf("starting the replay: %+v", err)
+	}
+	defer stop()
+
+	// the path is matched regardless of casing
+	originalUrl := "https://management.azure.com/subscriptions/33333333-3333-3333-3333-333333333333/resourcegroups/acctestRG-240202130000005678?api-version=2023-01-01"
+	request, _ := http.NewRequest(http.MethodGet, originalUrl, nil)
+	request, err = recordingRequestMiddleware(sharedRecorder)(request)
+	if err != nil {
+		t.Fatalf("running request middleware: %+v", err)
+	}
+	if request.URL.Host == "management.azure.com" {
+		t.Fatalf("expected the request to be redirected to the replay server")
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	response, err = recordingResponseMiddleware(sharedRecorder)(request, response)
+	if err != nil {
+		t.Fatalf("running response middleware: %+v", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected a 200 but got %d", response.StatusCode)
+	}
+	if response.Request.URL.String() != originalUrl {
+		t.Fatalf("expected the response to reference the original URL but got %q", response.Request.URL.String())
+	}
+	body, _ := io.ReadAll(response.Body)
+	if v := string(body); v != `{"name":"acctestRG-240202130000005678"}` {
+		t.Fatalf("expected the recorded response body but got %q", v)
+	}
+}
+
+func TestRecordingAttributesRequestsToTests(t *testing.T) {
+	testRecorder(t)
+	directory := t.TempDir()
+
+	for name, value := range map[string]string{"TestAccExample_basic": "111", "TestAccExample_complete": "222"} {
+		if _, err := StartRecording(RecordingModeRecord, directory, name, map[string]string{"{{RandomInteger}}": value}); err != nil {
+			t.Fatalf("starting the recording for %q: %+v", name, err)
+		}
+	}
+
+	testData := map[string]string{
+		"https://management.azure.com/subscriptions/abc/resourceGroups/acctestRG-111": "TestAccExample_basic",
+		"https://management.azure.com/subscriptions/abc/resourceGroups/acctestRG-222": "TestAccExample_complete",
+		"https://management.azure.com/subscriptions/abc/providers":                    sharedCassetteName,
+	}
+	for requestUrl, expected := range testData {
+		if actual := sharedRecorder.cassetteForRequest(requestUrl, ""); actual.name != expected {
+			t.Fatalf("expected %q to be attributed to %q but got %q", requestUrl, expected, actual.name)
+		}
+	}
+
+	if _, err := StartRecording(RecordingModeReplay, directory, "TestAccExample_other", nil); err == nil {
+		t.Fatalf("expected an error when mixing Recording Modes")
+	}
+}
+
+func TestRecordingSharesTheCassetteForATest(t *testing.T) {
+	testRecorder(t)
+	directory := t.TempDir()
+
+	// a test can build multiple sets of test data, for example for a related resource
+	name := "TestAccExample_related"
+	stops := make([]func() error, 0)
+	for _, value := range []string{"111", "222"} {
+		stop, err := StartRecording(RecordingModeRecord, directory, name, map[string]string{
+			"{{RandomInteger}}":   value,
+			"{{LocationPrimary}}": "westeurope",
+		})
+		if err != nil {
+			t.Fatalf("starting the recording for %q: %+v", name, err)
+		}
+		stops = append(stops, stop)
+	}
+
+	cassette := sharedRecorder.cassettes[name]
+	expected := map[string]string{
+		"{{RandomInteger}}":   "111",
+		"{{RandomInteger_2}}": "222",
+		"{{LocationPrimary}}": "westeurope",
+	}
+	if actual := cassette.testReplacements(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected the replacements %+v but got %+v", expected, actual)
+	}
+
+	requestUrl := "https://management.azure.com/subscriptions/abc/resourceGroups/acctestRG-222"
+	if actual := sharedRecorder.cassetteForRequest(requestUrl, ""); actual.name != name {
+		t.Fatalf("expected %q to be attributed to %q but got %q", requestUrl, name, actual.name)
+	}
+	if actual := cassette.placeholder(requestUrl); !strings.HasSuffix(actual, "acctestRG-{{RandomInteger_2}}") {
+		t.Fatalf("expected the second random integer to be replaced with its placeholder but got %q", actual)
+	}
+
+	// the Cassette is only saved once the last set of test data is done with
+	if err := stops[0](); err != nil {
+		t.Fatalf("stopping the first recording: %+v", err)
+	}
+	if _, ok := sharedRecorder.cassettes[name]; !ok {
+		t.Fatalf("expected the Cassette to remain in use by the second set of test data")
+	}
+	if _, err := os.Stat(cassettePath(directory, name)); !os.IsNotExist(err) {
+		t.Fatalf("expected the Cassette not to be saved until the second set of test data is done with")
+	}
+	if err := stops[1](); err != nil {
+		t.Fatalf("stopping the second recording: %+v", err)
+	}
+	if _, err := os.Stat(cassettePath(directory, name)); err != nil {
+		t.Fatalf("expected the Cassette to be saved: %+v", err)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const redactedValue = "REDACTED"
+
+// DefaultRedactedFields returns the names of the fields within request/response bodies which are
+// redacted prior to being logged or traced, in addition to any configured in the Provider block
+//
+// A field is redacted when its name ends with one of these (case-insensitively), for example both
+// `password` and `adminPassword` are redacted by `password`.
+func DefaultRedactedFields() []string {
+	return []string{
+		"accessKey",
+		"accessToken",
+		"connectionString",
+		"masterKey",
+		"password",
+		"primaryKey",
+		"privateKey",
+		"refreshToken",
+		"sasToken",
+		"sasUrl",
+		"secondaryKey",
+		"secret",
+		"sharedKey",
+	}
+}
+
+// redactedHeaders are the headers which are always redacted, since they contain credentials
+var redactedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Ocp-Apim-Subscription-Key",
+	"Set-Cookie",
+	"X-Functions-Key",
+	"X-Ms-Authorization-Auxiliary",
+	"X-Ms-Copy-Source-Authorization",
+}
+
+// redactedQueryParameters are the query string parameters which are always redacted, for example the
+// signature of a SAS Token
+var redactedQueryParameters = []string{
+	"code",
+	"sig",
+	"signature",
+}
+
+// sasSignatureRegex matches the signature within a SAS Token which is embedded in a string value
+var sasSignatureRegex = regexp.MustCompile(`(?i)([?&](sig|signature)=)[^&"'\s]+`)
+
+// secretListActionRegex matches the URI of the POST actions which return secrets (e.g. `listKeys`),
+// the response bodies of which are redacted entirely
+var secretListActionRegex = regexp.MustCompile(`(?i)/(list|regenerate)[a-z]*(keys?|secrets?|credentials?|connectionstrings?|sas)$`)
+
+// redactor redacts the secret-bearing values within a request/response before it's logged or traced
+type redactor struct {
+	// fields are the lower-cased names of the fields to redact, a field is redacted when its
+	// name is (or ends with) one of these
+	fields []string
+}
+
+func newRedactor(additionalFields []string) *redactor {
+	fields := make([]string, 0)
+	for _, v := range append(DefaultRedactedFields(), additionalFields...) {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			fields = append(fields, v)
+		}
+	}
+
+	return &redactor{
+		fields: fields,
+	}
+}
+
+func (r *redactor) isRedactedField(name string) bool {
+	name = strings.ToLower(name)
+	for _, v := range r.fields {
+		if strings.HasSuffix(name, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// url returns the URL with any secret-bearing query string parameters redacted
+func (r *redactor) url(input *url.URL) string {
+	if input == nil {
+		return ""
+	}
+	if input.RawQuery == "" {
+		return input.String()
+	}
+
+	output := *input
+	values := output.Query()
+	for k := range values {
+		if r.isRedactedQueryParameter(k) {
+			values.Set(k, redactedValue)
+		}
+	}
+	output.RawQuery = values.Encode()
+	return output.String()
+}
+
+func (r *redactor) isRedactedQueryParameter(name string) bool {
+	for _, v := range redactedQueryParameters {
+		if strings.EqualFold(name, v) {
+			return true
+		}
+	}
+	return r.isRedactedField(name)
+}
+
+// headers returns the headers as a map with any secret-bearing headers redacted
+func (r *redactor) headers(input http.Header) map[string]string {
+	output := make(map[string]string, len(input))
+	for k, v := range input {
+		value := strings.Join(v, ", ")
+		if r.isRedactedHeader(k) {
+			value = redactedValue
+		}
+		output[k] = value
+	}
+	return output
+}
+
+func (r *redactor) isRedactedHeader(name string) bool {
+	for _, v := range redactedHeaders {
+		if strings.EqualFold(name, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// body returns the request/response body with any secret-bearing values redacted
+//
+// JSON bodies have the values of any redacted fields replaced, and the signature of any SAS Tokens
+// removed - other bodies only have the signature of any SAS Tokens removed. The response bodies of
+// actions which list secrets (e.g. `listKeys`) are redacted entirely.
+func (r *redactor) body(requestUrl *url.URL, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	if requestUrl != nil && secretListActionRegex.MatchString(requestUrl.Path) {
+		return redactedValue
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return redactString(string(body))
+	}
+
+	// HTML escaping is disabled so that URLs (e.g. `&`) remain readable in the logs
+	var encoded bytes.Buffer
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(r.redactValue(decoded)); err != nil {
+		return redactString(string(body))
+	}
+	return strings.TrimSuffix(encoded.String(), "\n")
+}
+
+// recordedBody returns the request/response body with any secret-bearing values redacted, for use in a Cassette
+//
+// Unlike body, the response bodies of actions which list secrets (e.g. `listKeys`) are kept (with the secrets
+// themselves redacted) so that the structure of the response remains available to the replayed test.
+func (r *redactor) recordedBody(requestUrl *url.URL, body []byte) string {
+	if requestUrl == nil || !secretListActionRegex.MatchString(requestUrl.Path) {
+		return r.body(requestUrl, body)
+	}
+
+	listRedactor := &redactor{
+		fields: append([]string{"value"}, r.fields...),
+	}
+	return listRedactor.body(nil, body)
+}
+
+func (r *redactor) redactValue(input interface{}) interface{} {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			if r.isRedactedField(k) && value != nil {
+				v[k] = redactedValue
+				continue
+			}
+			v[k] = r.redactValue(value)
+		}
+		return v
+
+	case []interface{}:
+		for i := range v {
+			v[i] = r.redactValue(v[i])
+		}
+		return v
+
+	case string:
+		return redactString(v)
+	}
+
+	return input
+}
+
+func redactString(input string) string {
+	return sasSignatureRegex.ReplaceAllString(input, "${1}"+redactedValue)
+}
@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestRedactorBody(t *testing.T) {
+	testData := []struct {
+		name             string
+		url              string
+		additionalFields []string
+		input            string
+		expected         string
+	}{
+		{
+			name:     "empty",
+			url:      testSubscriptionPath,
+			input:    "",
+			expected: "",
+		},
+		{
+			name:     "no secrets",
+			url:      testSubscriptionPath,
+			input:    `{"name":"example","properties":{"sku":"Standard"}}`,
+			expected: `{"name":"example","properties":{"sku":"Standard"}}`,
+		},
+		{
+			name:     "nested password",
+			url:      testSubscriptionPath,
+			input:    `{"properties":{"osProfile":{"adminUsername":"adminuser","adminPassword":"P@ssw0rd1234!"}}}`,
+			expected: `{"properties":{"osProfile":{"adminPassword":"REDACTED","adminUsername":"adminuser"}}}`,
+		},
+		{
+			name:     "list of connection strings",
+			url:      testSubscriptionPath,
+			input:    `{"properties":{"siteConfig":[{"name":"db","connectionString":"Server=example;Password=secret"}]}}`,
+			expected: `{"properties":{"siteConfig":[{"connectionString":"REDACTED","name":"db"}]}}`,
+		},
+		{
+			name:     "null secret is kept",
+			url:      testSubscriptionPath,
+			input:    `{"properties":{"clientSecret":null}}`,
+			expected: `{"properties":{"clientSecret":null}}`,
+		},
+		{
+			name:     "sas token in a string value",
+			url:      testSubscriptionPath,
+			input:    `{"properties":{"packageUri":"https://example.blob.core.windows.net/c/p.zip?sv=2021-01-01&sig=abc%2Fdef&se=2030"}}`,
+			expected: `{"properties":{"packageUri":"https://example.blob.core.windows.net/c/p.zip?sv=2021-01-01&sig=REDACTED&se=2030"}}`,
+		},
+		{
+			name:             "additional field",
+			url:              testSubscriptionPath,
+			additionalFields: []string{"customerId"},
+			input:            `{"properties":{"customerId":"abc123","workspaceCustomerId":"def456"}}`,
+			expected:         `{"properties":{"customerId":"REDACTED","workspaceCustomerId":"REDACTED"}}`,
+		},
+		{
+			name:     "list keys action",
+			url:      testSubscriptionPath + "/listKeys",
+			input:    `{"keys":[{"keyName":"key1","value":"abc123"}]}`,
+			expected: "REDACTED",
+		},
+		{
+			name:     "list connection strings action",
+			url:      testSubscriptionPath + "/ListConnectionStrings",
+			input:    `{"connectionStrings":[]}`,
+			expected: "REDACTED",
+		},
+		{
+			name:     "non-json body",
+			url:      testSubscriptionPath,
+			input:    "https://example.blob.core.windows.net/c/p.zip?sv=2021-01-01&sig=abcdef",
+			expected: "https://example.blob.core.windows.net/c/p.zip?sv=2021-01-01&sig=REDACTED",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		requestUrl, err := url.Parse(v.url)
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", v.url, err)
+		}
+
+		actual := newRedactor(v.additionalFields).body(requestUrl, []byte(v.input))
+		if actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
+
+func TestRedactorUrl(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "https://management.azure.com" + testSubscriptionPath + "?api-version=2023-01-01",
+			expected: "https://management.azure.com" + testSubscriptionPath + "?api-version=2023-01-01",
+		},
+		{
+			input:    "https://example.blob.core.windows.net/container/blob?sv=2021-01-01&sig=abc%2Fdef",
+			expected: "https://example.blob.core.windows.net/container/blob?sig=REDACTED&sv=2021-01-01",
+		},
+		{
+			input:    "https://example.azurewebsites.net/api/function?code=abc123",
+			expected: "https://example.azurewebsites.net/api/function?code=REDACTED",
+		},
+	}
+
+	redactor := newRedactor(nil)
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.input)
+
+		input, err := url.Parse(v.input)
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", v.input, err)
+		}
+
+		actual := redactor.url(input)
+		if actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
+
+func TestRedactorHeaders(t *testing.T) {
+	input := http.Header{}
+	input.Set("Authorization", "Bearer abc123")
+	input.Set("Content-Type", "application/json")
+	input.Set("x-ms-authorization-auxiliary", "Bearer def456")
+
+	actual := newRedactor(nil).headers(input)
+
+	expected := map[string]string{
+		"Authorization":                "REDACTED",
+		"Content-Type":                 "application/json",
+		"X-Ms-Authorization-Auxiliary": "REDACTED",
+	}
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d headers but got %d: %+v", len(expected), len(actual), actual)
+	}
+	for k, v := range expected {
+		if actual[k] != v {
+			t.Fatalf("expected the header %q to be %q but got %q", k, v, actual[k])
+		}
+	}
+
+	if input.Get("Authorization") != "Bearer abc123" {
+		t.Fatalf("expected the request headers to be unchanged")
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// throttlingMaxRetries is the number of times a throttled request is retried by the retryPolicy, after which
+// the response is returned to the retry decorators used by the autorest clients
+const throttlingMaxRetries = 8
+
+// retryPolicy retries requests which have been throttled (HTTP 429/503), waiting for the duration specified
+// in the `Retry-After` header when present and otherwise backing off exponentially - with jitter added to
+// each wait, so that requests which were throttled at the same time aren't retried at the same time.
+//
+// checkRetry and backoff follow the CheckRetry and Backoff functions used by go-retryablehttp, which the
+// go-azure-sdk clients use to retry throttled requests internally - as such this is used for the autorest clients,
+// since the retry policy used by the go-azure-sdk clients can't be configured.
+type retryPolicy struct {
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+
+	// random returns a random number in the range [0.0, 1.0), used to add jitter to the waits
+	random func() float64
+
+	// sleep waits for the specified duration, or until the context is cancelled
+	sleep func(ctx context.Context, duration time.Duration) error
+}
+
+func newRetryPolicy(options ThrottlingOptions) *retryPolicy {
+	return &retryPolicy{
+		maxRetries: throttlingMaxRetries,
+		minWait:    throttlingDefaultWait,
+		maxWait:    options.MaxWait,
+		random:     rand.Float64,
+		sleep:      sleepWithContext,
+	}
+}
+
+// checkRetry returns whether the request should be retried - which is only the case for throttled requests,
+// other failures are retried by the autorest clients themselves
+func (p *retryPolicy) checkRetry(ctx context.Context, response *http.Response, err error) (bool, error) {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return false, ctxErr
+	}
+
+	return err == nil && isThrottled(response), nil
+}
+
+// backoff returns how long to wait before retrying the request, honouring the `Retry-After` header when present -
+// returning false when the `Retry-After` exceeds maxWait, in which case the request shouldn't be retried
+func (p *retryPolicy) backoff(attempt int, response *http.Response) (time.Duration, bool) {
+	wait := retryAfter(response, time.Now())
+	if wait <= 0 {
+		wait = time.Duration(float64(p.minWait) * math.Pow(2, float64(attempt)))
+	} else if p.maxWait > 0 && wait > p.maxWait {
+		return 0, false
+	}
+
+	// since the `Retry-After` is within maxWait, capping the wait doesn't shorten it
+	wait = withJitter(wait, p.random)
+	if p.maxWait > 0 && wait > p.maxWait {
+		wait = p.maxWait
+	}
+
+	return wait, true
+}
+
+// withRetries returns an autorest.SendDecorator retrying throttled requests using the retryPolicy - this sits
+// beneath the retry decorators used by the autorest clients, which only see a throttled response once the
+// retries have been exhausted
+func withRetries(policy *retryPolicy) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			rr := autorest.NewRetriableRequest(r)
+			for attempt := 0; ; attempt++ {
+				if err := rr.Prepare(); err != nil {
+					return nil, err
+				}
+
+				resp, err := s.Do(rr.Request())
+				retry, checkErr := policy.checkRetry(r.Context(), resp, err)
+				if checkErr != nil {
+					return resp, checkErr
+				}
+				if !retry || attempt >= policy.maxRetries {
+					return resp, err
+				}
+
+				delay, ok := policy.backoff(attempt, resp)
+				if !ok {
+					log.Printf("[DEBUG] Request to %s was throttled (%s) for longer than the maximum wait of %s - not retrying", r.URL, resp.Status, policy.maxWait)
+					return resp, err
+				}
+				log.Printf("[DEBUG] Request to %s was throttled (%s) - retrying in %s", r.URL, resp.Status, delay)
+				_ = autorest.DrainResponseBody(resp)
+				if err := policy.sleep(r.Context(), delay); err != nil {
+					return nil, err
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+func testRetryPolicy(t *testing.T, random float64) (*retryPolicy, *recordingSleeper) {
+	t.Helper()
+
+	sleeper := &recordingSleeper{}
+	return &retryPolicy{
+		maxRetries: 3,
+		minWait:    time.Second,
+		maxWait:    time.Minute,
+		random:     func() float64 { return random },
+		sleep:      sleeper.sleep,
+	}, sleeper
+}
+
+func TestRetryPolicyCheckRetry(t *testing.T) {
+	policy, _ := testRetryPolicy(t, 0)
+
+	testData := []struct {
+		StatusCode int
+		Expected   bool
+	}{
+		{StatusCode: http.StatusOK, Expected: false},
+		{StatusCode: http.StatusNotFound, Expected: false},
+		{StatusCode: http.StatusInternalServerError, Expected: false},
+		{StatusCode: http.StatusTooManyRequests, Expected: true},
+		{StatusCode: http.StatusServiceUnavailable, Expected: true},
+	}
+
+	for _, v := range testData {
+		actual, err := policy.checkRetry(context.Background(), &http.Response{StatusCode: v.StatusCode}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error for %d: %+v", v.StatusCode, err)
+		}
+		if actual != v.Expected {
+			t.Fatalf("expected %t for %d but got %t", v.Expected, v.StatusCode, actual)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if retry, err := policy.checkRetry(ctx, &http.Response{StatusCode: http.StatusTooManyRequests}, nil); retry || err == nil {
+		t.Fatalf("expected a cancelled request not to be retried")
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	testData := []struct {
+		Name       string
+		Attempt    int
+		RetryAfter string
+		Min        time.Duration
+		Max        time.Duration
+		NoRetry    bool
+	}{
+		{
+			Name:    "First Attempt",
+			Attempt: 0,
+			Min:     time.Second,
+			Max:     time.Second + time.Duration(float64(time.Second)*throttlingJitter),
+		},
+		{
+			Name:    "Exponential",
+			Attempt: 3,
+			Min:     8 * time.Second,
+			Max:     8*time.Second + time.Duration(float64(8*time.Second)*throttlingJitter),
+		},
+		{
+			Name:    "Capped",
+			Attempt: 10,
+			Min:     time.Minute,
+			Max:     time.Minute,
+		},
+		{
+			Name:       "Retry After",
+			Attempt:    5,
+			RetryAfter: "20",
+			Min:        20 * time.Second,
+			Max:        20*time.Second + time.Duration(float64(20*time.Second)*throttlingJitter),
+		},
+		{
+			Name:       "Retry After Jitter Capped",
+			Attempt:    0,
+			RetryAfter: "59",
+			Min:        59 * time.Second,
+			Max:        time.Minute,
+		},
+		{
+			// the request isn't retried before the `Retry-After`, which would be throttled again
+			Name:       "Retry After Exceeds Max Wait",
+			Attempt:    0,
+			RetryAfter: "600",
+			NoRetry:    true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		response := &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{},
+		}
+		if v.RetryAfter != "" {
+			response.Header.Set("Retry-After", v.RetryAfter)
+		}
+
+		// the lowest and highest jitter should both be within the bounds
+		for _, random := range []float64{0, 0.999999} {
+			policy, _ := testRetryPolicy(t, random)
+			actual, ok := policy.backoff(v.Attempt, response)
+			if ok == v.NoRetry {
+				t.Fatalf("expected the request to be retried to be %t but got %t", !v.NoRetry, ok)
+			}
+			if v.NoRetry {
+				continue
+			}
+			if actual < v.Min || actual > v.Max {
+				t.Fatalf("expected the wait to be within [%s, %s] but got %s", v.Min, v.Max, actual)
+			}
+		}
+	}
+}
+
+func TestRetriesWithAutorestSender(t *testing.T) {
+	arm := &fakeArmServer{
+		responses: []fakeArmResponse{
+			{statusCode: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": "4"}},
+			{statusCode: http.StatusServiceUnavailable},
+		},
+	}
+	server := httptest.NewServer(arm)
+	defer server.Close()
+
+	policy, sleeper := testRetryPolicy(t, 0)
+	sender := autorest.DecorateSender(server.Client(), withRetries(policy))
+
+	request, _ := http.NewRequest(http.MethodPut, server.URL+testSubscriptionPath, strings.NewReader(`{"hello":"world"}`))
+	response, err := sender.Do(request)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected a 200 but got %d", response.StatusCode)
+	}
+
+	if arm.requestCount() != 3 {
+		t.Fatalf("expected 3 requests but got %d", arm.requestCount())
+	}
+	for i, body := range arm.bodies {
+		if body != `{"hello":"world"}` {
+			t.Fatalf("expected the body to be resent for request %d but got %q", i, body)
+		}
+	}
+
+	expected := []time.Duration{4 * time.Second, 2 * time.Second}
+	if len(sleeper.durations) != len(expected) {
+		t.Fatalf("expected %d waits but got %d", len(expected), len(sleeper.durations))
+	}
+	for i, v := range expected {
+		if sleeper.durations[i] != v {
+			t.Fatalf("expected wait %d to be %s but got %s", i, v, sleeper.durations[i])
+		}
+	}
+}
+
+func TestRetriesAreLimited(t *testing.T) {
+	policy, sleeper := testRetryPolicy(t, 0)
+
+	arm := &fakeArmServer{}
+	for i := 0; i <= policy.maxRetries+1; i++ {
+		arm.responses = append(arm.responses, fakeArmResponse{statusCode: http.StatusTooManyRequests})
+	}
+	server := httptest.NewServer(arm)
+	defer server.Close()
+
+	sender := autorest.DecorateSender(server.Client(), withRetries(policy))
+
+	request, _ := http.NewRequest(http.MethodGet, server.URL+testSubscriptionPath, nil)
+	response, err := sender.Do(request)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+
+	// once the retries are exhausted the throttled response is returned
+	if response.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected a 429 but got %d", response.StatusCode)
+	}
+	if arm.requestCount() != policy.maxRetries+1 {
+		t.Fatalf("expected %d requests but got %d", policy.maxRetries+1, arm.requestCount())
+	}
+	if len(sleeper.durations) != policy.maxRetries {
+		t.Fatalf("expected %d waits but got %d", policy.maxRetries, len(sleeper.durations))
+	}
+}
+
+func TestRetriesWhenRetryAfterExceedsMaxWait(t *testing.T) {
+	arm := &fakeArmServer{
+		responses: []fakeArmResponse{
+			{statusCode: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": "600"}},
+		},
+	}
+	server := httptest.NewServer(arm)
+	defer server.Close()
+
+	policy, sleeper := testRetryPolicy(t, 0)
+	sender := autorest.DecorateSender(server.Client(), withRetries(policy))
+
+	request, _ := http.NewRequest(http.MethodGet, server.URL+testSubscriptionPath, nil)
+	response, err := sender.Do(request)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+
+	// the throttled response is returned rather than retrying before the `Retry-After`
+	if response.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected a 429 but got %d", response.StatusCode)
+	}
+	if arm.requestCount() != 1 {
+		t.Fatalf("expected 1 request but got %d", arm.requestCount())
+	}
+	if len(sleeper.durations) != 0 {
+		t.Fatalf("expected no waits but got %d", len(sleeper.durations))
+	}
+}
@@ -0,0 +1,419 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+// ThrottlingOptions configures how the clients handle throttling, that is slowing down requests as the
+// remaining quota for a Subscription (as returned in the `x-ms-ratelimit-remaining-*` headers) drops,
+// pausing requests of the same kind to a host once throttled, and retrying throttled requests.
+//
+// Throttled requests (HTTP 429/503) are retried by the retryPolicy for the autorest clients. The go-azure-sdk
+// clients instead retry throttled requests internally (honouring the `Retry-After` header) using a retry policy
+// which can't be configured - and since the middlewares run before the first attempt and after the last, these
+// options only slow down and pause the subsequent requests made by these clients.
+type ThrottlingOptions struct {
+	// Enabled specifies whether requests should be slowed down, paused and retried
+	Enabled bool
+
+	// MaxWait is the maximum duration requests are paused for (or a retry is delayed by) once a request has
+	// been throttled - where the `Retry-After` header exceeds this the request fails, rather than being sent
+	// before Azure allows and being throttled again
+	MaxWait time.Duration
+
+	// RemainingRequestsThreshold is the number of remaining requests for a Subscription below which
+	// requests for that Subscription are slowed down
+	RemainingRequestsThreshold int
+}
+
+// DefaultThrottlingOptions returns the ThrottlingOptions used when these aren't configured
+func DefaultThrottlingOptions() ThrottlingOptions {
+	return ThrottlingOptions{
+		Enabled:                    true,
+		MaxWait:                    2 * time.Minute,
+		RemainingRequestsThreshold: 100,
+	}
+}
+
+const (
+	// throttlingDefaultWait is the duration requests are paused for when a throttled response
+	// doesn't include a `Retry-After` header
+	throttlingDefaultWait = 2 * time.Second
+
+	// throttledRequestsPerSecond is the rate requests are slowed to when the remaining quota for
+	// a Subscription drops below the threshold, which is further reduced as the quota is exhausted
+	throttledRequestsPerSecond = 10.0
+
+	// minimumThrottledRequestsPerSecond is the lowest rate requests are slowed to
+	minimumThrottledRequestsPerSecond = 0.5
+
+	// throttlingJitter is the maximum proportion of a wait which is added to it at random, so that requests
+	// which were throttled at the same time don't all resume (or are retried) at the same time
+	throttlingJitter = 0.2
+)
+
+var subscriptionIdRegex = regexp.MustCompile(`(?i)/subscriptions/([^/]+)`)
+
+// sharedRateLimiter is shared across all clients (and Provider instances), since the throttling
+// limits are applied by Azure Resource Manager per Subscription (and by other APIs per host)
+var sharedRateLimiter = newRateLimiter()
+
+type throttlingPolicy struct {
+	options ThrottlingOptions
+	limiter *rateLimiter
+
+	// random returns a random number in the range [0.0, 1.0), used to add jitter to pauses
+	random func() float64
+
+	// sleep waits for the specified duration, or until the context is cancelled
+	sleep func(ctx context.Context, duration time.Duration) error
+}
+
+func newThrottlingPolicy(options ThrottlingOptions) *throttlingPolicy {
+	return &throttlingPolicy{
+		options: options,
+		limiter: sharedRateLimiter,
+		random:  rand.Float64,
+		sleep:   sleepWithContext,
+	}
+}
+
+func throttlingRequestMiddleware(policy *throttlingPolicy) client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		if err := policy.wait(request); err != nil {
+			return nil, err
+		}
+
+		return request, nil
+	}
+}
+
+func throttlingResponseMiddleware(policy *throttlingPolicy) client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		policy.observe(request, response)
+		return response, nil
+	}
+}
+
+// withThrottling returns an autorest.SendDecorator applying the throttlingPolicy to the legacy autorest clients
+func withThrottling(policy *throttlingPolicy) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			if err := policy.wait(r); err != nil {
+				return nil, err
+			}
+
+			resp, err := s.Do(r)
+			policy.observe(r, resp)
+			return resp, err
+		})
+	}
+}
+
+// wait blocks until the request can be sent, according to whether requests of the same kind to the host
+// have been paused after being throttled, and the remaining quota for the Subscription
+func (p *throttlingPolicy) wait(request *http.Request) error {
+	now := time.Now()
+
+	var delay time.Duration
+	if key, ok := pauseKey(request); ok {
+		// the request is failed rather than sent before the `Retry-After`, which would be throttled again
+		paused := p.limiter.pausedFor(key, now)
+		if p.options.MaxWait > 0 && paused > p.options.MaxWait {
+			return fmt.Errorf("requests to %s have been throttled for a further %s, which exceeds the maximum wait of %s", request.URL.Host, paused.Round(time.Second), p.options.MaxWait)
+		}
+
+		// jitter is added so that the paused requests don't all resume at the same time
+		delay = withJitter(paused, p.random)
+		if p.options.MaxWait > 0 && delay > p.options.MaxWait {
+			delay = p.options.MaxWait
+		}
+	}
+
+	if key, ok := rateLimitKey(request); ok {
+		delay = max(delay, p.limiter.reserve(key, now))
+	}
+
+	if delay <= 0 {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Requests are being throttled - delaying request to %s by %s", request.URL, delay)
+	return p.sleep(request.Context(), delay)
+}
+
+// observe pauses further requests of the same kind to the host when the request was throttled, and updates
+// the rate limiter using the `x-ms-ratelimit-remaining-*` headers from the response
+func (p *throttlingPolicy) observe(request *http.Request, response *http.Response) {
+	if response == nil {
+		return
+	}
+
+	if key, ok := pauseKey(request); ok && isThrottled(response) {
+		delay := p.pauseDuration(response)
+		log.Printf("[DEBUG] Request to %s was throttled (%s) - pausing requests for %q for %s", request.URL, response.Status, key, delay)
+		p.limiter.pause(key, time.Now().Add(delay))
+	}
+
+	key, ok := rateLimitKey(request)
+	if !ok {
+		return
+	}
+
+	header := fmt.Sprintf("x-ms-ratelimit-remaining-subscription-%s", requestKind(request))
+	value := response.Header.Get(header)
+	if value == "" {
+		return
+	}
+
+	remaining, err := strconv.Atoi(value)
+	if err != nil {
+		return
+	}
+
+	p.limiter.update(key, remaining, p.options.RemainingRequestsThreshold, time.Now())
+}
+
+// pauseDuration returns the duration requests should be paused for after being throttled, honouring the
+// `Retry-After` header when present - this isn't limited to MaxWait, instead requests are failed whilst
+// paused for longer than MaxWait (see wait)
+func (p *throttlingPolicy) pauseDuration(response *http.Response) time.Duration {
+	delay := retryAfter(response, time.Now())
+	if delay <= 0 {
+		delay = throttlingDefaultWait
+	}
+
+	return delay
+}
+
+func isThrottled(response *http.Response) bool {
+	return response != nil && (response.StatusCode == http.StatusTooManyRequests || response.StatusCode == http.StatusServiceUnavailable)
+}
+
+// retryAfter parses the `Retry-After` header (or the millisecond variants returned by some APIs) from the response
+func retryAfter(response *http.Response, now time.Time) time.Duration {
+	if response == nil {
+		return 0
+	}
+
+	for _, header := range []string{"retry-after-ms", "x-ms-retry-after-ms"} {
+		if v := response.Header.Get(header); v != "" {
+			if ms, err := strconv.ParseInt(v, 10, 64); err == nil && ms > 0 {
+				return time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+
+	v := response.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(v); err == nil {
+		return max(0, date.Sub(now))
+	}
+
+	return 0
+}
+
+// withJitter extends the duration by a random proportion of up to throttlingJitter - the duration is never
+// shortened, so that the `Retry-After` returned by the API is always honoured
+func withJitter(duration time.Duration, random func() float64) time.Duration {
+	if duration <= 0 {
+		return duration
+	}
+
+	return duration + time.Duration(float64(duration)*throttlingJitter*random())
+}
+
+// pauseKey returns the key used to pause requests once throttled, comprised of the host and the kind of
+// request - since throttling is applied by each API (and by ARM for each kind of request) rather than for
+// the Subscription as a whole
+func pauseKey(request *http.Request) (string, bool) {
+	if request == nil || request.URL == nil || request.URL.Host == "" {
+		return "", false
+	}
+
+	return fmt.Sprintf("%s/%s", strings.ToLower(request.URL.Host), requestKind(request)), true
+}
+
+// rateLimitKey returns the key for the rate limiter bucket for this request, comprised of the
+// Subscription ID and the kind of request - since ARM applies separate limits for reads/writes/deletes
+func rateLimitKey(request *http.Request) (string, bool) {
+	if request == nil || request.URL == nil {
+		return "", false
+	}
+
+	matches := subscriptionIdRegex.FindStringSubmatch(request.URL.Path)
+	if len(matches) != 2 {
+		return "", false
+	}
+
+	return fmt.Sprintf("%s/%s", strings.ToLower(matches[1]), requestKind(request)), true
+}
+
+func requestKind(request *http.Request) string {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead:
+		return "reads"
+	case http.MethodDelete:
+		return "deletes"
+	default:
+		return "writes"
+	}
+}
+
+func sleepWithContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rateLimiter is a set of token buckets keyed by Subscription and request kind, along with the
+// pauses keyed by host and request kind
+type rateLimiter struct {
+	lock    sync.Mutex
+	buckets map[string]*tokenBucket
+	pauses  map[string]time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		buckets: make(map[string]*tokenBucket),
+		pauses:  make(map[string]time.Time),
+	}
+}
+
+func (l *rateLimiter) bucket(key string) *tokenBucket {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{}
+		l.buckets[key] = b
+	}
+
+	return b
+}
+
+// reserve takes a token from the bucket for the specified key, returning how long to wait before sending the request
+func (l *rateLimiter) reserve(key string, now time.Time) time.Duration {
+	return l.bucket(key).reserve(now)
+}
+
+// pause blocks requests for the specified key until `until`
+func (l *rateLimiter) pause(key string, until time.Time) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if until.After(l.pauses[key]) {
+		l.pauses[key] = until
+	}
+}
+
+// pausedFor returns how long requests for the specified key remain paused for
+func (l *rateLimiter) pausedFor(key string, now time.Time) time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	until, ok := l.pauses[key]
+	if !ok {
+		return 0
+	}
+	if !until.After(now) {
+		delete(l.pauses, key)
+		return 0
+	}
+
+	return until.Sub(now)
+}
+
+// update adjusts the rate for the specified key based on the number of remaining requests
+func (l *rateLimiter) update(key string, remaining, threshold int, now time.Time) {
+	l.bucket(key).update(remaining, threshold, now)
+}
+
+// tokenBucket is unlimited until the remaining requests drop below the threshold, at which point the
+// rate is reduced proportionally to the remaining requests
+type tokenBucket struct {
+	lock sync.Mutex
+
+	capacity          float64
+	tokens            float64
+	requestsPerSecond float64
+	lastRefill        time.Time
+}
+
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.requestsPerSecond <= 0 {
+		return 0
+	}
+
+	b.refill(now)
+
+	// tokens are allowed to go negative, so that concurrent requests queue behind one another
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.requestsPerSecond * float64(time.Second))
+}
+
+func (b *tokenBucket) update(remaining, threshold int, now time.Time) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if remaining >= threshold {
+		b.requestsPerSecond = 0
+		return
+	}
+
+	b.refill(now)
+
+	wasUnlimited := b.requestsPerSecond <= 0
+	b.requestsPerSecond = math.Max(minimumThrottledRequestsPerSecond, throttledRequestsPerSecond*float64(remaining)/float64(threshold))
+	b.capacity = math.Max(1, float64(remaining))
+	if wasUnlimited || b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.lastRefill = now
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if !b.lastRefill.IsZero() && b.requestsPerSecond > 0 {
+		elapsed := now.Sub(b.lastRefill).Seconds()
+		b.tokens = math.Min(b.capacity, b.tokens+elapsed*b.requestsPerSecond)
+	}
+	b.lastRefill = now
+}
@@ -0,0 +1,495 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const testSubscriptionPath = "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example/providers/Microsoft.Foo/bars/example"
+
+// fakeArmServer is an httptest stand-in for Azure Resource Manager, which returns the queued
+// responses in turn (and then HTTP 200) - recording the requests it receives
+type fakeArmServer struct {
+	lock      sync.Mutex
+	responses []fakeArmResponse
+	bodies    []string
+}
+
+type fakeArmResponse struct {
+	statusCode int
+	headers    map[string]string
+}
+
+func (s *fakeArmServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	s.bodies = append(s.bodies, string(body))
+
+	response := fakeArmResponse{
+		statusCode: http.StatusOK,
+	}
+	if len(s.responses) > 0 {
+		response = s.responses[0]
+		s.responses = s.responses[1:]
+	}
+
+	for k, v := range response.headers {
+		w.Header().Set(k, v)
+	}
+	w.WriteHeader(response.statusCode)
+	_, _ = w.Write([]byte(`{}`))
+}
+
+func (s *fakeArmServer) requestCount() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return len(s.bodies)
+}
+
+type recordingSleeper struct {
+	lock      sync.Mutex
+	durations []time.Duration
+}
+
+func (s *recordingSleeper) sleep(_ context.Context, duration time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.durations = append(s.durations, duration)
+	return nil
+}
+
+func testThrottlingPolicy(t *testing.T, options ThrottlingOptions) (*throttlingPolicy, *recordingSleeper) {
+	t.Helper()
+
+	sleeper := &recordingSleeper{}
+	return &throttlingPolicy{
+		options: options,
+		limiter: newRateLimiter(),
+		random:  func() float64 { return 0 },
+		sleep:   sleeper.sleep,
+	}, sleeper
+}
+
+func sendWithPolicy(t *testing.T, server *httptest.Server, policy *throttlingPolicy, method, body string) *http.Response {
+	t.Helper()
+
+	return sendToPathWithPolicy(t, server, policy, method, testSubscriptionPath, body)
+}
+
+func sendToPathWithPolicy(t *testing.T, server *httptest.Server, policy *throttlingPolicy, method, path, body string) *http.Response {
+	t.Helper()
+
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	request, err := http.NewRequestWithContext(context.Background(), method, server.URL+path, reader)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+
+	request, err = throttlingRequestMiddleware(policy)(request)
+	if err != nil {
+		t.Fatalf("running request middleware: %+v", err)
+	}
+
+	response, err := server.Client().Do(request)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+
+	response, err = throttlingResponseMiddleware(policy)(request, response)
+	if err != nil {
+		t.Fatalf("running response middleware: %+v", err)
+	}
+
+	return response
+}
+
+func TestThrottlingPausesRequestsWithRetryAfter(t *testing.T) {
+	arm := &fakeArmServer{
+		responses: []fakeArmResponse{
+			{statusCode: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": "7"}},
+		},
+	}
+	server := httptest.NewServer(arm)
+	defer server.Close()
+
+	policy, sleeper := testThrottlingPolicy(t, DefaultThrottlingOptions())
+	response := sendWithPolicy(t, server, policy, http.MethodPut, `{"hello":"world"}`)
+
+	// throttled requests are retried by the SDK, so should be returned as-is rather than resent
+	if response.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected a 429 but got %d", response.StatusCode)
+	}
+	if arm.requestCount() != 1 {
+		t.Fatalf("expected 1 request but got %d", arm.requestCount())
+	}
+	if len(sleeper.durations) != 0 {
+		t.Fatalf("expected no waits before the request was throttled but got %d", len(sleeper.durations))
+	}
+
+	// subsequent requests for the Subscription should then be paused until the `Retry-After`
+	response = sendWithPolicy(t, server, policy, http.MethodPut, `{"hello":"world"}`)
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected a 200 but got %d", response.StatusCode)
+	}
+	if len(sleeper.durations) != 1 {
+		t.Fatalf("expected 1 wait but got %d", len(sleeper.durations))
+	}
+	if actual := sleeper.durations[0]; actual < 6*time.Second || actual > 7*time.Second {
+		t.Fatalf("expected the wait to be around 7s but got %s", actual)
+	}
+}
+
+func TestThrottlingHonoursMaxWait(t *testing.T) {
+	arm := &fakeArmServer{
+		responses: []fakeArmResponse{
+			{statusCode: http.StatusServiceUnavailable, headers: map[string]string{"Retry-After": "600"}},
+		},
+	}
+	server := httptest.NewServer(arm)
+	defer server.Close()
+
+	options := DefaultThrottlingOptions()
+	options.MaxWait = 3 * time.Second
+	policy, sleeper := testThrottlingPolicy(t, options)
+	sendWithPolicy(t, server, policy, http.MethodGet, "")
+
+	// since the `Retry-After` exceeds the MaxWait, subsequent requests fail rather than being sent early
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+testSubscriptionPath, nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	if _, err := throttlingRequestMiddleware(policy)(request); err == nil {
+		t.Fatalf("expected an error when paused for longer than %s", options.MaxWait)
+	}
+	if arm.requestCount() != 1 {
+		t.Fatalf("expected 1 request but got %d", arm.requestCount())
+	}
+	if len(sleeper.durations) != 0 {
+		t.Fatalf("expected no waits but got %d", len(sleeper.durations))
+	}
+
+	// writes are tracked separately to reads, so shouldn't be paused
+	sendWithPolicy(t, server, policy, http.MethodPut, "{}")
+	if len(sleeper.durations) != 0 || arm.requestCount() != 2 {
+		t.Fatalf("expected writes not to be paused when only reads were throttled")
+	}
+}
+
+func TestThrottlingPauseJitterHonoursMaxWait(t *testing.T) {
+	arm := &fakeArmServer{
+		responses: []fakeArmResponse{
+			{statusCode: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": "10"}},
+		},
+	}
+	server := httptest.NewServer(arm)
+	defer server.Close()
+
+	options := DefaultThrottlingOptions()
+	options.MaxWait = 10 * time.Second
+	policy, sleeper := testThrottlingPolicy(t, options)
+	policy.random = func() float64 { return 0.5 }
+	sendWithPolicy(t, server, policy, http.MethodGet, "")
+	sendWithPolicy(t, server, policy, http.MethodGet, "")
+
+	if len(sleeper.durations) != 1 {
+		t.Fatalf("expected 1 wait but got %d", len(sleeper.durations))
+	}
+	if actual := sleeper.durations[0]; actual != options.MaxWait {
+		t.Fatalf("expected the jitter to be capped at %s but got %s", options.MaxWait, actual)
+	}
+}
+
+func TestThrottlingPausesRequestsForHost(t *testing.T) {
+	arm := &fakeArmServer{
+		responses: []fakeArmResponse{
+			{statusCode: http.StatusServiceUnavailable, headers: map[string]string{"Retry-After": "5"}},
+		},
+	}
+	server := httptest.NewServer(arm)
+	defer server.Close()
+	otherServer := httptest.NewServer(&fakeArmServer{})
+	defer otherServer.Close()
+
+	policy, sleeper := testThrottlingPolicy(t, DefaultThrottlingOptions())
+	sendWithPolicy(t, server, policy, http.MethodGet, "")
+
+	// requests to other hosts shouldn't be paused, even for the same Subscription
+	sendWithPolicy(t, otherServer, policy, http.MethodGet, "")
+	if len(sleeper.durations) != 0 {
+		t.Fatalf("expected requests to other hosts not to be paused but got %d waits", len(sleeper.durations))
+	}
+
+	// whereas requests of the same kind to the same host should be, regardless of the Subscription
+	sendToPathWithPolicy(t, server, policy, http.MethodGet, "/subscriptions/22222222-2222-2222-2222-222222222222/resourceGroups/example", "")
+	if len(sleeper.durations) != 1 {
+		t.Fatalf("expected 1 wait but got %d", len(sleeper.durations))
+	}
+}
+
+func TestThrottlingPauseIncludesJitter(t *testing.T) {
+	arm := &fakeArmServer{
+		responses: []fakeArmResponse{
+			{statusCode: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": "10"}},
+		},
+	}
+	server := httptest.NewServer(arm)
+	defer server.Close()
+
+	policy, sleeper := testThrottlingPolicy(t, DefaultThrottlingOptions())
+	policy.random = func() float64 { return 0.5 }
+	sendWithPolicy(t, server, policy, http.MethodGet, "")
+	sendWithPolicy(t, server, policy, http.MethodGet, "")
+
+	if len(sleeper.durations) != 1 {
+		t.Fatalf("expected 1 wait but got %d", len(sleeper.durations))
+	}
+	// the remaining pause (just under 10s) is extended by 10%
+	if actual := sleeper.durations[0]; actual <= 10*time.Second || actual > 11*time.Second {
+		t.Fatalf("expected the wait to be around 11s but got %s", actual)
+	}
+}
+
+func TestThrottlingSlowsDownAsQuotaDrops(t *testing.T) {
+	arm := &fakeArmServer{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ms-ratelimit-remaining-subscription-writes", "2")
+		arm.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	policy, sleeper := testThrottlingPolicy(t, DefaultThrottlingOptions())
+	for i := 0; i < 5; i++ {
+		sendWithPolicy(t, server, policy, http.MethodPut, "{}")
+	}
+
+	if len(sleeper.durations) == 0 {
+		t.Fatalf("expected requests to be delayed once the remaining quota dropped below the threshold")
+	}
+
+	// reads are tracked separately to writes, so shouldn't be delayed
+	waits := len(sleeper.durations)
+	sendWithPolicy(t, server, policy, http.MethodGet, "")
+	if len(sleeper.durations) != waits {
+		t.Fatalf("expected reads not to be delayed when only the remaining writes are low")
+	}
+}
+
+func TestThrottlingWithAutorestSender(t *testing.T) {
+	arm := &fakeArmServer{
+		responses: []fakeArmResponse{
+			{statusCode: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": "1"}},
+		},
+	}
+	server := httptest.NewServer(arm)
+	defer server.Close()
+
+	policy, sleeper := testThrottlingPolicy(t, DefaultThrottlingOptions())
+	sender := autorest.DecorateSender(server.Client(), withThrottling(policy))
+
+	for _, expected := range []int{http.StatusTooManyRequests, http.StatusOK} {
+		request, _ := http.NewRequest(http.MethodGet, server.URL+testSubscriptionPath, nil)
+		response, err := sender.Do(request)
+		if err != nil {
+			t.Fatalf("sending request: %+v", err)
+		}
+		if response.StatusCode != expected {
+			t.Fatalf("expected a %d but got %d", expected, response.StatusCode)
+		}
+	}
+
+	if arm.requestCount() != 2 {
+		t.Fatalf("expected 2 requests but got %d", arm.requestCount())
+	}
+	if len(sleeper.durations) != 1 {
+		t.Fatalf("expected the second request to be paused")
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	testData := []struct {
+		Name     string
+		Headers  map[string]string
+		Expected time.Duration
+	}{
+		{
+			Name:     "No Header",
+			Headers:  map[string]string{},
+			Expected: 0,
+		},
+		{
+			Name: "Seconds",
+			Headers: map[string]string{
+				"Retry-After": "17",
+			},
+			Expected: 17 * time.Second,
+		},
+		{
+			Name: "HTTP Date",
+			Headers: map[string]string{
+				"Retry-After": now.Add(30 * time.Second).Format(http.TimeFormat),
+			},
+			Expected: 30 * time.Second,
+		},
+		{
+			Name: "Milliseconds",
+			Headers: map[string]string{
+				"Retry-After":    "10",
+				"retry-after-ms": "1500",
+			},
+			Expected: 1500 * time.Millisecond,
+		},
+		{
+			Name: "Milliseconds Prefixed",
+			Headers: map[string]string{
+				"x-ms-retry-after-ms": "250",
+			},
+			Expected: 250 * time.Millisecond,
+		},
+		{
+			Name: "Invalid Milliseconds",
+			Headers: map[string]string{
+				"Retry-After":    "3",
+				"retry-after-ms": "-1",
+			},
+			Expected: 3 * time.Second,
+		},
+		{
+			Name: "HTTP Date In The Past",
+			Headers: map[string]string{
+				"Retry-After": now.Add(-30 * time.Second).Format(http.TimeFormat),
+			},
+			Expected: 0,
+		},
+		{
+			Name: "Invalid",
+			Headers: map[string]string{
+				"Retry-After": "soon",
+			},
+			Expected: 0,
+		},
+	}
+
+	if actual := retryAfter(nil, now); actual != 0 {
+		t.Fatalf("expected no wait for a nil response but got %s", actual)
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		response := &http.Response{
+			Header: http.Header{},
+		}
+		for k, val := range v.Headers {
+			response.Header.Set(k, val)
+		}
+
+		if actual := retryAfter(response, now); actual != v.Expected {
+			t.Fatalf("expected %s but got %s", v.Expected, actual)
+		}
+	}
+}
+
+func TestWithJitter(t *testing.T) {
+	duration := 10 * time.Second
+	for _, random := range []float64{0, 0.25, 0.5, 0.999999} {
+		actual := withJitter(duration, func() float64 { return random })
+		if actual < duration || actual > duration+time.Duration(float64(duration)*throttlingJitter) {
+			t.Fatalf("expected the jitter for %f to be within [%s, %s] but got %s", random, duration, time.Duration(float64(duration)*(1+throttlingJitter)), actual)
+		}
+	}
+
+	if actual := withJitter(duration, func() float64 { return 0 }); actual != duration {
+		t.Fatalf("expected no jitter to return %s but got %s", duration, actual)
+	}
+	if actual := withJitter(0, func() float64 { return 0.5 }); actual != 0 {
+		t.Fatalf("expected no jitter to be added to an empty duration but got %s", actual)
+	}
+}
+
+func TestPauseKey(t *testing.T) {
+	testData := []struct {
+		Method   string
+		URL      string
+		Expected string
+	}{
+		{
+			Method:   http.MethodGet,
+			URL:      "https://Management.Azure.com" + testSubscriptionPath,
+			Expected: "management.azure.com/reads",
+		},
+		{
+			Method:   http.MethodPut,
+			URL:      "https://example.vault.azure.net/secrets/example",
+			Expected: "example.vault.azure.net/writes",
+		},
+		{
+			Method:   http.MethodDelete,
+			URL:      "/subscriptions/abc",
+			Expected: "",
+		},
+	}
+
+	for _, v := range testData {
+		request, _ := http.NewRequest(v.Method, v.URL, nil)
+		actual, _ := pauseKey(request)
+		if actual != v.Expected {
+			t.Fatalf("expected %q for %s %s but got %q", v.Expected, v.Method, v.URL, actual)
+		}
+	}
+}
+
+func TestRateLimitKey(t *testing.T) {
+	testData := []struct {
+		Method   string
+		URL      string
+		Expected string
+	}{
+		{
+			Method:   http.MethodGet,
+			URL:      "https://management.azure.com" + testSubscriptionPath,
+			Expected: "11111111-1111-1111-1111-111111111111/reads",
+		},
+		{
+			Method:   http.MethodPatch,
+			URL:      "https://management.azure.com/SUBSCRIPTIONS/ABC/resourceGroups/example",
+			Expected: "abc/writes",
+		},
+		{
+			Method:   http.MethodDelete,
+			URL:      "https://management.azure.com/subscriptions/abc/resourceGroups/example",
+			Expected: "abc/deletes",
+		},
+		{
+			Method:   http.MethodGet,
+			URL:      "https://example.vault.azure.net/secrets/example",
+			Expected: "",
+		},
+	}
+
+	for _, v := range testData {
+		request, _ := http.NewRequest(v.Method, v.URL, nil)
+		actual, _ := rateLimitKey(request)
+		if actual != v.Expected {
+			t.Fatalf("expected %q for %s %s but got %q", v.Expected, v.Method, v.URL, actual)
+		}
+	}
+}
@@ -0,0 +1,464 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-azurerm/version"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// TracingOptions configures how the requests made to Azure (and the responses) are logged, and
+// optionally exported as OpenTelemetry spans
+type TracingOptions struct {
+	// RedactedFields are the names of the fields within request/response bodies which should be
+	// redacted, in addition to DefaultRedactedFields
+	RedactedFields []string
+
+	// OtlpEndpoint is the URL of the OTLP/HTTP endpoint which spans should be exported to (for example
+	// `http://localhost:4318/v1/traces`), when empty spans aren't exported
+	OtlpEndpoint string
+}
+
+const (
+	tracerName = "github.com/hashicorp/terraform-provider-azurerm"
+
+	// otlpTracesPath is the default path for the OTLP/HTTP traces endpoint
+	otlpTracesPath = "/v1/traces"
+)
+
+var (
+	// tracerProviderLock guards the tracerProvider, which is shared across all clients (and Provider
+	// instances) since there's a single OTLP exporter for the plugin process
+	tracerProviderLock sync.Mutex
+	tracerProvider     trace.TracerProvider = noop.NewTracerProvider()
+
+	// tracerProviderShutdown flushes and stops the OTLP exporter, when one is configured
+	tracerProviderShutdown func(ctx context.Context) error
+)
+
+// ConfigureTracing configures the export of spans to the OTLP endpoint specified in the TracingOptions, this
+// is a no-op when no endpoint is specified or an exporter has already been configured
+func ConfigureTracing(ctx context.Context, options TracingOptions) error {
+	if options.OtlpEndpoint == "" {
+		return nil
+	}
+
+	tracerProviderLock.Lock()
+	defer tracerProviderLock.Unlock()
+
+	if tracerProviderShutdown != nil {
+		return nil
+	}
+
+	endpoint, err := url.Parse(options.OtlpEndpoint)
+	if err != nil {
+		return fmt.Errorf("parsing the OTLP endpoint %q: %+v", options.OtlpEndpoint, err)
+	}
+	if endpoint.Path == "" || endpoint.Path == "/" {
+		endpoint.Path = otlpTracesPath
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint.String()))
+	if err != nil {
+		return fmt.Errorf("building the OTLP exporter for %q: %+v", endpoint.String(), err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter, sdktrace.WithBatchTimeout(time.Second)),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", "terraform-provider-azurerm"),
+			attribute.String("service.version", version.ProviderVersion),
+		)),
+	)
+	log.Printf("[DEBUG] Exporting spans to the OTLP endpoint %q", endpoint.String())
+
+	tracerProvider = provider
+	tracerProviderShutdown = provider.Shutdown
+	return nil
+}
+
+// ShutdownTracing flushes any spans which have yet to be exported and stops the OTLP exporter, this
+// should be called prior to the plugin process exiting
+func ShutdownTracing(ctx context.Context) error {
+	tracerProviderLock.Lock()
+	defer tracerProviderLock.Unlock()
+
+	if tracerProviderShutdown == nil {
+		return nil
+	}
+
+	err := tracerProviderShutdown(ctx)
+	tracerProvider = noop.NewTracerProvider()
+	tracerProviderShutdown = nil
+	return err
+}
+
+func tracer() trace.Tracer {
+	tracerProviderLock.Lock()
+	defer tracerProviderLock.Unlock()
+
+	return tracerProvider.Tracer(tracerName, trace.WithInstrumentationVersion(version.ProviderVersion))
+}
+
+type operationScopeKey struct{}
+
+// operationScope is the Terraform operation (e.g. the Create of an `azurerm_resource_group`) which
+// a request was made as a part of
+type operationScope struct {
+	resourceType string
+	operation    string
+}
+
+// StartOperation starts a span for the Terraform operation (e.g. `Create`) for the specified Resource
+// or Data Source, which requests made using the returned context are nested within. The returned
+// function must be called with the result of the operation once it has completed.
+func StartOperation(ctx context.Context, resourceType string, operation string) (context.Context, func(err error)) {
+	ctx = context.WithValue(ctx, operationScopeKey{}, operationScope{
+		resourceType: resourceType,
+		operation:    operation,
+	})
+
+	ctx, span := tracer().Start(ctx, fmt.Sprintf("%s %s", resourceType, operation), trace.WithAttributes(
+		attribute.String("terraform.resource_type", resourceType),
+		attribute.String("terraform.operation", operation),
+	))
+
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+type requestTraceKey struct{}
+
+// requestTrace tracks a request which has been sent, so that the response can be correlated with it
+type requestTrace struct {
+	start time.Time
+	span  trace.Span
+
+	// ended ensures that the response (or error) is only logged once, since a request sent using the
+	// go-azure-sdk can end either when the response is received or when its context ends
+	ended sync.Once
+
+	lock sync.Mutex
+
+	// lastErr is the last error which occurred whilst sending the request (e.g. connecting to the host)
+	lastErr error
+
+	// stop unregisters the function ending the request when its context ends
+	stop func() bool
+}
+
+// clientTrace returns a httptrace.ClientTrace recording the errors which occur whilst sending the request,
+// which are otherwise only returned to the caller
+func (rt *requestTrace) clientTrace() *httptrace.ClientTrace {
+	record := func(err error) {
+		if err == nil {
+			return
+		}
+
+		rt.lock.Lock()
+		defer rt.lock.Unlock()
+		rt.lastErr = err
+	}
+
+	return &httptrace.ClientTrace{
+		DNSDone: func(info httptrace.DNSDoneInfo) {
+			record(info.Err)
+		},
+		ConnectDone: func(_, _ string, err error) {
+			record(err)
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			record(err)
+		},
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
+			record(info.Err)
+		},
+	}
+}
+
+// err returns the last error which occurred whilst sending the request, else the reason the context ended
+func (rt *requestTrace) err(ctx context.Context) error {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	if rt.lastErr != nil {
+		return rt.lastErr
+	}
+	return context.Cause(ctx)
+}
+
+func (rt *requestTrace) setStop(stop func() bool) {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+	rt.stop = stop
+}
+
+func (rt *requestTrace) unregister() {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+	if rt.stop != nil {
+		rt.stop()
+	}
+}
+
+func tracingRequestMiddleware(redactor *redactor) client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		request = startRequestTrace(request, redactor)
+
+		rt, ok := request.Context().Value(requestTraceKey{}).(*requestTrace)
+		if !ok {
+			return request, nil
+		}
+
+		// the go-azure-sdk only calls the response middleware once a response has been received - so when sending
+		// the request fails (e.g. the host can't be reached, or the context is cancelled) the errors are recorded,
+		// and the request is ended (logging the error) once its context ends
+		request = request.WithContext(httptrace.WithClientTrace(request.Context(), rt.clientTrace()))
+		rt.setStop(context.AfterFunc(request.Context(), func() {
+			endRequestTrace(request, nil, rt.err(request.Context()), redactor)
+		}))
+
+		return request, nil
+	}
+}
+
+func tracingResponseMiddleware(redactor *redactor) client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		endRequestTrace(request, response, nil, redactor)
+		return response, nil
+	}
+}
+
+// withTracing returns an autorest.SendDecorator applying the tracing to the legacy autorest clients
+func withTracing(redactor *redactor) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			r = startRequestTrace(r, redactor)
+			resp, err := s.Do(r)
+			endRequestTrace(r, resp, err, redactor)
+			return resp, err
+		})
+	}
+}
+
+// startRequestTrace logs the request and starts a span for it, returning the request with the
+// requestTrace stored in its context
+func startRequestTrace(request *http.Request, redactor *redactor) *http.Request {
+	ctx := request.Context()
+
+	fields := requestFields(ctx, request, redactor)
+	fields["http_request_headers"] = redactor.headers(request.Header)
+	if isBinaryContentType(request.Header.Get("Content-Type")) {
+		fields["http_request_body"] = "(binary)"
+	} else if body := readRequestBody(request); len(body) > 0 {
+		fields["http_request_body"] = redactor.body(request.URL, body)
+	}
+	tflog.Debug(ctx, "AzureRM Request", fields)
+
+	attributes := []attribute.KeyValue{
+		attribute.String("http.request.method", request.Method),
+		attribute.String("url.full", redactor.url(request.URL)),
+		attribute.String("server.address", request.URL.Hostname()),
+	}
+	if v := request.Header.Get(HeaderCorrelationRequestID); v != "" {
+		attributes = append(attributes, attribute.String("azure.correlation_request_id", v))
+	}
+	if scope, ok := ctx.Value(operationScopeKey{}).(operationScope); ok {
+		attributes = append(attributes,
+			attribute.String("terraform.resource_type", scope.resourceType),
+			attribute.String("terraform.operation", scope.operation),
+		)
+	}
+
+	ctx, span := tracer().Start(ctx, fmt.Sprintf("%s %s", request.Method, request.URL.Path),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+
+	return request.WithContext(context.WithValue(ctx, requestTraceKey{}, &requestTrace{
+		start: time.Now(),
+		span:  span,
+	}))
+}
+
+// endRequestTrace logs the response (or error) for the request and ends the span for it, this is a no-op
+// when the request has already been ended
+func endRequestTrace(request *http.Request, response *http.Response, err error, redactor *redactor) {
+	rt, ok := request.Context().Value(requestTraceKey{}).(*requestTrace)
+	if !ok {
+		logRequestEnd(request, response, err, redactor, nil)
+		return
+	}
+
+	rt.ended.Do(func() {
+		rt.unregister()
+		logRequestEnd(request, response, err, redactor, rt)
+	})
+}
+
+func logRequestEnd(request *http.Request, response *http.Response, err error, redactor *redactor, rt *requestTrace) {
+	ctx := request.Context()
+
+	fields := requestFields(ctx, request, redactor)
+	if rt != nil {
+		fields["http_duration_ms"] = time.Since(rt.start).Milliseconds()
+	}
+
+	switch {
+	case err != nil:
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "AzureRM Response Error", fields)
+
+	case response != nil:
+		fields["http_status_code"] = response.StatusCode
+		fields["http_response_headers"] = redactor.headers(response.Header)
+		if v := response.Header.Get(headerRequestID); v != "" {
+			fields["request_id"] = v
+		}
+		if isBinaryContentType(response.Header.Get("Content-Type")) {
+			fields["http_response_body"] = "(binary)"
+		} else if body := readResponseBody(request, response); len(body) > 0 {
+			fields["http_response_body"] = redactor.body(request.URL, body)
+		}
+		tflog.Debug(ctx, "AzureRM Response", fields)
+
+	default:
+		tflog.Debug(ctx, "AzureRM Request completed with no response", fields)
+	}
+
+	if rt == nil {
+		return
+	}
+
+	if err != nil {
+		rt.span.RecordError(err)
+		rt.span.SetStatus(codes.Error, err.Error())
+	}
+	if response != nil {
+		rt.span.SetAttributes(attribute.Int("http.response.status_code", response.StatusCode))
+		if v := response.Header.Get(headerRequestID); v != "" {
+			rt.span.SetAttributes(attribute.String("azure.request_id", v))
+		}
+		if response.StatusCode >= http.StatusBadRequest {
+			rt.span.SetStatus(codes.Error, response.Status)
+		}
+	}
+	rt.span.End()
+}
+
+// headerRequestID is the header containing the ID which Azure assigned to the request
+const headerRequestID = "x-ms-request-id"
+
+func requestFields(ctx context.Context, request *http.Request, redactor *redactor) map[string]interface{} {
+	fields := map[string]interface{}{
+		"http_method": request.Method,
+		"http_url":    redactor.url(request.URL),
+	}
+	if v := request.Header.Get(HeaderCorrelationRequestID); v != "" {
+		fields["correlation_request_id"] = v
+	}
+	if scope, ok := ctx.Value(operationScopeKey{}).(operationScope); ok {
+		fields["tf_resource_type"] = scope.resourceType
+		fields["tf_operation"] = scope.operation
+	}
+	return fields
+}
+
+// readRequestBody returns the body of the request, ensuring that it can still be sent (and replayed)
+func readRequestBody(request *http.Request) []byte {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil
+	}
+
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil
+		}
+		defer body.Close()
+
+		out, _ := io.ReadAll(body)
+		return out
+	}
+
+	out, err := io.ReadAll(request.Body)
+	if err != nil {
+		log.Printf("[DEBUG] Reading the body of the request to %s: %+v", request.URL, err)
+	}
+	request.Body = io.NopCloser(bytes.NewReader(out))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(out)), nil
+	}
+	return out
+}
+
+// readResponseBody returns the body of the response, ensuring that it can still be read by the client
+func readResponseBody(request *http.Request, response *http.Response) []byte {
+	if response.Body == nil || response.Body == http.NoBody {
+		return nil
+	}
+
+	out, err := io.ReadAll(response.Body)
+	if err != nil {
+		log.Printf("[DEBUG] Reading the body of the response from %s: %+v", request.URL, err)
+	}
+	_ = response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(out))
+	return out
+}
+
+// isBinaryContentType returns whether the body of a request/response with the specified Content-Type
+// shouldn't be logged, since it's binary (e.g. the contents of a Blob)
+func isBinaryContentType(contentType string) bool {
+	return strings.HasPrefix(strings.ToLower(contentType), "application/octet-stream")
+}
+
+// ContextWithValues returns a context which is cancelled alongside `ctx`, but which exposes the values from
+// `values` - for example to attribute the requests made by a Resource using the Provider's StopContext to the
+// Terraform operation being performed (and log these using the Terraform logger for that operation)
+func ContextWithValues(ctx context.Context, values context.Context) context.Context {
+	return valuesContext{
+		Context: ctx,
+		values:  values,
+	}
+}
+
+type valuesContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c valuesContext) Value(key any) any {
+	if v := c.values.Value(key); v != nil {
+		return v
+	}
+	return c.Context.Value(key)
+}
@@ -0,0 +1,300 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// testTracerProvider replaces the shared tracerProvider with one recording the spans, for the duration of the test
+func testTracerProvider(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracerProviderLock.Lock()
+	tracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracerProviderLock.Unlock()
+
+	t.Cleanup(func() {
+		tracerProviderLock.Lock()
+		tracerProvider = noop.NewTracerProvider()
+		tracerProviderLock.Unlock()
+	})
+
+	return recorder
+}
+
+func testTracingServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(headerRequestID, "00000000-0000-0000-0000-000000000001")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"name":"example","properties":{"primaryKey":"abc123"}}`))
+	}))
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key string) (attribute.Value, bool) {
+	for _, v := range span.Attributes() {
+		if string(v.Key) == key {
+			return v.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	recorder := testTracerProvider(t)
+	server := testTracingServer()
+	defer server.Close()
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	ctx, end := StartOperation(ctx, "azurerm_example", "Create")
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, server.URL+testSubscriptionPath+"?api-version=2023-01-01", strings.NewReader(`{"properties":{"adminPassword":"P@ssw0rd1234!"}}`))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	request.Header.Set("Authorization", "Bearer abc123")
+	request.Header.Set(HeaderCorrelationRequestID, "11111111-1111-1111-1111-111111111111")
+
+	redactor := newRedactor(nil)
+	request, err = tracingRequestMiddleware(redactor)(request)
+	if err != nil {
+		t.Fatalf("running request middleware: %+v", err)
+	}
+
+	response, err := server.Client().Do(request)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	response, err = tracingResponseMiddleware(redactor)(request, response)
+	if err != nil {
+		t.Fatalf("running response middleware: %+v", err)
+	}
+	end(nil)
+
+	// the response body must still be readable by the client
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("reading response body: %+v", err)
+	}
+	if !strings.Contains(string(body), "abc123") {
+		t.Fatalf("expected the response body to be unchanged but got %q", string(body))
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&logs)
+	if err != nil {
+		t.Fatalf("decoding logs: %+v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries but got %d: %+v", len(entries), entries)
+	}
+	for _, entry := range entries {
+		if entry["correlation_request_id"] != "11111111-1111-1111-1111-111111111111" {
+			t.Fatalf("expected the correlation request id to be logged but got %+v", entry)
+		}
+		if entry["tf_resource_type"] != "azurerm_example" || entry["tf_operation"] != "Create" {
+			t.Fatalf("expected the operation to be logged but got %+v", entry)
+		}
+	}
+	if v := entries[0]["http_request_body"]; v != `{"properties":{"adminPassword":"REDACTED"}}` {
+		t.Fatalf("expected the request body to be redacted but got %q", v)
+	}
+	if headers := entries[0]["http_request_headers"].(map[string]interface{}); headers["Authorization"] != "REDACTED" {
+		t.Fatalf("expected the Authorization header to be redacted but got %q", headers["Authorization"])
+	}
+	if v := entries[1]["http_response_body"]; v != `{"name":"example","properties":{"primaryKey":"REDACTED"}}` {
+		t.Fatalf("expected the response body to be redacted but got %q", v)
+	}
+	if v := entries[1]["http_status_code"]; v != float64(http.StatusCreated) {
+		t.Fatalf("expected the status code to be logged but got %v", v)
+	}
+	if _, ok := entries[1]["http_duration_ms"]; !ok {
+		t.Fatalf("expected the duration to be logged")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans but got %d", len(spans))
+	}
+	requestSpan, operationSpan := spans[0], spans[1]
+	if operationSpan.Name() != "azurerm_example Create" {
+		t.Fatalf("expected the operation span to be named %q but got %q", "azurerm_example Create", operationSpan.Name())
+	}
+	if requestSpan.Parent().SpanID() != operationSpan.SpanContext().SpanID() {
+		t.Fatalf("expected the request span to be nested within the operation span")
+	}
+	if v, ok := spanAttribute(requestSpan, "http.response.status_code"); !ok || v.AsInt64() != http.StatusCreated {
+		t.Fatalf("expected the status code attribute to be %d but got %v", http.StatusCreated, v.AsInt64())
+	}
+	if v, ok := spanAttribute(requestSpan, "azure.request_id"); !ok || v.AsString() != "00000000-0000-0000-0000-000000000001" {
+		t.Fatalf("expected the request id attribute to be set but got %q", v.AsString())
+	}
+	if v, ok := spanAttribute(requestSpan, "terraform.resource_type"); !ok || v.AsString() != "azurerm_example" {
+		t.Fatalf("expected the resource type attribute to be set but got %q", v.AsString())
+	}
+}
+
+func TestTracingMiddlewareWhenRequestFails(t *testing.T) {
+	recorder := testTracerProvider(t)
+
+	// the server is closed so that the connection is refused
+	server := testTracingServer()
+	server.Close()
+
+	var logs bytes.Buffer
+	ctx, cancel := context.WithCancel(tflogtest.RootLogger(context.Background(), &logs))
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+testSubscriptionPath, nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+
+	request, err = tracingRequestMiddleware(newRedactor(nil))(request)
+	if err != nil {
+		t.Fatalf("running request middleware: %+v", err)
+	}
+
+	// the go-azure-sdk doesn't call the response middleware when the request fails
+	if _, err := http.DefaultClient.Do(request); err == nil {
+		t.Fatalf("expected the request to fail")
+	}
+	if len(recorder.Ended()) != 0 {
+		t.Fatalf("expected the span to end once the context ends")
+	}
+	cancel()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(recorder.Ended()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span but got %d", len(spans))
+	}
+	if !strings.Contains(spans[0].Status().Description, "refused") {
+		t.Fatalf("expected the span status to contain the connection error but got %q", spans[0].Status().Description)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&logs)
+	if err != nil {
+		t.Fatalf("decoding logs: %+v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries but got %d: %+v", len(entries), entries)
+	}
+	if v, ok := entries[1]["error"].(string); !ok || !strings.Contains(v, "refused") {
+		t.Fatalf("expected the connection error to be logged but got %+v", entries[1])
+	}
+}
+
+func TestTracingMiddlewareEndsOnce(t *testing.T) {
+	recorder := testTracerProvider(t)
+	server := testTracingServer()
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	request, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+testSubscriptionPath, nil)
+	redactor := newRedactor(nil)
+	request, _ = tracingRequestMiddleware(redactor)(request)
+
+	response, err := server.Client().Do(request)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	if _, err := tracingResponseMiddleware(redactor)(request, response); err != nil {
+		t.Fatalf("running response middleware: %+v", err)
+	}
+
+	// ending the context once the response has been received mustn't end the request again
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span but got %d", len(spans))
+	}
+	if spans[0].Status().Description != "" {
+		t.Fatalf("expected the span not to contain an error but got %q", spans[0].Status().Description)
+	}
+}
+
+func TestTracingWithAutorestSender(t *testing.T) {
+	recorder := testTracerProvider(t)
+	server := testTracingServer()
+	defer server.Close()
+
+	sender := autorest.DecorateSender(server.Client(), withTracing(newRedactor(nil)))
+
+	request, _ := http.NewRequest(http.MethodPut, server.URL+testSubscriptionPath, strings.NewReader(`{}`))
+	response, err := sender.Do(request)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	if response.StatusCode != http.StatusCreated {
+		t.Fatalf("expected a 201 but got %d", response.StatusCode)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span but got %d", len(spans))
+	}
+	if v, ok := spanAttribute(spans[0], "http.response.status_code"); !ok || v.AsInt64() != http.StatusCreated {
+		t.Fatalf("expected the status code attribute to be %d but got %v", http.StatusCreated, v.AsInt64())
+	}
+}
+
+func TestStartOperationRecordsErrors(t *testing.T) {
+	recorder := testTracerProvider(t)
+
+	_, end := StartOperation(context.Background(), "azurerm_example", "Delete")
+	end(errors.New("deleting example"))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span but got %d", len(spans))
+	}
+	if spans[0].Status().Description != "deleting example" {
+		t.Fatalf("expected the span status to contain the error but got %q", spans[0].Status().Description)
+	}
+}
+
+func TestContextWithValues(t *testing.T) {
+	type key struct{}
+
+	stopCtx, cancel := context.WithCancel(context.Background())
+	operationCtx := context.WithValue(context.Background(), key{}, "example")
+	ctx := ContextWithValues(stopCtx, operationCtx)
+
+	if ctx.Value(key{}) != "example" {
+		t.Fatalf("expected the value from the operation context to be exposed")
+	}
+
+	cancel()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("expected the context to be cancelled alongside the stop context")
+	}
+}
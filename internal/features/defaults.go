@@ -67,5 +67,8 @@ func Default() UserFeatures {
 			VMBackupStopProtectionAndRetainDataOnDestroy: false,
 			PurgeProtectedItemsFromVaultOnDestroy:        false,
 		},
+		ExistingResources: ExistingResourcesFeatures{
+			CheckDuringPlan: false,
+		},
 	}
 }
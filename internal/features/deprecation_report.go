@@ -0,0 +1,17 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build deprecationreport
+
+package features
+
+// nextMajorVersionDeprecationsEnabled specifies whether DeprecatedInFourPointOh returns the deprecation message
+// without enabling the 4.0 Beta - this is only available when built with the `deprecationreport` build tag
+var nextMajorVersionDeprecationsEnabled = false
+
+// EnableNextMajorVersionDeprecations makes DeprecatedInFourPointOh return the deprecation message, without
+// changing any other behaviour of the provider. This is used by the `deprecation-report` tool to catalogue
+// the items which will be deprecated in the next major version.
+func EnableNextMajorVersionDeprecations() {
+	nextMajorVersionDeprecationsEnabled = true
+}
@@ -0,0 +1,9 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !deprecationreport
+
+package features
+
+// nextMajorVersionDeprecationsEnabled is only configurable when built with the `deprecationreport` build tag
+const nextMajorVersionDeprecationsEnabled = false
@@ -14,6 +14,9 @@ import (
 // This functionality calls out to the Azure MetaData Service to cache the list of supported
 // Azure Locations for the specified Endpoint - and then uses that to provide enhanced validation
 //
+// In addition, the Resource SKUs available within a Location are retrieved (and cached) from the
+// Resource Manager API to validate the SKUs, Sizes and Availability Zones of resources at plan time.
+//
 // This is enabled by default as of version 2.20 of the Azure Provider, and can be disabled by
 // setting the Environment Variable `ARM_PROVIDER_ENHANCED_VALIDATION` to `false`.
 func EnhancedValidationEnabled() bool {
@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package features
+
+import (
+	"os"
+)
+
+// KeyVaultCacheDirectory returns the directory used to cache the Resource IDs and Data Plane URIs of
+// Key Vaults on disk between runs of the Provider, or an empty string if the on-disk cache is disabled.
+//
+// Looking up the Key Vault which a nested item (e.g. a Key, Secret or Certificate) belongs to can be
+// expensive in large Tenants - caching these avoids repeating these lookups each time the Provider is
+// launched.
+//
+// This is disabled by default, and can be enabled by setting the Environment Variable
+// `ARM_KEY_VAULT_CACHE_DIRECTORY` to the path of a directory.
+func KeyVaultCacheDirectory() string {
+	return os.Getenv("ARM_KEY_VAULT_CACHE_DIRECTORY")
+}
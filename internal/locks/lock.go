@@ -3,6 +3,8 @@
 
 package locks
 
+import "context"
+
 // armMutexKV is the instance of MutexKV for ARM resources
 var armMutexKV = newMutexKV()
 
@@ -16,6 +18,35 @@ func ByName(name string, resourceType string) {
 	armMutexKV.Lock(updatedName)
 }
 
+// Acquire acquires the key once fewer than `limit` callers hold it, for example to limit the number of concurrent
+// operations against a parent resource - blocking until this is the case or the context is cancelled (e.g. when the
+// timeout for the operation is reached).
+//
+// The returned function must be called to release the key. A limit of 1 holds the key exclusively and is the
+// equivalent of ByID, which can be used alongside Acquire for the same key - for example to lock the parent
+// resource whilst it's updated, blocking until the operations against it have completed. Whilst a caller is waiting
+// to hold the key exclusively, further callers wait for it rather than acquiring the key alongside the existing holders.
+func Acquire(ctx context.Context, key string, limit int) (func(), error) {
+	return armMutexKV.Acquire(ctx, key, limit)
+}
+
+// AcquireByID is a context-aware equivalent of ByID, which can be used alongside ByID for the same ID
+func AcquireByID(ctx context.Context, id string) (func(), error) {
+	return armMutexKV.Acquire(ctx, id, 1)
+}
+
+// AcquireByName is a context-aware equivalent of ByName, which can be used alongside ByName for the same name
+func AcquireByName(ctx context.Context, name string, resourceType string) (func(), error) {
+	return AcquireByNameWithLimit(ctx, name, resourceType, 1)
+}
+
+// AcquireByNameWithLimit acquires the name once fewer than `limit` callers hold it - see Acquire. This can be used
+// alongside ByName for the same name, which holds the name exclusively.
+func AcquireByNameWithLimit(ctx context.Context, name string, resourceType string, limit int) (func(), error) {
+	updatedName := resourceType + "." + name
+	return armMutexKV.Acquire(ctx, updatedName, limit)
+}
+
 func MultipleByName(names *[]string, resourceType string) {
 	newSlice := removeDuplicatesFromStringArray(*names)
 
@@ -4,48 +4,77 @@
 package locks
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"sync"
 )
 
-// mutexKV is a simple key/value store for arbitrary mutexes. It can be used to
+// mutexKV is a simple key/value store for arbitrary mutexes (and semaphores). It can be used to
 // serialize changes across arbitrary collaborators that share knowledge of the
 // keys they must serialize on.
 type mutexKV struct {
 	lock  sync.Mutex
-	store map[string]*sync.Mutex
+	store map[string]*semaphore
 }
 
 // Locks the mutex for the given key. Caller is responsible for calling Unlock
 // for the same key
 func (m *mutexKV) Lock(key string) {
 	log.Printf("[DEBUG] Locking %q", key)
-	m.get(key).Lock()
+	// the key is held exclusively, alongside callers acquiring the same key with a higher limit - this can't
+	// fail since the context is never cancelled
+	_, _ = m.get(key).acquire(context.Background(), 1)
 	log.Printf("[DEBUG] Locked %q", key)
 }
 
 // Unlock the mutex for the given key. Caller must have called Lock for the same key first
 func (m *mutexKV) Unlock(key string) {
 	log.Printf("[DEBUG] Unlocking %q", key)
-	m.get(key).Unlock()
+	m.get(key).releaseExclusive()
 	log.Printf("[DEBUG] Unlocked %q", key)
 }
 
-// Returns a mutex for the given key, no guarantee of its lock status
-func (m *mutexKV) get(key string) *sync.Mutex {
+// Acquire acquires the given key once fewer than `limit` callers hold it, blocking until this is the case or the
+// context is cancelled. Caller is responsible for calling the returned function to release the key
+func (m *mutexKV) Acquire(ctx context.Context, key string, limit int) (func(), error) {
+	if limit < 1 {
+		return nil, fmt.Errorf("the limit for %q must be at least 1 but got %d", key, limit)
+	}
+	s := m.get(key)
+
+	log.Printf("[DEBUG] Acquiring %q (limit %d)", key, limit)
+	token, err := s.acquire(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[DEBUG] Acquired %q (limit %d)", key, limit)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			log.Printf("[DEBUG] Releasing %q", key)
+			s.release(token)
+			log.Printf("[DEBUG] Released %q", key)
+		})
+	}, nil
+}
+
+// Returns the semaphore for the given key, no guarantee of its lock status
+func (m *mutexKV) get(key string) *semaphore {
 	m.lock.Lock()
 	defer m.lock.Unlock()
-	mutex, ok := m.store[key]
+	s, ok := m.store[key]
 	if !ok {
-		mutex = &sync.Mutex{}
-		m.store[key] = mutex
+		s = newSemaphore(key)
+		m.store[key] = s
 	}
-	return mutex
+	return s
 }
 
 // newMutexKV returns a properly initialized mutexKV
 func newMutexKV() *mutexKV {
 	return &mutexKV{
-		store: make(map[string]*sync.Mutex),
+		store: make(map[string]*semaphore),
 	}
 }
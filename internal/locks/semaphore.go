@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+)
+
+// waitDiagnosticsInterval is how often the holders of a key are logged whilst waiting to acquire it, to help
+// diagnose deadlocks
+var waitDiagnosticsInterval = time.Minute
+
+// semaphore allows multiple concurrent holders of a key, tracking which goroutines currently hold it - where each
+// holder specifies the maximum number of concurrent holders it can be acquired alongside, and a holder using a limit
+// of 1 holds the key exclusively.
+//
+// Exclusive holders are preferred: once a goroutine is waiting to hold the key exclusively no further holders can
+// acquire it alongside the existing holders, so that a steady stream of shared holders can't starve it.
+type semaphore struct {
+	key string
+
+	lock      sync.Mutex
+	exclusive bool
+	holders   map[uint64]holder
+	next      uint64
+
+	// waitingExclusive is the number of goroutines waiting to hold the key exclusively
+	waitingExclusive int
+
+	// released is closed (and replaced) whenever a holder releases the key, to wake any goroutines waiting to acquire it
+	released chan struct{}
+}
+
+// holder describes the goroutine holding (or waiting to acquire) a key
+type holder struct {
+	// goroutine is the ID of the goroutine, which is only captured when debug logging is enabled
+	goroutine string
+
+	// callers are the program counters of the stack acquiring the key, which are only resolved when
+	// the holder is logged
+	callers []uintptr
+
+	exclusive bool
+	since     time.Time
+}
+
+func (h holder) String() string {
+	if h.goroutine == "" {
+		return caller(h.callers)
+	}
+
+	return fmt.Sprintf("goroutine %s (%s)", h.goroutine, caller(h.callers))
+}
+
+func newSemaphore(key string) *semaphore {
+	return &semaphore{
+		key:      key,
+		holders:  make(map[uint64]holder),
+		released: make(chan struct{}),
+	}
+}
+
+// acquire blocks until fewer than `limit` holders (and no exclusive holder) hold the key, or the context is
+// cancelled - returning a token identifying the holder. A limit of 1 holds the key exclusively, other limits also
+// wait for any goroutines waiting to hold the key exclusively.
+func (s *semaphore) acquire(ctx context.Context, limit int) (uint64, error) {
+	current := holder{
+		callers:   callers(),
+		exclusive: limit == 1,
+	}
+	if logging.IsDebugOrHigher() {
+		current.goroutine = goroutineID()
+	}
+
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("acquiring the lock %q: %w", s.key, err)
+	}
+
+	ticker := time.NewTicker(waitDiagnosticsInterval)
+	defer ticker.Stop()
+
+	started := time.Now()
+	waiting := false
+	for {
+		s.lock.Lock()
+		if !s.exclusive && len(s.holders) < limit && (current.exclusive || s.waitingExclusive == 0) {
+			if waiting {
+				s.waitingExclusive--
+			}
+			current.since = time.Now()
+			s.next++
+			s.holders[s.next] = current
+			s.exclusive = current.exclusive
+			token := s.next
+			s.lock.Unlock()
+			return token, nil
+		}
+		if current.exclusive && !waiting {
+			waiting = true
+			s.waitingExclusive++
+		}
+		released := s.released
+		s.lock.Unlock()
+
+		select {
+		case <-released:
+
+		case <-ctx.Done():
+			if waiting {
+				s.stopWaitingExclusive()
+			}
+			return 0, fmt.Errorf("waiting to acquire the lock %q which is held by %s: %w", s.key, s.describeHolders(), ctx.Err())
+
+		case <-ticker.C:
+			log.Printf("[WARN] %s has been waiting %s to acquire the lock %q which is held by %s", current, time.Since(started).Round(time.Second), s.key, s.describeHolders())
+		}
+	}
+}
+
+// release releases the key held by the specified token
+func (s *semaphore) release(token uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.releaseHolder(token)
+}
+
+// releaseExclusive releases the key held exclusively by any holder, this is only used for mutexes since these can
+// be unlocked by a different goroutine than the one which locked them
+func (s *semaphore) releaseExclusive() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for token, v := range s.holders {
+		if v.exclusive {
+			s.releaseHolder(token)
+			return
+		}
+	}
+
+	panic(fmt.Sprintf("releasing the lock %q which isn't held", s.key))
+}
+
+// stopWaitingExclusive is called when a goroutine gives up waiting to hold the key exclusively, waking any
+// goroutines which were waiting for it to acquire the key
+func (s *semaphore) stopWaitingExclusive() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.waitingExclusive--
+	s.notify()
+}
+
+// releaseHolder removes the holder and wakes any goroutines waiting to acquire the key, the lock must be held
+func (s *semaphore) releaseHolder(token uint64) {
+	v, ok := s.holders[token]
+	if !ok {
+		panic(fmt.Sprintf("releasing the lock %q which isn't held", s.key))
+	}
+	delete(s.holders, token)
+	if v.exclusive {
+		s.exclusive = false
+	}
+
+	s.notify()
+}
+
+// notify wakes any goroutines waiting to acquire the key, the lock must be held
+func (s *semaphore) notify() {
+	close(s.released)
+	s.released = make(chan struct{})
+}
+
+func (s *semaphore) describeHolders() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.holders) == 0 {
+		return "no goroutines"
+	}
+
+	holders := make([]string, 0, len(s.holders))
+	for _, v := range s.holders {
+		holders = append(holders, fmt.Sprintf("%s since %s", v, v.since.Format(time.RFC3339)))
+	}
+	sort.Strings(holders)
+
+	return strings.Join(holders, ", ")
+}
+
+// goroutineID returns the ID of the current goroutine, which is only used to help diagnose deadlocks
+func goroutineID() string {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+
+	// the stack trace starts with `goroutine 123 [running]:`
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i > 0 {
+		return string(buf[:i])
+	}
+
+	return "unknown"
+}
+
+// callers returns the program counters of the current stack, which are resolved using caller
+func callers() []uintptr {
+	pcs := make([]uintptr, 16)
+	return pcs[:runtime.Callers(3, pcs)]
+}
+
+// caller returns the function (and line) outside of this package which is acquiring the lock
+func caller(pcs []uintptr) string {
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if filepath.Base(filepath.Dir(frame.File)) != "locks" || strings.HasSuffix(frame.File, "_test.go") {
+			return fmt.Sprintf("%s:%d", frame.Function, frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAcquireLimitsConcurrency(t *testing.T) {
+	kv := newMutexKV()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var current, maximum int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			release, err := kv.Acquire(ctx, "example", 2)
+			if err != nil {
+				t.Errorf("acquiring: %+v", err)
+				return
+			}
+			defer release()
+
+			v := atomic.AddInt32(&current, 1)
+			for {
+				existing := atomic.LoadInt32(&maximum)
+				if v <= existing || atomic.CompareAndSwapInt32(&maximum, existing, v) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&current, -1)
+		}()
+	}
+	wg.Wait()
+
+	if maximum != 2 {
+		t.Fatalf("expected at most 2 concurrent holders but got %d", maximum)
+	}
+}
+
+func TestAcquireHonoursContextCancellation(t *testing.T) {
+	kv := newMutexKV()
+	kv.Lock("example")
+	defer kv.Unlock("example")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := kv.Acquire(ctx, "example", 1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the context deadline to be exceeded but got %+v", err)
+	}
+	// the error should describe which goroutine holds the lock
+	if !strings.Contains(err.Error(), "TestAcquireHonoursContextCancellation") {
+		t.Fatalf("expected the error to contain the holder of the lock but got %+v", err)
+	}
+}
+
+func TestAcquireAlongsideLock(t *testing.T) {
+	kv := newMutexKV()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	release, err := kv.Acquire(ctx, "example", 1)
+	if err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+
+	locked := make(chan struct{})
+	go func() {
+		kv.Lock("example")
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		t.Fatalf("expected Lock to block whilst the key is acquired")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// releasing multiple times is a no-op
+	release()
+	release()
+	<-locked
+	kv.Unlock("example")
+}
+
+func TestLockAlongsideAcquireWithLimit(t *testing.T) {
+	kv := newMutexKV()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Lock is used alongside a higher limit for the same key, waiting until every holder has released it
+	first, err := kv.Acquire(ctx, "example", 2)
+	if err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	second, err := kv.Acquire(ctx, "example", 2)
+	if err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+
+	locked := make(chan struct{})
+	go func() {
+		kv.Lock("example")
+		close(locked)
+	}()
+
+	first()
+	select {
+	case <-locked:
+		t.Fatalf("expected Lock to block whilst the key is acquired")
+	case <-time.After(50 * time.Millisecond):
+	}
+	second()
+	<-locked
+
+	// whilst locked, acquiring the key with a higher limit blocks until it's unlocked
+	acquireCtx, acquireCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer acquireCancel()
+	if _, err := kv.Acquire(acquireCtx, "example", 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected acquiring a locked key to block but got %+v", err)
+	}
+
+	kv.Unlock("example")
+	release, err := kv.Acquire(ctx, "example", 2)
+	if err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	release()
+}
+
+func TestAcquireWithDifferentLimits(t *testing.T) {
+	kv := newMutexKV()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	first, err := kv.Acquire(ctx, "example", 3)
+	if err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	defer first()
+	second, err := kv.Acquire(ctx, "example", 3)
+	if err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+
+	// the limit applies to the caller, so a lower limit waits until fewer holders hold the key
+	acquireCtx, acquireCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer acquireCancel()
+	if _, err := kv.Acquire(acquireCtx, "example", 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected acquiring the key with a limit of 2 to block but got %+v", err)
+	}
+
+	second()
+	release, err := kv.Acquire(ctx, "example", 2)
+	if err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	release()
+
+	if _, err := kv.Acquire(ctx, "example", 0); err == nil {
+		t.Fatalf("expected an error for a limit of 0")
+	}
+}
+
+func TestLockIsPreferredOverAcquire(t *testing.T) {
+	kv := newMutexKV()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	first, err := kv.Acquire(ctx, "example", 4)
+	if err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+
+	locked := make(chan struct{})
+	go func() {
+		kv.Lock("example")
+		close(locked)
+	}()
+	waitForExclusiveWaiter(t, kv.get("example"))
+
+	// once Lock is waiting, further holders wait for it rather than being acquired alongside the existing holder
+	acquireCtx, acquireCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer acquireCancel()
+	if _, err := kv.Acquire(acquireCtx, "example", 4); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected acquiring the key to wait for Lock but got %+v", err)
+	}
+
+	acquired := make(chan func())
+	go func() {
+		release, err := kv.Acquire(ctx, "example", 4)
+		if err != nil {
+			t.Errorf("acquiring: %+v", err)
+		}
+		acquired <- release
+	}()
+
+	first()
+	<-locked
+	select {
+	case <-acquired:
+		t.Fatalf("expected acquiring the key to block whilst it's locked")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	kv.Unlock("example")
+	release := <-acquired
+	release()
+}
+
+func TestAcquireOnceLockStopsWaiting(t *testing.T) {
+	kv := newMutexKV()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	first, err := kv.Acquire(ctx, "example", 4)
+	if err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	defer first()
+
+	lockCtx, lockCancel := context.WithCancel(ctx)
+	lockErr := make(chan error)
+	go func() {
+		_, err := kv.Acquire(lockCtx, "example", 1)
+		lockErr <- err
+	}()
+	waitForExclusiveWaiter(t, kv.get("example"))
+
+	acquired := make(chan error)
+	go func() {
+		release, err := kv.Acquire(ctx, "example", 4)
+		if err == nil {
+			release()
+		}
+		acquired <- err
+	}()
+
+	// once the exclusive holder gives up waiting, the other holders can acquire the key alongside the existing holder
+	lockCancel()
+	if err := <-lockErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the context to be cancelled but got %+v", err)
+	}
+	if err := <-acquired; err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+}
+
+// waitForExclusiveWaiter waits until a goroutine is waiting to hold the key exclusively
+func waitForExclusiveWaiter(t *testing.T, s *semaphore) {
+	t.Helper()
+
+	for i := 0; i < 1000; i++ {
+		s.lock.Lock()
+		waiting := s.waitingExclusive
+		s.lock.Unlock()
+		if waiting > 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("expected a goroutine to be waiting to hold the key exclusively")
+}
+
+func TestUnlockFromAnotherGoroutine(t *testing.T) {
+	kv := newMutexKV()
+	kv.Lock("example")
+
+	done := make(chan struct{})
+	go func() {
+		kv.Unlock("example")
+		close(done)
+	}()
+	<-done
+
+	kv.Lock("example")
+	kv.Unlock("example")
+}
+
+func TestAcquireOnlyCapturesGoroutineWhenDebugLogging(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	t.Setenv("TF_LOG", "")
+	s := newSemaphore("example")
+	token, err := s.acquire(ctx, 1)
+	if err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	if v := s.holders[token]; v.goroutine != "" {
+		t.Fatalf("expected the goroutine not to be captured without debug logging but got %q", v.goroutine)
+	}
+	if v := s.describeHolders(); !strings.Contains(v, "TestAcquireOnlyCapturesGoroutineWhenDebugLogging") {
+		t.Fatalf("expected the holder to contain the caller but got %q", v)
+	}
+	s.release(token)
+
+	t.Setenv("TF_LOG", "DEBUG")
+	token, err = s.acquire(ctx, 1)
+	if err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	if v := s.holders[token]; v.goroutine == "" {
+		t.Fatalf("expected the goroutine to be captured with debug logging")
+	}
+	s.release(token)
+}
@@ -0,0 +1,219 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2020-05-01/resourcemanagementprivatelink"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/fakearm"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestExistenceCheckDuringPlan(t *testing.T) {
+	server := fakearm.New(t, fakearm.Options{})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client, err := server.Client(ctx)
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	resourceGroupId := commonids.NewResourceGroupID(server.SubscriptionId, "example-resources")
+	privateLinkId := resourcemanagementprivatelink.NewResourceManagementPrivateLinkID(server.SubscriptionId, resourceGroupId.ResourceGroupName, "example")
+	identityId := commonids.NewUserAssignedIdentityID(server.SubscriptionId, resourceGroupId.ResourceGroupName, "example")
+	lockId := fmt.Sprintf("%s/providers/Microsoft.Authorization/locks/example", resourceGroupId.ID())
+	testData := []struct {
+		Name         string
+		ResourceType string
+		Id           string
+		Config       map[string]interface{}
+
+		// Checked specifies whether the Resource is checked, which requires that it exposes a Resource Identity
+		// or overrides the existence check
+		Checked bool
+	}{
+		{
+			Name:         "Untyped Resource with a Resource Identity",
+			ResourceType: "azurerm_resource_group",
+			Id:           resourceGroupId.ID(),
+			Config: map[string]interface{}{
+				"name":     resourceGroupId.ResourceGroupName,
+				"location": "West Europe",
+			},
+			Checked: true,
+		},
+		{
+			Name:         "Typed Resource with a Resource Identity",
+			ResourceType: "azurerm_resource_management_private_link",
+			Id:           privateLinkId.ID(),
+			Config: map[string]interface{}{
+				"name":                privateLinkId.ResourceManagementPrivateLinkName,
+				"resource_group_name": privateLinkId.ResourceGroupName,
+				"location":            "West Europe",
+			},
+			Checked: true,
+		},
+		{
+			Name:         "Typed Resource overriding the Existence Check",
+			ResourceType: "azurerm_user_assigned_identity",
+			Id:           identityId.ID(),
+			Config: map[string]interface{}{
+				"name":                identityId.UserAssignedIdentityName,
+				"resource_group_name": identityId.ResourceGroupName,
+				"location":            "West Europe",
+			},
+			Checked: true,
+		},
+		{
+			Name:         "Resource without a Resource Identity",
+			ResourceType: "azurerm_management_lock",
+			Id:           lockId,
+			Config: map[string]interface{}{
+				"name":       "example",
+				"scope":      resourceGroupId.ID(),
+				"lock_level": "CanNotDelete",
+			},
+			Checked: false,
+		},
+	}
+
+	resources := AzureProvider().ResourcesMap
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+		resource := resources[v.ResourceType]
+
+		// the resource doesn't exist, so the plan should succeed without the resource being created
+		client.Features.ExistingResources.CheckDuringPlan = true
+		if err := planCreate(ctx, resource, v.Config, client); err != nil {
+			t.Fatalf("expected the plan to succeed when the resource doesn't exist but got %+v", err)
+		}
+		for _, request := range server.Requests() {
+			if request.Method != http.MethodGet {
+				t.Fatalf("expected only GET requests to be sent during the plan but got %s %s", request.Method, request.Path)
+			}
+		}
+		if _, ok := server.Resource(v.Id); ok {
+			t.Fatalf("expected %s not to be created during the plan", v.Id)
+		}
+
+		// once it exists, the plan should fail requiring that the resource is imported
+		server.PutResource(v.Id, map[string]interface{}{"location": "westeurope"})
+		err := planCreate(ctx, resource, v.Config, client)
+		if !v.Checked {
+			if err != nil {
+				t.Fatalf("expected the plan to succeed for a resource which isn't checked but got %+v", err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), "already exists") || !strings.Contains(err.Error(), v.Id) {
+			t.Fatalf("expected an error requiring %s to be imported but got %+v", v.Id, err)
+		}
+
+		// unless the feature is disabled
+		client.Features.ExistingResources.CheckDuringPlan = false
+		if err := planCreate(ctx, resource, v.Config, client); err != nil {
+			t.Fatalf("expected the plan to succeed when the feature is disabled but got %+v", err)
+		}
+	}
+}
+
+func TestExistenceCheckDuringPlanResults(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client := &clients.Client{}
+	client.Features.ExistingResources.CheckDuringPlan = true
+	id := commonids.NewResourceGroupID("00000000-0000-0000-0000-000000000000", "example-resources")
+
+	testData := []struct {
+		Name     string
+		Id       resourceids.Id
+		Exists   bool
+		Err      error
+		Expected string
+	}{
+		{
+			Name: "Doesn't Exist",
+			Id:   id,
+		},
+		{
+			Name:     "Exists",
+			Id:       id,
+			Exists:   true,
+			Expected: "already exists",
+		},
+		{
+			// the ID can't be built during the plan, so the check is skipped
+			Name: "Unknown ID",
+		},
+		{
+			// errors (e.g. insufficient permissions to retrieve the resource) are surfaced rather than skipped
+			Name:     "Error",
+			Id:       id,
+			Err:      fmt.Errorf("unexpected status 403"),
+			Expected: "checking whether the azurerm_example being created already exists: unexpected status 403",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		resource := &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+				},
+			},
+		}
+		sdk.ApplyExistenceCheck("azurerm_example", resource, func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) (resourceids.Id, bool, error) {
+			return v.Id, v.Exists, v.Err
+		})
+
+		err := planCreate(ctx, resource, map[string]interface{}{"name": "example"}, client)
+		if v.Expected == "" {
+			if err != nil {
+				t.Fatalf("expected the plan to succeed but got %+v", err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), v.Expected) {
+			t.Fatalf("expected an error containing %q but got %+v", v.Expected, err)
+		}
+	}
+}
+
+// planCreate runs the diff for creating the resource using the specified configuration, as done during the plan
+func planCreate(ctx context.Context, resource *pluginsdk.Resource, config map[string]interface{}, client *clients.Client) error {
+	raw, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	configSchema := resource.CoreConfigSchema()
+	value, err := ctyjson.Unmarshal(raw, configSchema.ImpliedType())
+	if err != nil {
+		return err
+	}
+
+	// the configuration is exposed to the diff via the prior state when creating a resource
+	state := &terraform.InstanceState{
+		RawConfig: value,
+	}
+	_, err = resource.SimpleDiff(ctx, state, terraform.NewResourceConfigShimmed(value, configSchema), client)
+	return err
+}
@@ -338,6 +338,21 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 				},
 			},
 		},
+
+		"existing_resources": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"check_during_plan": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+				},
+			},
+		},
 	}
 
 	// this is a temporary hack to enable us to gradually add provider blocks to test configurations
@@ -571,5 +586,15 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 		}
 	}
 
+	if raw, ok := val["existing_resources"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 && items[0] != nil {
+			existingResourcesRaw := items[0].(map[string]interface{})
+			if v, ok := existingResourcesRaw["check_during_plan"]; ok {
+				featuresMap.ExistingResources.CheckDuringPlan = v.(bool)
+			}
+		}
+	}
+
 	return featuresMap
 }
@@ -179,6 +179,11 @@ func TestExpandFeatures(t *testing.T) {
 							"purge_protected_items_from_vault_on_destroy":          true,
 						},
 					},
+					"existing_resources": []interface{}{
+						map[string]interface{}{
+							"check_during_plan": true,
+						},
+					},
 				},
 			},
 			Expected: features.UserFeatures{
@@ -243,6 +248,9 @@ func TestExpandFeatures(t *testing.T) {
 					VMBackupStopProtectionAndRetainDataOnDestroy: true,
 					PurgeProtectedItemsFromVaultOnDestroy:        true,
 				},
+				ExistingResources: features.ExistingResourcesFeatures{
+					CheckDuringPlan: true,
+				},
 			},
 		},
 		{
@@ -340,6 +348,11 @@ func TestExpandFeatures(t *testing.T) {
 							"purge_protected_items_from_vault_on_destroy":          false,
 						},
 					},
+					"existing_resources": []interface{}{
+						map[string]interface{}{
+							"check_during_plan": false,
+						},
+					},
 				},
 			},
 			Expected: features.UserFeatures{
@@ -404,6 +417,9 @@ func TestExpandFeatures(t *testing.T) {
 					VMBackupStopProtectionAndRetainDataOnDestroy: false,
 					PurgeProtectedItemsFromVaultOnDestroy:        false,
 				},
+				ExistingResources: features.ExistingResourcesFeatures{
+					CheckDuringPlan: false,
+				},
 			},
 		},
 	}
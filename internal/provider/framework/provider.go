@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	pluginsdk "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	providerfunction "github.com/hashicorp/terraform-provider-azurerm/internal/provider/function"
+	"github.com/hashicorp/terraform-provider-azurerm/version"
+)
+
+var (
+	_ provider.ProviderWithFunctions          = &azureRmFrameworkProvider{}
+	_ provider.ProviderWithEphemeralResources = &azureRmFrameworkProvider{}
+	_ provider.ProviderWithListResources      = &azureRmFrameworkProvider{}
+)
+
+// azureRmFrameworkProvider is the Plugin Framework half of the Azure Provider, which is muxed alongside
+// the Plugin SDKv2 Provider - at this time this only exposes Provider Functions, Ephemeral Resources and List
+// Resources, with all Resources and Data Sources continuing to be served by the Plugin SDKv2 Provider
+type azureRmFrameworkProvider struct {
+	// v2Provider is the Plugin SDKv2 Provider, whose schema this Provider must match
+	v2Provider *pluginsdk.Provider
+
+	// ephemeralResources is the list of Ephemeral Resources exposed by this Provider
+	ephemeralResources []func() ephemeral.EphemeralResource
+
+	// listResources is the list of List Resources exposed by this Provider
+	listResources []func() list.ListResource
+
+	// resourceIds are the Resource ID types used by the Provider Functions to parse and normalise Resource IDs
+	resourceIds []resourceids.ResourceId
+}
+
+// NewFrameworkProvider returns the Plugin Framework Provider, using the schema of the specified Plugin SDKv2 Provider
+// since the Provider schemas must be identical when muxing Providers together.
+func NewFrameworkProvider(v2Provider *pluginsdk.Provider, ephemeralResources []func() ephemeral.EphemeralResource, listResources []func() list.ListResource, resourceIds []resourceids.ResourceId) provider.Provider {
+	return &azureRmFrameworkProvider{
+		v2Provider:         v2Provider,
+		ephemeralResources: ephemeralResources,
+		listResources:      listResources,
+		resourceIds:        resourceIds,
+	}
+}
+
+func (p *azureRmFrameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, response *provider.MetadataResponse) {
+	response.TypeName = "azurerm"
+	response.Version = version.ProviderVersion
+}
+
+func (p *azureRmFrameworkProvider) Schema(ctx context.Context, _ provider.SchemaRequest, response *provider.SchemaResponse) {
+	v2Schema, err := pluginsdk.NewGRPCProviderServer(p.v2Provider).GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		response.Diagnostics.AddError("retrieving the Plugin SDKv2 Provider Schema", err.Error())
+		return
+	}
+	for _, diag := range v2Schema.Diagnostics {
+		if diag.Severity == tfprotov5.DiagnosticSeverityError {
+			response.Diagnostics.AddError(diag.Summary, diag.Detail)
+		}
+	}
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	output, err := providerSchemaFromProtocol(v2Schema.Provider)
+	if err != nil {
+		response.Diagnostics.AddError("converting the Plugin SDKv2 Provider Schema", fmt.Sprintf("%+v", err))
+		return
+	}
+
+	response.Schema = *output
+}
+
+// Configure doesn't configure the Provider block, which is instead configured by the Plugin SDKv2 Provider - since
+// the mux server configures each Provider in order, the Plugin SDKv2 Provider has been configured at this point
+// and so its Client is passed through to the Ephemeral Resources and List Resources.
+func (p *azureRmFrameworkProvider) Configure(_ context.Context, _ provider.ConfigureRequest, response *provider.ConfigureResponse) {
+	response.EphemeralResourceData = p.v2Provider.Meta()
+	response.ListResourceData = p.v2Provider.Meta()
+}
+
+func (p *azureRmFrameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *azureRmFrameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *azureRmFrameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		providerfunction.NewBuildResourceIdFunction(p.resourceIds),
+		providerfunction.NewNormaliseResourceIdFunction(p.resourceIds),
+		providerfunction.NewParseResourceIdFunction(p.resourceIds),
+	}
+}
+
+func (p *azureRmFrameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return p.ephemeralResources
+}
+
+func (p *azureRmFrameworkProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return p.listResources
+}
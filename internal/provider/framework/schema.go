@@ -0,0 +1,256 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// providerSchemaFromProtocol converts the protocol representation of the Plugin SDKv2 Provider schema into the
+// equivalent Plugin Framework Provider schema, such that both Providers expose an identical Provider block
+func providerSchemaFromProtocol(input *tfprotov5.Schema) (*schema.Schema, error) {
+	if input == nil || input.Block == nil {
+		return &schema.Schema{}, nil
+	}
+
+	attributes, blocks, err := expandSchemaBlock(input.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	output := schema.Schema{
+		Attributes: attributes,
+		Blocks:     blocks,
+	}
+	output.Description, output.MarkdownDescription = expandDescription(input.Block.Description, input.Block.DescriptionKind)
+	output.DeprecationMessage = expandDeprecationMessage(input.Block.Deprecated, input.Block.DeprecationMessage)
+
+	return &output, nil
+}
+
+func expandSchemaBlock(input *tfprotov5.SchemaBlock) (map[string]schema.Attribute, map[string]schema.Block, error) {
+	attributes := make(map[string]schema.Attribute)
+	for _, v := range input.Attributes {
+		attribute, err := expandSchemaAttribute(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("converting attribute %q: %+v", v.Name, err)
+		}
+		attributes[v.Name] = attribute
+	}
+
+	blocks := make(map[string]schema.Block)
+	for _, v := range input.BlockTypes {
+		block, err := expandSchemaNestedBlock(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("converting block %q: %+v", v.TypeName, err)
+		}
+		blocks[v.TypeName] = block
+	}
+
+	return attributes, blocks, nil
+}
+
+func expandSchemaNestedBlock(input *tfprotov5.SchemaNestedBlock) (schema.Block, error) {
+	if input.Block == nil {
+		return nil, fmt.Errorf("the nested block was nil")
+	}
+
+	attributes, blocks, err := expandSchemaBlock(input.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	description, markdownDescription := expandDescription(input.Block.Description, input.Block.DescriptionKind)
+	deprecationMessage := expandDeprecationMessage(input.Block.Deprecated, input.Block.DeprecationMessage)
+
+	nestedObject := schema.NestedBlockObject{
+		Attributes: attributes,
+		Blocks:     blocks,
+	}
+
+	switch input.Nesting {
+	case tfprotov5.SchemaNestedBlockNestingModeList:
+		return schema.ListNestedBlock{
+			NestedObject:        nestedObject,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case tfprotov5.SchemaNestedBlockNestingModeSet:
+		return schema.SetNestedBlock{
+			NestedObject:        nestedObject,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case tfprotov5.SchemaNestedBlockNestingModeSingle:
+		return schema.SingleNestedBlock{
+			Attributes:          attributes,
+			Blocks:              blocks,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported nesting mode %q", input.Nesting)
+}
+
+func expandSchemaAttribute(input *tfprotov5.SchemaAttribute) (schema.Attribute, error) {
+	description, markdownDescription := expandDescription(input.Description, input.DescriptionKind)
+	deprecationMessage := expandDeprecationMessage(input.Deprecated, input.DeprecationMessage)
+
+	switch {
+	case input.Type.Is(tftypes.String):
+		return schema.StringAttribute{
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case input.Type.Is(tftypes.Bool):
+		return schema.BoolAttribute{
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case input.Type.Is(tftypes.Number):
+		return schema.NumberAttribute{
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case input.Type.Is(tftypes.List{}):
+		elementType, err := expandAttrType(input.Type.(tftypes.List).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return schema.ListAttribute{
+			ElementType:         elementType,
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case input.Type.Is(tftypes.Set{}):
+		elementType, err := expandAttrType(input.Type.(tftypes.Set).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return schema.SetAttribute{
+			ElementType:         elementType,
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case input.Type.Is(tftypes.Map{}):
+		elementType, err := expandAttrType(input.Type.(tftypes.Map).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return schema.MapAttribute{
+			ElementType:         elementType,
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", input.Type.String())
+}
+
+func expandAttrType(input tftypes.Type) (attr.Type, error) {
+	switch {
+	case input.Is(tftypes.String):
+		return types.StringType, nil
+
+	case input.Is(tftypes.Bool):
+		return types.BoolType, nil
+
+	case input.Is(tftypes.Number):
+		return types.NumberType, nil
+
+	case input.Is(tftypes.List{}):
+		elementType, err := expandAttrType(input.(tftypes.List).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.ListType{ElemType: elementType}, nil
+
+	case input.Is(tftypes.Set{}):
+		elementType, err := expandAttrType(input.(tftypes.Set).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.SetType{ElemType: elementType}, nil
+
+	case input.Is(tftypes.Map{}):
+		elementType, err := expandAttrType(input.(tftypes.Map).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.MapType{ElemType: elementType}, nil
+
+	case input.Is(tftypes.Object{}):
+		attributeTypes := make(map[string]attr.Type)
+		for k, v := range input.(tftypes.Object).AttributeTypes {
+			attributeType, err := expandAttrType(v)
+			if err != nil {
+				return nil, err
+			}
+			attributeTypes[k] = attributeType
+		}
+		return types.ObjectType{AttrTypes: attributeTypes}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", input.String())
+}
+
+// expandDeprecationMessage returns the deprecation message for a deprecated attribute or block, where older versions
+// of the protocol only expose whether the field is deprecated
+func expandDeprecationMessage(deprecated bool, message string) string {
+	if !deprecated {
+		return ""
+	}
+	if message != "" {
+		return message
+	}
+	return "Deprecated"
+}
+
+func expandDescription(input string, kind tfprotov5.StringKind) (description string, markdownDescription string) {
+	if kind == tfprotov5.StringKindMarkdown {
+		return "", input
+	}
+	return input, ""
+}
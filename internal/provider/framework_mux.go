@@ -0,0 +1,167 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+// ProtoV5ProviderServerFactory returns a factory for the muxed Provider Server, combining the Plugin SDKv2
+// Provider (which exposes the Resources and Data Sources) with the Plugin Framework Provider (which exposes
+// the Provider Functions, Ephemeral Resources and List Resources)
+func ProtoV5ProviderServerFactory(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	return protoV5ProviderServerFactory(ctx, AzureProvider())
+}
+
+// ProtoV5TestProviderServerFactory returns a factory for the muxed Provider Server used in the Acceptance Tests, which
+// uses the Plugin SDKv2 Provider returned from TestAzureProvider
+func ProtoV5TestProviderServerFactory(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	return protoV5ProviderServerFactory(ctx, TestAzureProvider())
+}
+
+func protoV5ProviderServerFactory(ctx context.Context, v2Provider *schema.Provider) (func() tfprotov5.ProviderServer, error) {
+	// NOTE: the Plugin SDKv2 Provider must be first, since the Plugin Framework Provider uses its Client
+	providers := []func() tfprotov5.ProviderServer{
+		v2Provider.GRPCProvider,
+		providerserver.NewProtocol5(framework.NewFrameworkProvider(v2Provider, supportedEphemeralResources(), supportedListResources(v2Provider), supportedResourceIds())),
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, providers...)
+	if err != nil {
+		return nil, fmt.Errorf("building the mux server: %+v", err)
+	}
+
+	return muxServer.ProviderServer, nil
+}
+
+// supportedServiceRegistrations returns each Service Registration, keyed by name - since most Service Registrations
+// are both Typed and Untyped, these are de-duplicated by name
+func supportedServiceRegistrations() map[string]interface{} {
+	services := make(map[string]interface{})
+	for _, service := range SupportedTypedServices() {
+		services[service.Name()] = service
+	}
+	for _, service := range SupportedUntypedServices() {
+		services[service.Name()] = service
+	}
+	return services
+}
+
+// supportedEphemeralResources returns the Ephemeral Resources exposed by each Service Registration
+func supportedEphemeralResources() []func() ephemeral.EphemeralResource {
+	output := make([]func() ephemeral.EphemeralResource, 0)
+	seen := make(map[string]struct{})
+	for _, service := range supportedServiceRegistrations() {
+		v, ok := service.(sdk.ServiceRegistrationWithEphemeralResources)
+		if !ok {
+			continue
+		}
+
+		for _, ephemeralResource := range v.EphemeralResources() {
+			key := ephemeralResource.ResourceType()
+			if _, exists := seen[key]; exists {
+				panic(fmt.Sprintf("An existing Ephemeral Resource exists for %q", key))
+			}
+			seen[key] = struct{}{}
+
+			output = append(output, sdk.NewEphemeralResourceWrapper(ephemeralResource))
+		}
+	}
+
+	return output
+}
+
+// supportedListResources returns the List Resources exposed by each Service Registration, which list the Resources
+// exposed by the Plugin SDKv2 Provider `v2Provider`
+func supportedListResources(v2Provider *schema.Provider) []func() list.ListResource {
+	server := sdk.NewManagedResourceServer(schema.NewGRPCProviderServer(v2Provider))
+
+	output := make([]func() list.ListResource, 0)
+	seen := make(map[string]struct{})
+	for _, service := range supportedServiceRegistrations() {
+		v, ok := service.(sdk.ServiceRegistrationWithListResources)
+		if !ok {
+			continue
+		}
+
+		for _, listResource := range v.ListResources() {
+			key := listResource.ResourceType()
+			if _, exists := seen[key]; exists {
+				panic(fmt.Sprintf("An existing List Resource exists for %q", key))
+			}
+			if _, exists := v2Provider.ResourcesMap[key]; !exists {
+				panic(fmt.Sprintf("the List Resource %q doesn't have a matching Resource", key))
+			}
+			seen[key] = struct{}{}
+
+			output = append(output, sdk.NewListResourceWrapper(listResource, server))
+		}
+	}
+
+	return output
+}
+
+// supportedResourceIds returns the Resource ID types used by each Service Registration, by the Typed Resources
+// exposing a Resource Identity and by the List Resources - which are used by the Provider Functions to normalise
+// Resource IDs. Resource ID types containing a Scope are omitted, since the Scope is normalised separately.
+func supportedResourceIds() []resourceids.ResourceId {
+	names := make([]string, 0)
+	services := supportedServiceRegistrations()
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	output := make([]resourceids.ResourceId, 0)
+	seen := make(map[reflect.Type]struct{})
+	add := func(id resourceids.ResourceId) {
+		key := reflect.TypeOf(id)
+		if _, exists := seen[key]; exists {
+			return
+		}
+		for _, segment := range id.Segments() {
+			if segment.Type == resourceids.ScopeSegmentType {
+				return
+			}
+		}
+		seen[key] = struct{}{}
+		output = append(output, id)
+	}
+
+	for _, name := range names {
+		service := services[name]
+		if v, ok := service.(sdk.ServiceRegistrationWithResourceIds); ok {
+			for _, id := range v.ResourceIds() {
+				add(id)
+			}
+		}
+		if v, ok := service.(sdk.TypedServiceRegistration); ok {
+			for _, resource := range v.Resources() {
+				if r, ok := resource.(sdk.ResourceWithIdentity); ok {
+					add(r.Identity())
+				}
+			}
+		}
+		if v, ok := service.(sdk.ServiceRegistrationWithListResources); ok {
+			for _, listResource := range v.ListResources() {
+				add(listResource.Identity())
+			}
+		}
+	}
+
+	return output
+}
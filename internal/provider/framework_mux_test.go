@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+func TestProtoV5ProviderServerFactory(t *testing.T) {
+	ctx := context.Background()
+
+	factory, err := ProtoV5ProviderServerFactory(ctx)
+	if err != nil {
+		t.Fatalf("building the provider server factory: %+v", err)
+	}
+
+	// the mux server validates that the Provider schemas of each Provider match when retrieving the schema
+	response, err := factory().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("retrieving the provider schema: %+v", err)
+	}
+	for _, diag := range response.Diagnostics {
+		if diag.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("retrieving the provider schema: %s: %s", diag.Summary, diag.Detail)
+		}
+	}
+
+	for _, name := range []string{"build_resource_id", "normalise_resource_id", "parse_resource_id"} {
+		if _, ok := response.Functions[name]; !ok {
+			t.Fatalf("expected the provider function %q to be exposed", name)
+		}
+	}
+
+	if _, ok := response.ResourceSchemas["azurerm_resource_group"]; !ok {
+		t.Fatalf("expected the resource `azurerm_resource_group` to be exposed")
+	}
+
+	for _, name := range []string{"azurerm_access_token", "azurerm_key_vault_certificate", "azurerm_key_vault_secret", "azurerm_storage_account_blob_container_sas", "azurerm_storage_account_sas"} {
+		if _, ok := response.EphemeralResourceSchemas[name]; !ok {
+			t.Fatalf("expected the ephemeral resource %q to be exposed", name)
+		}
+	}
+	listResources := []string{
+		"azurerm_key_vault",
+		"azurerm_kubernetes_cluster",
+		"azurerm_linux_virtual_machine",
+		"azurerm_resource_group",
+		"azurerm_storage_account",
+		"azurerm_subnet",
+		"azurerm_virtual_network",
+		"azurerm_windows_virtual_machine",
+	}
+	for _, name := range listResources {
+		if _, ok := response.ListResourceSchemas[name]; !ok {
+			t.Fatalf("expected the list resource %q to be exposed", name)
+		}
+	}
+
+	identitySchemas, err := factory().GetResourceIdentitySchemas(ctx, &tfprotov5.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatalf("retrieving the resource identity schemas: %+v", err)
+	}
+	for _, diag := range identitySchemas.Diagnostics {
+		if diag.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("retrieving the resource identity schemas: %s: %s", diag.Summary, diag.Detail)
+		}
+	}
+	for _, name := range listResources {
+		if _, ok := identitySchemas.IdentitySchemas[name]; !ok {
+			t.Fatalf("expected the resource %q to expose a resource identity", name)
+		}
+	}
+}
+
+func TestSupportedResourceIds(t *testing.T) {
+	ids := supportedResourceIds()
+
+	seen := make(map[reflect.Type]struct{})
+	for _, id := range ids {
+		key := reflect.TypeOf(id)
+		if _, exists := seen[key]; exists {
+			t.Fatalf("the Resource ID type %s was returned more than once", key)
+		}
+		seen[key] = struct{}{}
+
+		for _, segment := range id.Segments() {
+			if segment.Type == resourceids.ScopeSegmentType {
+				t.Fatalf("the Resource ID type %s contains a Scope so shouldn't be returned", key)
+			}
+		}
+	}
+
+	for _, expected := range []resourceids.ResourceId{&commonids.ResourceGroupId{}, &commonids.SubnetId{}, &commonids.KeyVaultId{}} {
+		if _, ok := seen[reflect.TypeOf(expected)]; !ok {
+			t.Fatalf("expected the Resource ID type %T to be returned", expected)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = BuildResourceIdFunction{}
+
+// BuildResourceIdFunction builds an Azure Resource Manager ID from its components, in the same manner as
+// the `resourceId` function available in ARM Templates
+type BuildResourceIdFunction struct {
+	// knownResourceIds are the Resource ID types whose parsers are used to normalise the Resource ID
+	knownResourceIds []resourceids.ResourceId
+}
+
+// NewBuildResourceIdFunction returns a factory for the BuildResourceIdFunction, normalising the Resource ID using the parsers
+// for the Resource ID types `knownResourceIds` where possible
+func NewBuildResourceIdFunction(knownResourceIds []resourceids.ResourceId) func() function.Function {
+	return func() function.Function {
+		return &BuildResourceIdFunction{
+			knownResourceIds: knownResourceIds,
+		}
+	}
+}
+
+func (b BuildResourceIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "build_resource_id"
+}
+
+func (b BuildResourceIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:     "build_resource_id",
+		Description: "Builds an Azure Resource Manager ID from the Subscription ID, Resource Group Name, Resource Type and the name(s) of the (nested) Resource.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "subscription_id",
+				Description: "The ID of the Subscription containing the Resource.",
+			},
+			function.StringParameter{
+				Name:        "resource_group_name",
+				Description: "The name of the Resource Group containing the Resource, or an empty string for Subscription-level Resources.",
+			},
+			function.StringParameter{
+				Name:        "resource_type",
+				Description: "The full Resource Type, for example `Microsoft.Network/virtualNetworks/subnets`.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:        "resource_names",
+			Description: "The name of each (nested) Resource, matching the Resource Types within `resource_type`.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (b BuildResourceIdFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var subscriptionId, resourceGroupName, resourceType string
+	var resourceNames []string
+	response.Error = function.ConcatFuncErrors(request.Arguments.Get(ctx, &subscriptionId, &resourceGroupName, &resourceType, &resourceNames))
+	if response.Error != nil {
+		return
+	}
+
+	if subscriptionId == "" {
+		response.Error = function.NewArgumentFuncError(0, "`subscription_id` cannot be empty")
+		return
+	}
+
+	typeSegments := strings.Split(strings.Trim(resourceType, "/"), "/")
+	if len(typeSegments) < 2 || typeSegments[0] == "" {
+		response.Error = function.NewArgumentFuncError(2, fmt.Sprintf("expected `resource_type` to be in the format `Namespace/resourceType[/nestedResourceType]` but got %q", resourceType))
+		return
+	}
+
+	if len(resourceNames) != len(typeSegments)-1 {
+		response.Error = function.NewArgumentFuncError(3, fmt.Sprintf("expected %d resource names for the Resource Type %q but got %d", len(typeSegments)-1, resourceType, len(resourceNames)))
+		return
+	}
+
+	for i, name := range resourceNames {
+		if name == "" {
+			response.Error = function.NewArgumentFuncError(int64(3+i), "resource names cannot be empty")
+			return
+		}
+	}
+
+	id := buildResourceId(parsedResourceId{
+		SubscriptionId:    subscriptionId,
+		ResourceGroupName: resourceGroupName,
+		ResourceProvider:  typeSegments[0],
+		ResourceTypes:     typeSegments[1:],
+		ResourceNames:     resourceNames,
+	})
+
+	result, err := normaliseResourceId(id, b.knownResourceIds)
+	if err != nil {
+		response.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Result.Set(ctx, result))
+}
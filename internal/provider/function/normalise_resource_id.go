@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = NormaliseResourceIdFunction{}
+
+// NormaliseResourceIdFunction returns an Azure Resource Manager ID in its canonical casing
+type NormaliseResourceIdFunction struct {
+	// knownResourceIds are the Resource ID types whose parsers are used to normalise the Resource ID
+	knownResourceIds []resourceids.ResourceId
+}
+
+// NewNormaliseResourceIdFunction returns a factory for the NormaliseResourceIdFunction, normalising the Resource ID using the parsers
+// for the Resource ID types `knownResourceIds` where possible
+func NewNormaliseResourceIdFunction(knownResourceIds []resourceids.ResourceId) func() function.Function {
+	return func() function.Function {
+		return &NormaliseResourceIdFunction{
+			knownResourceIds: knownResourceIds,
+		}
+	}
+}
+
+func (n NormaliseResourceIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "normalise_resource_id"
+}
+
+func (n NormaliseResourceIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:     "normalise_resource_id",
+		Description: "Normalises the casing of an Azure Resource Manager ID, for example returned from an Azure API, into the casing used by the Azure Provider.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The Azure Resource Manager ID to normalise.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (n NormaliseResourceIdFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var id string
+	response.Error = function.ConcatFuncErrors(request.Arguments.Get(ctx, &id))
+	if response.Error != nil {
+		return
+	}
+
+	result, err := normaliseResourceId(id, n.knownResourceIds)
+	if err != nil {
+		response.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Result.Set(ctx, result))
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = ParseResourceIdFunction{}
+
+// ParseResourceIdFunction parses an Azure Resource Manager ID into its components
+type ParseResourceIdFunction struct {
+	// knownResourceIds are the Resource ID types whose parsers are used to parse the Resource ID
+	knownResourceIds []resourceids.ResourceId
+}
+
+// NewParseResourceIdFunction returns a factory for the ParseResourceIdFunction, parsing the Resource ID using the parsers
+// for the Resource ID types `knownResourceIds` where possible
+func NewParseResourceIdFunction(knownResourceIds []resourceids.ResourceId) func() function.Function {
+	return func() function.Function {
+		return &ParseResourceIdFunction{
+			knownResourceIds: knownResourceIds,
+		}
+	}
+}
+
+var parseResourceIdResultAttributeTypes = map[string]attr.Type{
+	"subscription_id":     types.StringType,
+	"resource_group_name": types.StringType,
+	"resource_provider":   types.StringType,
+	"resource_scope":      types.StringType,
+	"resource_type":       types.StringType,
+	"resource_name":       types.StringType,
+	"parent_resources":    types.MapType{ElemType: types.StringType},
+}
+
+func (p ParseResourceIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "parse_resource_id"
+}
+
+func (p ParseResourceIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:     "parse_resource_id",
+		Description: "Parses an Azure Resource Manager ID into its components.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The Azure Resource Manager ID to parse.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parseResourceIdResultAttributeTypes,
+		},
+	}
+}
+
+func (p ParseResourceIdFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var id string
+	response.Error = function.ConcatFuncErrors(request.Arguments.Get(ctx, &id))
+	if response.Error != nil {
+		return
+	}
+
+	parsed, err := parseResourceId(id, p.knownResourceIds)
+	if err != nil {
+		response.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	parentResources := make(map[string]attr.Value)
+	for k, v := range parsed.ParentResources() {
+		parentResources[k] = types.StringValue(v)
+	}
+
+	result, diags := types.ObjectValue(parseResourceIdResultAttributeTypes, map[string]attr.Value{
+		"subscription_id":     types.StringValue(parsed.SubscriptionId),
+		"resource_group_name": types.StringValue(parsed.ResourceGroupName),
+		"resource_provider":   types.StringValue(parsed.ResourceProvider),
+		"resource_scope":      types.StringValue(parsed.ResourceScope),
+		"resource_type":       types.StringValue(parsed.FullResourceType()),
+		"resource_name":       types.StringValue(parsed.ResourceName()),
+		"parent_resources":    types.MapValueMust(types.StringType, parentResources),
+	})
+	if diags.HasError() {
+		response.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Result.Set(ctx, result))
+}
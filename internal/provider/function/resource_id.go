@@ -0,0 +1,241 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+// parsedResourceId is a generic representation of an Azure Resource Manager ID
+type parsedResourceId struct {
+	SubscriptionId    string
+	ResourceGroupName string
+
+	// ResourceProvider is the Resource Provider Namespace of the Resource (e.g. `Microsoft.Network`)
+	ResourceProvider string
+
+	// ResourceScope is the ID of the Resource this Resource is scoped to, when this is an Extension Resource
+	ResourceScope string
+
+	// ResourceTypes are the (nested) Resource Types within the Resource Provider, e.g. `virtualNetworks` and `subnets`
+	ResourceTypes []string
+
+	// ResourceNames are the names of the (nested) Resources, matching the ResourceTypes
+	ResourceNames []string
+}
+
+// FullResourceType returns the full Resource Type, e.g. `Microsoft.Network/virtualNetworks/subnets`
+func (p parsedResourceId) FullResourceType() string {
+	if p.ResourceProvider == "" {
+		if p.ResourceGroupName != "" {
+			return "Microsoft.Resources/resourceGroups"
+		}
+		return "Microsoft.Resources/subscriptions"
+	}
+
+	return strings.Join(append([]string{p.ResourceProvider}, p.ResourceTypes...), "/")
+}
+
+// ResourceName returns the name of the Resource this ID refers to
+func (p parsedResourceId) ResourceName() string {
+	if len(p.ResourceNames) > 0 {
+		return p.ResourceNames[len(p.ResourceNames)-1]
+	}
+	if p.ResourceGroupName != "" {
+		return p.ResourceGroupName
+	}
+	return p.SubscriptionId
+}
+
+// ParentResources returns a map of the parent Resource Types to their names
+func (p parsedResourceId) ParentResources() map[string]string {
+	output := make(map[string]string)
+	for i := 0; i < len(p.ResourceTypes)-1; i++ {
+		output[p.ResourceTypes[i]] = p.ResourceNames[i]
+	}
+	return output
+}
+
+// parseResourceId parses an Azure Resource Manager ID into its components, using the parser for one of the Resource ID
+// types within `knownResourceIds` where available, else splitting the Resource ID into its segments
+func parseResourceId(input string, knownResourceIds []resourceids.ResourceId) (*parsedResourceId, error) {
+	if id, parsed, ok := parseKnownResourceId(input, knownResourceIds); ok {
+		return parsedResourceIdFromSegments(id.Segments(), *parsed, knownResourceIds), nil
+	}
+
+	return splitResourceId(input)
+}
+
+// parseKnownResourceId parses the Resource ID using the parser for the first Resource ID type within
+// `knownResourceIds` which matches it, returning the populated Resource ID and the ParseResult
+func parseKnownResourceId(input string, knownResourceIds []resourceids.ResourceId) (resourceids.ResourceId, *resourceids.ParseResult, bool) {
+	for _, knownId := range knownResourceIds {
+		// the Resource ID is populated when parsing, so a new instance is needed for each call
+		id := reflect.New(reflect.TypeOf(knownId).Elem()).Interface().(resourceids.ResourceId)
+		parser := resourceids.NewParserFromResourceIdType(id)
+		parsed, err := parser.Parse(input, true)
+		if err != nil {
+			continue
+		}
+
+		if err := id.FromParseResult(*parsed); err != nil {
+			continue
+		}
+
+		return id, parsed, true
+	}
+
+	return nil, nil, false
+}
+
+// parsedResourceIdFromSegments maps the ParseResult for a known Resource ID type into its components, where the
+// Static Segments following the Resource Provider are the (nested) Resource Types, each followed by its name
+func parsedResourceIdFromSegments(segments []resourceids.Segment, parsed resourceids.ParseResult, knownResourceIds []resourceids.ResourceId) *parsedResourceId {
+	output := parsedResourceId{}
+	for i := 0; i < len(segments); i++ {
+		segment := segments[i]
+		value := parsed.Parsed[segment.Name]
+
+		switch segment.Type {
+		case resourceids.SubscriptionIdSegmentType:
+			output.SubscriptionId = value
+
+		case resourceids.ResourceGroupSegmentType:
+			output.ResourceGroupName = value
+
+		case resourceids.ScopeSegmentType:
+			// the Subscription and Resource Group are taken from the Scope, which is only exposed as the
+			// ResourceScope when it's a Resource (as for an ID of an unknown type)
+			scope, err := parseResourceId(value, knownResourceIds)
+			if err != nil {
+				output.ResourceScope = value
+				continue
+			}
+			output.SubscriptionId = scope.SubscriptionId
+			output.ResourceGroupName = scope.ResourceGroupName
+			if scope.ResourceProvider != "" {
+				output.ResourceScope = buildResourceId(*scope)
+			}
+
+		case resourceids.ResourceProviderSegmentType:
+			if output.ResourceProvider != "" {
+				output.ResourceScope = buildResourceId(output)
+			}
+			output.ResourceProvider = value
+			output.ResourceTypes = []string{}
+			output.ResourceNames = []string{}
+
+		case resourceids.StaticSegmentType:
+			// the Static Segments prior to the Resource Provider (e.g. `resourceGroups`) are the keys for the
+			// segment which follows, as is the `providers` segment preceding the Resource Provider
+			if output.ResourceProvider == "" || i+1 >= len(segments) || segments[i+1].Type == resourceids.ResourceProviderSegmentType {
+				continue
+			}
+
+			output.ResourceTypes = append(output.ResourceTypes, value)
+			output.ResourceNames = append(output.ResourceNames, parsed.Parsed[segments[i+1].Name])
+			i++
+		}
+	}
+
+	return &output
+}
+
+// splitResourceId splits an Azure Resource Manager ID of an unknown type into its components - Extension Resources (that
+// is, those nested under another Resource's `/providers/` segment) expose the ID of the parent as the ResourceScope
+func splitResourceId(input string) (*parsedResourceId, error) {
+	segments := strings.Split(strings.Trim(input, "/"), "/")
+	if !strings.HasPrefix(input, "/") || len(segments)%2 != 0 {
+		return nil, fmt.Errorf("%q is not a valid Azure Resource Manager ID - expected an even number of segments beginning with `/`", input)
+	}
+
+	output := parsedResourceId{}
+	lastProvidersIndex := -1
+	for i := 0; i < len(segments); i += 2 {
+		key, value := segments[i], segments[i+1]
+		if key == "" || value == "" {
+			return nil, fmt.Errorf("%q is not a valid Azure Resource Manager ID - segments cannot be empty", input)
+		}
+
+		switch {
+		case i == 0 && strings.EqualFold(key, "subscriptions"):
+			output.SubscriptionId = value
+
+		case i == 2 && output.SubscriptionId != "" && strings.EqualFold(key, "resourceGroups"):
+			output.ResourceGroupName = value
+
+		case strings.EqualFold(key, "providers"):
+			if lastProvidersIndex != -1 {
+				output.ResourceScope = "/" + strings.Join(segments[:i], "/")
+			}
+			lastProvidersIndex = i
+			output.ResourceProvider = value
+			output.ResourceTypes = []string{}
+			output.ResourceNames = []string{}
+
+		case lastProvidersIndex != -1:
+			output.ResourceTypes = append(output.ResourceTypes, key)
+			output.ResourceNames = append(output.ResourceNames, value)
+
+		default:
+			return nil, fmt.Errorf("%q is not a valid Azure Resource Manager ID - unexpected segment %q", input, key)
+		}
+	}
+
+	if output.ResourceProvider != "" && len(output.ResourceTypes) == 0 {
+		return nil, fmt.Errorf("%q is not a valid Azure Resource Manager ID - expected a Resource Type after the Resource Provider %q", input, output.ResourceProvider)
+	}
+
+	return &output, nil
+}
+
+// normaliseResourceId returns the Resource ID in its canonical casing, using the parser for one of the Resource ID
+// types within `knownResourceIds` where available, else normalising the casing of the well-known segments
+func normaliseResourceId(input string, knownResourceIds []resourceids.ResourceId) (string, error) {
+	if id, _, ok := parseKnownResourceId(input, knownResourceIds); ok {
+		return id.ID(), nil
+	}
+
+	parsed, err := splitResourceId(input)
+	if err != nil {
+		return "", err
+	}
+
+	if parsed.ResourceScope != "" {
+		scope, err := normaliseResourceId(parsed.ResourceScope, knownResourceIds)
+		if err != nil {
+			return "", fmt.Errorf("normalising the scope for %q: %+v", input, err)
+		}
+		parsed.ResourceScope = scope
+	}
+
+	return buildResourceId(*parsed), nil
+}
+
+func buildResourceId(input parsedResourceId) string {
+	output := ""
+	if input.ResourceScope != "" {
+		output = input.ResourceScope
+	} else {
+		if input.SubscriptionId != "" {
+			output += fmt.Sprintf("/subscriptions/%s", input.SubscriptionId)
+		}
+		if input.ResourceGroupName != "" {
+			output += fmt.Sprintf("/resourceGroups/%s", input.ResourceGroupName)
+		}
+	}
+
+	if input.ResourceProvider != "" {
+		output += fmt.Sprintf("/providers/%s", input.ResourceProvider)
+		for i, resourceType := range input.ResourceTypes {
+			output += fmt.Sprintf("/%s/%s", resourceType, input.ResourceNames[i])
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,270 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testKnownResourceIds are the Resource ID types used to parse and normalise Resource IDs in the tests, which the Provider
+// instead sources from the Service Registrations
+var testKnownResourceIds = []resourceids.ResourceId{
+	&commonids.ResourceGroupId{},
+	&commonids.SubnetId{},
+}
+
+func TestParseResourceId(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected *parsedResourceId
+	}{
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012",
+			Expected: &parsedResourceId{
+				SubscriptionId: "12345678-1234-9876-4563-123456789012",
+			},
+		},
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example",
+			Expected: &parsedResourceId{
+				SubscriptionId:    "12345678-1234-9876-4563-123456789012",
+				ResourceGroupName: "example",
+			},
+		},
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1",
+			Expected: &parsedResourceId{
+				SubscriptionId:    "12345678-1234-9876-4563-123456789012",
+				ResourceGroupName: "example",
+				ResourceProvider:  "Microsoft.Network",
+				ResourceTypes:     []string{"virtualNetworks", "subnets"},
+				ResourceNames:     []string{"network1", "subnet1"},
+			},
+		},
+		{
+			// the casing of a known Resource ID is normalised by its parser
+			Input: "/SUBSCRIPTIONS/12345678-1234-9876-4563-123456789012/resourcegroups/example/providers/microsoft.network/VIRTUALNETWORKS/network1/subnets/subnet1",
+			Expected: &parsedResourceId{
+				SubscriptionId:    "12345678-1234-9876-4563-123456789012",
+				ResourceGroupName: "example",
+				ResourceProvider:  "Microsoft.Network",
+				ResourceTypes:     []string{"virtualNetworks", "subnets"},
+				ResourceNames:     []string{"network1", "subnet1"},
+			},
+		},
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.KeyVault/vaults/vault1/providers/Microsoft.Authorization/locks/lock1",
+			Expected: &parsedResourceId{
+				SubscriptionId:    "12345678-1234-9876-4563-123456789012",
+				ResourceGroupName: "example",
+				ResourceProvider:  "Microsoft.Authorization",
+				ResourceScope:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.KeyVault/vaults/vault1",
+				ResourceTypes:     []string{"locks"},
+				ResourceNames:     []string{"lock1"},
+			},
+		},
+		{
+			// missing leading slash
+			Input: "subscriptions/12345678-1234-9876-4563-123456789012",
+		},
+		{
+			// odd number of segments
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups",
+		},
+		{
+			// resource provider without a resource type
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Network",
+		},
+		{
+			// unexpected segment before the resource provider
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/virtualNetworks/network1",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := parseResourceId(v.Input, testKnownResourceIds)
+		if err != nil {
+			if v.Expected == nil {
+				continue
+			}
+			t.Fatalf("parsing %q: %+v", v.Input, err)
+		}
+		if v.Expected == nil {
+			t.Fatalf("expected an error parsing %q but didn't get one", v.Input)
+		}
+
+		if !reflect.DeepEqual(*actual, *v.Expected) {
+			t.Fatalf("expected %+v but got %+v", *v.Expected, *actual)
+		}
+	}
+}
+
+func TestNormaliseResourceId(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected string
+		Error    bool
+	}{
+		{
+			Input:    "/SUBSCRIPTIONS/12345678-1234-9876-4563-123456789012/RESOURCEGROUPS/example",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example",
+		},
+		{
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/example/providers/microsoft.network/virtualnetworks/network1/SUBNETS/subnet1",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1",
+		},
+		{
+			// unknown resource types retain their casing, other than the well-known segments
+			Input:    "/SUBSCRIPTIONS/12345678-1234-9876-4563-123456789012/resourcegroups/example/PROVIDERS/Microsoft.Foo/bars/bar1",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Foo/bars/bar1",
+		},
+		{
+			// the scope of extension resources is normalised too
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/example/providers/Microsoft.Authorization/locks/lock1",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Authorization/locks/lock1",
+		},
+		{
+			Input: "not-a-resource-id",
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := normaliseResourceId(v.Input, testKnownResourceIds)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+			t.Fatalf("normalising %q: %+v", v.Input, err)
+		}
+		if v.Error {
+			t.Fatalf("expected an error normalising %q but didn't get one", v.Input)
+		}
+
+		if actual != v.Expected {
+			t.Fatalf("expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestBuildResourceIdFunction(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Args     []attr.Value
+		Expected string
+		Error    bool
+	}{
+		{
+			Name: "Subnet",
+			Args: []attr.Value{
+				types.StringValue("12345678-1234-9876-4563-123456789012"),
+				types.StringValue("example"),
+				types.StringValue("microsoft.network/virtualNetworks/subnets"),
+				types.TupleValueMust([]attr.Type{types.StringType, types.StringType}, []attr.Value{types.StringValue("network1"), types.StringValue("subnet1")}),
+			},
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1",
+		},
+		{
+			Name: "Subscription Level Resource",
+			Args: []attr.Value{
+				types.StringValue("12345678-1234-9876-4563-123456789012"),
+				types.StringValue(""),
+				types.StringValue("Microsoft.Foo/bars"),
+				types.TupleValueMust([]attr.Type{types.StringType}, []attr.Value{types.StringValue("bar1")}),
+			},
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/providers/Microsoft.Foo/bars/bar1",
+		},
+		{
+			Name: "Mismatched Names",
+			Args: []attr.Value{
+				types.StringValue("12345678-1234-9876-4563-123456789012"),
+				types.StringValue("example"),
+				types.StringValue("Microsoft.Network/virtualNetworks/subnets"),
+				types.TupleValueMust([]attr.Type{types.StringType}, []attr.Value{types.StringValue("network1")}),
+			},
+			Error: true,
+		},
+		{
+			Name: "Missing Resource Type",
+			Args: []attr.Value{
+				types.StringValue("12345678-1234-9876-4563-123456789012"),
+				types.StringValue("example"),
+				types.StringValue("Microsoft.Network"),
+				types.TupleValueMust([]attr.Type{}, []attr.Value{}),
+			},
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		response := function.RunResponse{
+			Result: function.NewResultData(types.StringUnknown()),
+		}
+		BuildResourceIdFunction{knownResourceIds: testKnownResourceIds}.Run(context.Background(), function.RunRequest{
+			Arguments: function.NewArgumentsData(v.Args),
+		}, &response)
+
+		if response.Error != nil {
+			if v.Error {
+				continue
+			}
+			t.Fatalf("running the function: %+v", response.Error)
+		}
+		if v.Error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if actual := response.Result.Value().(types.String).ValueString(); actual != v.Expected {
+			t.Fatalf("expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestParseResourceIdFunction(t *testing.T) {
+	response := function.RunResponse{
+		Result: function.NewResultData(types.ObjectUnknown(parseResourceIdResultAttributeTypes)),
+	}
+	ParseResourceIdFunction{knownResourceIds: testKnownResourceIds}.Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringValue("/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1"),
+		}),
+	}, &response)
+	if response.Error != nil {
+		t.Fatalf("running the function: %+v", response.Error)
+	}
+
+	attributes := response.Result.Value().(types.Object).Attributes()
+	expected := map[string]string{
+		"subscription_id":     "12345678-1234-9876-4563-123456789012",
+		"resource_group_name": "example",
+		"resource_provider":   "Microsoft.Network",
+		"resource_scope":      "",
+		"resource_type":       "Microsoft.Network/virtualNetworks/subnets",
+		"resource_name":       "subnet1",
+	}
+	for k, v := range expected {
+		if actual := attributes[k].(types.String).ValueString(); actual != v {
+			t.Fatalf("expected %q to be %q but got %q", k, v, actual)
+		}
+	}
+
+	parentResources := attributes["parent_resources"].(types.Map).Elements()
+	if len(parentResources) != 1 || parentResources["virtualNetworks"].(types.String).ValueString() != "network1" {
+		t.Fatalf("expected `parent_resources` to contain `virtualNetworks` but got %+v", parentResources)
+	}
+}
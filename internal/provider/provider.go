@@ -119,6 +119,11 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 
 	// then handle the untyped services
 	for _, service := range SupportedUntypedServices() {
+		var existenceChecks map[string]sdk.UntypedExistenceCheckFunc
+		if v, ok := service.(sdk.UntypedServiceRegistrationWithExistenceChecks); ok {
+			existenceChecks = v.ExistenceChecks()
+		}
+
 		logEntry("[DEBUG] Registering Data Sources for %q..", service.Name())
 		for k, v := range service.SupportedDataSources() {
 			if existing := dataSources[k]; existing != nil {
@@ -134,10 +139,30 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				panic(fmt.Sprintf("An existing Resource exists for %q", k))
 			}
 
+			// Typed Resources have the existence check applied by the ResourceWrapper
+			sdk.ApplyExistenceCheck(k, v, existenceChecks[k])
 			resources[k] = v
 		}
 	}
 
+	// expose `tags_all` and apply the `default_tags` and `ignore_tags` from the Provider block to all Resources
+	for resourceType, resource := range resources {
+		applyProviderTags(resourceType, resource)
+	}
+
+	// attribute the requests made by all Resources and Data Sources to the Terraform operation being performed
+	for resourceType, resource := range resources {
+		applyTracing(resourceType, resource)
+	}
+	for dataSourceType, dataSource := range dataSources {
+		applyTracing(dataSourceType, dataSource)
+	}
+
+	// resolve the timeout for each operation performed on a Resource using the `default_timeouts` from the Provider block
+	for resourceType, resource := range resources {
+		applyDefaultTimeouts(resourceType, resource)
+	}
+
 	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"subscription_id": {
@@ -319,12 +344,41 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 
 			"features": schemaFeatures(supportLegacyTestSuite),
 
+			"default_tags": schemaDefaultTags(),
+
+			"default_timeouts": schemaDefaultTimeouts(),
+
+			"ignore_tags": schemaIgnoreTags(),
+
+			"throttling": schemaThrottling(),
+
+			"tracing": schemaTracing(),
+
 			// Advanced feature flags
 			"skip_provider_registration": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ARM_SKIP_PROVIDER_REGISTRATION", false),
 				Description: "Should the AzureRM Provider skip registering all of the Resource Providers that it supports, if they're not already registered?",
+				Deprecated:  "This property is superseded by `resource_provider_registrations` and will be removed in a future version of the AzureRM Provider - setting `skip_provider_registration` to `true` is equivalent to setting `resource_provider_registrations` to `none`.",
+			},
+
+			"resource_provider_registrations": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_RESOURCE_PROVIDER_REGISTRATIONS", string(resourceproviders.RegistrationProfileExtended)),
+				ValidateFunc: validation.StringInSlice(resourceproviders.PossibleValuesForRegistrationProfile(), false),
+				Description:  "The set of Resource Providers which should be automatically registered for the Subscription. Possible values are `none`, `core`, `extended` and `all`. Defaults to `extended`.",
+			},
+
+			"resource_providers_to_register": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Description: "A list of additional Resource Providers which should be automatically registered for the Subscription.",
 			},
 
 			"storage_use_azuread": {
@@ -441,19 +495,36 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 }
 
 func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData, authConfig *auth.Credentials) (*clients.Client, diag.Diagnostics) {
-	skipProviderRegistration := d.Get("skip_provider_registration").(bool)
+	resourceProvidersToRegister, err := expandResourceProvidersToRegister(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	skipProviderRegistration := len(resourceProvidersToRegister) == 0
+
+	defaultTimeouts, err := expandDefaultTimeouts(d.Get("default_timeouts").([]interface{}))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if err := defaultTimeouts.Validate(p.ResourcesMap); err != nil {
+		return nil, diag.FromErr(err)
+	}
 
 	clientBuilder := clients.ClientBuilder{
 		AuthConfig:                  authConfig,
+		DefaultTimeouts:             *defaultTimeouts,
 		DisableCorrelationRequestID: d.Get("disable_correlation_request_id").(bool),
 		DisableTerraformPartnerID:   d.Get("disable_terraform_partner_id").(bool),
 		Features:                    expandFeatures(d.Get("features").([]interface{})),
 		MetadataHost:                d.Get("metadata_host").(string),
 		PartnerID:                   d.Get("partner_id").(string),
+		ProviderTags:                expandProviderTags(d.Get("default_tags").([]interface{}), d.Get("ignore_tags").([]interface{})),
+		ResourceProvidersToRegister: resourceProvidersToRegister,
 		SkipProviderRegistration:    skipProviderRegistration,
 		StorageUseAzureAD:           d.Get("storage_use_azuread").(bool),
 		SubscriptionID:              d.Get("subscription_id").(string),
 		TerraformVersion:            p.TerraformVersion,
+		Throttling:                  expandThrottling(d.Get("throttling").([]interface{})),
+		Tracing:                     expandTracing(d.Get("tracing").([]interface{})),
 
 		// this field is intentionally not exposed in the provider block, since it's only used for
 		// platform level tracing
@@ -473,18 +544,49 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 
 	client.StopContext = stopCtx
 
+	var diags diag.Diagnostics
 	if !skipProviderRegistration {
 		subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
-		requiredResourceProviders := resourceproviders.Required()
 		ctx2, cancel := context.WithTimeout(ctx, 30*time.Minute)
 		defer cancel()
 
-		if err := resourceproviders.EnsureRegistered(ctx2, client.Resource.ResourceProvidersClient, subscriptionId, requiredResourceProviders); err != nil {
+		warnings, err := resourceproviders.EnsureRegistered(ctx2, client.Resource.ResourceProvidersClient, subscriptionId, resourceProvidersToRegister)
+		if err != nil {
 			return nil, diag.Errorf(resourceProviderRegistrationErrorFmt, err)
 		}
+
+		for _, warning := range warnings {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Unable to register the Resource Provider %q", warning.ResourceProvider),
+				Detail:   fmt.Sprintf(resourceProviderRegistrationWarningFmt, warning.ResourceProvider, warning.Error),
+			})
+		}
 	}
 
-	return client, nil
+	return client, diags
+}
+
+// expandResourceProvidersToRegister returns the Resource Providers which should be automatically registered,
+// based on the `resource_provider_registrations` and `resource_providers_to_register` fields
+func expandResourceProvidersToRegister(d *schema.ResourceData) (map[string]struct{}, error) {
+	profile := resourceproviders.RegistrationProfile(d.Get("resource_provider_registrations").(string))
+	if d.Get("skip_provider_registration").(bool) {
+		profile = resourceproviders.RegistrationProfileNone
+	}
+
+	additional := make([]string, 0)
+	if v, ok := d.Get("resource_providers_to_register").([]interface{}); ok && len(v) > 0 {
+		additional = *utils.ExpandStringSlice(v)
+	} else if v := os.Getenv("ARM_RESOURCE_PROVIDERS_TO_REGISTER"); v != "" {
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				additional = append(additional, name)
+			}
+		}
+	}
+
+	return resourceproviders.ForRegistrationProfile(profile, additional)
 }
 
 const resourceProviderRegistrationErrorFmt = `Error ensuring Resource Providers are registered.
@@ -492,8 +594,9 @@ const resourceProviderRegistrationErrorFmt = `Error ensuring Resource Providers
 Terraform automatically attempts to register the Resource Providers it supports to
 ensure it's able to provision resources.
 
-If you don't have permission to register Resource Providers you may wish to use the
-"skip_provider_registration" flag in the Provider block to disable this functionality.
+If you don't have permission to register Resource Providers you may wish to set the
+"resource_provider_registrations" field in the Provider block to "none" to disable this
+functionality (or to "core" to only register the most commonly used Resource Providers).
 
 Please note that if you opt out of Resource Provider Registration and Terraform tries
 to provision a resource from a Resource Provider which is unregistered, then the errors
@@ -504,7 +607,19 @@ may appear misleading - for example:
 Could indicate either that the Resource Provider "Microsoft.Foo" requires registration,
 but this could also indicate that this Azure Region doesn't support this API version.
 
-More information on the "skip_provider_registration" flag can be found here:
-https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs#skip_provider_registration
+More information on the "resource_provider_registrations" field can be found here:
+https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs#resource_provider_registrations
+
+Original Error: %s`
+
+const resourceProviderRegistrationWarningFmt = `The credentials being used don't have permission to register the Resource Provider %q.
+
+Resources using this Resource Provider can still be provisioned if it's been registered
+by other means - otherwise you may see errors such as:
+
+> API version 2019-XX-XX was not found for Microsoft.Foo
+
+To avoid this warning you can set the "resource_provider_registrations" field in the
+Provider block to "none" or "core" - or register this Resource Provider outside of Terraform.
 
 Original Error: %s`
@@ -638,3 +638,56 @@ func schemaContainsLocalAuthenticationDisabled(input map[string]*schema.Schema)
 
 	return nil
 }
+
+func TestResourcesWithWriteOnlyFieldsHaveAVersionField(t *testing.T) {
+	// This test validates that each write-only field within a Resource is marked as Sensitive and has a companion
+	// `{name}_version` field - since the value of a write-only field is never persisted into the state, it's not
+	// possible to detect when the value changes, so the value is only sent to the API when the version changes.
+	provider := TestAzureProvider()
+
+	// intentionally sorting these so the output is consistent
+	resourceNames := make([]string, 0)
+	for resourceName := range provider.ResourcesMap {
+		resourceNames = append(resourceNames, resourceName)
+	}
+	sort.Strings(resourceNames)
+
+	for _, resourceName := range resourceNames {
+		resource := provider.ResourcesMap[resourceName]
+
+		if err := schemaContainsWriteOnlyFieldsWithoutAVersion(resource.Schema); err != nil {
+			t.Fatalf("the Resource %q contains an invalid write-only field: %+v", resourceName, err)
+		}
+	}
+}
+
+func schemaContainsWriteOnlyFieldsWithoutAVersion(input map[string]*schema.Schema) error {
+	// intentionally sorting these so the output is consistent
+	fieldNames := make([]string, 0)
+	for fieldName := range input {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	for _, fieldName := range fieldNames {
+		field := input[fieldName]
+		if !field.WriteOnly {
+			continue
+		}
+
+		if !field.Sensitive {
+			return fmt.Errorf("field %q is write-only and should be marked as Sensitive", fieldName)
+		}
+
+		versionFieldName := fieldName + pluginsdk.WriteOnlyVersionSuffix
+		version, ok := input[versionFieldName]
+		if !ok {
+			return fmt.Errorf("field %q is write-only but the field %q doesn't exist", fieldName, versionFieldName)
+		}
+		if version.Type != pluginsdk.TypeInt || version.WriteOnly {
+			return fmt.Errorf("field %q should be an integer which isn't write-only", versionFieldName)
+		}
+	}
+
+	return nil
+}
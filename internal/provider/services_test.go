@@ -40,6 +40,34 @@ func TestTypedResourcesContainValidModelObjects(t *testing.T) {
 	}
 }
 
+func TestTypedDataSourcesBuild(t *testing.T) {
+	// This test builds each of the Typed Data Sources, which validates the ModelObject against the Schema
+	for _, service := range SupportedTypedServices() {
+		t.Logf("Service %q..", service.Name())
+		for _, dataSource := range service.DataSources() {
+			t.Logf("- DataSource %q..", dataSource.ResourceType())
+			wrapper := sdk.NewDataSourceWrapper(dataSource)
+			if _, err := wrapper.DataSource(); err != nil {
+				t.Errorf("building %q: %+v", dataSource.ResourceType(), err)
+			}
+		}
+	}
+}
+
+func TestTypedResourcesBuild(t *testing.T) {
+	// This test builds each of the Typed Resources, which validates the ModelObject against the Schema
+	for _, service := range SupportedTypedServices() {
+		t.Logf("Service %q..", service.Name())
+		for _, resource := range service.Resources() {
+			t.Logf("- Resource %q..", resource.ResourceType())
+			wrapper := sdk.NewResourceWrapper(resource)
+			if _, err := wrapper.Resource(); err != nil {
+				t.Errorf("building %q: %+v", resource.ResourceType(), err)
+			}
+		}
+	}
+}
+
 func TestTypedResourcesContainValidIDParsers(t *testing.T) {
 	// This test confirms that all of the Typed Resources return an ID Validation method
 	// which is used to ensure that each of the resources will validate the Resource ID
@@ -197,9 +225,11 @@ func TestTypedResourcesUsePointersForOptionalProperties(t *testing.T) {
 			}
 
 			modelType := reflect.TypeOf(model).Elem()
-			schema := resource.Arguments()
+			schema, computedOnly, err := sdk.PluginSdkSchema(resource)
+			if err != nil {
+				t.Fatalf("building schema for %q: %+v", resource.ResourceType(), err)
+			}
 			walkModel(modelType, schema)
-			computedOnly := resource.Attributes()
 			walkModel(modelType, computedOnly)
 		}
 	}
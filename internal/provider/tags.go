@@ -0,0 +1,389 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	resourceTags "github.com/hashicorp/go-azure-sdk/resource-manager/resources/2023-07-01/tags"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func schemaDefaultTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"tags": {
+					Type:         pluginsdk.TypeMap,
+					Optional:     true,
+					ValidateFunc: tags.Validate,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+					Description: "A mapping of tags which should be assigned to all Resources supporting tags, Tags defined on the Resource take precedence.",
+				},
+			},
+		},
+	}
+}
+
+func schemaIgnoreTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"keys": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+					Description: "A list of Tag Keys which should be ignored across all Resources.",
+				},
+
+				"key_prefixes": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+					Description: "A list of Tag Key Prefixes which should be ignored across all Resources.",
+				},
+			},
+		},
+	}
+}
+
+func expandProviderTags(defaultTags []interface{}, ignoreTags []interface{}) tags.ProviderConfig {
+	output := tags.ProviderConfig{
+		DefaultTags: map[string]string{},
+	}
+
+	if len(defaultTags) > 0 && defaultTags[0] != nil {
+		raw := defaultTags[0].(map[string]interface{})
+		for k, v := range tags.Expand(raw["tags"].(map[string]interface{})) {
+			output.DefaultTags[k] = *v
+		}
+	}
+
+	if len(ignoreTags) > 0 && ignoreTags[0] != nil {
+		raw := ignoreTags[0].(map[string]interface{})
+		output.IgnoreTags.Keys = *utils.ExpandStringSlice(raw["keys"].(*pluginsdk.Set).List())
+		output.IgnoreTags.KeyPrefixes = *utils.ExpandStringSlice(raw["key_prefixes"].(*pluginsdk.Set).List())
+	}
+
+	return output
+}
+
+// resourcesWithDataPlaneTags are the Resources exposing a `tags` field which are assigned to a Data Plane
+// object (rather than a Resource Manager Resource), to which the `default_tags` and `ignore_tags` defined in the
+// Provider block aren't applicable
+var resourcesWithDataPlaneTags = map[string]struct{}{
+	"azurerm_app_configuration_feature":                              {},
+	"azurerm_app_configuration_key":                                  {},
+	"azurerm_key_vault_certificate":                                  {},
+	"azurerm_key_vault_key":                                          {},
+	"azurerm_key_vault_managed_storage_account":                      {},
+	"azurerm_key_vault_managed_storage_account_sas_token_definition": {},
+	"azurerm_key_vault_secret":                                       {},
+}
+
+// resourceSupportsProviderTags returns whether the `default_tags` and `ignore_tags` defined in the
+// Provider block are applicable to this Resource - which requires a user-configurable `tags` map
+// assigned to a Resource Manager Resource
+func resourceSupportsProviderTags(resourceType string, resource *pluginsdk.Resource) bool {
+	if _, ok := resourcesWithDataPlaneTags[resourceType]; ok {
+		return false
+	}
+
+	v, ok := resource.Schema["tags"]
+	if !ok || v.Type != pluginsdk.TypeMap || (!v.Optional && !v.Required) {
+		return false
+	}
+
+	if _, exists := resource.Schema["tags_all"]; exists {
+		return false
+	}
+
+	return true
+}
+
+// applyProviderTags exposes the `tags_all` attribute on the specified Resource and wraps the CRUD functions
+// so that the `default_tags` and `ignore_tags` defined in the Provider block are taken into account.
+//
+// This is done for both Typed and Untyped Resources, to avoid needing to update each Resource individually -
+// with the Tags expanded and flattened using the ProviderConfig from the `tags` package:
+//   - during Create/Update the Default Tags are merged into the `tags` field, so that `tags.Expand` (and the
+//     Typed SDK's Decode) receives the effective set of Tags
+//   - during Update the ignored Tags currently assigned to the Resource are also merged into the `tags` field,
+//     and a change to only the Default Tags (which Update functions don't detect) is applied using the Tags API
+//     rather than by the Update function, unless other fields have also changed
+//   - during Read the `tags` field is reset to only those Tags defined on the Resource, with the effective set
+//     (less any ignored Tags) exposed in `tags_all`
+func applyProviderTags(resourceType string, resource *pluginsdk.Resource) {
+	if !resourceSupportsProviderTags(resourceType, resource) {
+		return
+	}
+
+	resource.Schema["tags_all"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeMap,
+		Computed: true,
+		Elem: &pluginsdk.Schema{
+			Type: pluginsdk.TypeString,
+		},
+	}
+
+	supportsUpdate := resource.Update != nil || resource.UpdateContext != nil || resource.UpdateWithoutTimeout != nil //nolint:staticcheck
+
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	if resource.Create != nil { //nolint:staticcheck
+		resource.Create = legacyProviderTagsWrapper(resource.Create, true) //nolint:staticcheck
+	}
+	if resource.CreateContext != nil {
+		resource.CreateContext = providerTagsWrapper(resource.CreateContext, true)
+	}
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	if resource.Update != nil { //nolint:staticcheck
+		resource.Update = legacyProviderTagsWrapper(resource.Update, true) //nolint:staticcheck
+	}
+	if resource.UpdateContext != nil {
+		resource.UpdateContext = providerTagsWrapper(resource.UpdateContext, true)
+	}
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	if resource.Read != nil { //nolint:staticcheck
+		resource.Read = legacyProviderTagsWrapper(resource.Read, false) //nolint:staticcheck
+	}
+	if resource.ReadContext != nil {
+		resource.ReadContext = providerTagsWrapper(resource.ReadContext, false)
+	}
+
+	customizeDiff := func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+		if d.Id() != "" {
+			// `tags_all` is missing from the State for Resources last refreshed by an earlier version of the
+			// Provider, this is populated by the next Read rather than showing a change
+			if state := d.GetRawState(); !state.IsNull() && state.Type().HasAttribute("tags_all") && state.GetAttr("tags_all").IsNull() {
+				return d.Clear("tags_all")
+			}
+
+			// a Resource which doesn't support being updated is only recreated when the `tags` field changes, so
+			// a change to only the Default Tags is applied when the Resource is next recreated
+			if !supportsUpdate && !d.HasChange("tags") {
+				return nil
+			}
+		}
+
+		if !d.NewValueKnown("tags") {
+			return d.SetNewComputed("tags_all")
+		}
+
+		config := providerTagsFromMeta(meta)
+		configured := d.Get("tags").(map[string]interface{})
+		_, effective := config.Flatten(config.Expand(configured), configured)
+		if err := d.SetNew("tags_all", effective); err != nil {
+			return fmt.Errorf("setting `tags_all`: %+v", err)
+		}
+
+		return nil
+	}
+	if existing := resource.CustomizeDiff; existing != nil {
+		resource.CustomizeDiff = pluginsdk.CustomDiffInSequence(existing, customizeDiff)
+	} else {
+		resource.CustomizeDiff = customizeDiff
+	}
+}
+
+func providerTagsFromMeta(meta interface{}) tags.ProviderConfig {
+	if client, ok := meta.(*clients.Client); ok && client != nil {
+		return client.ProviderTags
+	}
+
+	return tags.ProviderConfig{}
+}
+
+func providerTagsWrapper(in func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics, mergeDefaults bool) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+		config := providerTagsFromMeta(meta)
+		configured, updated, err := configuredTags(ctx, d, meta, config, mergeDefaults)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		var diags diag.Diagnostics
+		if !updated {
+			diags = in(ctx, d, meta)
+		}
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+
+		if err := setProviderTags(d, config, configured); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+
+		return diags
+	}
+}
+
+func legacyProviderTagsWrapper(in func(*pluginsdk.ResourceData, interface{}) error, mergeDefaults bool) func(*pluginsdk.ResourceData, interface{}) error {
+	return func(d *pluginsdk.ResourceData, meta interface{}) error {
+		ctx := context.Background()
+		if client, ok := meta.(*clients.Client); ok && client != nil && client.StopContext != nil {
+			ctx = client.StopContext
+		}
+
+		config := providerTagsFromMeta(meta)
+		configured, updated, err := configuredTags(ctx, d, meta, config, mergeDefaults)
+		if err != nil {
+			return err
+		}
+
+		if !updated {
+			if err := in(d, meta); err != nil {
+				return err
+			}
+		}
+		if d.Id() == "" {
+			return nil
+		}
+
+		return setProviderTags(d, config, configured)
+	}
+}
+
+// configuredTags returns the Tags defined on the Resource, optionally merging the Default Tags into the
+// `tags` field so that these are sent to the API.
+//
+// When updating an existing Resource the ignored Tags currently assigned to the Resource are also merged into
+// the `tags` field, so that these aren't removed when the Tags are replaced. Since Update functions only send the
+// Tags when `tags` has changed, a change to only the Default Tags is applied using the Tags API instead - in which
+// case this returns true when the Update function doesn't need to be run, since no other fields have changed.
+func configuredTags(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, config tags.ProviderConfig, mergeDefaults bool) (map[string]interface{}, bool, error) {
+	configured := d.Get("tags").(map[string]interface{})
+	if !mergeDefaults || !config.Enabled() {
+		return configured, false, nil
+	}
+
+	desired := tags.Flatten(config.Expand(configured))
+
+	defaultsChanged := false
+	if d.Id() != "" {
+		defaultsChanged = d.HasChange("tags_all") && !d.HasChange("tags")
+		if (defaultsChanged || d.HasChange("tags")) && config.IgnoreTags.Enabled() {
+			remote, err := remoteTags(ctx, d, meta)
+			if err != nil {
+				return nil, false, err
+			}
+			desired = config.MergeIgnored(desired, remote)
+		}
+	}
+
+	if err := d.Set("tags", desired); err != nil {
+		return nil, false, fmt.Errorf("setting `tags`: %+v", err)
+	}
+
+	if defaultsChanged {
+		updated, err := updateRemoteTags(ctx, d, meta, desired)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return configured, updated && !d.HasChangeExcept("tags_all"), nil
+	}
+
+	return configured, false, nil
+}
+
+// tagsScopeForResource returns the Scope used to manage the Tags for this Resource using the Tags API, which is
+// available for all Resource Manager Resources - as such this returns nil for Resources with a Data Plane ID
+func tagsScopeForResource(d *pluginsdk.ResourceData, meta interface{}) *commonids.ScopeId {
+	if client, ok := meta.(*clients.Client); !ok || client == nil || client.Resource == nil {
+		return nil
+	}
+	if !strings.HasPrefix(strings.ToLower(d.Id()), "/subscriptions/") {
+		return nil
+	}
+
+	scope := commonids.NewScopeID(d.Id())
+	return &scope
+}
+
+// remoteTags returns the Tags currently assigned to the Resource within Azure
+func remoteTags(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) (map[string]interface{}, error) {
+	scope := tagsScopeForResource(d, meta)
+	if scope == nil {
+		return nil, nil
+	}
+
+	ctx, cancel := timeouts.ForUpdate(ctx, d)
+	defer cancel()
+
+	resp, err := meta.(*clients.Client).Resource.TagsClient.GetAtScope(ctx, *scope)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving the Tags for %s: %+v", *scope, err)
+	}
+
+	output := make(map[string]interface{})
+	if resp.Model != nil && resp.Model.Properties.Tags != nil {
+		for k, v := range *resp.Model.Properties.Tags {
+			output[k] = v
+		}
+	}
+
+	return output, nil
+}
+
+// updateRemoteTags replaces the Tags assigned to the Resource within Azure with `input`, returning whether
+// the Tags were updated - which isn't possible for Resources with a Data Plane ID
+func updateRemoteTags(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, input map[string]interface{}) (bool, error) {
+	scope := tagsScopeForResource(d, meta)
+	if scope == nil {
+		log.Printf("[DEBUG] the Default Tags for %q can only be updated alongside a change to `tags`", d.Id())
+		return false, nil
+	}
+
+	ctx, cancel := timeouts.ForUpdate(ctx, d)
+	defer cancel()
+
+	payload := resourceTags.TagsResource{
+		Properties: resourceTags.Tags{
+			Tags: pointer.To(tags.ToTypedObject(tags.Expand(input))),
+		},
+	}
+	if _, err := meta.(*clients.Client).Resource.TagsClient.CreateOrUpdateAtScope(ctx, *scope, payload); err != nil {
+		return false, fmt.Errorf("updating the Tags for %s: %+v", *scope, err)
+	}
+
+	return true, nil
+}
+
+// setProviderTags splits the Tags returned from the API (as set into the `tags` field by the Read function)
+// into the Tags defined on the Resource (`tags`) and the effective set of Tags (`tags_all`)
+func setProviderTags(d *pluginsdk.ResourceData, config tags.ProviderConfig, configured map[string]interface{}) error {
+	flattened, flattenedAll := config.Flatten(tags.Expand(d.Get("tags").(map[string]interface{})), configured)
+
+	if err := d.Set("tags", flattened); err != nil {
+		return fmt.Errorf("setting `tags`: %+v", err)
+	}
+
+	if err := d.Set("tags_all", flattenedAll); err != nil {
+		return fmt.Errorf("setting `tags_all`: %+v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,393 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	resourceTags "github.com/hashicorp/go-azure-sdk/resource-manager/resources/2023-07-01/tags"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	resourceClient "github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"golang.org/x/oauth2"
+)
+
+func TestExpandProviderTags(t *testing.T) {
+	testData := []struct {
+		Name        string
+		DefaultTags []interface{}
+		IgnoreTags  []interface{}
+		Expected    tags.ProviderConfig
+	}{
+		{
+			Name:        "Empty Blocks",
+			DefaultTags: []interface{}{},
+			IgnoreTags:  []interface{}{},
+			Expected: tags.ProviderConfig{
+				DefaultTags: map[string]string{},
+			},
+		},
+		{
+			Name: "Default Tags",
+			DefaultTags: []interface{}{
+				map[string]interface{}{
+					"tags": map[string]interface{}{
+						"cost-center": "123",
+						"owner":       "platform",
+					},
+				},
+			},
+			IgnoreTags: []interface{}{},
+			Expected: tags.ProviderConfig{
+				DefaultTags: map[string]string{
+					"cost-center": "123",
+					"owner":       "platform",
+				},
+			},
+		},
+		{
+			Name:        "Ignore Tags",
+			DefaultTags: []interface{}{},
+			IgnoreTags: []interface{}{
+				map[string]interface{}{
+					"keys":         pluginsdk.NewSet(pluginsdk.HashString, []interface{}{"CreatedBy"}),
+					"key_prefixes": pluginsdk.NewSet(pluginsdk.HashString, []interface{}{"hidden-"}),
+				},
+			},
+			Expected: tags.ProviderConfig{
+				DefaultTags: map[string]string{},
+				IgnoreTags: tags.IgnoreConfig{
+					Keys:        []string{"CreatedBy"},
+					KeyPrefixes: []string{"hidden-"},
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual := expandProviderTags(v.DefaultTags, v.IgnoreTags)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestResourcesWithTagsExposeTagsAll(t *testing.T) {
+	provider := TestAzureProvider()
+
+	for resourceName, resource := range provider.ResourcesMap {
+		v, ok := resource.Schema["tags"]
+		if !ok || v.Type != pluginsdk.TypeMap || (!v.Optional && !v.Required) {
+			continue
+		}
+
+		if _, ok := resourcesWithDataPlaneTags[resourceName]; ok {
+			if _, exists := resource.Schema["tags_all"]; exists {
+				t.Fatalf("the Resource %q assigns Data Plane tags and shouldn't expose a `tags_all` field", resourceName)
+			}
+			continue
+		}
+
+		tagsAll, ok := resource.Schema["tags_all"]
+		if !ok {
+			t.Fatalf("the Resource %q exposes a `tags` field but not a `tags_all` field", resourceName)
+		}
+		if !tagsAll.Computed || tagsAll.Optional || tagsAll.Required {
+			t.Fatalf("the `tags_all` field for the Resource %q should be Computed only", resourceName)
+		}
+	}
+}
+
+func noopContext(_ context.Context, _ *pluginsdk.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func TestProviderTagsDiff(t *testing.T) {
+	testData := []struct {
+		Name             string
+		SupportsUpdate   bool
+		ExistingTagsAll  map[string]string
+		ExpectedTagsAll  map[string]string
+		ExpectedForceNew bool
+	}{
+		{
+			Name:            "Missing from the State",
+			SupportsUpdate:  true,
+			ExistingTagsAll: nil,
+			ExpectedTagsAll: nil,
+		},
+		{
+			Name:            "Missing from the State without Update",
+			SupportsUpdate:  false,
+			ExistingTagsAll: nil,
+			ExpectedTagsAll: nil,
+		},
+		{
+			Name:           "Default Tags Changed",
+			SupportsUpdate: true,
+			ExistingTagsAll: map[string]string{
+				"environment": "production",
+			},
+			// only the Tags which have changed are included in the diff
+			ExpectedTagsAll: map[string]string{
+				"cost-center": "123",
+			},
+		},
+		{
+			Name:           "Default Tags Changed without Update",
+			SupportsUpdate: false,
+			ExistingTagsAll: map[string]string{
+				"environment": "production",
+			},
+			ExpectedTagsAll: nil,
+		},
+	}
+
+	meta := &clients.Client{
+		ProviderTags: tags.ProviderConfig{
+			DefaultTags: map[string]string{
+				"cost-center": "123",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		resource := &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"tags": tags.ForceNewSchema(),
+			},
+			CreateContext: noopContext,
+			ReadContext:   noopContext,
+			DeleteContext: noopContext,
+		}
+		if v.SupportsUpdate {
+			resource.Schema["tags"] = tags.Schema()
+			resource.UpdateContext = noopContext
+		}
+		applyProviderTags("azurerm_example", resource)
+
+		attributes := map[string]string{
+			"id":               "/some/id",
+			"tags.%":           "1",
+			"tags.environment": "production",
+		}
+		tagsAll := cty.NullVal(cty.Map(cty.String))
+		if v.ExistingTagsAll != nil {
+			values := make(map[string]cty.Value)
+			for key, value := range v.ExistingTagsAll {
+				attributes["tags_all."+key] = value
+				values[key] = cty.StringVal(value)
+			}
+			attributes["tags_all.%"] = strconv.Itoa(len(v.ExistingTagsAll))
+			tagsAll = cty.MapVal(values)
+		}
+		state := &terraform.InstanceState{
+			ID:         "/some/id",
+			Attributes: attributes,
+			RawState: cty.ObjectVal(map[string]cty.Value{
+				"id":       cty.StringVal("/some/id"),
+				"tags":     cty.MapVal(map[string]cty.Value{"environment": cty.StringVal("production")}),
+				"tags_all": tagsAll,
+			}),
+		}
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"tags": map[string]interface{}{
+				"environment": "production",
+			},
+		})
+
+		diff, err := resource.Diff(context.Background(), state, config, meta)
+		if err != nil {
+			t.Fatalf("diffing: %+v", err)
+		}
+
+		if diff.RequiresNew() != v.ExpectedForceNew {
+			t.Fatalf("expected RequiresNew to be %t but got %t", v.ExpectedForceNew, diff.RequiresNew())
+		}
+
+		changed := diff != nil && diff.Attributes["tags_all.%"] != nil
+		if v.ExpectedTagsAll == nil {
+			if changed {
+				t.Fatalf("expected no change to `tags_all` but got %+v", diff.Attributes)
+			}
+			continue
+		}
+
+		if !changed {
+			t.Fatalf("expected a change to `tags_all`")
+		}
+		for key, value := range v.ExpectedTagsAll {
+			if attr := diff.Attributes["tags_all."+key]; attr == nil || attr.New != value {
+				t.Fatalf("expected `tags_all.%s` to be %q but got %+v", key, value, attr)
+			}
+		}
+	}
+}
+
+func TestProviderTagsUpdateDefaultTagsOnly(t *testing.T) {
+	testData := []struct {
+		Name           string
+		Sku            string
+		ExpectedUpdate bool
+	}{
+		{
+			// the Default Tags are updated using the Tags API, which is all that's changed
+			Name:           "Default Tags Only",
+			Sku:            "Basic",
+			ExpectedUpdate: false,
+		},
+		{
+			Name:           "Default Tags and Other Fields",
+			Sku:            "Standard",
+			ExpectedUpdate: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		var lock sync.Mutex
+		var tagsRequests []resourceTags.TagsResource
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			var payload resourceTags.TagsResource
+			if r.Method != http.MethodPut || json.NewDecoder(r.Body).Decode(&payload) != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			tagsRequests = append(tagsRequests, payload)
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(payload)
+		}))
+		defer server.Close()
+
+		tagsClient, err := resourceTags.NewTagsClientWithBaseURI(environments.ResourceManagerAPI(server.URL))
+		if err != nil {
+			t.Fatalf("building Tags client: %+v", err)
+		}
+		tagsClient.Client.Authorizer = testAuthorizer{}
+
+		var sent map[string]interface{}
+		updated := false
+		resource := &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"sku": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+				},
+				"tags": tags.Schema(),
+			},
+			CreateContext: noopContext,
+			ReadContext:   noopContext,
+			UpdateContext: func(_ context.Context, d *pluginsdk.ResourceData, _ interface{}) diag.Diagnostics {
+				updated = true
+				sent = d.Get("tags").(map[string]interface{})
+				return nil
+			},
+			DeleteContext: noopContext,
+		}
+		applyProviderTags("azurerm_example", resource)
+
+		meta := &clients.Client{
+			ProviderTags: tags.ProviderConfig{
+				DefaultTags: map[string]string{
+					"cost-center": "123",
+				},
+			},
+			Resource: &resourceClient.Client{
+				TagsClient: tagsClient,
+			},
+		}
+		id := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1"
+		state := &terraform.InstanceState{
+			ID: id,
+			Attributes: map[string]string{
+				"id":                   id,
+				"sku":                  "Basic",
+				"tags.%":               "1",
+				"tags.environment":     "production",
+				"tags_all.%":           "1",
+				"tags_all.environment": "production",
+			},
+			RawState: cty.ObjectVal(map[string]cty.Value{
+				"id":       cty.StringVal(id),
+				"sku":      cty.StringVal("Basic"),
+				"tags":     cty.MapVal(map[string]cty.Value{"environment": cty.StringVal("production")}),
+				"tags_all": cty.MapVal(map[string]cty.Value{"environment": cty.StringVal("production")}),
+			}),
+		}
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"sku": v.Sku,
+			"tags": map[string]interface{}{
+				"environment": "production",
+			},
+		})
+
+		diff, err := resource.Diff(context.Background(), state, config, meta)
+		if err != nil {
+			t.Fatalf("diffing: %+v", err)
+		}
+		newState, diags := resource.Apply(context.Background(), state, diff, meta)
+		if diags.HasError() {
+			t.Fatalf("applying: %+v", diags)
+		}
+
+		expected := map[string]string{
+			"cost-center": "123",
+			"environment": "production",
+		}
+		if len(tagsRequests) != 1 || !reflect.DeepEqual(tagsRequests[0].Properties.Tags, &expected) {
+			t.Fatalf("expected the Tags %+v to be updated once using the Tags API but got %+v", expected, tagsRequests)
+		}
+
+		if updated != v.ExpectedUpdate {
+			t.Fatalf("expected the Update function to be run to be %t but got %t", v.ExpectedUpdate, updated)
+		}
+		if updated && !reflect.DeepEqual(sent, map[string]interface{}{"cost-center": "123", "environment": "production"}) {
+			t.Fatalf("expected the effective Tags to be available to the Update function but got %+v", sent)
+		}
+
+		if actual := newState.Attributes["tags_all.cost-center"]; actual != "123" {
+			t.Fatalf("expected `tags_all` to contain the Default Tags but got %+v", newState.Attributes)
+		}
+		if _, ok := newState.Attributes["tags.cost-center"]; ok {
+			t.Fatalf("expected `tags` not to contain the Default Tags but got %+v", newState.Attributes)
+		}
+	}
+}
+
+// testAuthorizer is an auth.Authorizer returning a static access token, since the test server doesn't validate these
+type testAuthorizer struct{}
+
+func (testAuthorizer) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	return &oauth2.Token{
+		AccessToken: "tags",
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(time.Hour),
+	}, nil
+}
+
+func (testAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return []*oauth2.Token{}, nil
+}
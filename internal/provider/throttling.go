@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func schemaThrottling() *pluginsdk.Schema {
+	defaults := common.DefaultThrottlingOptions()
+
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"enabled": {
+					Type:        pluginsdk.TypeBool,
+					Optional:    true,
+					Default:     defaults.Enabled,
+					Description: "Should requests be slowed down as the remaining Azure Resource Manager quota for the Subscription drops, and paused once throttled?",
+				},
+
+				"max_wait_in_seconds": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Default:      int(defaults.MaxWait.Seconds()),
+					ValidateFunc: validation.IntBetween(1, 3600),
+					Description:  "The maximum number of seconds requests are paused for (or a retry is delayed by) once a request has been throttled, regardless of the `Retry-After` header.",
+				},
+
+				"remaining_requests_threshold": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Default:      defaults.RemainingRequestsThreshold,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "The number of remaining requests for a Subscription below which requests are slowed down.",
+				},
+			},
+		},
+	}
+}
+
+func expandThrottling(input []interface{}) common.ThrottlingOptions {
+	// these are the defaults if omitted from the config
+	output := common.DefaultThrottlingOptions()
+
+	if len(input) == 0 || input[0] == nil {
+		return output
+	}
+
+	raw := input[0].(map[string]interface{})
+	output.Enabled = raw["enabled"].(bool)
+	output.MaxWait = time.Duration(raw["max_wait_in_seconds"].(int)) * time.Second
+	output.RemainingRequestsThreshold = raw["remaining_requests_threshold"].(int)
+
+	return output
+}
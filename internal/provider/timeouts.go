@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+)
+
+func schemaDefaultTimeouts() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"create": schemaDefaultTimeout("The minimum timeout for Create operations on all Resources."),
+
+				"read": schemaDefaultTimeout("The minimum timeout for Read operations on all Resources."),
+
+				"update": schemaDefaultTimeout("The minimum timeout for Update operations on all Resources."),
+
+				"delete": schemaDefaultTimeout("The minimum timeout for Delete operations on all Resources."),
+
+				"resource": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"type": {
+								Type:         pluginsdk.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringIsNotEmpty,
+								Description:  "The Resource Type which these timeouts apply to, for example `azurerm_kubernetes_cluster`.",
+							},
+
+							"create": schemaDefaultTimeout("The timeout for Create operations on this Resource Type."),
+
+							"read": schemaDefaultTimeout("The timeout for Read operations on this Resource Type."),
+
+							"update": schemaDefaultTimeout("The timeout for Update operations on this Resource Type."),
+
+							"delete": schemaDefaultTimeout("The timeout for Delete operations on this Resource Type."),
+						},
+					},
+					Description: "One or more blocks defining the timeouts for a specific Resource Type, which take precedence over the timeouts for all Resources.",
+				},
+			},
+		},
+	}
+}
+
+func schemaDefaultTimeout(description string) *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:         pluginsdk.TypeString,
+		Optional:     true,
+		ValidateFunc: validateDefaultTimeout,
+		Description:  fmt.Sprintf("%s Specified as a duration, for example `90m` or `2h`.", description),
+	}
+}
+
+func validateDefaultTimeout(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	duration, err := time.ParseDuration(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("expected %q to be a duration (for example `90m` or `2h`), got %q: %+v", k, v, err))
+		return
+	}
+	if duration <= 0 {
+		errors = append(errors, fmt.Errorf("expected %q to be a positive duration, got %q", k, v))
+	}
+
+	return
+}
+
+func expandDefaultTimeouts(input []interface{}) (*timeouts.ProviderDefaults, error) {
+	output := timeouts.ProviderDefaults{
+		ResourceTypes: map[string]timeouts.OperationTimeouts{},
+	}
+
+	if len(input) == 0 || input[0] == nil {
+		return &output, nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	output.OperationTimeouts = expandDefaultOperationTimeouts(raw)
+
+	for _, item := range raw["resource"].([]interface{}) {
+		if item == nil {
+			continue
+		}
+		v := item.(map[string]interface{})
+
+		resourceType := v["type"].(string)
+		if _, exists := output.ResourceTypes[resourceType]; exists {
+			return nil, fmt.Errorf("the `default_timeouts` block contains multiple `resource` blocks for %q", resourceType)
+		}
+		output.ResourceTypes[resourceType] = expandDefaultOperationTimeouts(v)
+	}
+
+	return &output, nil
+}
+
+func expandDefaultOperationTimeouts(input map[string]interface{}) timeouts.OperationTimeouts {
+	duration := func(key string) *time.Duration {
+		v, ok := input[key].(string)
+		if !ok || v == "" {
+			return nil
+		}
+
+		// this has been validated by the schema
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return nil
+		}
+		return pointer.To(parsed)
+	}
+
+	return timeouts.OperationTimeouts{
+		Create: duration("create"),
+		Read:   duration("read"),
+		Update: duration("update"),
+		Delete: duration("delete"),
+	}
+}
+
+// applyDefaultTimeouts wraps the CRUD functions (and Importer) of the Resource so that the timeout for each operation
+// accounts for the `default_timeouts` defined within the Provider block. These are resolved from the Client for each
+// operation (see ForCreate, ForRead etc in the `timeouts` package) rather than updating the Resource's own defaults,
+// since the Resources are shared by each alias of the Provider - which can define different `default_timeouts`.
+//
+// This must be applied after applyTracing, which ensures that the CRUD functions accept a context.
+func applyDefaultTimeouts(resourceType string, resource *pluginsdk.Resource) {
+	if resource.Timeouts == nil {
+		return
+	}
+	resourceDefaults := *resource.Timeouts
+
+	// the Plugin SDK applies the Resource's own default timeout to the context for these functions, so these are
+	// exposed as the WithoutTimeout variants with the timeout applied by the wrapper instead
+	if resource.CreateContext != nil {
+		resource.CreateWithoutTimeout = defaultTimeoutsWrapper(resourceType, resourceDefaults, timeouts.ForCreate, resource.CreateContext)
+		resource.CreateContext = nil
+	}
+	if resource.ReadContext != nil {
+		resource.ReadWithoutTimeout = defaultTimeoutsWrapper(resourceType, resourceDefaults, timeouts.ForRead, resource.ReadContext)
+		resource.ReadContext = nil
+	}
+	if resource.UpdateContext != nil {
+		resource.UpdateWithoutTimeout = defaultTimeoutsWrapper(resourceType, resourceDefaults, timeouts.ForUpdate, resource.UpdateContext)
+		resource.UpdateContext = nil
+	}
+	if resource.DeleteContext != nil {
+		resource.DeleteWithoutTimeout = defaultTimeoutsWrapper(resourceType, resourceDefaults, timeouts.ForDelete, resource.DeleteContext)
+		resource.DeleteContext = nil
+	}
+
+	// the Importer can be shared between Resources, so this is copied rather than updated in-place
+	if resource.Importer != nil && resource.Importer.StateContext != nil {
+		importer := *resource.Importer
+		stateContext := importer.StateContext
+		importer.StateContext = func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
+			return stateContext(contextWithDefaultTimeouts(ctx, resourceType, resourceDefaults, meta), d, meta)
+		}
+		resource.Importer = &importer
+	}
+}
+
+func defaultTimeoutsWrapper(resourceType string, resourceDefaults pluginsdk.ResourceTimeout, withTimeout func(context.Context, *pluginsdk.ResourceData) (context.Context, context.CancelFunc), in func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+		ctx, cancel := withTimeout(contextWithDefaultTimeouts(ctx, resourceType, resourceDefaults, meta), d)
+		defer cancel()
+
+		return in(ctx, d, meta)
+	}
+}
+
+// contextWithDefaultTimeouts exposes the `default_timeouts` from the Provider block which configured the Client
+func contextWithDefaultTimeouts(ctx context.Context, resourceType string, resourceDefaults pluginsdk.ResourceTimeout, meta interface{}) context.Context {
+	client, ok := meta.(*clients.Client)
+	if !ok || client == nil {
+		return ctx
+	}
+
+	return timeouts.WithProviderDefaults(ctx, resourceType, resourceDefaults, client.DefaultTimeouts)
+}
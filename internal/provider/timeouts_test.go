@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+)
+
+func TestExpandDefaultTimeouts(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		Expected *timeouts.ProviderDefaults
+		Error    bool
+	}{
+		{
+			Name:  "Omitted",
+			Input: []interface{}{},
+			Expected: &timeouts.ProviderDefaults{
+				ResourceTypes: map[string]timeouts.OperationTimeouts{},
+			},
+		},
+		{
+			Name: "All Resources",
+			Input: []interface{}{
+				map[string]interface{}{
+					"create":   "90m",
+					"read":     "",
+					"update":   "1h",
+					"delete":   "",
+					"resource": []interface{}{},
+				},
+			},
+			Expected: &timeouts.ProviderDefaults{
+				OperationTimeouts: timeouts.OperationTimeouts{
+					Create: pointer.To(90 * time.Minute),
+					Update: pointer.To(time.Hour),
+				},
+				ResourceTypes: map[string]timeouts.OperationTimeouts{},
+			},
+		},
+		{
+			Name: "Resource Types",
+			Input: []interface{}{
+				map[string]interface{}{
+					"create": "",
+					"read":   "",
+					"update": "",
+					"delete": "",
+					"resource": []interface{}{
+						map[string]interface{}{
+							"type":   "azurerm_kubernetes_cluster",
+							"create": "3h",
+							"read":   "",
+							"update": "3h",
+							"delete": "2h",
+						},
+					},
+				},
+			},
+			Expected: &timeouts.ProviderDefaults{
+				ResourceTypes: map[string]timeouts.OperationTimeouts{
+					"azurerm_kubernetes_cluster": {
+						Create: pointer.To(3 * time.Hour),
+						Update: pointer.To(3 * time.Hour),
+						Delete: pointer.To(2 * time.Hour),
+					},
+				},
+			},
+		},
+		{
+			Name: "Duplicate Resource Types",
+			Input: []interface{}{
+				map[string]interface{}{
+					"create": "",
+					"read":   "",
+					"update": "",
+					"delete": "",
+					"resource": []interface{}{
+						map[string]interface{}{
+							"type":   "azurerm_kubernetes_cluster",
+							"create": "3h",
+							"read":   "",
+							"update": "",
+							"delete": "",
+						},
+						map[string]interface{}{
+							"type":   "azurerm_kubernetes_cluster",
+							"create": "",
+							"read":   "",
+							"update": "",
+							"delete": "2h",
+						},
+					},
+				},
+			},
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, err := expandDefaultTimeouts(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.Error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestValidateDefaultTimeout(t *testing.T) {
+	testData := []struct {
+		Input string
+		Valid bool
+	}{
+		{Input: "", Valid: false},
+		{Input: "90", Valid: false},
+		{Input: "-1h", Valid: false},
+		{Input: "0s", Valid: false},
+		{Input: "90m", Valid: true},
+		{Input: "1h30m", Valid: true},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		_, errors := validateDefaultTimeout(v.Input, "create")
+		if valid := len(errors) == 0; valid != v.Valid {
+			t.Fatalf("expected %t but got %t", v.Valid, valid)
+		}
+	}
+}
+
+func TestApplyDefaultTimeouts(t *testing.T) {
+	var timeout time.Duration
+	resource := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{},
+		CreateContext: func(ctx context.Context, _ *pluginsdk.ResourceData, _ interface{}) diag.Diagnostics {
+			deadline, _ := ctx.Deadline()
+			timeout = time.Until(deadline).Round(time.Minute)
+			return nil
+		},
+		ReadContext:   noopContext,
+		DeleteContext: noopContext,
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+	}
+	applyDefaultTimeouts("azurerm_example", resource)
+
+	if resource.CreateContext != nil || resource.CreateWithoutTimeout == nil {
+		t.Fatalf("expected the Create function to apply the timeout itself")
+	}
+
+	// each alias of the Provider can define different `default_timeouts`, which apply only to that alias
+	testData := []struct {
+		Defaults timeouts.ProviderDefaults
+		Expected time.Duration
+	}{
+		{
+			Defaults: timeouts.ProviderDefaults{
+				OperationTimeouts: timeouts.OperationTimeouts{
+					Create: pointer.To(2 * time.Hour),
+				},
+			},
+			Expected: 2 * time.Hour,
+		},
+		{
+			Defaults: timeouts.ProviderDefaults{},
+			Expected: 30 * time.Minute,
+		},
+		{
+			Defaults: timeouts.ProviderDefaults{
+				ResourceTypes: map[string]timeouts.OperationTimeouts{
+					"azurerm_example": {
+						Create: pointer.To(10 * time.Minute),
+					},
+				},
+			},
+			Expected: 10 * time.Minute,
+		},
+	}
+	for _, v := range testData {
+		client := &clients.Client{
+			DefaultTimeouts: v.Defaults,
+		}
+		if diags := resource.CreateWithoutTimeout(context.Background(), resource.Data(&terraform.InstanceState{}), client); diags.HasError() {
+			t.Fatalf("creating: %+v", diags)
+		}
+		if timeout != v.Expected {
+			t.Fatalf("expected the timeout to be %s but got %s", v.Expected, timeout)
+		}
+	}
+
+	if *resource.Timeouts.Create != 30*time.Minute {
+		t.Fatalf("expected the Resource's default timeout to be unchanged but got %s", *resource.Timeouts.Create)
+	}
+}
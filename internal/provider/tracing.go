@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func schemaTracing() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"redacted_fields": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					Description: "A list of field names within request and response bodies which should be redacted when logged, in addition to the fields which are always redacted.",
+				},
+
+				"otlp_endpoint": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+					Description:  "The URL of an OTLP/HTTP endpoint which spans for the requests made to Azure should be exported to. This can also be sourced from the `ARM_TRACING_OTLP_ENDPOINT` Environment Variable.",
+				},
+			},
+		},
+	}
+}
+
+func expandTracing(input []interface{}) common.TracingOptions {
+	output := common.TracingOptions{
+		// the endpoint can be specified without the block, so that tracing can be enabled without changing the configuration
+		OtlpEndpoint: os.Getenv("ARM_TRACING_OTLP_ENDPOINT"),
+	}
+
+	if len(input) == 0 || input[0] == nil {
+		return output
+	}
+
+	raw := input[0].(map[string]interface{})
+	for _, v := range raw["redacted_fields"].([]interface{}) {
+		if field, ok := v.(string); ok && field != "" {
+			output.RedactedFields = append(output.RedactedFields, field)
+		}
+	}
+	if v := raw["otlp_endpoint"].(string); v != "" {
+		output.OtlpEndpoint = v
+	}
+
+	return output
+}
+
+// applyTracing wraps the CRUD functions of the Resource (or Data Source) in a span for the Terraform operation,
+// so that the requests made to Azure are attributed to the Resource Type and operation being performed
+func applyTracing(resourceType string, resource *pluginsdk.Resource) {
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	if resource.Create != nil { //nolint:staticcheck
+		resource.CreateContext = legacyTracingWrapper(resourceType, "Create", resource.Create) //nolint:staticcheck
+		resource.Create = nil                                                                  //nolint:staticcheck
+	} else if resource.CreateContext != nil {
+		resource.CreateContext = tracingWrapper(resourceType, "Create", resource.CreateContext)
+	}
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	if resource.Read != nil { //nolint:staticcheck
+		resource.ReadContext = legacyTracingWrapper(resourceType, "Read", resource.Read) //nolint:staticcheck
+		resource.Read = nil                                                              //nolint:staticcheck
+	} else if resource.ReadContext != nil {
+		resource.ReadContext = tracingWrapper(resourceType, "Read", resource.ReadContext)
+	}
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	if resource.Update != nil { //nolint:staticcheck
+		resource.UpdateContext = legacyTracingWrapper(resourceType, "Update", resource.Update) //nolint:staticcheck
+		resource.Update = nil                                                                  //nolint:staticcheck
+	} else if resource.UpdateContext != nil {
+		resource.UpdateContext = tracingWrapper(resourceType, "Update", resource.UpdateContext)
+	}
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	if resource.Delete != nil { //nolint:staticcheck
+		resource.DeleteContext = legacyTracingWrapper(resourceType, "Delete", resource.Delete) //nolint:staticcheck
+		resource.Delete = nil                                                                  //nolint:staticcheck
+	} else if resource.DeleteContext != nil {
+		resource.DeleteContext = tracingWrapper(resourceType, "Delete", resource.DeleteContext)
+	}
+}
+
+func tracingWrapper(resourceType string, operation string, in func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+		ctx, end := common.StartOperation(ctx, resourceType, operation)
+
+		diags := in(ctx, d, metaWithOperationContext(ctx, meta))
+		end(diagnosticsError(diags))
+
+		return diags
+	}
+}
+
+func legacyTracingWrapper(resourceType string, operation string, in func(*pluginsdk.ResourceData, interface{}) error) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+		ctx, end := common.StartOperation(ctx, resourceType, operation)
+
+		err := in(d, metaWithOperationContext(ctx, meta))
+		end(err)
+
+		return diag.FromErr(err)
+	}
+}
+
+// metaWithOperationContext returns a copy of the Client where the StopContext (which the Untyped Resources use to
+// make requests) exposes the values from the context for the operation - such as the span and Terraform logger
+func metaWithOperationContext(ctx context.Context, meta interface{}) interface{} {
+	client, ok := meta.(*clients.Client)
+	if !ok || client == nil || client.StopContext == nil {
+		return meta
+	}
+
+	output := *client
+	output.StopContext = common.ContextWithValues(client.StopContext, ctx)
+	return &output
+}
+
+func diagnosticsError(diags diag.Diagnostics) error {
+	for _, v := range diags {
+		if v.Severity == diag.Error {
+			return fmt.Errorf("%s: %s", v.Summary, v.Detail)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestExpandTracing(t *testing.T) {
+	t.Setenv("ARM_TRACING_OTLP_ENDPOINT", "http://localhost:4318")
+
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		Expected common.TracingOptions
+	}{
+		{
+			Name:  "Omitted",
+			Input: []interface{}{},
+			Expected: common.TracingOptions{
+				OtlpEndpoint: "http://localhost:4318",
+			},
+		},
+		{
+			Name: "Configured",
+			Input: []interface{}{
+				map[string]interface{}{
+					"redacted_fields": []interface{}{"customerId"},
+					"otlp_endpoint":   "http://collector:4318/v1/traces",
+				},
+			},
+			Expected: common.TracingOptions{
+				RedactedFields: []string{"customerId"},
+				OtlpEndpoint:   "http://collector:4318/v1/traces",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := expandTracing(v.Input)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestApplyTracingToLegacyFunctions(t *testing.T) {
+	type key struct{}
+
+	stopCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := &clients.Client{
+		StopContext: stopCtx,
+	}
+
+	var received *clients.Client
+	resource := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{},
+		Read: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			received = meta.(*clients.Client)
+			return nil
+		},
+	}
+	applyTracing("azurerm_example", resource)
+
+	if resource.Read != nil { //nolint:staticcheck
+		t.Fatalf("expected the legacy Read function to be replaced")
+	}
+	if resource.ReadContext == nil {
+		t.Fatalf("expected a ReadContext function")
+	}
+
+	ctx := context.WithValue(context.Background(), key{}, "operation")
+	if diags := resource.ReadContext(ctx, resource.TestResourceData(), client); diags.HasError() {
+		t.Fatalf("reading: %+v", diags)
+	}
+
+	if received == nil || received == client {
+		t.Fatalf("expected the legacy Read function to receive a copy of the Client")
+	}
+	if received.StopContext.Value(key{}) != "operation" {
+		t.Fatalf("expected the StopContext to expose the values from the operation context")
+	}
+	if client.StopContext != stopCtx {
+		t.Fatalf("expected the original Client to be unchanged")
+	}
+
+	cancel()
+	if received.StopContext.Err() == nil {
+		t.Fatalf("expected the StopContext to be cancelled alongside the original StopContext")
+	}
+}
@@ -74,3 +74,17 @@ func populateCache(ctx context.Context, client *providers.ProvidersClient, subsc
 	unregisteredResourceProviders = &unregisteredProviders
 	return nil
 }
+
+// markAsRegistered updates the cache once the Resource Provider `name` has been registered, so that subsequent
+// calls to EnsureRegistered (e.g. from other instances of the Provider) don't attempt to register it again
+func markAsRegistered(name string) {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	if registeredResourceProviders == nil || unregisteredResourceProviders == nil {
+		return
+	}
+
+	(*registeredResourceProviders)[name] = struct{}{}
+	delete(*unregisteredResourceProviders, name)
+}
@@ -7,86 +7,136 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2022-09-01/providers"
 	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders/custompollers"
 )
 
-func EnsureRegistered(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId, requiredRPs map[string]struct{}) error {
+// maxConcurrentRegistrations is the number of Resource Providers which are registered at once, to avoid
+// being throttled by the Resource Manager API
+const maxConcurrentRegistrations = 10
+
+// RegistrationWarning describes a Resource Provider which couldn't be registered because the credentials
+// being used don't have permission to register it - which is surfaced as a warning, rather than an error,
+// since the Resource Provider may not be used in the configuration.
+type RegistrationWarning struct {
+	// ResourceProvider is the namespace of the Resource Provider which couldn't be registered
+	ResourceProvider string
+
+	// Error is the error returned when attempting to register the Resource Provider
+	Error error
+}
+
+// EnsureRegistered registers the Resource Providers within `requiredRPs` which aren't already registered
+// in the Subscription - returning a RegistrationWarning for each Resource Provider which couldn't be registered
+// due to insufficient permissions, and an error if any other Resource Provider couldn't be registered.
+func EnsureRegistered(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId, requiredRPs map[string]struct{}) ([]RegistrationWarning, error) {
 	if cachedResourceProviders == nil || registeredResourceProviders == nil || unregisteredResourceProviders == nil {
 		if err := populateCache(ctx, client, subscriptionId); err != nil {
-			return fmt.Errorf("populating Resource Provider cache: %+v", err)
+			return nil, fmt.Errorf("populating Resource Provider cache: %+v", err)
 		}
 	}
 
 	log.Printf("[DEBUG] Determining which Resource Providers require Registration")
 	providersToRegister, err := DetermineWhichRequiredResourceProvidersRequireRegistration(requiredRPs)
 	if err != nil {
-		return fmt.Errorf("determining which Required Resource Providers require registration: %+v", err)
+		return nil, fmt.Errorf("determining which Required Resource Providers require registration: %+v", err)
 	}
 
-	if len(*providersToRegister) > 0 {
-		log.Printf("[DEBUG] Registering %d Resource Providers", len(*providersToRegister))
-		if err := registerForSubscription(ctx, client, subscriptionId, *providersToRegister); err != nil {
-			return err
-		}
-	} else {
+	if len(*providersToRegister) == 0 {
 		log.Printf("[DEBUG] All required Resource Providers are registered")
+		return nil, nil
 	}
 
-	return nil
+	log.Printf("[DEBUG] Registering %d Resource Providers", len(*providersToRegister))
+	return registerForSubscription(ctx, client, subscriptionId, *providersToRegister)
 }
 
-// registerForSubscription registers the specified Resource Providers in the current Subscription
-func registerForSubscription(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId, providersToRegister []string) error {
-	var err error
-	var failedProviders []string
+// registerForSubscription registers the specified Resource Providers in the current Subscription in parallel
+func registerForSubscription(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId, providersToRegister []string) ([]RegistrationWarning, error) {
+	var lock sync.Mutex
 	var wg sync.WaitGroup
-	wg.Add(len(providersToRegister))
+	failures := make(map[string]error)
+	warnings := make([]RegistrationWarning, 0)
 
+	semaphore := make(chan struct{}, maxConcurrentRegistrations)
 	for _, providerName := range providersToRegister {
+		wg.Add(1)
 		go func(p string) {
 			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
 			log.Printf("[DEBUG] Registering Resource Provider %q with namespace", p)
-			if innerErr := registerWithSubscription(ctx, client, subscriptionId, p); innerErr != nil {
-				failedProviders = append(failedProviders, p)
-				if err == nil {
-					err = innerErr
-				} else {
-					err = fmt.Errorf("%s\n%s", err, innerErr)
-				}
+			forbidden, err := registerWithSubscription(ctx, client, subscriptionId, p)
+
+			lock.Lock()
+			defer lock.Unlock()
+			switch {
+			case err == nil:
+				markAsRegistered(p)
+
+			case forbidden:
+				log.Printf("[WARN] Insufficient permissions to register the Resource Provider %q: %+v", p, err)
+				warnings = append(warnings, RegistrationWarning{
+					ResourceProvider: p,
+					Error:            err,
+				})
+
+			default:
+				failures[p] = err
 			}
 		}(providerName)
 	}
 
 	wg.Wait()
 
-	if len(failedProviders) > 0 {
-		err = fmt.Errorf("Cannot register providers: %s. Errors were: %s", strings.Join(failedProviders, ", "), err)
+	sort.Slice(warnings, func(i, j int) bool {
+		return warnings[i].ResourceProvider < warnings[j].ResourceProvider
+	})
+
+	if len(failures) > 0 {
+		failedProviders := make([]string, 0, len(failures))
+		for name := range failures {
+			failedProviders = append(failedProviders, name)
+		}
+		sort.Strings(failedProviders)
+
+		errors := make([]string, 0, len(failedProviders))
+		for _, name := range failedProviders {
+			errors = append(errors, failures[name].Error())
+		}
+
+		return warnings, fmt.Errorf("Cannot register providers: %s. Errors were: %s", strings.Join(failedProviders, ", "), strings.Join(errors, "\n"))
 	}
-	return err
+
+	return warnings, nil
 }
 
-func registerWithSubscription(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId, providerName string) error {
+// registerWithSubscription registers the Resource Provider `providerName` within the Subscription, returning
+// whether the registration failed due to insufficient permissions alongside any error
+func registerWithSubscription(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId, providerName string) (bool, error) {
 	providerId := providers.NewSubscriptionProviderID(subscriptionId.SubscriptionId, providerName)
 	log.Printf("[DEBUG] Registering %s..", providerId)
-	if _, err := client.Register(ctx, providerId, providers.ProviderRegistrationRequest{}); err != nil {
-		return fmt.Errorf("Cannot register provider %s with Azure Resource Manager: %s.", providerName, err)
+	if resp, err := client.Register(ctx, providerId, providers.ProviderRegistrationRequest{}); err != nil {
+		return response.WasForbidden(resp.HttpResponse), fmt.Errorf("Cannot register provider %s with Azure Resource Manager: %s.", providerName, err)
 	}
 
 	log.Printf("[DEBUG] Waiting for %s to finish registering..", providerId)
 	pollerType := custompollers.NewResourceProviderRegistrationPoller(client, providerId)
 	poller := pollers.NewPoller(pollerType, 10*time.Second, pollers.DefaultNumberOfDroppedConnectionsToAllow)
 	if err := poller.PollUntilDone(ctx); err != nil {
-		return fmt.Errorf("waiting for %s to be registered: %s", providerId, err)
+		return false, fmt.Errorf("waiting for %s to be registered: %s", providerId, err)
 	}
 
 	log.Printf("[DEBUG] %s is registered.", providerId)
 
-	return nil
+	return false, nil
 }
@@ -3,83 +3,186 @@
 
 package resourceproviders
 
-// Required returns core Resource Providers used by the AzureRM Provider
-// Terraform auto-registers core Resource Providers, since those RP’s should be enabled by default
-// but that list is something we come up with based on experience.
-// whilst all may not be used by every user - the intention is that we determine which should be
-// registered such that we can avoid obscure errors where Resource Providers aren't registered.
-// new core Resource Providers should be added to this list as they're used in the Provider
-// (this is the approach used by Microsoft in their tooling)
-func Required() map[string]struct{} {
-	// NOTE: Resource Providers in this list are case sensitive
-	return map[string]struct{}{
-		"Microsoft.AppConfiguration":        {},
-		"Microsoft.ApiManagement":           {},
-		"Microsoft.AppPlatform":             {},
-		"Microsoft.Authorization":           {},
-		"Microsoft.Automation":              {},
-		"Microsoft.AVS":                     {},
-		"Microsoft.Blueprint":               {},
-		"Microsoft.BotService":              {},
-		"Microsoft.Cache":                   {},
-		"Microsoft.Cdn":                     {},
-		"Microsoft.CognitiveServices":       {},
-		"Microsoft.Compute":                 {},
-		"Microsoft.ContainerInstance":       {},
-		"Microsoft.ContainerRegistry":       {},
-		"Microsoft.ContainerService":        {},
-		"Microsoft.CostManagement":          {},
-		"Microsoft.CustomProviders":         {},
-		"Microsoft.Databricks":              {},
-		"Microsoft.DataFactory":             {},
-		"Microsoft.DataLakeAnalytics":       {},
-		"Microsoft.DataLakeStore":           {},
-		"Microsoft.DataMigration":           {},
-		"Microsoft.DataProtection":          {},
-		"Microsoft.DBforMariaDB":            {},
-		"Microsoft.DBforMySQL":              {},
-		"Microsoft.DBforPostgreSQL":         {},
-		"Microsoft.DesktopVirtualization":   {},
-		"Microsoft.Devices":                 {},
-		"Microsoft.DevTestLab":              {},
-		"Microsoft.DocumentDB":              {},
-		"Microsoft.EventGrid":               {},
-		"Microsoft.EventHub":                {},
-		"Microsoft.HDInsight":               {},
-		"Microsoft.HealthcareApis":          {},
-		"Microsoft.GuestConfiguration":      {},
-		"Microsoft.KeyVault":                {},
-		"Microsoft.Kusto":                   {},
-		"microsoft.insights":                {},
-		"Microsoft.Logic":                   {},
-		"Microsoft.MachineLearningServices": {},
-		"Microsoft.Maintenance":             {},
-		"Microsoft.ManagedIdentity":         {},
-		"Microsoft.ManagedServices":         {},
-		"Microsoft.Management":              {},
-		"Microsoft.Maps":                    {},
-		"Microsoft.MarketplaceOrdering":     {},
-		"Microsoft.Media":                   {},
-		"Microsoft.MixedReality":            {},
-		"Microsoft.Network":                 {},
-		"Microsoft.NotificationHubs":        {},
-		"Microsoft.OperationalInsights":     {},
-		"Microsoft.OperationsManagement":    {},
-		"Microsoft.PolicyInsights":          {},
-		"Microsoft.PowerBIDedicated":        {},
-		"Microsoft.Relay":                   {},
-		"Microsoft.RecoveryServices":        {},
-		"Microsoft.Resources":               {},
-		"Microsoft.SignalRService":          {},
-		"Microsoft.Search":                  {},
-		"Microsoft.Security":                {},
-		"Microsoft.SecurityInsights":        {},
-		"Microsoft.ServiceBus":              {},
-		"Microsoft.ServiceFabric":           {},
-		"Microsoft.Sql":                     {},
-		"Microsoft.Storage":                 {},
-		"Microsoft.StreamAnalytics":         {},
-		"Microsoft.TimeSeriesInsights":      {},
-		"Microsoft.Web":                     {},
+import (
+	"fmt"
+	"strings"
+)
+
+// RegistrationProfile defines which set of Resource Providers the AzureRM Provider automatically registers,
+// configured using the `resource_provider_registrations` field within the Provider block
+type RegistrationProfile string
+
+const (
+	// RegistrationProfileNone registers no Resource Providers, other than those specified explicitly
+	RegistrationProfileNone RegistrationProfile = "none"
+
+	// RegistrationProfileCore registers the Resource Providers used by the most common resources (e.g. Compute,
+	// Networking and Storage), which is intended for use where the permissions to register Resource Providers
+	// are restricted
+	RegistrationProfileCore RegistrationProfile = "core"
+
+	// RegistrationProfileExtended registers the Core Resource Providers and the other Resource Providers which
+	// have historically been registered by the AzureRM Provider - and is the default
+	RegistrationProfileExtended RegistrationProfile = "extended"
+
+	// RegistrationProfileAll registers all of the Resource Providers used by the AzureRM Provider
+	RegistrationProfileAll RegistrationProfile = "all"
+)
+
+func PossibleValuesForRegistrationProfile() []string {
+	return []string{
+		string(RegistrationProfileNone),
+		string(RegistrationProfileCore),
+		string(RegistrationProfileExtended),
+		string(RegistrationProfileAll),
+	}
+}
+
+// registrationProfileTiers orders the Registration Profiles, where each Profile includes the Resource Providers
+// within the Profiles before it
+var registrationProfileTiers = []RegistrationProfile{
+	RegistrationProfileCore,
+	RegistrationProfileExtended,
+	RegistrationProfileAll,
+}
+
+// resourceProviders maps each of the Resource Providers used by the AzureRM Provider to the (lowest) Registration
+// Profile which registers it. Terraform auto-registers these Resource Providers to avoid the obscure errors returned
+// when a Resource Provider isn't registered - whilst not all may be used by every user, this list is something we
+// come up with based on experience (which is the approach used by Microsoft in their tooling).
+//
+// New Resource Providers should be added to the `all` tier as they're used in the Provider.
+//
+// NOTE: Resource Providers in this list are case sensitive
+var resourceProviders = map[string]RegistrationProfile{
+	"Microsoft.Authorization":           RegistrationProfileCore,
+	"Microsoft.Compute":                 RegistrationProfileCore,
+	"Microsoft.ContainerInstance":       RegistrationProfileCore,
+	"Microsoft.ContainerRegistry":       RegistrationProfileCore,
+	"Microsoft.ContainerService":        RegistrationProfileCore,
+	"Microsoft.DocumentDB":              RegistrationProfileCore,
+	"Microsoft.EventHub":                RegistrationProfileCore,
+	"Microsoft.KeyVault":                RegistrationProfileCore,
+	"Microsoft.ManagedIdentity":         RegistrationProfileCore,
+	"Microsoft.Network":                 RegistrationProfileCore,
+	"Microsoft.OperationalInsights":     RegistrationProfileCore,
+	"Microsoft.Resources":               RegistrationProfileCore,
+	"Microsoft.ServiceBus":              RegistrationProfileCore,
+	"Microsoft.Sql":                     RegistrationProfileCore,
+	"Microsoft.Storage":                 RegistrationProfileCore,
+	"Microsoft.Web":                     RegistrationProfileCore,
+	"microsoft.insights":                RegistrationProfileCore,
+	"Microsoft.ApiManagement":           RegistrationProfileExtended,
+	"Microsoft.AppConfiguration":        RegistrationProfileExtended,
+	"Microsoft.AppPlatform":             RegistrationProfileExtended,
+	"Microsoft.Automation":              RegistrationProfileExtended,
+	"Microsoft.AVS":                     RegistrationProfileExtended,
+	"Microsoft.Blueprint":               RegistrationProfileExtended,
+	"Microsoft.BotService":              RegistrationProfileExtended,
+	"Microsoft.Cache":                   RegistrationProfileExtended,
+	"Microsoft.Cdn":                     RegistrationProfileExtended,
+	"Microsoft.CognitiveServices":       RegistrationProfileExtended,
+	"Microsoft.CostManagement":          RegistrationProfileExtended,
+	"Microsoft.CustomProviders":         RegistrationProfileExtended,
+	"Microsoft.Databricks":              RegistrationProfileExtended,
+	"Microsoft.DataFactory":             RegistrationProfileExtended,
+	"Microsoft.DataLakeAnalytics":       RegistrationProfileExtended,
+	"Microsoft.DataLakeStore":           RegistrationProfileExtended,
+	"Microsoft.DataMigration":           RegistrationProfileExtended,
+	"Microsoft.DataProtection":          RegistrationProfileExtended,
+	"Microsoft.DBforMariaDB":            RegistrationProfileExtended,
+	"Microsoft.DBforMySQL":              RegistrationProfileExtended,
+	"Microsoft.DBforPostgreSQL":         RegistrationProfileExtended,
+	"Microsoft.DesktopVirtualization":   RegistrationProfileExtended,
+	"Microsoft.Devices":                 RegistrationProfileExtended,
+	"Microsoft.DevTestLab":              RegistrationProfileExtended,
+	"Microsoft.EventGrid":               RegistrationProfileExtended,
+	"Microsoft.GuestConfiguration":      RegistrationProfileExtended,
+	"Microsoft.HDInsight":               RegistrationProfileExtended,
+	"Microsoft.HealthcareApis":          RegistrationProfileExtended,
+	"Microsoft.Kusto":                   RegistrationProfileExtended,
+	"Microsoft.Logic":                   RegistrationProfileExtended,
+	"Microsoft.MachineLearningServices": RegistrationProfileExtended,
+	"Microsoft.Maintenance":             RegistrationProfileExtended,
+	"Microsoft.ManagedServices":         RegistrationProfileExtended,
+	"Microsoft.Management":              RegistrationProfileExtended,
+	"Microsoft.Maps":                    RegistrationProfileExtended,
+	"Microsoft.MarketplaceOrdering":     RegistrationProfileExtended,
+	"Microsoft.Media":                   RegistrationProfileExtended,
+	"Microsoft.MixedReality":            RegistrationProfileExtended,
+	"Microsoft.NotificationHubs":        RegistrationProfileExtended,
+	"Microsoft.OperationsManagement":    RegistrationProfileExtended,
+	"Microsoft.PolicyInsights":          RegistrationProfileExtended,
+	"Microsoft.PowerBIDedicated":        RegistrationProfileExtended,
+	"Microsoft.RecoveryServices":        RegistrationProfileExtended,
+	"Microsoft.Relay":                   RegistrationProfileExtended,
+	"Microsoft.Search":                  RegistrationProfileExtended,
+	"Microsoft.Security":                RegistrationProfileExtended,
+	"Microsoft.SecurityInsights":        RegistrationProfileExtended,
+	"Microsoft.ServiceFabric":           RegistrationProfileExtended,
+	"Microsoft.SignalRService":          RegistrationProfileExtended,
+	"Microsoft.StreamAnalytics":         RegistrationProfileExtended,
+	"Microsoft.TimeSeriesInsights":      RegistrationProfileExtended,
+	"Microsoft.AlertsManagement":        RegistrationProfileAll,
+	"Microsoft.AnalysisServices":        RegistrationProfileAll,
+	"Microsoft.App":                     RegistrationProfileAll,
+	"Microsoft.Batch":                   RegistrationProfileAll,
+	"Microsoft.Chaos":                   RegistrationProfileAll,
+	"Microsoft.Communication":           RegistrationProfileAll,
+	"Microsoft.Dashboard":               RegistrationProfileAll,
+	"Microsoft.DataShare":               RegistrationProfileAll,
+	"Microsoft.DevCenter":               RegistrationProfileAll,
+	"Microsoft.DigitalTwins":            RegistrationProfileAll,
+	"Microsoft.HybridCompute":           RegistrationProfileAll,
+	"Microsoft.IoTCentral":              RegistrationProfileAll,
+	"Microsoft.KubernetesConfiguration": RegistrationProfileAll,
+	"Microsoft.LoadTestService":         RegistrationProfileAll,
+	"Microsoft.Monitor":                 RegistrationProfileAll,
+	"Microsoft.NetApp":                  RegistrationProfileAll,
+	"Microsoft.Purview":                 RegistrationProfileAll,
+	"Microsoft.RedHatOpenShift":         RegistrationProfileAll,
+	"Microsoft.ServiceLinker":           RegistrationProfileAll,
+	"Microsoft.SqlVirtualMachine":       RegistrationProfileAll,
+	"Microsoft.StorageCache":            RegistrationProfileAll,
+	"Microsoft.StorageSync":             RegistrationProfileAll,
+	"Microsoft.Synapse":                 RegistrationProfileAll,
+	"Microsoft.VideoIndexer":            RegistrationProfileAll,
+	"Microsoft.Workloads":               RegistrationProfileAll,
+}
+
+// ForRegistrationProfile returns the Resource Providers which should be registered for the Registration Profile
+// `profile` - alongside the Resource Providers specified in `additional`.
+func ForRegistrationProfile(profile RegistrationProfile, additional []string) (map[string]struct{}, error) {
+	tier := -1
+	if profile != RegistrationProfileNone {
+		for i, v := range registrationProfileTiers {
+			if strings.EqualFold(string(v), string(profile)) {
+				tier = i
+			}
+		}
+		if tier == -1 {
+			return nil, fmt.Errorf("unsupported Resource Provider Registration Profile %q - supported values are %s", profile, strings.Join(PossibleValuesForRegistrationProfile(), ", "))
+		}
 	}
+
+	out := make(map[string]struct{})
+	for name, v := range resourceProviders {
+		for i := 0; i <= tier; i++ {
+			if registrationProfileTiers[i] == v {
+				out[name] = struct{}{}
+			}
+		}
+	}
+
+	for _, name := range additional {
+		out[name] = struct{}{}
+	}
+
+	return out, nil
+}
+
+// Required returns the Resource Providers registered by default (those within the `extended` Registration Profile)
+func Required() map[string]struct{} {
+	out, _ := ForRegistrationProfile(RegistrationProfileExtended, nil)
+	return out
 }
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"testing"
+)
+
+func TestForRegistrationProfile(t *testing.T) {
+	counts := make(map[RegistrationProfile]int)
+	for _, profile := range PossibleValuesForRegistrationProfile() {
+		out, err := ForRegistrationProfile(RegistrationProfile(profile), nil)
+		if err != nil {
+			t.Fatalf("retrieving the Resource Providers for %q: %+v", profile, err)
+		}
+		counts[RegistrationProfile(profile)] = len(out)
+	}
+
+	if counts[RegistrationProfileNone] != 0 {
+		t.Fatalf("expected no Resource Providers for `none` but got %d", counts[RegistrationProfileNone])
+	}
+	if !(counts[RegistrationProfileCore] < counts[RegistrationProfileExtended] && counts[RegistrationProfileExtended] < counts[RegistrationProfileAll]) {
+		t.Fatalf("expected each Registration Profile to include the Resource Providers from the previous Profile but got %+v", counts)
+	}
+
+	// each Profile should include those from the previous Profile
+	core, _ := ForRegistrationProfile(RegistrationProfileCore, nil)
+	extended, _ := ForRegistrationProfile(RegistrationProfileExtended, nil)
+	for name := range core {
+		if _, ok := extended[name]; !ok {
+			t.Fatalf("expected %q to be included in the `extended` Registration Profile", name)
+		}
+	}
+}
+
+func TestForRegistrationProfileAdditional(t *testing.T) {
+	out, err := ForRegistrationProfile(RegistrationProfileNone, []string{"Microsoft.Compute", "Microsoft.Example"})
+	if err != nil {
+		t.Fatalf("retrieving the Resource Providers: %+v", err)
+	}
+	if len(out) != 2 {
+		t.Fatalf("expected 2 Resource Providers but got %d", len(out))
+	}
+	for _, name := range []string{"Microsoft.Compute", "Microsoft.Example"} {
+		if _, ok := out[name]; !ok {
+			t.Fatalf("expected %q to be registered", name)
+		}
+	}
+}
+
+func TestForRegistrationProfileInvalid(t *testing.T) {
+	if _, err := ForRegistrationProfile("legacy", nil); err == nil {
+		t.Fatalf("expected an error for an unsupported Registration Profile but didn't get one")
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceskus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/skus"
+)
+
+// cachedResourceSkus is a map of the Subscription ID and (normalized) Location to the Resource SKUs available within it.
+//
+// Since the Resource SKUs API returns every SKU in every Location (which is large, and gets progressively
+// larger over time) this is populated lazily, for each Location as it's used.
+var cachedResourceSkus = make(map[string]*cachedResourceSkusEntry)
+
+// cacheLock guards cachedResourceSkus - the lock for each entry is used whilst retrieving the Resource SKUs
+var cacheLock = &sync.Mutex{}
+
+// cachedResourceSkusEntry is the result of retrieving the Resource SKUs for a Subscription and Location, including
+// any error - which is cached so that it's only returned once per run, rather than retried for each resource
+type cachedResourceSkusEntry struct {
+	lock      sync.Mutex
+	populated bool
+	skus      []skus.ResourceSku
+	err       error
+}
+
+// CacheResourceSkusForLocation attempts to retrieve the Resource SKUs available in the specified Location from the
+// Resource Manager API and caches them, for use in enhanced validation
+func CacheResourceSkusForLocation(ctx context.Context, client *skus.SkusClient, subscriptionId commonids.SubscriptionId, locationName string) error {
+	if _, err := resourceSkusForLocation(ctx, client, subscriptionId, locationName); err != nil {
+		return fmt.Errorf("populating cache: %+v", err)
+	}
+
+	return nil
+}
+
+func ClearCache() {
+	cacheLock.Lock()
+	cachedResourceSkus = make(map[string]*cachedResourceSkusEntry)
+	cacheLock.Unlock()
+}
+
+// resourceSkusForLocation returns the Resource SKUs available in the specified Location, retrieving them from the
+// Resource Manager API if they haven't been cached already
+func resourceSkusForLocation(ctx context.Context, client *skus.SkusClient, subscriptionId commonids.SubscriptionId, locationName string) ([]skus.ResourceSku, error) {
+	normalized := location.Normalize(locationName)
+	key := fmt.Sprintf("%s|%s", subscriptionId.ID(), normalized)
+
+	cacheLock.Lock()
+	entry, ok := cachedResourceSkus[key]
+	if !ok {
+		entry = &cachedResourceSkusEntry{}
+		cachedResourceSkus[key] = entry
+	}
+	cacheLock.Unlock()
+
+	// the lock for the entry is held whilst the Resource SKUs are retrieved, so that parallel validation of
+	// resources within the same Subscription and Location only results in a single API call
+	entry.lock.Lock()
+	defer entry.lock.Unlock()
+
+	if entry.populated {
+		return entry.skus, entry.err
+	}
+
+	opts := skus.DefaultResourceSkusListOperationOptions()
+	opts.Filter = pointer.To(fmt.Sprintf("location eq '%s'", normalized))
+	resp, err := client.ResourceSkusListComplete(ctx, subscriptionId, opts)
+	if err != nil {
+		entry.err = fmt.Errorf("listing Resource SKUs for the location %q: %+v", normalized, err)
+	} else {
+		entry.skus = resp.Items
+	}
+	entry.populated = true
+
+	return entry.skus, entry.err
+}
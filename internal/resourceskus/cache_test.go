@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceskus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/skus"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"golang.org/x/oauth2"
+)
+
+func TestResourceSkusForLocationCache(t *testing.T) {
+	failingSubscriptionId := commonids.NewSubscriptionID("33333333-3333-3333-3333-333333333333")
+
+	requests := make(map[string]*int32)
+	var requestsLock sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestsLock.Lock()
+		if _, ok := requests[r.URL.Path]; !ok {
+			requests[r.URL.Path] = new(int32)
+		}
+		atomic.AddInt32(requests[r.URL.Path], 1)
+		requestsLock.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == failingSubscriptionId.ID()+"/providers/Microsoft.Compute/skus" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"code":"AuthorizationFailed","message":"not authorized"}}`))
+			return
+		}
+		w.Write([]byte(`{"value":[{"resourceType":"virtualMachines","name":"Standard_B1s","locations":["westeurope"]}]}`))
+	}))
+	defer server.Close()
+
+	client, err := skus.NewSkusClientWithBaseURI(environments.ResourceManagerAPI(server.URL))
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+	client.Client.Authorizer = testAuthorizer{}
+
+	ClearCache()
+	defer ClearCache()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	first := commonids.NewSubscriptionID("11111111-1111-1111-1111-111111111111")
+	second := commonids.NewSubscriptionID("22222222-2222-2222-2222-222222222222")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		for _, subscriptionId := range []commonids.SubscriptionId{first, second} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				v, err := resourceSkusForLocation(ctx, client, subscriptionId, "West Europe")
+				if err != nil {
+					t.Errorf("retrieving Resource SKUs for %s: %+v", subscriptionId, err)
+					return
+				}
+				if len(v) != 1 {
+					t.Errorf("expected 1 Resource SKU for %s but got %d", subscriptionId, len(v))
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	for _, subscriptionId := range []commonids.SubscriptionId{first, second} {
+		if v := requests[subscriptionId.ID()+"/providers/Microsoft.Compute/skus"]; v == nil || *v != 1 {
+			t.Fatalf("expected a single request for %s", subscriptionId)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := resourceSkusForLocation(ctx, client, failingSubscriptionId, "westeurope"); err == nil {
+			t.Fatalf("expected an error for %s but didn't get one", failingSubscriptionId)
+		}
+	}
+	if v := requests[failingSubscriptionId.ID()+"/providers/Microsoft.Compute/skus"]; v == nil || *v != 1 {
+		t.Fatalf("expected the error for %s to be cached", failingSubscriptionId)
+	}
+}
+
+// testAuthorizer is an auth.Authorizer returning a static access token, since the test server doesn't validate these
+type testAuthorizer struct{}
+
+func (testAuthorizer) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	return &oauth2.Token{
+		AccessToken: "resourceskus",
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(time.Hour),
+	}, nil
+}
+
+func (testAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return []*oauth2.Token{}, nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceskus
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/zones"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// LocationFunc returns the Subscription ID and Location which the SKU should be validated against, for Resources where
+// the Location isn't exposed on the Resource itself (and so has to be retrieved, for example from a parent Resource).
+//
+// An empty `locationName` indicates that the Location couldn't be determined, in which case the SKU isn't validated.
+type LocationFunc func(ctx context.Context, d *pluginsdk.ResourceDiff, client *clients.Client) (subscriptionId string, locationName string, err error)
+
+// ValidateSkuAvailability returns a CustomizeDiffFunc which validates that the SKU specified in the field `skuKey` is
+// available for the Resource Type `resourceType` within the Location specified in the `location` field - and, where
+// `zonesKey` is specified, that the SKU is available in the Availability Zone(s) specified in that field.
+//
+// Validation only takes place when these fields are changing and their values are known at plan time.
+func ValidateSkuAvailability(resourceType ResourceType, skuKey string, zonesKey string) pluginsdk.CustomizeDiffFunc {
+	return ValidateSkuAvailabilityUsingLocationFunc(resourceType, "location", skuKey, zonesKey, func(ctx context.Context, d *pluginsdk.ResourceDiff, client *clients.Client) (string, string, error) {
+		if client.Account == nil {
+			return "", "", nil
+		}
+
+		return client.Account.SubscriptionId, d.Get("location").(string), nil
+	})
+}
+
+// ValidateSkuAvailabilityUsingLocationFunc returns a CustomizeDiffFunc which validates that the SKU specified in the
+// field `skuKey` is available for the Resource Type `resourceType` within the Location returned from `locationFunc`
+// (which is determined from the field `locationKey`) - and, where `zonesKey` is specified, that the SKU is available
+// in the Availability Zone(s) specified in that field.
+//
+// Validation only takes place when Enhanced Validation is enabled and these fields are changing and their values are
+// known at plan time - `locationFunc` is only called in this case, since it may need to make API calls.
+func ValidateSkuAvailabilityUsingLocationFunc(resourceType ResourceType, locationKey string, skuKey string, zonesKey string, locationFunc LocationFunc) pluginsdk.CustomizeDiffFunc {
+	return func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+		if !enhancedEnabled {
+			return nil
+		}
+
+		keys := []string{locationKey, skuKey}
+		if zonesKey != "" {
+			keys = append(keys, zonesKey)
+		}
+		if !changedAndKnown(d, keys...) {
+			return nil
+		}
+
+		client, ok := meta.(*clients.Client)
+		if !ok || client.Compute == nil {
+			return nil
+		}
+
+		subscriptionId, locationName, err := locationFunc(ctx, d, client)
+		if err != nil {
+			return err
+		}
+		if locationName == "" {
+			return nil
+		}
+
+		return ValidateSkuForLocation(ctx, client.Compute.SkusClient, commonids.NewSubscriptionID(subscriptionId), resourceType, locationName, d.Get(skuKey).(string), ZonesFromDiff(d, zonesKey))
+	}
+}
+
+// ValidateZonesAvailability returns a CustomizeDiffFunc which validates that the Availability Zone(s) specified in the
+// field `zonesKey` are available within the Location specified in the `location` field.
+//
+// Validation only takes place when these fields are changing and their values are known at plan time.
+func ValidateZonesAvailability(zonesKey string) pluginsdk.CustomizeDiffFunc {
+	return func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+		if !changedAndKnown(d, "location", zonesKey) {
+			return nil
+		}
+
+		client, ok := meta.(*clients.Client)
+		if !ok || client.Compute == nil || client.Account == nil {
+			return nil
+		}
+
+		subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
+		return ValidateZonesForLocation(ctx, client.Compute.SkusClient, subscriptionId, d.Get("location").(string), ZonesFromDiff(d, zonesKey))
+	}
+}
+
+// ZonesFromDiff returns the Availability Zone(s) specified in the field `key`, which can either be a single zone
+// (e.g. `zone`) or a set of zones (e.g. `zones`)
+func ZonesFromDiff(d *pluginsdk.ResourceDiff, key string) []string {
+	if key == "" {
+		return nil
+	}
+
+	switch v := d.Get(key).(type) {
+	case string:
+		if v != "" {
+			return []string{v}
+		}
+	case *pluginsdk.Set:
+		return zones.ExpandUntyped(v.List())
+	}
+
+	return nil
+}
+
+func changedAndKnown(d *pluginsdk.ResourceDiff, keys ...string) bool {
+	if !d.HasChanges(keys...) {
+		return false
+	}
+
+	for _, key := range keys {
+		if !d.NewValueKnown(key) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceskus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestValidateSkuAvailabilityUsingLocationFuncWhenDisabled(t *testing.T) {
+	previous := enhancedEnabled
+	enhancedEnabled = false
+	t.Cleanup(func() {
+		enhancedEnabled = previous
+	})
+
+	locationFunc := func(ctx context.Context, d *pluginsdk.ResourceDiff, client *clients.Client) (string, string, error) {
+		t.Fatalf("expected the Location not to be retrieved when Enhanced Validation is disabled")
+		return "", "", nil
+	}
+
+	// the diff isn't used when Enhanced Validation is disabled
+	validate := ValidateSkuAvailabilityUsingLocationFunc(ResourceTypeVirtualMachines, "kubernetes_cluster_id", "vm_size", "zones", locationFunc)
+	if err := validate(context.TODO(), nil, &clients.Client{}); err != nil {
+		t.Fatalf("expected no error but got: %+v", err)
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceskus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/skus"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+// this is only here to aid testing
+var enhancedEnabled = features.EnhancedValidationEnabled()
+
+// ResourceType is the type of resource which a Resource SKU applies to, as returned from the Resource SKUs API
+type ResourceType string
+
+const (
+	ResourceTypeDisks           ResourceType = "disks"
+	ResourceTypeVirtualMachines ResourceType = "virtualMachines"
+)
+
+// availableSku describes a Resource SKU within a specific Location
+type availableSku struct {
+	// name is the name of the SKU, as returned from the API
+	name string
+
+	// restrictionReason is the reason why this SKU can't be used within this Subscription, where restricted
+	restrictionReason *string
+
+	// zones is the list of Availability Zones this SKU is available in, excluding any restricted zones
+	zones []string
+}
+
+// ValidateSkuForLocation validates that the SKU `skuName` for the Resource Type `resourceType` is available for use
+// within the specified Location - and, where `zones` are specified, that the SKU is available in those Availability Zones.
+//
+// NOTE: this is best-effort - if Enhanced Validation is disabled or the Resource SKUs can't be retrieved, this is a no-op
+func ValidateSkuForLocation(ctx context.Context, client *skus.SkusClient, subscriptionId commonids.SubscriptionId, resourceType ResourceType, locationName, skuName string, zones []string) error {
+	if !enhancedEnabled || client == nil || locationName == "" || skuName == "" {
+		return nil
+	}
+
+	resourceSkus, err := resourceSkusForLocation(ctx, client, subscriptionId, locationName)
+	if err != nil {
+		log.Printf("[DEBUG] error retrieving Resource SKUs: %s. Enhanced validation will be unavailable", err)
+		return nil
+	}
+
+	return validateSku(availableSkusForResourceType(resourceSkus, resourceType, locationName), resourceType, locationName, skuName, zones)
+}
+
+// ValidateZonesForLocation validates that the Availability Zones `zones` are available within the specified Location.
+//
+// Since there's no API returning the Availability Zones for a Location, these are determined from the Virtual Machine
+// SKUs which are available within the Location.
+//
+// NOTE: this is best-effort - if Enhanced Validation is disabled or the Resource SKUs can't be retrieved, this is a no-op
+func ValidateZonesForLocation(ctx context.Context, client *skus.SkusClient, subscriptionId commonids.SubscriptionId, locationName string, zones []string) error {
+	if !enhancedEnabled || client == nil || locationName == "" || len(zones) == 0 {
+		return nil
+	}
+
+	resourceSkus, err := resourceSkusForLocation(ctx, client, subscriptionId, locationName)
+	if err != nil {
+		log.Printf("[DEBUG] error retrieving Resource SKUs: %s. Enhanced validation will be unavailable", err)
+		return nil
+	}
+
+	return validateZones(availableSkusForResourceType(resourceSkus, ResourceTypeVirtualMachines, locationName), locationName, zones)
+}
+
+func availableSkusForResourceType(input []skus.ResourceSku, resourceType ResourceType, locationName string) map[string]availableSku {
+	normalizedLocation := location.Normalize(locationName)
+
+	output := make(map[string]availableSku)
+	for _, item := range input {
+		if item.Name == nil || item.ResourceType == nil || !strings.EqualFold(*item.ResourceType, string(resourceType)) {
+			continue
+		}
+		if item.Locations != nil && !containsLocation(*item.Locations, normalizedLocation) {
+			continue
+		}
+
+		sku := availableSku{
+			name:  *item.Name,
+			zones: make([]string, 0),
+		}
+
+		if item.LocationInfo != nil {
+			for _, info := range *item.LocationInfo {
+				if info.Location == nil || location.Normalize(*info.Location) != normalizedLocation || info.Zones == nil {
+					continue
+				}
+				sku.zones = append(sku.zones, *info.Zones...)
+			}
+		}
+
+		if item.Restrictions != nil {
+			for _, restriction := range *item.Restrictions {
+				if restriction.Type == nil {
+					continue
+				}
+
+				reason := "Unknown"
+				if restriction.ReasonCode != nil {
+					reason = string(*restriction.ReasonCode)
+				}
+
+				switch *restriction.Type {
+				case skus.ResourceSkuRestrictionsTypeLocation:
+					if restriction.Values != nil && containsLocation(*restriction.Values, normalizedLocation) {
+						sku.restrictionReason = &reason
+					}
+
+				case skus.ResourceSkuRestrictionsTypeZone:
+					info := restriction.RestrictionInfo
+					if info == nil || info.Locations == nil || info.Zones == nil || !containsLocation(*info.Locations, normalizedLocation) {
+						continue
+					}
+
+					zones := make([]string, 0)
+					for _, zone := range sku.zones {
+						if !contains(*info.Zones, zone) {
+							zones = append(zones, zone)
+						}
+					}
+					sku.zones = zones
+				}
+			}
+		}
+
+		sort.Strings(sku.zones)
+		output[strings.ToLower(sku.name)] = sku
+	}
+
+	return output
+}
+
+func validateSku(available map[string]availableSku, resourceType ResourceType, locationName, skuName string, zones []string) error {
+	// the API may not return any SKUs for this Resource Type (e.g. in some Azure Environments) - in which case we can't validate this
+	if len(available) == 0 {
+		return nil
+	}
+
+	sku, ok := available[strings.ToLower(skuName)]
+	if !ok {
+		return fmt.Errorf("the %s SKU %q is not available in the location %q - available SKUs in this region are: %s", resourceType, skuName, locationName, strings.Join(unrestrictedSkuNames(available), ", "))
+	}
+
+	if sku.restrictionReason != nil {
+		return fmt.Errorf("the %s SKU %q is restricted for this Subscription in the location %q (reason: %s) - available SKUs in this region are: %s", resourceType, sku.name, locationName, *sku.restrictionReason, strings.Join(unrestrictedSkuNames(available), ", "))
+	}
+
+	for _, zone := range zones {
+		if contains(sku.zones, zone) {
+			continue
+		}
+
+		if len(sku.zones) == 0 {
+			return fmt.Errorf("the %s SKU %q is not available in any Availability Zones in the location %q", resourceType, sku.name, locationName)
+		}
+
+		return fmt.Errorf("the %s SKU %q is not available in the Availability Zone %q in the location %q - available zones for this SKU in this region are: %s", resourceType, sku.name, zone, locationName, strings.Join(sku.zones, ", "))
+	}
+
+	return nil
+}
+
+func validateZones(available map[string]availableSku, locationName string, zones []string) error {
+	if len(available) == 0 {
+		return nil
+	}
+
+	supportedZones := make([]string, 0)
+	for _, sku := range available {
+		if sku.restrictionReason != nil {
+			continue
+		}
+		for _, zone := range sku.zones {
+			if !contains(supportedZones, zone) {
+				supportedZones = append(supportedZones, zone)
+			}
+		}
+	}
+	sort.Strings(supportedZones)
+
+	for _, zone := range zones {
+		if contains(supportedZones, zone) {
+			continue
+		}
+
+		if len(supportedZones) == 0 {
+			return fmt.Errorf("the location %q does not support Availability Zones", locationName)
+		}
+
+		return fmt.Errorf("the Availability Zone %q is not available in the location %q - available zones in this region are: %s", zone, locationName, strings.Join(supportedZones, ", "))
+	}
+
+	return nil
+}
+
+func unrestrictedSkuNames(input map[string]availableSku) []string {
+	output := make([]string, 0)
+	for _, v := range input {
+		if v.restrictionReason == nil {
+			output = append(output, v.name)
+		}
+	}
+	sort.Strings(output)
+	return output
+}
+
+func containsLocation(input []string, normalizedLocation string) bool {
+	for _, v := range input {
+		if location.Normalize(v) == normalizedLocation {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(input []string, value string) bool {
+	for _, v := range input {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,228 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceskus
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/skus"
+)
+
+func testResourceSkus() []skus.ResourceSku {
+	return []skus.ResourceSku{
+		{
+			Name:         pointer.To("Standard_D2s_v3"),
+			ResourceType: pointer.To("virtualMachines"),
+			Locations:    &[]string{"WestEurope"},
+			LocationInfo: &[]skus.ResourceSkuLocationInfo{
+				{
+					Location: pointer.To("WestEurope"),
+					Zones:    &[]string{"3", "1", "2"},
+				},
+			},
+		},
+		{
+			Name:         pointer.To("Standard_F2"),
+			ResourceType: pointer.To("virtualMachines"),
+			Locations:    &[]string{"WestEurope"},
+			LocationInfo: &[]skus.ResourceSkuLocationInfo{
+				{
+					Location: pointer.To("WestEurope"),
+					Zones:    &[]string{"1", "2", "3"},
+				},
+			},
+			Restrictions: &[]skus.ResourceSkuRestrictions{
+				{
+					Type:   pointer.To(skus.ResourceSkuRestrictionsTypeZone),
+					Values: &[]string{"westeurope"},
+					RestrictionInfo: &skus.ResourceSkuRestrictionInfo{
+						Locations: &[]string{"westeurope"},
+						Zones:     &[]string{"3"},
+					},
+					ReasonCode: pointer.To(skus.ResourceSkuRestrictionsReasonCodeNotAvailableForSubscription),
+				},
+			},
+		},
+		{
+			Name:         pointer.To("Standard_M128s"),
+			ResourceType: pointer.To("virtualMachines"),
+			Locations:    &[]string{"WestEurope"},
+			LocationInfo: &[]skus.ResourceSkuLocationInfo{
+				{
+					Location: pointer.To("WestEurope"),
+				},
+			},
+			Restrictions: &[]skus.ResourceSkuRestrictions{
+				{
+					Type:   pointer.To(skus.ResourceSkuRestrictionsTypeLocation),
+					Values: &[]string{"westeurope"},
+					RestrictionInfo: &skus.ResourceSkuRestrictionInfo{
+						Locations: &[]string{"westeurope"},
+					},
+					ReasonCode: pointer.To(skus.ResourceSkuRestrictionsReasonCodeNotAvailableForSubscription),
+				},
+			},
+		},
+		{
+			Name:         pointer.To("Standard_LRS"),
+			ResourceType: pointer.To("disks"),
+			Locations:    &[]string{"WestEurope"},
+			LocationInfo: &[]skus.ResourceSkuLocationInfo{
+				{
+					Location: pointer.To("WestEurope"),
+					Zones:    &[]string{"1", "2", "3"},
+				},
+			},
+		},
+		{
+			Name:         pointer.To("Premium_ZRS"),
+			ResourceType: pointer.To("disks"),
+			Locations:    &[]string{"WestEurope"},
+			LocationInfo: &[]skus.ResourceSkuLocationInfo{
+				{
+					Location: pointer.To("WestEurope"),
+				},
+			},
+		},
+	}
+}
+
+func TestValidateSku(t *testing.T) {
+	testCases := []struct {
+		resourceType ResourceType
+		skuName      string
+		zones        []string
+		valid        bool
+	}{
+		{
+			resourceType: ResourceTypeVirtualMachines,
+			skuName:      "Standard_D2s_v3",
+			valid:        true,
+		},
+		{
+			// casing differs
+			resourceType: ResourceTypeVirtualMachines,
+			skuName:      "standard_d2s_v3",
+			valid:        true,
+		},
+		{
+			resourceType: ResourceTypeVirtualMachines,
+			skuName:      "Standard_D2s_v3",
+			zones:        []string{"1", "3"},
+			valid:        true,
+		},
+		{
+			resourceType: ResourceTypeVirtualMachines,
+			skuName:      "Standard_D2s_v3",
+			zones:        []string{"4"},
+			valid:        false,
+		},
+		{
+			// not available in this location
+			resourceType: ResourceTypeVirtualMachines,
+			skuName:      "Standard_NC6",
+			valid:        false,
+		},
+		{
+			// disk SKUs aren't virtual machine SKUs
+			resourceType: ResourceTypeVirtualMachines,
+			skuName:      "Standard_LRS",
+			valid:        false,
+		},
+		{
+			// restricted for this subscription in this location
+			resourceType: ResourceTypeVirtualMachines,
+			skuName:      "Standard_M128s",
+			valid:        false,
+		},
+		{
+			resourceType: ResourceTypeVirtualMachines,
+			skuName:      "Standard_F2",
+			zones:        []string{"2"},
+			valid:        true,
+		},
+		{
+			// restricted for this subscription in this zone
+			resourceType: ResourceTypeVirtualMachines,
+			skuName:      "Standard_F2",
+			zones:        []string{"3"},
+			valid:        false,
+		},
+		{
+			resourceType: ResourceTypeDisks,
+			skuName:      "Standard_LRS",
+			zones:        []string{"1"},
+			valid:        true,
+		},
+		{
+			// zone-redundant disks can't be placed in a zone
+			resourceType: ResourceTypeDisks,
+			skuName:      "Premium_ZRS",
+			zones:        []string{"1"},
+			valid:        false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("[DEBUG] Testing %s SKU %q (zones %v)", tc.resourceType, tc.skuName, tc.zones)
+
+		available := availableSkusForResourceType(testResourceSkus(), tc.resourceType, "West Europe")
+		err := validateSku(available, tc.resourceType, "West Europe", tc.skuName, tc.zones)
+		if valid := err == nil; valid != tc.valid {
+			t.Fatalf("expected valid to be %t but got %t: %+v", tc.valid, valid, err)
+		}
+	}
+}
+
+func TestValidateSkuUnavailableMessage(t *testing.T) {
+	available := availableSkusForResourceType(testResourceSkus(), ResourceTypeVirtualMachines, "westeurope")
+	err := validateSku(available, ResourceTypeVirtualMachines, "westeurope", "Standard_NC6", nil)
+	if err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+
+	expected := `the virtualMachines SKU "Standard_NC6" is not available in the location "westeurope" - available SKUs in this region are: Standard_D2s_v3, Standard_F2`
+	if err.Error() != expected {
+		t.Fatalf("expected the error to be %q but got %q", expected, err.Error())
+	}
+}
+
+func TestValidateSkuNoSkusForLocation(t *testing.T) {
+	// when the API returns no SKUs for the Location, validation is skipped
+	available := availableSkusForResourceType(testResourceSkus(), ResourceTypeVirtualMachines, "eastus")
+	if err := validateSku(available, ResourceTypeVirtualMachines, "eastus", "Standard_NC6", nil); err != nil {
+		t.Fatalf("expected no error but got: %+v", err)
+	}
+}
+
+func TestValidateZones(t *testing.T) {
+	testCases := []struct {
+		zones []string
+		valid bool
+	}{
+		{
+			zones: []string{"1"},
+			valid: true,
+		},
+		{
+			zones: []string{"1", "2", "3"},
+			valid: true,
+		},
+		{
+			zones: []string{"4"},
+			valid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("[DEBUG] Testing zones %v", tc.zones)
+
+		available := availableSkusForResourceType(testResourceSkus(), ResourceTypeVirtualMachines, "westeurope")
+		err := validateZones(available, "westeurope", tc.zones)
+		if valid := err == nil; valid != tc.valid {
+			t.Fatalf("expected valid to be %t but got %t: %+v", tc.valid, valid, err)
+		}
+	}
+}
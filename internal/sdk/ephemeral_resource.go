@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// An EphemeralResource is an object which retrieves (or generates) a value, typically a secret, for use
+// elsewhere in the configuration - which unlike a Data Source is never persisted into the Plan or the State
+//
+// Ephemeral Resources are served by the Plugin Framework, as such these must define their Arguments and
+// Attributes using the Typed Schema - and require Terraform 1.10 or later.
+type EphemeralResource interface {
+	// ModelObject is an instance of the object the Schema is decoded/encoded into
+	ModelObject() interface{}
+
+	// ResourceType is the exposed name of this ephemeral resource (e.g. `azurerm_example`)
+	ResourceType() string
+
+	// TypedArguments is a list of user-configurable (that is: Required or Optional) arguments for this
+	// Ephemeral Resource
+	TypedArguments() TypedSchema
+
+	// TypedAttributes is a list of read-only (e.g. Computed-only) attributes for this Ephemeral Resource
+	TypedAttributes() TypedSchema
+
+	// Open retrieves the values for this Ephemeral Resource using the information from the Terraform
+	// Configuration, which must be set using EphemeralResourceMetaData.Encode
+	Open() EphemeralResourceFunc
+}
+
+// EphemeralResourceRunFunc is the function which can be run
+// ctx provides a Context instance with the timeout for this function
+// metadata is a reference to an object containing the Client, Configuration and a Logger
+type EphemeralResourceRunFunc func(ctx context.Context, metadata EphemeralResourceMetaData) error
+
+type EphemeralResourceFunc struct {
+	// Func is the function which should be called for this Ephemeral Resource Func
+	Func EphemeralResourceRunFunc
+
+	// Timeout is the timeout for this function, which (unlike Resources) can't be overridden by users
+	// since Ephemeral Resources don't support a `timeouts` block
+	Timeout time.Duration
+}
+
+type EphemeralResourceMetaData struct {
+	// Client is a reference to the Azure Providers Client - providing a typed reference to this object
+	Client *clients.Client
+
+	// Logger provides a logger for debug purposes
+	Logger Logger
+
+	arguments  TypedSchema
+	attributes TypedSchema
+
+	// config is the raw Terraform Configuration for this Ephemeral Resource
+	config tftypes.Value
+
+	// result is the raw result of this Ephemeral Resource, as set by Encode
+	result *tftypes.Value
+}
+
+// Decode will decode the Terraform Configuration for this Ephemeral Resource into the specified model,
+// which must be a pointer to an object using `tfschema` struct tags
+func (emd EphemeralResourceMetaData) Decode(input interface{}) error {
+	return DecodeFrameworkValue(emd.arguments, emd.attributes, emd.config, input)
+}
+
+// Encode will encode the specified model as the result of this Ephemeral Resource, which must be a pointer
+// to an object using `tfschema` struct tags
+//
+// NOTE: the values for the Arguments are always taken from the Terraform Configuration
+func (emd EphemeralResourceMetaData) Encode(input interface{}) error {
+	encoded, err := EncodeFrameworkValue(emd.arguments, emd.attributes, input)
+	if err != nil {
+		return err
+	}
+
+	result, err := mergeEphemeralResult(emd.arguments, emd.config, *encoded)
+	if err != nil {
+		return err
+	}
+
+	*emd.result = *result
+	return nil
+}
+
+// mergeEphemeralResult returns the encoded result, using the values from the configuration for each
+// Argument which isn't Computed - since Terraform expects these to match the configuration
+func mergeEphemeralResult(arguments TypedSchema, config tftypes.Value, encoded tftypes.Value) (*tftypes.Value, error) {
+	values := make(map[string]tftypes.Value)
+	if err := encoded.As(&values); err != nil {
+		return nil, fmt.Errorf("converting the result to an object: %+v", err)
+	}
+
+	configValues := make(map[string]tftypes.Value)
+	if !config.IsNull() && config.IsKnown() {
+		if err := config.As(&configValues); err != nil {
+			return nil, fmt.Errorf("converting the configuration to an object: %+v", err)
+		}
+	}
+
+	for k, v := range arguments.Attributes {
+		if v.Computed {
+			continue
+		}
+		if value, ok := configValues[k]; ok {
+			values[k] = value
+		}
+	}
+	for k, v := range arguments.Blocks {
+		if v.Computed {
+			continue
+		}
+		if value, ok := configValues[k]; ok {
+			values[k] = value
+		}
+	}
+
+	out := tftypes.NewValue(encoded.Type(), values)
+	return &out, nil
+}
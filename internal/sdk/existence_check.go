@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// existenceCheckDefaultTimeout is the maximum duration the existence check for a single resource can take during
+// the plan, when the Resource doesn't define a Read timeout
+const existenceCheckDefaultTimeout = 5 * time.Minute
+
+// ExistenceCheckFunc builds the ID of the resource being created from the configuration (which is available using
+// `metadata.DecodeDiff`) and performs the same lookup as the Create function - returning the ID and whether the
+// resource already exists.
+//
+// A nil ID should be returned when the ID can't be built during the plan, for example when the `name` references
+// an attribute of another resource which is yet to be created, in which case the check is skipped.
+type ExistenceCheckFunc func(ctx context.Context, metadata ResourceMetaData) (id resourceids.Id, exists bool, err error)
+
+// UntypedExistenceCheckFunc is the equivalent of an ExistenceCheckFunc for an Untyped Resource, where the
+// configuration is available from the ResourceDiff.
+type UntypedExistenceCheckFunc func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) (id resourceids.Id, exists bool, err error)
+
+// existenceLookupFunc returns the Resource ID of the resource being created and whether it already exists, where
+// an empty Resource ID means that this can't be determined during the plan
+type existenceLookupFunc func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) (id string, exists bool, err error)
+
+// ApplyExistenceCheck appends a CustomizeDiff function to the Resource which, when the `check_during_plan` feature
+// within the `existing_resources` block is enabled, determines whether a resource which is being created already
+// exists - failing the plan with the same error (requiring that the resource is imported) that'd be returned during
+// the apply.
+//
+// By default the Resource ID is built from the configuration using the Resource Identity, then parsed by the
+// Resource's Importer and retrieved using the Resource's Read function (as done when importing the resource) - as
+// such Resources which don't expose a Resource Identity (or whose Resource Identity contains attributes which aren't
+// within the configuration) are skipped. Where `check` is specified this is used instead, which allows a Resource
+// to override (or provide) the lookup.
+//
+// This is applied by the ResourceWrapper to Typed Resources (using ResourceWithExistenceCheck as the override), and
+// by the Provider to Untyped Resources (using UntypedServiceRegistrationWithExistenceChecks as the override).
+func ApplyExistenceCheck(resourceType string, resource *pluginsdk.Resource, check UntypedExistenceCheckFunc) {
+	lookup := existenceCheckUsingImporter(resource)
+	if check != nil {
+		lookup = func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) (string, bool, error) {
+			id, exists, err := check(ctx, d, meta)
+			if err != nil || id == nil {
+				return "", exists, err
+			}
+			return id.ID(), exists, nil
+		}
+	}
+	if lookup == nil {
+		return
+	}
+
+	timeout := existenceCheckDefaultTimeout
+	if resource.Timeouts != nil && resource.Timeouts.Read != nil {
+		timeout = *resource.Timeouts.Read
+	}
+
+	customizeDiff := func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+		client, ok := meta.(*clients.Client)
+		if !ok || client == nil || !client.Features.ExistingResources.CheckDuringPlan {
+			return nil
+		}
+
+		// only resources which are being created are checked, existing resources are already present in the State
+		if d.Id() != "" {
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		id, exists, err := lookup(ctx, d, client)
+		if err != nil {
+			return fmt.Errorf("checking whether the %s being created already exists: %+v", resourceType, err)
+		}
+		if id == "" {
+			log.Printf("[DEBUG] skipping the existence check for %s since the ID isn't known until apply", resourceType)
+			return nil
+		}
+		if exists {
+			return tf.ImportAsExistsError(resourceType, id)
+		}
+
+		return nil
+	}
+
+	if existing := resource.CustomizeDiff; existing != nil {
+		resource.CustomizeDiff = pluginsdk.CustomDiffInSequence(existing, customizeDiff)
+	} else {
+		resource.CustomizeDiff = customizeDiff
+	}
+}
+
+// existenceCheckUsingImporter returns a lookup which builds the Resource Identity from the configuration, which the
+// Resource's Importer builds the Resource ID from, then runs the Resource's Read function to determine whether the
+// resource exists - or nil when the Resource ID can't be built this way.
+func existenceCheckUsingImporter(resource *pluginsdk.Resource) existenceLookupFunc {
+	if resource.Identity == nil || resource.Importer == nil || resource.Importer.StateContext == nil {
+		return nil
+	}
+
+	// the attributes within the Resource Identity (e.g. `name` and `resource_group_name`) must be present within the
+	// configuration - other than the `subscription_id` which (when omitted) is taken from the Provider block
+	keys := make([]string, 0)
+	for key := range resource.Identity.SchemaMap() {
+		if _, ok := resource.Schema[key]; ok {
+			keys = append(keys, key)
+			continue
+		}
+		if key != "subscription_id" {
+			return nil
+		}
+	}
+
+	return func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) (string, bool, error) {
+		identity := make(map[string]string)
+		for _, key := range keys {
+			if !d.NewValueKnown(key) {
+				return "", false, nil
+			}
+			if v, ok := d.Get(key).(string); ok && v != "" {
+				identity[key] = v
+			}
+		}
+
+		imported, err := resource.Importer.StateContext(ctx, resource.Data(&terraform.InstanceState{Identity: identity}), meta)
+		if err != nil {
+			return "", false, fmt.Errorf("building the Resource ID from the configuration: %+v", err)
+		}
+		if len(imported) == 0 || imported[0].Id() == "" {
+			return "", false, fmt.Errorf("building the Resource ID from the configuration: the Importer returned no resources")
+		}
+
+		id := imported[0].Id()
+		state, diags := resource.RefreshWithoutUpgrade(ctx, imported[0].State(), meta)
+		if diags.HasError() {
+			return "", false, fmt.Errorf("retrieving %s: %+v", id, diags)
+		}
+
+		// the Read function removes the Resource ID when the resource doesn't exist
+		return id, state != nil && state.ID != "", nil
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// A ListResource lists the existing instances of a Resource within Azure, so that these can be discovered
+// (and the configuration to import them generated) using `terraform query`
+//
+// List Resources are served by the Plugin Framework but list the Resources exposed by the Plugin SDKv2
+// Provider, and require Terraform 1.14 or later.
+type ListResource interface {
+	// ResourceType is the name of the Resource being listed (e.g. `azurerm_example`)
+	ResourceType() string
+
+	// Identity is the Resource ID type of the Resource being listed, which is used to build the Resource
+	// Identity returned for each instance of this Resource
+	Identity() resourceids.ResourceId
+
+	// List returns the instances of this Resource within the Subscription, optionally filtered to a
+	// Resource Group when ListResourceMetaData.ResourceGroupName is specified
+	List() ListResourceFunc
+}
+
+// ListResourceRunFunc is the function which can be run
+// ctx provides a Context instance with the timeout for this function
+// metadata is a reference to an object containing the Client, the Filters and a Logger
+type ListResourceRunFunc func(ctx context.Context, metadata ListResourceMetaData) ([]ListResourceResult, error)
+
+type ListResourceFunc struct {
+	// Func is the function which should be called for this List Resource Func
+	Func ListResourceRunFunc
+
+	// Timeout is the timeout for this function
+	Timeout time.Duration
+}
+
+type ListResourceMetaData struct {
+	// Client is a reference to the Azure Providers Client - providing a typed reference to this object
+	Client *clients.Client
+
+	// Logger provides a logger for debug purposes
+	Logger Logger
+
+	// ResourceGroupName is the name of the Resource Group which the Resources should be listed within,
+	// when this is empty the Resources within the Subscription should be listed
+	ResourceGroupName string
+}
+
+type ListResourceResult struct {
+	// ID is the Resource ID for this instance of the Resource, which must be the same Resource ID type
+	// returned from Identity
+	ID resourceids.ResourceId
+
+	// DisplayName is a human-readable name for this instance of the Resource, shown by Terraform when
+	// listing these Resources - when empty the `name` within the Resource Identity is used
+	DisplayName string
+
+	// Tags are the Tags assigned to this instance of the Resource, which are used to filter the results
+	// by the `tags` specified in the configuration - as such this should be populated where supported
+	Tags map[string]string
+}
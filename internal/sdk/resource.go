@@ -25,13 +25,12 @@ type resourceWithPluginSdkSchema interface {
 	Attributes() map[string]*schema.Schema
 }
 
+// resourceBase is the common base for both Data Sources and Resources
+//
+// In addition, Data Sources and Resources must define their Arguments and Attributes by implementing either
+// resourceWithPluginSdkSchema (using the types defined in Plugin SDKv2) or resourceWithTypedSchema (using the
+// Typed Schema, which is rendered into both the Plugin SDKv2 and Plugin Framework) - see PluginSdkSchema.
 type resourceBase interface {
-	// resourceWithPluginSdkSchema ensure that the Arguments and Attributes are sourced
-	// from Plugin SDKv2 for now - longer term we'll likely introduce a `Typed Schema`
-	// which will cross-compile down to both the Plugin SDKv2 and Plugin Framework, but
-	// that's a story for another day.
-	resourceWithPluginSdkSchema
-
 	// ModelObject is an instance of the object the Schema is decoded/encoded into
 	ModelObject() interface{}
 
@@ -94,13 +93,13 @@ type ResourceWithStateMigration interface {
 	StateUpgraders() StateUpgradeData
 }
 
+// StateUpgradeData defines the Schema Version and State Upgraders for a Resource - where only the Resource ID
+// needs to be rewritten (for example to fix the casing), ResourceIdStateUpgrade can be used as the Upgrader
 type StateUpgradeData struct {
 	SchemaVersion int
 	Upgraders     map[int]pluginsdk.StateUpgrade
 }
 
-// TODO: a generic state migration for updating ID's
-
 type ResourceWithCustomImporter interface {
 	Resource
 
@@ -108,6 +107,33 @@ type ResourceWithCustomImporter interface {
 	CustomImporter() ResourceRunFunc
 }
 
+// ResourceWithExistenceCheck is an optional interface
+//
+// Resources are checked during the plan (when the `check_during_plan` feature within the `existing_resources`
+// block is enabled) to determine whether a resource being created already exists - which by default uses the
+// Resource Identity, Importer and Read function (see ApplyExistenceCheck). Resources implementing this interface
+// override this lookup, for example where the Resource doesn't expose a Resource Identity.
+type ResourceWithExistenceCheck interface {
+	Resource
+
+	// ExistenceCheck returns a function which builds the ID of the resource from the configuration and performs
+	// the same lookup as the Create function - which must only perform read-only requests
+	ExistenceCheck() ExistenceCheckFunc
+}
+
+// ResourceWithIdentity is an optional interface
+//
+// Resources implementing this interface expose a Resource Identity (containing the `subscription_id`,
+// `resource_group_name`, `name` and any parent segments) derived from the Resource ID type - which is
+// set automatically once the Resource has been Read and allows the Resource to be imported using an
+// `identity` rather than the Resource ID.
+type ResourceWithIdentity interface {
+	Resource
+
+	// Identity returns the Resource ID type used for this Resource, e.g. `&commonids.SubnetId{}`
+	Identity() resourceids.ResourceId
+}
+
 // ResourceWithUpdate is an optional interface
 //
 // Notably the Arguments for Resources implementing this interface
@@ -188,6 +214,9 @@ type ResourceMetaData struct {
 	// ResourceDiff is a reference to the ResourceDiff object from Terraform's Plugin SDK
 	ResourceDiff *schema.ResourceDiff
 
+	// resourceSchema is the Schema for this Resource, which is used to drive Encode and Decode
+	resourceSchema map[string]*schema.Schema
+
 	// serializationDebugLogger is used for testing purposes
 	serializationDebugLogger Logger
 }
@@ -5,8 +5,11 @@ package sdk
 
 import (
 	"fmt"
+	"math/big"
 	"reflect"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -26,7 +29,7 @@ func (rmd ResourceMetaData) Decode(input interface{}) error {
 	if rmd.ResourceData == nil {
 		return fmt.Errorf("ResourceData was nil")
 	}
-	return decodeReflectedType(input, rmd.ResourceData, rmd.serializationDebugLogger)
+	return decodeReflectedType(input, rmd.ResourceData, rmd.resourceSchema, rmd.serializationDebugLogger)
 }
 
 // DecodeDiff decodes the Terraform Schema into the specified object in the
@@ -36,7 +39,7 @@ func (rmd ResourceMetaData) DecodeDiff(input interface{}) error {
 	if rmd.ResourceDiff == nil {
 		return fmt.Errorf("ResourceDiff was nil")
 	}
-	return decodeReflectedType(input, rmd.ResourceDiff, rmd.serializationDebugLogger)
+	return decodeReflectedType(input, rmd.ResourceDiff, rmd.resourceSchema, rmd.serializationDebugLogger)
 }
 
 // stateRetriever is a convenience wrapper around the Plugin SDK to be able to test it more accurately
@@ -46,7 +49,16 @@ type stateRetriever interface {
 	GetOkExists(key string) (interface{}, bool)
 }
 
-func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debugLogger Logger) error {
+// writeOnlyRetriever retrieves the values of write-only attributes, which are only available from the configuration
+type writeOnlyRetriever interface {
+	GetRawConfig() cty.Value
+	GetRawConfigAt(valPath cty.Path) (cty.Value, diag.Diagnostics)
+}
+
+// decodeReflectedType decodes the values for each field within the model which is defined in the Schema, fields
+// which aren't defined in the Schema are left as-is. The ModelObject is validated against the Schema when the
+// Resource is built, so this doesn't validate the model again on each call.
+func decodeReflectedType(input interface{}, stateRetriever stateRetriever, resourceSchema map[string]*schema.Schema, debugLogger Logger) error {
 	if reflect.TypeOf(input).Kind() != reflect.Ptr {
 		return fmt.Errorf("need a pointer")
 	}
@@ -62,7 +74,19 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 		}
 
 		if structTags != nil {
+			fieldSchema, ok := resourceSchema[structTags.hclPath]
+			if resourceSchema != nil && !ok {
+				debugLogger.Infof("The HCL Path %q isn't defined in the Schema - skipping", structTags.hclPath)
+				continue
+			}
+
 			tfschemaValue, valExists := stateRetriever.GetOkExists(structTags.hclPath)
+			if fieldSchema != nil && fieldSchema.WriteOnly {
+				tfschemaValue, valExists, err = writeOnlyValue(stateRetriever, structTags.hclPath, field.Type)
+				if err != nil {
+					return fmt.Errorf("retrieving the write-only value for %q: %+v", field.Name, err)
+				}
+			}
 			if !valExists {
 				continue
 			}
@@ -78,6 +102,45 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 	return nil
 }
 
+// writeOnlyValue returns the value of the write-only attribute key from the configuration, in the same format
+// as the value would be returned from the state - the value isn't available outside of Create and Update
+func writeOnlyValue(stateRetriever stateRetriever, key string, fieldType reflect.Type) (interface{}, bool, error) {
+	retriever, ok := stateRetriever.(writeOnlyRetriever)
+	if !ok || retriever.GetRawConfig().IsNull() {
+		return nil, false, nil
+	}
+
+	value, diags := retriever.GetRawConfigAt(cty.GetAttrPath(key))
+	if diags.HasError() {
+		return nil, false, fmt.Errorf("%+v", diags)
+	}
+	if value.IsNull() || !value.IsKnown() {
+		return nil, false, nil
+	}
+
+	switch value.Type() {
+	case cty.String:
+		return value.AsString(), true, nil
+	case cty.Bool:
+		return value.True(), true, nil
+	case cty.Number:
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Float32 || fieldType.Kind() == reflect.Float64 {
+			f, _ := value.AsBigFloat().Float64()
+			return f, true, nil
+		}
+		i, accuracy := value.AsBigFloat().Int64()
+		if accuracy != big.Exact {
+			return nil, false, fmt.Errorf("the value %s isn't an integer", value.AsBigFloat().String())
+		}
+		return int(i), true, nil
+	}
+
+	return nil, false, fmt.Errorf("the type %s isn't supported for write-only attributes", value.Type().FriendlyName())
+}
+
 func setValue(input, tfschemaValue interface{}, index int, fieldName string, debugLogger Logger) (errOut error) {
 	debugLogger.Infof("setting value for %q..", fieldName)
 	defer func() {
@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 type decodeTestData struct {
@@ -1026,10 +1029,109 @@ func TestResourceDecode_NestedThreeLevelsDeepMultipleOptionalItems(t *testing.T)
 	}.test(t)
 }
 
+func TestDecode_WriteOnlyFields(t *testing.T) {
+	type SimpleType struct {
+		Name            string `tfschema:"name"`
+		Password        string `tfschema:"password"`
+		PasswordVersion int    `tfschema:"password_version"`
+	}
+	resourceSchema := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"password": {
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			WriteOnly: true,
+		},
+		"password_version": {
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+	}
+
+	state := testDataGetter{
+		values: map[string]interface{}{
+			"name":             "bingo",
+			"password":         "from-the-state",
+			"password_version": 2,
+		},
+	}
+
+	t.Log("configuration available")
+	input := &SimpleType{}
+	getter := testDataWriteOnlyGetter{
+		testDataGetter: state,
+		config: cty.ObjectVal(map[string]cty.Value{
+			"name":             cty.StringVal("bingo"),
+			"password":         cty.StringVal("from-the-config"),
+			"password_version": cty.NumberIntVal(2),
+		}),
+	}
+	if err := decodeReflectedType(input, getter, resourceSchema, ConsoleLogger{}); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	expected := &SimpleType{
+		Name:            "bingo",
+		Password:        "from-the-config",
+		PasswordVersion: 2,
+	}
+	if !reflect.DeepEqual(input, expected) {
+		t.Fatalf("\nExpected: %+v\n\n Received %+v\n\n", expected, input)
+	}
+
+	t.Log("configuration unavailable")
+	input = &SimpleType{}
+	getter.config = cty.NullVal(cty.DynamicPseudoType)
+	if err := decodeReflectedType(input, getter, resourceSchema, ConsoleLogger{}); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	expected.Password = ""
+	if !reflect.DeepEqual(input, expected) {
+		t.Fatalf("\nExpected: %+v\n\n Received %+v\n\n", expected, input)
+	}
+}
+
+func TestDecode_FieldsNotInSchema(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+	state := testDataGetter{
+		values: map[string]interface{}{
+			"name":    "bingo",
+			"missing": "bongo",
+		},
+	}
+
+	// models can be shared between a Resource and a Data Source, so fields which aren't in the Schema are left as-is
+	type MissingFromSchema struct {
+		Name    string `tfschema:"name"`
+		Missing string `tfschema:"missing"`
+	}
+	input := &MissingFromSchema{
+		Missing: "existing",
+	}
+	if err := decodeReflectedType(input, state, resourceSchema, ConsoleLogger{}); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	expected := &MissingFromSchema{
+		Name:    "bingo",
+		Missing: "existing",
+	}
+	if !reflect.DeepEqual(input, expected) {
+		t.Fatalf("\nExpected: %+v\n\n Received %+v\n\n", expected, input)
+	}
+}
+
 func (testData decodeTestData) test(t *testing.T) {
 	debugLogger := ConsoleLogger{}
 	state := testData.stateWrapper()
-	if err := decodeReflectedType(testData.Input, state, debugLogger); err != nil {
+	if err := decodeReflectedType(testData.Input, state, nil, debugLogger); err != nil {
 		if testData.ExpectError {
 			// we're good
 			return
@@ -1070,3 +1172,20 @@ func (td testDataGetter) GetOkExists(key string) (interface{}, bool) {
 	val, ok := td.values[key]
 	return val, ok
 }
+
+type testDataWriteOnlyGetter struct {
+	testDataGetter
+	config cty.Value
+}
+
+func (td testDataWriteOnlyGetter) GetRawConfig() cty.Value {
+	return td.config
+}
+
+func (td testDataWriteOnlyGetter) GetRawConfigAt(valPath cty.Path) (cty.Value, diag.Diagnostics) {
+	val, err := valPath.Apply(td.config)
+	if err != nil {
+		return cty.DynamicVal, diag.FromErr(err)
+	}
+	return val, nil
+}
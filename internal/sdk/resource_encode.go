@@ -12,7 +12,7 @@ import (
 
 // Encode will encode the specified object into the Terraform State
 // NOTE: this requires that the object passed in is a pointer and
-// all fields contain `tfschema` struct tags
+// all fields contain `tfschema` struct tags which exist in the Schema
 func (rmd ResourceMetaData) Encode(input interface{}) error {
 	if reflect.TypeOf(input).Kind() != reflect.Ptr {
 		return fmt.Errorf("need a pointer")
@@ -26,7 +26,22 @@ func (rmd ResourceMetaData) Encode(input interface{}) error {
 		return err
 	}
 
+	if rmd.resourceSchema != nil {
+		// check each key up-front so that nothing is set into the state when the model doesn't match the Schema
+		for k := range serialized {
+			if _, ok := rmd.resourceSchema[k]; !ok {
+				return fmt.Errorf("the HCL Path %q isn't defined in the Schema", k)
+			}
+		}
+	}
+
 	for k, v := range serialized {
+		// the values of write-only attributes must never be persisted into the state
+		if fieldSchema, ok := rmd.resourceSchema[k]; ok && fieldSchema.WriteOnly {
+			rmd.serializationDebugLogger.Infof("The HCL Path %q is write-only - skipping", k)
+			continue
+		}
+
 		//lintignore:R001
 		if err := rmd.ResourceData.Set(k, v); err != nil {
 			return fmt.Errorf("setting %q: %+v", k, err)
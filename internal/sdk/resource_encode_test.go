@@ -9,6 +9,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 type encodeTestData struct {
@@ -329,6 +330,53 @@ func TestResourceEncode_TopLevelOmitted(t *testing.T) {
 	}.test(t)
 }
 
+func TestResourceEncode_TopLevelWriteOnly(t *testing.T) {
+	type SimpleType struct {
+		String          string `tfschema:"string"`
+		Password        string `tfschema:"password"`
+		PasswordVersion int    `tfschema:"password_version"`
+	}
+	resourceSchema := map[string]*schema.Schema{
+		"string": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"password": {
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			WriteOnly: true,
+		},
+		"password_version": {
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+	}
+	metaData := ResourceMetaData{
+		ResourceData:             schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{}),
+		resourceSchema:           resourceSchema,
+		serializationDebugLogger: ConsoleLogger{},
+	}
+	input := &SimpleType{
+		String:          "world",
+		Password:        "s3cr3t",
+		PasswordVersion: 1,
+	}
+	if err := metaData.Encode(input); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if v := metaData.ResourceData.Get("string").(string); v != "world" {
+		t.Fatalf("expected `string` to be %q but got %q", "world", v)
+	}
+	if v := metaData.ResourceData.Get("password_version").(int); v != 1 {
+		t.Fatalf("expected `password_version` to be 1 but got %d", v)
+	}
+	if v := metaData.ResourceData.Get("password").(string); v != "" {
+		t.Fatalf("expected the write-only `password` not to be set but got %q", v)
+	}
+}
+
 func TestResourceEncode_TopLevelComputed(t *testing.T) {
 	type SimpleType struct {
 		ComputedString        string             `tfschema:"computed_string" computed:"true"`
@@ -995,6 +1043,47 @@ func TestResourceEncode_NestedThreeLevelsDeepMultipleOptionalItems(t *testing.T)
 	}.test(t)
 }
 
+func TestResourceEncode_SchemaMismatch(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+	}
+
+	type Matching struct {
+		Name    string `tfschema:"name"`
+		Enabled bool   `tfschema:"enabled"`
+	}
+	metaData := ResourceMetaData{
+		ResourceData:             schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{}),
+		resourceSchema:           resourceSchema,
+		serializationDebugLogger: ConsoleLogger{},
+	}
+	if err := metaData.Encode(&Matching{Name: "bingo", Enabled: true}); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if v := metaData.ResourceData.Get("name").(string); v != "bingo" {
+		t.Fatalf("expected `name` to be %q but got %q", "bingo", v)
+	}
+
+	type MissingFromSchema struct {
+		Name    string `tfschema:"name"`
+		Missing string `tfschema:"missing"`
+	}
+	metaData.ResourceData = schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+	if err := metaData.Encode(&MissingFromSchema{Name: "bingo", Missing: "bongo"}); err == nil {
+		t.Fatalf("expected an error for a field which isn't in the schema but didn't get one")
+	}
+	if v := metaData.ResourceData.Get("name").(string); v != "" {
+		t.Fatalf("expected nothing to be set into the state but `name` was %q", v)
+	}
+}
+
 func (testData encodeTestData) test(t *testing.T) {
 	objType := reflect.TypeOf(testData.Input).Elem()
 	objVal := reflect.ValueOf(testData.Input).Elem()
@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ pluginsdk.StateUpgrade = ResourceIdStateUpgrade{}
+
+// ResourceIdStateUpgrade is a generic State Upgrade which rewrites the Resource ID within the `id` field
+// (and optionally any other fields containing Resource IDs) from one Resource ID format into another - for
+// example to fix the casing of a segment, or to move to a Resource ID type with differently named segments.
+//
+// This can be used by both Typed and Untyped Resources, for example:
+//
+//	Upgraders: map[int]pluginsdk.StateUpgrade{
+//		0: sdk.ResourceIdStateUpgrade{
+//			PointInTimeSchema: map[string]*pluginsdk.Schema{ ... },
+//			OldId:             &parse.ExampleId{},
+//			NewId:             &examples.ExampleId{},
+//		},
+//	}
+type ResourceIdStateUpgrade struct {
+	// PointInTimeSchema is a point-in-time reference to the Schema at the time of this version
+	// NOTE: as with other State Upgrades this shouldn't reference the existing schema
+	PointInTimeSchema map[string]*pluginsdk.Schema
+
+	// OldId is the Resource ID type used to (insensitively) parse the existing Resource ID in the `id` field
+	OldId resourceids.ResourceId
+
+	// NewId is the Resource ID type which the `id` field is rewritten into, if omitted the OldId is used
+	// which normalises the casing of the existing Resource ID
+	NewId resourceids.ResourceId
+
+	// Fields is an optional list of other (top-level) fields containing Resource IDs which should be rewritten
+	Fields []ResourceIdStateUpgradeField
+}
+
+// ResourceIdStateUpgradeField defines a field containing a Resource ID (or a List/Set of Resource IDs)
+// which should be rewritten by the ResourceIdStateUpgrade
+type ResourceIdStateUpgradeField struct {
+	// Name is the name of the top-level field within the State
+	Name string
+
+	// OldId is the Resource ID type used to (insensitively) parse the existing value(s)
+	OldId resourceids.ResourceId
+
+	// NewId is the Resource ID type which the value(s) are rewritten into, if omitted the OldId is used
+	NewId resourceids.ResourceId
+}
+
+func (u ResourceIdStateUpgrade) Schema() map[string]*pluginsdk.Schema {
+	return u.PointInTimeSchema
+}
+
+func (u ResourceIdStateUpgrade) UpgradeFunc() pluginsdk.StateUpgraderFunc {
+	return ResourceIdUpgradeFunc(u.OldId, u.NewId, u.Fields...)
+}
+
+// ResourceIdUpgradeFunc returns a StateUpgraderFunc which rewrites the Resource ID within the `id` field from
+// the `oldId` format into the `newId` format - in addition to any other fields specified - which can be used
+// within a hand-written State Upgrade which also needs to make other changes
+func ResourceIdUpgradeFunc(oldId resourceids.ResourceId, newId resourceids.ResourceId, fields ...ResourceIdStateUpgradeField) pluginsdk.StateUpgraderFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		oldValue, ok := rawState["id"].(string)
+		if !ok || oldValue == "" {
+			return nil, fmt.Errorf("the `id` field was not found in the state")
+		}
+
+		newValue, err := MigrateResourceId(oldValue, oldId, newId)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[DEBUG] Updating ID from %q to %q", oldValue, newValue)
+		rawState["id"] = newValue
+
+		for _, field := range fields {
+			if err := migrateResourceIdField(rawState, field); err != nil {
+				return nil, err
+			}
+		}
+
+		return rawState, nil
+	}
+}
+
+// MigrateResourceId (insensitively) parses the input using the `oldId` Resource ID type and returns the
+// Resource ID in the format of the `newId` Resource ID type - when `newId` is nil this normalises the casing
+// of the input.
+//
+// The non-static segments (e.g. the Subscription ID, Resource Group and user-specified segments) are matched
+// up by position rather than by name, as such the segments can be named differently in each Resource ID type
+// but must be of the same type and in the same order.
+func MigrateResourceId(input string, oldId resourceids.ResourceId, newId resourceids.ResourceId) (string, error) {
+	if oldId == nil {
+		return "", fmt.Errorf("the old Resource ID type must be specified")
+	}
+	if newId == nil {
+		newId = oldId
+	}
+
+	parsed, err := resourceids.NewParserFromResourceIdType(newResourceIdInstance(oldId)).Parse(input, true)
+	if err != nil {
+		return "", fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	oldSegments := valueSegments(oldId)
+	newSegments := valueSegments(newId)
+	if len(oldSegments) != len(newSegments) {
+		return "", fmt.Errorf("the new Resource ID has %d segments containing values but %q has %d", len(newSegments), input, len(oldSegments))
+	}
+
+	values := make(map[string]string)
+	for i, segment := range newSegments {
+		oldSegment := oldSegments[i]
+		if oldSegment.Type != segment.Type {
+			return "", fmt.Errorf("the segment %q for the new Resource ID is a %s segment but the segment %q at the same position is a %s segment", segment.Name, segment.Type, oldSegment.Name, oldSegment.Type)
+		}
+
+		value, ok := parsed.Parsed[oldSegment.Name]
+		if !ok {
+			return "", fmt.Errorf("the segment %q wasn't found in %q", oldSegment.Name, input)
+		}
+		values[segment.Name] = value
+	}
+
+	id := newResourceIdInstance(newId)
+	if err := id.FromParseResult(resourceids.ParseResult{Parsed: values, RawInput: input}); err != nil {
+		return "", fmt.Errorf("building the new Resource ID from %q: %+v", input, err)
+	}
+
+	return id.ID(), nil
+}
+
+// valueSegments returns the segments of the Resource ID type which contain a value, in order - that is
+// excluding the static and Resource Provider segments, which are defined by the Resource ID type
+func valueSegments(input resourceids.ResourceId) []resourceids.Segment {
+	output := make([]resourceids.Segment, 0)
+	for _, segment := range input.Segments() {
+		switch segment.Type {
+		case resourceids.StaticSegmentType, resourceids.ResourceProviderSegmentType:
+			continue
+		}
+		output = append(output, segment)
+	}
+	return output
+}
+
+func migrateResourceIdField(rawState map[string]interface{}, field ResourceIdStateUpgradeField) error {
+	raw, ok := rawState[field.Name]
+	if !ok || raw == nil {
+		return nil
+	}
+
+	switch v := raw.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		newValue, err := MigrateResourceId(v, field.OldId, field.NewId)
+		if err != nil {
+			return fmt.Errorf("migrating `%s`: %+v", field.Name, err)
+		}
+		log.Printf("[DEBUG] Updating `%s` from %q to %q", field.Name, v, newValue)
+		rawState[field.Name] = newValue
+
+	case []interface{}:
+		values := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			value, ok := item.(string)
+			if !ok || value == "" {
+				values = append(values, item)
+				continue
+			}
+			newValue, err := MigrateResourceId(value, field.OldId, field.NewId)
+			if err != nil {
+				return fmt.Errorf("migrating `%s`: %+v", field.Name, err)
+			}
+			values = append(values, newValue)
+		}
+		rawState[field.Name] = values
+
+	default:
+		return fmt.Errorf("migrating `%s`: expected a string or a list of strings but got %T", field.Name, raw)
+	}
+
+	return nil
+}
+
+// newResourceIdInstance returns a new instance of the specified Resource ID type, since the Resource ID is
+// populated when parsing and the same type is used for every State Upgrade
+func newResourceIdInstance(input resourceids.ResourceId) resourceids.ResourceId {
+	t := reflect.TypeOf(input)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return reflect.New(t).Interface().(resourceids.ResourceId)
+}
@@ -0,0 +1,245 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/automation/2015-10-31/webhook"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/communication/2023-03-31/communicationservices"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/redis/2023-08-01/redis"
+)
+
+func TestResourceIdUpgradeFunc(t *testing.T) {
+	testData := []struct {
+		Name     string
+		OldId    resourceids.ResourceId
+		NewId    resourceids.ResourceId
+		Input    string
+		Expected string
+		Error    bool
+	}{
+		{
+			// compute/migration/managed_disk_v0_to_v1.go
+			Name:     "Managed Disk",
+			OldId:    &commonids.ManagedDiskId{},
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Compute/Disks/disk1",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Compute/disks/disk1",
+		},
+		{
+			// redis/migration/redis_cache_v0_to_v1.go
+			Name:     "Redis Cache",
+			OldId:    &redis.RediId{},
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/group1/providers/Microsoft.Cache/Redis/cache1",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Cache/redis/cache1",
+		},
+		{
+			// automation/migration/automation_webhook_migration_v0_to_v1.go
+			Name:     "Automation Webhook",
+			OldId:    &webhook.WebHookId{},
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1/webhooks/webhook1",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1/webHooks/webhook1",
+		},
+		{
+			// communication/migration/service_v0_to_v1.go
+			Name:     "Communication Service",
+			OldId:    &communicationservices.CommunicationServiceId{},
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Communication/CommunicationServices/service1",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Communication/communicationServices/service1",
+		},
+		{
+			Name:     "Dedicated Host",
+			OldId:    &commonids.DedicatedHostId{},
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Compute/hostgroups/group1/hosts/host1",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Compute/hostGroups/group1/hosts/host1",
+		},
+		{
+			// kusto/migration/kusto_cluster_migration_v0_to_v1.go
+			Name:     "Kusto Cluster",
+			OldId:    &commonids.KustoClusterId{},
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Kusto/Clusters/cluster1",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Kusto/clusters/cluster1",
+		},
+		{
+			// appservice/migration/service_plan.go
+			Name:     "App Service Plan",
+			OldId:    &commonids.AppServicePlanId{},
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Web/serverfarms/plan1",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Web/serverFarms/plan1",
+		},
+		{
+			// managedidentity/migration/user_assigned_identity_V0_to_V1.go
+			Name:     "User Assigned Identity",
+			OldId:    &commonids.UserAssignedIdentityId{},
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.ManagedIdentity/UserAssignedIdentities/identity1",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1",
+		},
+		{
+			Name:     "Already Normalised",
+			OldId:    &commonids.VirtualMachineScaleSetId{},
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Compute/virtualMachineScaleSets/scaleSet1",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Compute/virtualMachineScaleSets/scaleSet1",
+		},
+		{
+			Name:     "Renamed Segment",
+			OldId:    &legacyServerId{},
+			NewId:    &commonids.SqlServerId{},
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/group1/providers/Microsoft.Sql/Servers/server1",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Sql/servers/server1",
+		},
+		{
+			Name:     "Renamed Segment Reversed",
+			OldId:    &commonids.SqlServerId{},
+			NewId:    &legacyServerId{},
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/group1/providers/Microsoft.Sql/Servers/server1",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Sql/servers/server1",
+		},
+		{
+			Name:  "Different Resource Type",
+			OldId: &commonids.ManagedDiskId{},
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Cache/redis/cache1",
+			Error: true,
+		},
+		{
+			Name:  "Renamed Segment with a Different Type",
+			OldId: &legacyServerId{},
+			NewId: &commonids.SubscriptionId{},
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Sql/servers/server1",
+			Error: true,
+		},
+		{
+			Name:  "Missing Segment",
+			OldId: &commonids.ResourceGroupId{},
+			NewId: &commonids.ManagedDiskId{},
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		rawState := map[string]interface{}{
+			"id":   v.Input,
+			"name": "example",
+		}
+		actual, err := ResourceIdUpgradeFunc(v.OldId, v.NewId)(context.TODO(), rawState, nil)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+			t.Fatalf("upgrading: %+v", err)
+		}
+		if v.Error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if actual["id"] != v.Expected {
+			t.Fatalf("expected %q but got %q", v.Expected, actual["id"])
+		}
+		if actual["name"] != "example" {
+			t.Fatalf("expected other fields to be unchanged but got %q", actual["name"])
+		}
+	}
+}
+
+func TestResourceIdUpgradeFunc_Fields(t *testing.T) {
+	upgrade := ResourceIdStateUpgrade{
+		OldId: &commonids.VirtualMachineId{},
+		Fields: []ResourceIdStateUpgradeField{
+			{
+				Name:  "disk_id",
+				OldId: &commonids.ManagedDiskId{},
+			},
+			{
+				Name:  "identity_ids",
+				OldId: &commonids.UserAssignedIdentityId{},
+			},
+			{
+				Name:  "optional_disk_id",
+				OldId: &commonids.ManagedDiskId{},
+			},
+		},
+	}
+
+	rawState := map[string]interface{}{
+		"id":               "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/group1/providers/Microsoft.Compute/VirtualMachines/vm1",
+		"disk_id":          "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/group1/providers/Microsoft.Compute/Disks/disk1",
+		"optional_disk_id": "",
+		"identity_ids": []interface{}{
+			"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.ManagedIdentity/UserAssignedIdentities/identity1",
+			"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity2",
+		},
+	}
+	actual, err := upgrade.UpgradeFunc()(context.TODO(), rawState, nil)
+	if err != nil {
+		t.Fatalf("upgrading: %+v", err)
+	}
+
+	expected := map[string]interface{}{
+		"id":               "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Compute/virtualMachines/vm1",
+		"disk_id":          "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Compute/disks/disk1",
+		"optional_disk_id": "",
+		"identity_ids": []interface{}{
+			"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1",
+			"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity2",
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+
+	rawState["disk_id"] = "not-a-resource-id"
+	if _, err := upgrade.UpgradeFunc()(context.TODO(), rawState, nil); err == nil {
+		t.Fatalf("expected an error for an invalid `disk_id` but didn't get one")
+	}
+}
+
+// legacyServerId is a Resource ID type with differently named segments to commonids.SqlServerId (e.g. `name`
+// rather than `serverName`), as found in the legacy `parse` packages
+type legacyServerId struct {
+	SubscriptionId string
+	ResourceGroup  string
+	Name           string
+}
+
+func (id legacyServerId) ID() string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Sql/servers/%s", id.SubscriptionId, id.ResourceGroup, id.Name)
+}
+
+func (id legacyServerId) String() string {
+	return id.ID()
+}
+
+func (id *legacyServerId) FromParseResult(input resourceids.ParseResult) error {
+	var ok bool
+	if id.SubscriptionId, ok = input.Parsed["subscription"]; !ok {
+		return fmt.Errorf("the segment 'subscription' was not found")
+	}
+	if id.ResourceGroup, ok = input.Parsed["resourceGroup"]; !ok {
+		return fmt.Errorf("the segment 'resourceGroup' was not found")
+	}
+	if id.Name, ok = input.Parsed["name"]; !ok {
+		return fmt.Errorf("the segment 'name' was not found")
+	}
+	return nil
+}
+
+func (id legacyServerId) Segments() []resourceids.Segment {
+	return []resourceids.Segment{
+		resourceids.StaticSegment("staticSubscriptions", "subscriptions", "subscriptions"),
+		resourceids.SubscriptionIdSegment("subscription", "12345678-1234-9876-4563-123456789012"),
+		resourceids.StaticSegment("staticResourceGroups", "resourceGroups", "resourceGroups"),
+		resourceids.ResourceGroupSegment("resourceGroup", "example-resource-group"),
+		resourceids.StaticSegment("staticProviders", "providers", "providers"),
+		resourceids.ResourceProviderSegment("staticMicrosoftSql", "Microsoft.Sql", "Microsoft.Sql"),
+		resourceids.StaticSegment("staticServers", "servers", "servers"),
+		resourceids.UserSpecifiedSegment("name", "serverValue"),
+	}
+}
@@ -4,6 +4,7 @@
 package sdk
 
 import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -41,6 +42,16 @@ type UntypedServiceRegistration interface {
 	SupportedResources() map[string]*pluginsdk.Resource
 }
 
+// UntypedServiceRegistrationWithExistenceChecks is a superset of UntypedServiceRegistration allowing the
+// Resources within this Service to override the lookup used during the plan to determine whether a resource
+// being created already exists - the equivalent of ResourceWithExistenceCheck for Untyped Resources.
+type UntypedServiceRegistrationWithExistenceChecks interface {
+	UntypedServiceRegistration
+
+	// ExistenceChecks returns a map of Resource Type to the function checking whether the resource exists
+	ExistenceChecks() map[string]UntypedExistenceCheckFunc
+}
+
 // TypedServiceRegistrationWithAGitHubLabel is a superset of TypedServiceRegistration allowing
 // a single GitHub Label to be specified that will be automatically applied to any Pull Requests
 // making changes to this package.
@@ -64,3 +75,30 @@ type UntypedServiceRegistrationWithAGitHubLabel interface {
 
 	AssociatedGitHubLabel() string
 }
+
+// ServiceRegistrationWithEphemeralResources is an optional interface which can be implemented by either a
+// TypedServiceRegistration or an UntypedServiceRegistration, returning the Ephemeral Resources supported
+// by this Service - which are served by the Plugin Framework Provider.
+type ServiceRegistrationWithEphemeralResources interface {
+	// EphemeralResources returns a list of Ephemeral Resources supported by this Service
+	EphemeralResources() []EphemeralResource
+}
+
+// ServiceRegistrationWithListResources is an optional interface which can be implemented by either a
+// TypedServiceRegistration or an UntypedServiceRegistration, returning the List Resources supported
+// by this Service - which are served by the Plugin Framework Provider.
+type ServiceRegistrationWithListResources interface {
+	// ListResources returns a list of List Resources supported by this Service
+	ListResources() []ListResource
+}
+
+// ServiceRegistrationWithResourceIds is an optional interface which can be implemented by either a
+// TypedServiceRegistration or an UntypedServiceRegistration, returning the Resource ID types used by this
+// Service - which are used by the Provider Functions to normalise Resource IDs into their canonical casing.
+//
+// NOTE: the Resource ID types used by Typed Resources implementing ResourceWithIdentity and by List Resources
+// are included automatically, so needn't be returned here.
+type ServiceRegistrationWithResourceIds interface {
+	// ResourceIds returns a list of the Resource ID types used by this Service
+	ResourceIds() []resourceids.ResourceId
+}
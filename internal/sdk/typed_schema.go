@@ -0,0 +1,381 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// resourceWithTypedSchema defines the Arguments and Attributes for this resource using
+// the Typed Schema, which can be rendered into both the Plugin SDKv2 and Plugin Framework
+//
+// Data Sources/Resources implement either this interface or resourceWithPluginSdkSchema
+type resourceWithTypedSchema interface {
+	// TypedArguments is a list of user-configurable (that is: Required, Optional, or Optional and Computed)
+	// arguments for this Resource
+	TypedArguments() TypedSchema
+
+	// TypedAttributes is a list of read-only (e.g. Computed-only) attributes
+	TypedAttributes() TypedSchema
+}
+
+// AttributeType is the type of value held by an Attribute
+type AttributeType string
+
+const (
+	AttributeTypeBool    AttributeType = "bool"
+	AttributeTypeFloat64 AttributeType = "float64"
+	AttributeTypeInt64   AttributeType = "int64"
+	AttributeTypeString  AttributeType = "string"
+
+	// the collection types require the ElementType to be specified
+	AttributeTypeList AttributeType = "list"
+	AttributeTypeMap  AttributeType = "map"
+	AttributeTypeSet  AttributeType = "set"
+)
+
+func (t AttributeType) isPrimitive() bool {
+	switch t {
+	case AttributeTypeBool, AttributeTypeFloat64, AttributeTypeInt64, AttributeTypeString:
+		return true
+	}
+	return false
+}
+
+// BlockNestingMode defines how the nested objects within a Block are stored
+type BlockNestingMode string
+
+const (
+	BlockNestingModeList BlockNestingMode = "list"
+	BlockNestingModeSet  BlockNestingMode = "set"
+)
+
+// TypedSchema is a Go-native definition of the Attributes and Blocks for a Data Source/Resource,
+// which is rendered into the schema types used by the Plugin SDKv2 and Plugin Framework
+type TypedSchema struct {
+	// Attributes is a map of the HCL name to the definition of each Attribute
+	Attributes map[string]Attribute
+
+	// Blocks is a map of the HCL name to the definition of each (nested) Block
+	Blocks map[string]Block
+}
+
+// Attribute defines a single (primitive or collection) value within the TypedSchema
+type Attribute struct {
+	// Type is the type of value held by this Attribute
+	Type AttributeType
+
+	// ElementType is the type of each element within this Attribute, when Type is a collection type
+	ElementType AttributeType
+
+	Required bool
+	Optional bool
+	Computed bool
+
+	// ForceNew specifies that changing this Attribute requires the Resource to be recreated
+	ForceNew bool
+
+	// Sensitive specifies that the value of this Attribute should be redacted from output
+	Sensitive bool
+
+	// Default is the value used when this Attribute isn't specified, which must match the Type
+	Default interface{}
+
+	// Description is a human-readable description of this Attribute
+	Description string
+
+	// Deprecated is the deprecation message output when this Attribute is used
+	Deprecated string
+
+	// Validation defines the (optional) validation applied to this value, or to each element within a collection
+	Validation *AttributeValidation
+}
+
+// AttributeValidation defines the validation applied to an Attribute, in a manner which can be
+// rendered into both the Plugin SDKv2 and Plugin Framework
+type AttributeValidation struct {
+	// AllowedValues is a list of the (case-sensitive) values this String can be set to
+	AllowedValues []string
+
+	// NotEmpty specifies that this String cannot be an empty string
+	NotEmpty bool
+
+	// MinLength and MaxLength are the (inclusive) bounds for the length of this String, where 0 is unbounded
+	MinLength int
+	MaxLength int
+
+	// Pattern is a regular expression which this String must match
+	Pattern string
+
+	// Minimum and Maximum are the (inclusive) bounds for this Int64
+	Minimum *int64
+	Maximum *int64
+}
+
+// Block defines a (nested) Block within the TypedSchema
+type Block struct {
+	// NestingMode defines how the nested objects within this Block are stored
+	NestingMode BlockNestingMode
+
+	Required bool
+	Optional bool
+	Computed bool
+
+	// ForceNew specifies that changing this Block requires the Resource to be recreated
+	ForceNew bool
+
+	// MinItems and MaxItems are the (inclusive) bounds for the number of items within this Block, where 0 is unbounded
+	MinItems int
+	MaxItems int
+
+	// Description is a human-readable description of this Block
+	Description string
+
+	// Deprecated is the deprecation message output when this Block is used
+	Deprecated string
+
+	// Attributes is a map of the HCL name to the definition of each Attribute within this Block
+	Attributes map[string]Attribute
+
+	// Blocks is a map of the HCL name to the definition of each Block nested within this Block
+	Blocks map[string]Block
+}
+
+// Validate validates that the TypedSchema is internally consistent
+func (s TypedSchema) Validate() error {
+	return validateTypedSchema("", s.Attributes, s.Blocks)
+}
+
+func validateTypedSchema(prefix string, attributes map[string]Attribute, blocks map[string]Block) error {
+	for _, k := range sortedKeys(attributes) {
+		if _, exists := blocks[k]; exists {
+			return fmt.Errorf("%q is defined as both an Attribute and a Block", prefix+k)
+		}
+		if err := attributes[k].validate(); err != nil {
+			return fmt.Errorf("validating Attribute %q: %+v", prefix+k, err)
+		}
+	}
+
+	for _, k := range sortedKeys(blocks) {
+		block := blocks[k]
+		if err := block.validate(); err != nil {
+			return fmt.Errorf("validating Block %q: %+v", prefix+k, err)
+		}
+		if err := validateTypedSchema(fmt.Sprintf("%s%s.", prefix, k), block.Attributes, block.Blocks); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a Attribute) validate() error {
+	if err := validateOptionality(a.Required, a.Optional, a.Computed); err != nil {
+		return err
+	}
+
+	if a.Type.isPrimitive() {
+		if a.ElementType != "" {
+			return fmt.Errorf("an ElementType cannot be specified for the primitive type %q", a.Type)
+		}
+	} else {
+		switch a.Type {
+		case AttributeTypeList, AttributeTypeMap, AttributeTypeSet:
+			if !a.ElementType.isPrimitive() {
+				return fmt.Errorf("the collection type %q requires a primitive ElementType but got %q", a.Type, a.ElementType)
+			}
+		default:
+			return fmt.Errorf("unsupported type %q", a.Type)
+		}
+	}
+
+	if a.Default != nil {
+		if a.Required {
+			return fmt.Errorf("a Default cannot be specified for a Required Attribute")
+		}
+		if !a.Type.isPrimitive() {
+			return fmt.Errorf("a Default can only be specified for a primitive type")
+		}
+		if !defaultMatchesType(a.Type, a.Default) {
+			return fmt.Errorf("the Default %+v doesn't match the type %q", a.Default, a.Type)
+		}
+	}
+
+	if a.Validation != nil {
+		valueType := a.Type
+		if !a.Type.isPrimitive() {
+			valueType = a.ElementType
+		}
+		if err := a.Validation.validate(valueType); err != nil {
+			return fmt.Errorf("validating the Validation: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func (b Block) validate() error {
+	if err := validateOptionality(b.Required, b.Optional, b.Computed); err != nil {
+		return err
+	}
+
+	switch b.NestingMode {
+	case BlockNestingModeList, BlockNestingModeSet:
+	default:
+		return fmt.Errorf("unsupported nesting mode %q", b.NestingMode)
+	}
+
+	if b.MinItems < 0 || b.MaxItems < 0 {
+		return fmt.Errorf("MinItems and MaxItems cannot be negative")
+	}
+	if b.MaxItems > 0 && b.MinItems > b.MaxItems {
+		return fmt.Errorf("MinItems (%d) cannot be greater than MaxItems (%d)", b.MinItems, b.MaxItems)
+	}
+
+	if len(b.Attributes) == 0 && len(b.Blocks) == 0 {
+		return fmt.Errorf("at least one Attribute or Block must be specified")
+	}
+
+	return nil
+}
+
+func (v AttributeValidation) validate(valueType AttributeType) error {
+	stringValidation := len(v.AllowedValues) > 0 || v.NotEmpty || v.MinLength > 0 || v.MaxLength > 0 || v.Pattern != ""
+	if stringValidation && valueType != AttributeTypeString {
+		return fmt.Errorf("AllowedValues, NotEmpty, MinLength, MaxLength and Pattern can only be used with Strings")
+	}
+	if (v.Minimum != nil || v.Maximum != nil) && valueType != AttributeTypeInt64 {
+		return fmt.Errorf("Minimum and Maximum can only be used with Int64s")
+	}
+
+	if v.MinLength < 0 || v.MaxLength < 0 {
+		return fmt.Errorf("MinLength and MaxLength cannot be negative")
+	}
+	if v.MaxLength > 0 && v.MinLength > v.MaxLength {
+		return fmt.Errorf("MinLength (%d) cannot be greater than MaxLength (%d)", v.MinLength, v.MaxLength)
+	}
+	if v.Minimum != nil && v.Maximum != nil && *v.Minimum > *v.Maximum {
+		return fmt.Errorf("Minimum (%d) cannot be greater than Maximum (%d)", *v.Minimum, *v.Maximum)
+	}
+
+	if v.Pattern != "" {
+		if _, err := regexp.Compile(v.Pattern); err != nil {
+			return fmt.Errorf("compiling the Pattern %q: %+v", v.Pattern, err)
+		}
+	}
+
+	return nil
+}
+
+func validateOptionality(required, optional, computed bool) error {
+	if required && (optional || computed) {
+		return fmt.Errorf("Required cannot be combined with Optional or Computed")
+	}
+	if !required && !optional && !computed {
+		return fmt.Errorf("one of Required, Optional or Computed must be specified")
+	}
+	return nil
+}
+
+func defaultMatchesType(attributeType AttributeType, input interface{}) bool {
+	switch attributeType {
+	case AttributeTypeBool:
+		_, ok := input.(bool)
+		return ok
+	case AttributeTypeFloat64:
+		_, ok := input.(float64)
+		return ok
+	case AttributeTypeInt64:
+		switch input.(type) {
+		case int, int64:
+			return true
+		}
+	case AttributeTypeString:
+		_, ok := input.(string)
+		return ok
+	}
+
+	return false
+}
+
+// combineTypedSchema combines the arguments (user-configurable) and attributes (read-only) into a
+// single TypedSchema - in the same manner as combineSchema for the Plugin SDKv2 schema
+func combineTypedSchema(arguments TypedSchema, attributes TypedSchema) (*TypedSchema, error) {
+	out := TypedSchema{
+		Attributes: make(map[string]Attribute),
+		Blocks:     make(map[string]Block),
+	}
+
+	for k, v := range arguments.Attributes {
+		if v.Computed && !(v.Optional || v.Required) {
+			return nil, fmt.Errorf("%q is a Computed-only field - this should be specified as an Attribute", k)
+		}
+		out.Attributes[k] = v
+	}
+	for k, v := range arguments.Blocks {
+		if v.Computed && !(v.Optional || v.Required) {
+			return nil, fmt.Errorf("%q is a Computed-only field - this should be specified as an Attribute", k)
+		}
+		out.Blocks[k] = v
+	}
+
+	for k, v := range attributes.Attributes {
+		if _, exists := out.Attributes[k]; exists {
+			return nil, fmt.Errorf("%q already exists in the schema", k)
+		}
+		if v.Optional || v.Required {
+			return nil, fmt.Errorf("%q is a user-specifyable field - this should be specified as an Argument", k)
+		}
+
+		// every attribute has to be computed
+		v.Computed = true
+		out.Attributes[k] = v
+	}
+	for k, v := range attributes.Blocks {
+		if _, exists := out.Blocks[k]; exists {
+			return nil, fmt.Errorf("%q already exists in the schema", k)
+		}
+		if v.Optional || v.Required {
+			return nil, fmt.Errorf("%q is a user-specifyable field - this should be specified as an Argument", k)
+		}
+
+		out.Blocks[k] = computedBlock(v)
+	}
+
+	if err := out.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// computedBlock returns a copy of the Block with it, and everything nested within it, marked as Computed
+func computedBlock(input Block) Block {
+	output := input
+	output.Computed = true
+
+	output.Attributes = make(map[string]Attribute, len(input.Attributes))
+	for k, v := range input.Attributes {
+		v.Computed = true
+		output.Attributes[k] = v
+	}
+
+	output.Blocks = make(map[string]Block, len(input.Blocks))
+	for k, v := range input.Blocks {
+		output.Blocks[k] = computedBlock(v)
+	}
+
+	return output
+}
+
+func sortedKeys[T any](input map[string]T) []string {
+	keys := make([]string, 0, len(input))
+	for k := range input {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,518 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// FrameworkResourceSchema renders the Arguments and Attributes defined using the Typed Schema into
+// the equivalent Plugin Framework Resource schema
+//
+// NOTE: the Plugin Framework doesn't support Computed Blocks, as such these are rendered as Nested Attributes
+func FrameworkResourceSchema(arguments TypedSchema, attributes TypedSchema) (*resourceschema.Schema, error) {
+	combined, err := combineTypedSchema(arguments, attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	out := resourceschema.Schema{
+		Attributes: make(map[string]resourceschema.Attribute),
+		Blocks:     make(map[string]resourceschema.Block),
+	}
+	for k, v := range combined.Attributes {
+		out.Attributes[k] = v.frameworkResourceAttribute()
+	}
+	for k, v := range combined.Blocks {
+		if v.Computed && !v.Optional {
+			out.Attributes[k] = v.frameworkResourceNestedAttribute()
+			continue
+		}
+		out.Blocks[k] = v.frameworkResourceBlock()
+	}
+
+	return &out, nil
+}
+
+// FrameworkDataSourceSchema renders the Arguments and Attributes defined using the Typed Schema into
+// the equivalent Plugin Framework Data Source schema
+//
+// NOTE: the Plugin Framework doesn't support Computed Blocks, as such these are rendered as Nested Attributes
+func FrameworkDataSourceSchema(arguments TypedSchema, attributes TypedSchema) (*datasourceschema.Schema, error) {
+	combined, err := combineTypedSchema(arguments, attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	out := datasourceschema.Schema{
+		Attributes: make(map[string]datasourceschema.Attribute),
+		Blocks:     make(map[string]datasourceschema.Block),
+	}
+	for k, v := range combined.Attributes {
+		out.Attributes[k] = v.frameworkDataSourceAttribute()
+	}
+	for k, v := range combined.Blocks {
+		if v.Computed && !v.Optional {
+			out.Attributes[k] = v.frameworkDataSourceNestedAttribute()
+			continue
+		}
+		out.Blocks[k] = v.frameworkDataSourceBlock()
+	}
+
+	return &out, nil
+}
+
+func (a Attribute) frameworkResourceAttribute() resourceschema.Attribute {
+	validators := a.frameworkValidators()
+
+	switch a.Type {
+	case AttributeTypeBool:
+		out := resourceschema.BoolAttribute{
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed || a.Default != nil,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+		if v, ok := a.Default.(bool); ok {
+			out.Default = booldefault.StaticBool(v)
+		}
+		if a.ForceNew {
+			out.PlanModifiers = []planmodifier.Bool{boolplanmodifier.RequiresReplace()}
+		}
+		return out
+
+	case AttributeTypeFloat64:
+		out := resourceschema.Float64Attribute{
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed || a.Default != nil,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+		if v, ok := a.Default.(float64); ok {
+			out.Default = float64default.StaticFloat64(v)
+		}
+		if a.ForceNew {
+			out.PlanModifiers = []planmodifier.Float64{float64planmodifier.RequiresReplace()}
+		}
+		return out
+
+	case AttributeTypeInt64:
+		out := resourceschema.Int64Attribute{
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed || a.Default != nil,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+		if validators != nil {
+			out.Validators = []validator.Int64{validators}
+		}
+		switch v := a.Default.(type) {
+		case int:
+			out.Default = int64default.StaticInt64(int64(v))
+		case int64:
+			out.Default = int64default.StaticInt64(v)
+		}
+		if a.ForceNew {
+			out.PlanModifiers = []planmodifier.Int64{int64planmodifier.RequiresReplace()}
+		}
+		return out
+
+	case AttributeTypeList:
+		out := resourceschema.ListAttribute{
+			ElementType:        frameworkValueType(a.ElementType),
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+		if validators != nil {
+			out.Validators = []validator.List{validators}
+		}
+		if a.ForceNew {
+			out.PlanModifiers = []planmodifier.List{listplanmodifier.RequiresReplace()}
+		}
+		return out
+
+	case AttributeTypeMap:
+		out := resourceschema.MapAttribute{
+			ElementType:        frameworkValueType(a.ElementType),
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+		if validators != nil {
+			out.Validators = []validator.Map{validators}
+		}
+		if a.ForceNew {
+			out.PlanModifiers = []planmodifier.Map{mapplanmodifier.RequiresReplace()}
+		}
+		return out
+
+	case AttributeTypeSet:
+		out := resourceschema.SetAttribute{
+			ElementType:        frameworkValueType(a.ElementType),
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+		if validators != nil {
+			out.Validators = []validator.Set{validators}
+		}
+		if a.ForceNew {
+			out.PlanModifiers = []planmodifier.Set{setplanmodifier.RequiresReplace()}
+		}
+		return out
+	}
+
+	out := resourceschema.StringAttribute{
+		Required:           a.Required,
+		Optional:           a.Optional,
+		Computed:           a.Computed || a.Default != nil,
+		Sensitive:          a.Sensitive,
+		Description:        a.Description,
+		DeprecationMessage: a.Deprecated,
+	}
+	if validators != nil {
+		out.Validators = []validator.String{validators}
+	}
+	if v, ok := a.Default.(string); ok {
+		out.Default = stringdefault.StaticString(v)
+	}
+	if a.ForceNew {
+		out.PlanModifiers = []planmodifier.String{stringplanmodifier.RequiresReplace()}
+	}
+	return out
+}
+
+func (a Attribute) frameworkDataSourceAttribute() datasourceschema.Attribute {
+	validators := a.frameworkValidators()
+
+	switch a.Type {
+	case AttributeTypeBool:
+		return datasourceschema.BoolAttribute{
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+
+	case AttributeTypeFloat64:
+		return datasourceschema.Float64Attribute{
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+
+	case AttributeTypeInt64:
+		out := datasourceschema.Int64Attribute{
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+		if validators != nil {
+			out.Validators = []validator.Int64{validators}
+		}
+		return out
+
+	case AttributeTypeList:
+		out := datasourceschema.ListAttribute{
+			ElementType:        frameworkValueType(a.ElementType),
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+		if validators != nil {
+			out.Validators = []validator.List{validators}
+		}
+		return out
+
+	case AttributeTypeMap:
+		out := datasourceschema.MapAttribute{
+			ElementType:        frameworkValueType(a.ElementType),
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+		if validators != nil {
+			out.Validators = []validator.Map{validators}
+		}
+		return out
+
+	case AttributeTypeSet:
+		out := datasourceschema.SetAttribute{
+			ElementType:        frameworkValueType(a.ElementType),
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+		if validators != nil {
+			out.Validators = []validator.Set{validators}
+		}
+		return out
+	}
+
+	out := datasourceschema.StringAttribute{
+		Required:           a.Required,
+		Optional:           a.Optional,
+		Computed:           a.Computed,
+		Sensitive:          a.Sensitive,
+		Description:        a.Description,
+		DeprecationMessage: a.Deprecated,
+	}
+	if validators != nil {
+		out.Validators = []validator.String{validators}
+	}
+	return out
+}
+
+func (b Block) frameworkResourceBlock() resourceschema.Block {
+	nestedObject := resourceschema.NestedBlockObject{
+		Attributes: make(map[string]resourceschema.Attribute),
+		Blocks:     make(map[string]resourceschema.Block),
+	}
+	for k, v := range b.Attributes {
+		nestedObject.Attributes[k] = v.frameworkResourceAttribute()
+	}
+	for k, v := range b.Blocks {
+		nestedObject.Blocks[k] = v.frameworkResourceBlock()
+	}
+
+	sizeValidator := b.frameworkSizeValidator()
+	if b.NestingMode == BlockNestingModeSet {
+		out := resourceschema.SetNestedBlock{
+			NestedObject:       nestedObject,
+			Description:        b.Description,
+			DeprecationMessage: b.Deprecated,
+		}
+		if sizeValidator != nil {
+			out.Validators = []validator.Set{sizeValidator}
+		}
+		if b.ForceNew {
+			out.PlanModifiers = []planmodifier.Set{setplanmodifier.RequiresReplace()}
+		}
+		return out
+	}
+
+	out := resourceschema.ListNestedBlock{
+		NestedObject:       nestedObject,
+		Description:        b.Description,
+		DeprecationMessage: b.Deprecated,
+	}
+	if sizeValidator != nil {
+		out.Validators = []validator.List{sizeValidator}
+	}
+	if b.ForceNew {
+		out.PlanModifiers = []planmodifier.List{listplanmodifier.RequiresReplace()}
+	}
+	return out
+}
+
+func (b Block) frameworkResourceNestedAttribute() resourceschema.Attribute {
+	nestedObject := resourceschema.NestedAttributeObject{
+		Attributes: make(map[string]resourceschema.Attribute),
+	}
+	for k, v := range b.Attributes {
+		nestedObject.Attributes[k] = v.frameworkResourceAttribute()
+	}
+	for k, v := range b.Blocks {
+		nestedObject.Attributes[k] = v.frameworkResourceNestedAttribute()
+	}
+
+	if b.NestingMode == BlockNestingModeSet {
+		return resourceschema.SetNestedAttribute{
+			NestedObject:       nestedObject,
+			Computed:           true,
+			Description:        b.Description,
+			DeprecationMessage: b.Deprecated,
+		}
+	}
+
+	return resourceschema.ListNestedAttribute{
+		NestedObject:       nestedObject,
+		Computed:           true,
+		Description:        b.Description,
+		DeprecationMessage: b.Deprecated,
+	}
+}
+
+func (b Block) frameworkDataSourceBlock() datasourceschema.Block {
+	nestedObject := datasourceschema.NestedBlockObject{
+		Attributes: make(map[string]datasourceschema.Attribute),
+		Blocks:     make(map[string]datasourceschema.Block),
+	}
+	for k, v := range b.Attributes {
+		nestedObject.Attributes[k] = v.frameworkDataSourceAttribute()
+	}
+	for k, v := range b.Blocks {
+		nestedObject.Blocks[k] = v.frameworkDataSourceBlock()
+	}
+
+	sizeValidator := b.frameworkSizeValidator()
+	if b.NestingMode == BlockNestingModeSet {
+		out := datasourceschema.SetNestedBlock{
+			NestedObject:       nestedObject,
+			Description:        b.Description,
+			DeprecationMessage: b.Deprecated,
+		}
+		if sizeValidator != nil {
+			out.Validators = []validator.Set{sizeValidator}
+		}
+		return out
+	}
+
+	out := datasourceschema.ListNestedBlock{
+		NestedObject:       nestedObject,
+		Description:        b.Description,
+		DeprecationMessage: b.Deprecated,
+	}
+	if sizeValidator != nil {
+		out.Validators = []validator.List{sizeValidator}
+	}
+	return out
+}
+
+func (b Block) frameworkDataSourceNestedAttribute() datasourceschema.Attribute {
+	nestedObject := datasourceschema.NestedAttributeObject{
+		Attributes: make(map[string]datasourceschema.Attribute),
+	}
+	for k, v := range b.Attributes {
+		nestedObject.Attributes[k] = v.frameworkDataSourceAttribute()
+	}
+	for k, v := range b.Blocks {
+		nestedObject.Attributes[k] = v.frameworkDataSourceNestedAttribute()
+	}
+
+	if b.NestingMode == BlockNestingModeSet {
+		return datasourceschema.SetNestedAttribute{
+			NestedObject:       nestedObject,
+			Computed:           true,
+			Description:        b.Description,
+			DeprecationMessage: b.Deprecated,
+		}
+	}
+
+	return datasourceschema.ListNestedAttribute{
+		NestedObject:       nestedObject,
+		Computed:           true,
+		Description:        b.Description,
+		DeprecationMessage: b.Deprecated,
+	}
+}
+
+// frameworkValidators returns the Plugin Framework validator for this Attribute, if any
+func (a Attribute) frameworkValidators() *attributeValidationValidator {
+	if a.Validation == nil {
+		return nil
+	}
+	return &attributeValidationValidator{
+		validation: *a.Validation,
+	}
+}
+
+// frameworkSizeValidator returns the Plugin Framework validator used to validate the number of items
+// within this Block - which is also used to enforce a Required Block
+func (b Block) frameworkSizeValidator() *blockSizeValidator {
+	minItems := b.MinItems
+	if b.Required && minItems == 0 {
+		minItems = 1
+	}
+	if minItems == 0 && b.MaxItems == 0 {
+		return nil
+	}
+	return &blockSizeValidator{
+		minItems: minItems,
+		maxItems: b.MaxItems,
+	}
+}
+
+func frameworkValueType(input AttributeType) attr.Type {
+	switch input {
+	case AttributeTypeBool:
+		return types.BoolType
+	case AttributeTypeFloat64:
+		return types.Float64Type
+	case AttributeTypeInt64:
+		return types.Int64Type
+	}
+
+	return types.StringType
+}
+
+// frameworkObjectType returns the Plugin Framework type of the object described by these Attributes and Blocks
+func frameworkObjectType(attributes map[string]Attribute, blocks map[string]Block) types.ObjectType {
+	out := types.ObjectType{
+		AttrTypes: make(map[string]attr.Type, len(attributes)+len(blocks)),
+	}
+	for k, v := range attributes {
+		out.AttrTypes[k] = v.frameworkType()
+	}
+	for k, v := range blocks {
+		elementType := frameworkObjectType(v.Attributes, v.Blocks)
+		if v.NestingMode == BlockNestingModeSet {
+			out.AttrTypes[k] = types.SetType{ElemType: elementType}
+		} else {
+			out.AttrTypes[k] = types.ListType{ElemType: elementType}
+		}
+	}
+	return out
+}
+
+func (a Attribute) frameworkType() attr.Type {
+	switch a.Type {
+	case AttributeTypeList:
+		return types.ListType{ElemType: frameworkValueType(a.ElementType)}
+	case AttributeTypeMap:
+		return types.MapType{ElemType: frameworkValueType(a.ElementType)}
+	case AttributeTypeSet:
+		return types.SetType{ElemType: frameworkValueType(a.ElementType)}
+	}
+	return frameworkValueType(a.Type)
+}
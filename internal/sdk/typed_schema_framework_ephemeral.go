@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+
+	ephemeralschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// FrameworkEphemeralResourceSchema renders the Arguments and Attributes defined using the Typed Schema into
+// the equivalent Plugin Framework Ephemeral Resource schema
+//
+// NOTE: the Plugin Framework doesn't support Computed Blocks, as such these are rendered as Nested Attributes
+func FrameworkEphemeralResourceSchema(arguments TypedSchema, attributes TypedSchema) (*ephemeralschema.Schema, error) {
+	combined, err := combineTypedSchema(arguments, attributes)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateEphemeralTypedSchema("", combined.Attributes, combined.Blocks); err != nil {
+		return nil, err
+	}
+
+	out := ephemeralschema.Schema{
+		Attributes: make(map[string]ephemeralschema.Attribute),
+		Blocks:     make(map[string]ephemeralschema.Block),
+	}
+	for k, v := range combined.Attributes {
+		out.Attributes[k] = v.frameworkEphemeralResourceAttribute()
+	}
+	for k, v := range combined.Blocks {
+		if v.Computed && !v.Optional {
+			out.Attributes[k] = v.frameworkEphemeralResourceNestedAttribute()
+			continue
+		}
+		out.Blocks[k] = v.frameworkEphemeralResourceBlock()
+	}
+
+	return &out, nil
+}
+
+// validateEphemeralTypedSchema validates that the Typed Schema doesn't use any features which aren't supported
+// by Ephemeral Resources - since these are never persisted there's no plan to apply a Default or ForceNew to,
+// as such any default values should be handled within the Open function instead
+func validateEphemeralTypedSchema(prefix string, attributes map[string]Attribute, blocks map[string]Block) error {
+	for _, k := range sortedKeys(attributes) {
+		if attributes[k].Default != nil {
+			return fmt.Errorf("%q specifies a Default which isn't supported by Ephemeral Resources", prefix+k)
+		}
+		if attributes[k].ForceNew {
+			return fmt.Errorf("%q specifies ForceNew which isn't supported by Ephemeral Resources", prefix+k)
+		}
+	}
+
+	for _, k := range sortedKeys(blocks) {
+		if blocks[k].ForceNew {
+			return fmt.Errorf("%q specifies ForceNew which isn't supported by Ephemeral Resources", prefix+k)
+		}
+		if err := validateEphemeralTypedSchema(fmt.Sprintf("%s%s.", prefix, k), blocks[k].Attributes, blocks[k].Blocks); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a Attribute) frameworkEphemeralResourceAttribute() ephemeralschema.Attribute {
+	validators := a.frameworkValidators()
+
+	switch a.Type {
+	case AttributeTypeBool:
+		return ephemeralschema.BoolAttribute{
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+
+	case AttributeTypeFloat64:
+		return ephemeralschema.Float64Attribute{
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+
+	case AttributeTypeInt64:
+		out := ephemeralschema.Int64Attribute{
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+		if validators != nil {
+			out.Validators = []validator.Int64{validators}
+		}
+		return out
+
+	case AttributeTypeList:
+		out := ephemeralschema.ListAttribute{
+			ElementType:        frameworkValueType(a.ElementType),
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+		if validators != nil {
+			out.Validators = []validator.List{validators}
+		}
+		return out
+
+	case AttributeTypeMap:
+		out := ephemeralschema.MapAttribute{
+			ElementType:        frameworkValueType(a.ElementType),
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+		if validators != nil {
+			out.Validators = []validator.Map{validators}
+		}
+		return out
+
+	case AttributeTypeSet:
+		out := ephemeralschema.SetAttribute{
+			ElementType:        frameworkValueType(a.ElementType),
+			Required:           a.Required,
+			Optional:           a.Optional,
+			Computed:           a.Computed,
+			Sensitive:          a.Sensitive,
+			Description:        a.Description,
+			DeprecationMessage: a.Deprecated,
+		}
+		if validators != nil {
+			out.Validators = []validator.Set{validators}
+		}
+		return out
+	}
+
+	out := ephemeralschema.StringAttribute{
+		Required:           a.Required,
+		Optional:           a.Optional,
+		Computed:           a.Computed,
+		Sensitive:          a.Sensitive,
+		Description:        a.Description,
+		DeprecationMessage: a.Deprecated,
+	}
+	if validators != nil {
+		out.Validators = []validator.String{validators}
+	}
+	return out
+}
+
+func (b Block) frameworkEphemeralResourceBlock() ephemeralschema.Block {
+	nestedObject := ephemeralschema.NestedBlockObject{
+		Attributes: make(map[string]ephemeralschema.Attribute),
+		Blocks:     make(map[string]ephemeralschema.Block),
+	}
+	for k, v := range b.Attributes {
+		nestedObject.Attributes[k] = v.frameworkEphemeralResourceAttribute()
+	}
+	for k, v := range b.Blocks {
+		nestedObject.Blocks[k] = v.frameworkEphemeralResourceBlock()
+	}
+
+	sizeValidator := b.frameworkSizeValidator()
+	if b.NestingMode == BlockNestingModeSet {
+		out := ephemeralschema.SetNestedBlock{
+			NestedObject:       nestedObject,
+			Description:        b.Description,
+			DeprecationMessage: b.Deprecated,
+		}
+		if sizeValidator != nil {
+			out.Validators = []validator.Set{sizeValidator}
+		}
+		return out
+	}
+
+	out := ephemeralschema.ListNestedBlock{
+		NestedObject:       nestedObject,
+		Description:        b.Description,
+		DeprecationMessage: b.Deprecated,
+	}
+	if sizeValidator != nil {
+		out.Validators = []validator.List{sizeValidator}
+	}
+	return out
+}
+
+func (b Block) frameworkEphemeralResourceNestedAttribute() ephemeralschema.Attribute {
+	nestedObject := ephemeralschema.NestedAttributeObject{
+		Attributes: make(map[string]ephemeralschema.Attribute),
+	}
+	for k, v := range b.Attributes {
+		nestedObject.Attributes[k] = v.frameworkEphemeralResourceAttribute()
+	}
+	for k, v := range b.Blocks {
+		nestedObject.Attributes[k] = v.frameworkEphemeralResourceNestedAttribute()
+	}
+
+	if b.NestingMode == BlockNestingModeSet {
+		return ephemeralschema.SetNestedAttribute{
+			NestedObject:       nestedObject,
+			Computed:           true,
+			Description:        b.Description,
+			DeprecationMessage: b.Deprecated,
+		}
+	}
+
+	return ephemeralschema.ListNestedAttribute{
+		NestedObject:       nestedObject,
+		Computed:           true,
+		Description:        b.Description,
+		DeprecationMessage: b.Deprecated,
+	}
+}
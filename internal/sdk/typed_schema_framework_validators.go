@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ validator.String = attributeValidationValidator{}
+	_ validator.Int64  = attributeValidationValidator{}
+	_ validator.List   = attributeValidationValidator{}
+	_ validator.Map    = attributeValidationValidator{}
+	_ validator.Set    = attributeValidationValidator{}
+
+	_ validator.List = blockSizeValidator{}
+	_ validator.Set  = blockSizeValidator{}
+)
+
+// attributeValidationValidator is a Plugin Framework validator which applies the AttributeValidation
+// to a value - or to each element within a collection
+type attributeValidationValidator struct {
+	validation AttributeValidation
+}
+
+func (v attributeValidationValidator) Description(_ context.Context) string {
+	conditions := make([]string, 0)
+	if len(v.validation.AllowedValues) > 0 {
+		conditions = append(conditions, fmt.Sprintf("be one of %s", strings.Join(v.validation.AllowedValues, ", ")))
+	}
+	if v.validation.NotEmpty {
+		conditions = append(conditions, "not be empty")
+	}
+	if v.validation.MinLength > 0 {
+		conditions = append(conditions, fmt.Sprintf("be at least %d characters", v.validation.MinLength))
+	}
+	if v.validation.MaxLength > 0 {
+		conditions = append(conditions, fmt.Sprintf("be at most %d characters", v.validation.MaxLength))
+	}
+	if v.validation.Pattern != "" {
+		conditions = append(conditions, fmt.Sprintf("match the regular expression %q", v.validation.Pattern))
+	}
+	if v.validation.Minimum != nil {
+		conditions = append(conditions, fmt.Sprintf("be at least %d", *v.validation.Minimum))
+	}
+	if v.validation.Maximum != nil {
+		conditions = append(conditions, fmt.Sprintf("be at most %d", *v.validation.Maximum))
+	}
+
+	return fmt.Sprintf("value must %s", strings.Join(conditions, " and "))
+}
+
+func (v attributeValidationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v attributeValidationValidator) ValidateString(_ context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	response.Diagnostics.Append(v.validateValue(request.Path, request.ConfigValue)...)
+}
+
+func (v attributeValidationValidator) ValidateInt64(_ context.Context, request validator.Int64Request, response *validator.Int64Response) {
+	response.Diagnostics.Append(v.validateValue(request.Path, request.ConfigValue)...)
+}
+
+func (v attributeValidationValidator) ValidateList(_ context.Context, request validator.ListRequest, response *validator.ListResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+	for i, element := range request.ConfigValue.Elements() {
+		response.Diagnostics.Append(v.validateValue(request.Path.AtListIndex(i), element)...)
+	}
+}
+
+func (v attributeValidationValidator) ValidateMap(_ context.Context, request validator.MapRequest, response *validator.MapResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+	for key, element := range request.ConfigValue.Elements() {
+		response.Diagnostics.Append(v.validateValue(request.Path.AtMapKey(key), element)...)
+	}
+}
+
+func (v attributeValidationValidator) ValidateSet(_ context.Context, request validator.SetRequest, response *validator.SetResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+	for _, element := range request.ConfigValue.Elements() {
+		response.Diagnostics.Append(v.validateValue(request.Path.AtSetValue(element), element)...)
+	}
+}
+
+func (v attributeValidationValidator) validateValue(valuePath path.Path, value attr.Value) (diags diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+
+	switch val := value.(type) {
+	case types.String:
+		s := val.ValueString()
+		if len(v.validation.AllowedValues) > 0 {
+			allowed := false
+			for _, allowedValue := range v.validation.AllowedValues {
+				if s == allowedValue {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				diags.AddAttributeError(valuePath, "Invalid Attribute Value", fmt.Sprintf("expected the value to be one of [%s] but got %q", strings.Join(v.validation.AllowedValues, ", "), s))
+			}
+		}
+		if v.validation.NotEmpty && s == "" {
+			diags.AddAttributeError(valuePath, "Invalid Attribute Value", "expected the value not to be an empty string")
+		}
+		if v.validation.MinLength > 0 && len(s) < v.validation.MinLength {
+			diags.AddAttributeError(valuePath, "Invalid Attribute Value", fmt.Sprintf("expected the value to be at least %d characters but got %d", v.validation.MinLength, len(s)))
+		}
+		if v.validation.MaxLength > 0 && len(s) > v.validation.MaxLength {
+			diags.AddAttributeError(valuePath, "Invalid Attribute Value", fmt.Sprintf("expected the value to be at most %d characters but got %d", v.validation.MaxLength, len(s)))
+		}
+		if v.validation.Pattern != "" && !regexp.MustCompile(v.validation.Pattern).MatchString(s) {
+			diags.AddAttributeError(valuePath, "Invalid Attribute Value", fmt.Sprintf("expected the value %q to match the regular expression %q", s, v.validation.Pattern))
+		}
+
+	case types.Int64:
+		i := val.ValueInt64()
+		if v.validation.Minimum != nil && i < *v.validation.Minimum {
+			diags.AddAttributeError(valuePath, "Invalid Attribute Value", fmt.Sprintf("expected the value to be at least %d but got %d", *v.validation.Minimum, i))
+		}
+		if v.validation.Maximum != nil && i > *v.validation.Maximum {
+			diags.AddAttributeError(valuePath, "Invalid Attribute Value", fmt.Sprintf("expected the value to be at most %d but got %d", *v.validation.Maximum, i))
+		}
+	}
+
+	return
+}
+
+// blockSizeValidator is a Plugin Framework validator which validates the number of items within a Block,
+// since the Plugin Framework doesn't support MinItems and MaxItems on Blocks directly
+type blockSizeValidator struct {
+	minItems int
+	maxItems int
+}
+
+func (v blockSizeValidator) Description(_ context.Context) string {
+	if v.maxItems == 0 {
+		return fmt.Sprintf("block must contain at least %d items", v.minItems)
+	}
+	return fmt.Sprintf("block must contain between %d and %d items", v.minItems, v.maxItems)
+}
+
+func (v blockSizeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v blockSizeValidator) ValidateList(_ context.Context, request validator.ListRequest, response *validator.ListResponse) {
+	if request.ConfigValue.IsUnknown() {
+		return
+	}
+	response.Diagnostics.Append(v.validateSize(request.Path, len(request.ConfigValue.Elements()))...)
+}
+
+func (v blockSizeValidator) ValidateSet(_ context.Context, request validator.SetRequest, response *validator.SetResponse) {
+	if request.ConfigValue.IsUnknown() {
+		return
+	}
+	response.Diagnostics.Append(v.validateSize(request.Path, len(request.ConfigValue.Elements()))...)
+}
+
+func (v blockSizeValidator) validateSize(blockPath path.Path, count int) (diags diag.Diagnostics) {
+	if count < v.minItems || (v.maxItems > 0 && count > v.maxItems) {
+		diags.AddAttributeError(blockPath, "Invalid Block", fmt.Sprintf("%s but got %d", v.Description(context.Background()), count))
+	}
+	return
+}
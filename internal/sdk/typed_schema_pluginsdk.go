@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+// PluginSdkSchema returns the Plugin SDKv2 Arguments and Attributes for the specified Data Source or
+// Resource - which are either defined using the Plugin SDKv2 types, or rendered from the Typed Schema
+func PluginSdkSchema(input resourceBase) (arguments map[string]*schema.Schema, attributes map[string]*schema.Schema, err error) {
+	if v, ok := input.(resourceWithPluginSdkSchema); ok {
+		return v.Arguments(), v.Attributes(), nil
+	}
+
+	if v, ok := input.(resourceWithTypedSchema); ok {
+		arguments, err = v.TypedArguments().PluginSdkSchema()
+		if err != nil {
+			return nil, nil, fmt.Errorf("rendering the Arguments: %+v", err)
+		}
+		attributes, err = v.TypedAttributes().PluginSdkSchema()
+		if err != nil {
+			return nil, nil, fmt.Errorf("rendering the Attributes: %+v", err)
+		}
+		return arguments, attributes, nil
+	}
+
+	return nil, nil, fmt.Errorf("%q must define either a Plugin SDKv2 schema (Arguments/Attributes) or a Typed Schema (TypedArguments/TypedAttributes)", input.ResourceType())
+}
+
+// PluginSdkSchema renders the TypedSchema into the equivalent Plugin SDKv2 schema
+func (s TypedSchema) PluginSdkSchema() (map[string]*schema.Schema, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	return pluginSdkSchemaForTypedSchema(s.Attributes, s.Blocks), nil
+}
+
+func pluginSdkSchemaForTypedSchema(attributes map[string]Attribute, blocks map[string]Block) map[string]*schema.Schema {
+	out := make(map[string]*schema.Schema, len(attributes)+len(blocks))
+	for k, v := range attributes {
+		out[k] = v.pluginSdkSchema()
+	}
+	for k, v := range blocks {
+		out[k] = v.pluginSdkSchema()
+	}
+	return out
+}
+
+func (a Attribute) pluginSdkSchema() *schema.Schema {
+	out := &schema.Schema{
+		Required:    a.Required,
+		Optional:    a.Optional,
+		Computed:    a.Computed,
+		ForceNew:    a.ForceNew,
+		Sensitive:   a.Sensitive,
+		Description: a.Description,
+		Deprecated:  a.Deprecated,
+	}
+
+	if a.Type.isPrimitive() {
+		out.Type = pluginSdkValueType(a.Type)
+		out.ValidateFunc = a.Validation.pluginSdkValidateFunc()
+		out.Default = a.Default
+		if v, ok := a.Default.(int64); ok {
+			// the Plugin SDKv2 represents TypeInt as an int
+			out.Default = int(v)
+		}
+		return out
+	}
+
+	switch a.Type {
+	case AttributeTypeList:
+		out.Type = schema.TypeList
+	case AttributeTypeMap:
+		out.Type = schema.TypeMap
+	case AttributeTypeSet:
+		out.Type = schema.TypeSet
+	}
+	out.Elem = &schema.Schema{
+		Type:         pluginSdkValueType(a.ElementType),
+		ValidateFunc: a.Validation.pluginSdkValidateFunc(),
+	}
+
+	return out
+}
+
+func (b Block) pluginSdkSchema() *schema.Schema {
+	out := &schema.Schema{
+		Required:    b.Required,
+		Optional:    b.Optional,
+		Computed:    b.Computed,
+		ForceNew:    b.ForceNew,
+		MinItems:    b.MinItems,
+		MaxItems:    b.MaxItems,
+		Description: b.Description,
+		Deprecated:  b.Deprecated,
+		Elem: &schema.Resource{
+			Schema: pluginSdkSchemaForTypedSchema(b.Attributes, b.Blocks),
+		},
+	}
+
+	switch b.NestingMode {
+	case BlockNestingModeList:
+		out.Type = schema.TypeList
+	case BlockNestingModeSet:
+		out.Type = schema.TypeSet
+	}
+
+	return out
+}
+
+func pluginSdkValueType(input AttributeType) schema.ValueType {
+	switch input {
+	case AttributeTypeBool:
+		return schema.TypeBool
+	case AttributeTypeFloat64:
+		return schema.TypeFloat
+	case AttributeTypeInt64:
+		return schema.TypeInt
+	}
+
+	return schema.TypeString
+}
+
+func (v *AttributeValidation) pluginSdkValidateFunc() schema.SchemaValidateFunc { //nolint:staticcheck
+	if v == nil {
+		return nil
+	}
+
+	validators := make([]schema.SchemaValidateFunc, 0) //nolint:staticcheck
+	if len(v.AllowedValues) > 0 {
+		validators = append(validators, validation.StringInSlice(v.AllowedValues, false))
+	}
+	if v.NotEmpty {
+		validators = append(validators, validation.StringIsNotEmpty)
+	}
+	if v.MinLength > 0 || v.MaxLength > 0 {
+		maxLength := v.MaxLength
+		if maxLength == 0 {
+			maxLength = int(^uint(0) >> 1)
+		}
+		validators = append(validators, validation.StringLenBetween(v.MinLength, maxLength))
+	}
+	if v.Pattern != "" {
+		validators = append(validators, validation.StringMatch(regexp.MustCompile(v.Pattern), fmt.Sprintf("must match the regular expression %q", v.Pattern)))
+	}
+	if v.Minimum != nil {
+		validators = append(validators, validation.IntAtLeast(int(*v.Minimum)))
+	}
+	if v.Maximum != nil {
+		validators = append(validators, validation.IntAtMost(int(*v.Maximum)))
+	}
+
+	switch len(validators) {
+	case 0:
+		return nil
+	case 1:
+		return validators[0]
+	}
+	return validation.All(validators...)
+}
@@ -0,0 +1,425 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	pluginsdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type typedSchemaTestModel struct {
+	Name     string               `tfschema:"name"`
+	Count    int64                `tfschema:"instance_count"`
+	Enabled  bool                 `tfschema:"enabled"`
+	Tags     map[string]string    `tfschema:"tags"`
+	Zones    []string             `tfschema:"zones"`
+	Settings []typedSchemaSetting `tfschema:"setting"`
+	Endpoint string               `tfschema:"endpoint"`
+}
+
+type typedSchemaSetting struct {
+	Key   string `tfschema:"key"`
+	Value string `tfschema:"value"`
+}
+
+func typedSchemaTestArguments() TypedSchema {
+	return TypedSchema{
+		Attributes: map[string]Attribute{
+			"name": {
+				Type:       AttributeTypeString,
+				Required:   true,
+				ForceNew:   true,
+				Validation: &AttributeValidation{NotEmpty: true, MaxLength: 24},
+			},
+			"instance_count": {
+				Type:       AttributeTypeInt64,
+				Optional:   true,
+				Default:    int64(2),
+				Validation: &AttributeValidation{Minimum: pointer.To(int64(1)), Maximum: pointer.To(int64(10))},
+			},
+			"enabled": {
+				Type:     AttributeTypeBool,
+				Optional: true,
+			},
+			"tags": {
+				Type:        AttributeTypeMap,
+				ElementType: AttributeTypeString,
+				Optional:    true,
+			},
+			"zones": {
+				Type:        AttributeTypeSet,
+				ElementType: AttributeTypeString,
+				Optional:    true,
+				Validation:  &AttributeValidation{AllowedValues: []string{"1", "2", "3"}},
+			},
+		},
+		Blocks: map[string]Block{
+			"setting": {
+				NestingMode: BlockNestingModeList,
+				Optional:    true,
+				MaxItems:    2,
+				Attributes: map[string]Attribute{
+					"key": {
+						Type:     AttributeTypeString,
+						Required: true,
+					},
+					"value": {
+						Type:     AttributeTypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func typedSchemaTestAttributes() TypedSchema {
+	return TypedSchema{
+		Attributes: map[string]Attribute{
+			"endpoint": {
+				Type: AttributeTypeString,
+			},
+		},
+	}
+}
+
+func TestTypedSchema_Validate(t *testing.T) {
+	testData := []struct {
+		Name        string
+		Input       TypedSchema
+		ExpectError bool
+	}{
+		{
+			Name:  "Valid",
+			Input: typedSchemaTestArguments(),
+		},
+		{
+			Name: "Required and Optional",
+			Input: TypedSchema{
+				Attributes: map[string]Attribute{
+					"name": {Type: AttributeTypeString, Required: true, Optional: true},
+				},
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Collection without an Element Type",
+			Input: TypedSchema{
+				Attributes: map[string]Attribute{
+					"zones": {Type: AttributeTypeList, Optional: true},
+				},
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Default of the wrong type",
+			Input: TypedSchema{
+				Attributes: map[string]Attribute{
+					"instance_count": {Type: AttributeTypeInt64, Optional: true, Default: "two"},
+				},
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "String Validation on an Int64",
+			Input: TypedSchema{
+				Attributes: map[string]Attribute{
+					"instance_count": {Type: AttributeTypeInt64, Optional: true, Validation: &AttributeValidation{NotEmpty: true}},
+				},
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Invalid Pattern",
+			Input: TypedSchema{
+				Attributes: map[string]Attribute{
+					"name": {Type: AttributeTypeString, Required: true, Validation: &AttributeValidation{Pattern: "^[a-z+$"}},
+				},
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Empty Block",
+			Input: TypedSchema{
+				Blocks: map[string]Block{
+					"setting": {NestingMode: BlockNestingModeList, Optional: true},
+				},
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Invalid nested Attribute",
+			Input: TypedSchema{
+				Blocks: map[string]Block{
+					"setting": {
+						NestingMode: BlockNestingModeSet,
+						Optional:    true,
+						Attributes: map[string]Attribute{
+							"key": {Type: AttributeTypeString},
+						},
+					},
+				},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := v.Input.Validate()
+		if v.ExpectError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.ExpectError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestTypedSchema_PluginSdkSchema(t *testing.T) {
+	actual, err := typedSchemaTestArguments().PluginSdkSchema()
+	if err != nil {
+		t.Fatalf("rendering: %+v", err)
+	}
+
+	if err := pluginsdkschema.InternalMap(actual).InternalValidate(nil); err != nil {
+		t.Fatalf("validating the rendered schema: %+v", err)
+	}
+
+	name := actual["name"]
+	if name.Type != pluginsdkschema.TypeString || !name.Required || !name.ForceNew || name.ValidateFunc == nil {
+		t.Fatalf("unexpected schema for `name`: %+v", name)
+	}
+	if _, errs := name.ValidateFunc("", "name"); len(errs) == 0 {
+		t.Fatalf("expected an empty `name` to fail validation")
+	}
+
+	count := actual["instance_count"]
+	if count.Type != pluginsdkschema.TypeInt || count.Default != 2 {
+		t.Fatalf("unexpected schema for `instance_count`: %+v", count)
+	}
+	if _, errs := count.ValidateFunc(11, "instance_count"); len(errs) == 0 {
+		t.Fatalf("expected a `instance_count` of 11 to fail validation")
+	}
+
+	zones := actual["zones"]
+	if zones.Type != pluginsdkschema.TypeSet || zones.Elem.(*pluginsdkschema.Schema).Type != pluginsdkschema.TypeString {
+		t.Fatalf("unexpected schema for `zones`: %+v", zones)
+	}
+
+	setting := actual["setting"]
+	if setting.Type != pluginsdkschema.TypeList || setting.MaxItems != 2 {
+		t.Fatalf("unexpected schema for `setting`: %+v", setting)
+	}
+	if _, ok := setting.Elem.(*pluginsdkschema.Resource).Schema["key"]; !ok {
+		t.Fatalf("expected `setting` to contain `key`")
+	}
+}
+
+func TestFrameworkResourceSchema(t *testing.T) {
+	ctx := context.Background()
+
+	actual, err := FrameworkResourceSchema(typedSchemaTestArguments(), typedSchemaTestAttributes())
+	if err != nil {
+		t.Fatalf("rendering: %+v", err)
+	}
+
+	if diags := actual.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("validating the rendered schema: %+v", diags)
+	}
+
+	name, ok := actual.Attributes["name"].(schema.StringAttribute)
+	if !ok || !name.Required || len(name.PlanModifiers) != 1 || len(name.Validators) != 1 {
+		t.Fatalf("unexpected schema for `name`: %+v", actual.Attributes["name"])
+	}
+
+	count, ok := actual.Attributes["instance_count"].(schema.Int64Attribute)
+	if !ok || !count.Optional || !count.Computed || count.Default == nil {
+		t.Fatalf("unexpected schema for `instance_count`: %+v", actual.Attributes["instance_count"])
+	}
+
+	endpoint, ok := actual.Attributes["endpoint"].(schema.StringAttribute)
+	if !ok || !endpoint.Computed {
+		t.Fatalf("unexpected schema for `endpoint`: %+v", actual.Attributes["endpoint"])
+	}
+
+	setting, ok := actual.Blocks["setting"].(schema.ListNestedBlock)
+	if !ok || len(setting.Validators) != 1 {
+		t.Fatalf("unexpected schema for `setting`: %+v", actual.Blocks["setting"])
+	}
+}
+
+func TestFrameworkDataSourceSchema(t *testing.T) {
+	ctx := context.Background()
+
+	attributes := typedSchemaTestAttributes()
+	attributes.Blocks = map[string]Block{
+		"instance": {
+			NestingMode: BlockNestingModeList,
+			Attributes: map[string]Attribute{
+				"id": {Type: AttributeTypeString},
+			},
+		},
+	}
+
+	actual, err := FrameworkDataSourceSchema(TypedSchema{
+		Attributes: map[string]Attribute{
+			"name": {Type: AttributeTypeString, Required: true},
+		},
+	}, attributes)
+	if err != nil {
+		t.Fatalf("rendering: %+v", err)
+	}
+
+	if diags := actual.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("validating the rendered schema: %+v", diags)
+	}
+
+	// Computed Blocks are rendered as Nested Attributes since the Plugin Framework doesn't support these
+	if _, ok := actual.Attributes["instance"]; !ok {
+		t.Fatalf("expected the Computed Block `instance` to be rendered as a Nested Attribute")
+	}
+}
+
+func TestFrameworkEphemeralResourceSchema(t *testing.T) {
+	ctx := context.Background()
+
+	arguments := typedSchemaTestArguments()
+	name := arguments.Attributes["name"]
+	name.ForceNew = false
+	arguments.Attributes["name"] = name
+	instanceCount := arguments.Attributes["instance_count"]
+	instanceCount.Default = nil
+	arguments.Attributes["instance_count"] = instanceCount
+
+	attributes := typedSchemaTestAttributes()
+	attributes.Blocks = map[string]Block{
+		"instance": {
+			NestingMode: BlockNestingModeList,
+			Attributes: map[string]Attribute{
+				"id": {Type: AttributeTypeString},
+			},
+		},
+	}
+
+	actual, err := FrameworkEphemeralResourceSchema(arguments, attributes)
+	if err != nil {
+		t.Fatalf("rendering: %+v", err)
+	}
+
+	if diags := actual.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("validating the rendered schema: %+v", diags)
+	}
+
+	if _, ok := actual.Blocks["setting"]; !ok {
+		t.Fatalf("expected the Block `setting` to be rendered as a Block")
+	}
+	if _, ok := actual.Attributes["instance"]; !ok {
+		t.Fatalf("expected the Computed Block `instance` to be rendered as a Nested Attribute")
+	}
+
+	// Defaults and ForceNew aren't supported since Ephemeral Resources have no plan
+	if _, err := FrameworkEphemeralResourceSchema(typedSchemaTestArguments(), typedSchemaTestAttributes()); err == nil {
+		t.Fatalf("expected an error when a Default or ForceNew is specified but didn't get one")
+	}
+}
+
+func TestFrameworkValue_RoundTrip(t *testing.T) {
+	input := typedSchemaTestModel{
+		Name:    "example",
+		Count:   3,
+		Enabled: true,
+		Tags: map[string]string{
+			"env": "test",
+		},
+		Zones: []string{"1", "2"},
+		Settings: []typedSchemaSetting{
+			{Key: "first", Value: "one"},
+			{Key: "second"},
+		},
+		Endpoint: "https://example.com",
+	}
+
+	encoded, err := EncodeFrameworkValue(typedSchemaTestArguments(), typedSchemaTestAttributes(), &input)
+	if err != nil {
+		t.Fatalf("encoding: %+v", err)
+	}
+
+	var actual typedSchemaTestModel
+	if err := DecodeFrameworkValue(typedSchemaTestArguments(), typedSchemaTestAttributes(), *encoded, &actual); err != nil {
+		t.Fatalf("decoding: %+v", err)
+	}
+
+	if diff := cmp.Diff(input, actual); diff != "" {
+		t.Fatalf("unexpected difference after round-tripping: %s", diff)
+	}
+}
+
+func TestDecodeFrameworkValue_NullValues(t *testing.T) {
+	combined, err := combineTypedSchema(typedSchemaTestArguments(), typedSchemaTestAttributes())
+	if err != nil {
+		t.Fatalf("combining: %+v", err)
+	}
+	terraformType := frameworkObjectType(combined.Attributes, combined.Blocks).TerraformType(context.Background()).(tftypes.Object)
+
+	values := make(map[string]tftypes.Value)
+	for k, v := range terraformType.AttributeTypes {
+		values[k] = tftypes.NewValue(v, nil)
+	}
+	values["name"] = tftypes.NewValue(tftypes.String, "example")
+	values["endpoint"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	var actual typedSchemaTestModel
+	if err := DecodeFrameworkValue(typedSchemaTestArguments(), typedSchemaTestAttributes(), tftypes.NewValue(terraformType, values), &actual); err != nil {
+		t.Fatalf("decoding: %+v", err)
+	}
+
+	expected := typedSchemaTestModel{
+		Name: "example",
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Fatalf("unexpected difference: %s", diff)
+	}
+}
+
+func TestValidateModelObjectAgainstTypedSchema(t *testing.T) {
+	resource := typedSchemaTestResource{}
+	if err := validateModelObjectAgainstTypedSchema(&typedSchemaTestModel{}, resource); err != nil {
+		t.Fatalf("expected the model to be valid but got: %+v", err)
+	}
+
+	type invalidModel struct {
+		Name    string `tfschema:"name"`
+		Missing string `tfschema:"missing"`
+	}
+	if err := validateModelObjectAgainstTypedSchema(&invalidModel{}, resource); err == nil {
+		t.Fatalf("expected an error for a `tfschema` tag which doesn't exist in the Typed Schema")
+	}
+
+	type invalidNestedModel struct {
+		Settings []struct {
+			Missing string `tfschema:"missing"`
+		} `tfschema:"setting"`
+	}
+	if err := validateModelObjectAgainstTypedSchema(&invalidNestedModel{}, resource); err == nil {
+		t.Fatalf("expected an error for a nested `tfschema` tag which doesn't exist in the Typed Schema")
+	}
+}
+
+type typedSchemaTestResource struct{}
+
+func (typedSchemaTestResource) TypedArguments() TypedSchema {
+	return typedSchemaTestArguments()
+}
+
+func (typedSchemaTestResource) TypedAttributes() TypedSchema {
+	return typedSchemaTestAttributes()
+}
@@ -0,0 +1,349 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// DecodeFrameworkValue decodes the Plugin Framework value (e.g. the raw Config, Plan or State) into the
+// specified model, using the `tfschema` struct tags in the same manner as Decode
+//
+// The Typed Schema is used to convert the value into the same types as the Plugin SDKv2 returns, such
+// that the same model can be used regardless of which is serving the Resource.
+func DecodeFrameworkValue(arguments TypedSchema, attributes TypedSchema, input tftypes.Value, model interface{}) error {
+	combined, err := combineTypedSchema(arguments, attributes)
+	if err != nil {
+		return fmt.Errorf("building Typed Schema: %+v", err)
+	}
+
+	values, err := decodeFrameworkObject(combined.Attributes, combined.Blocks, input)
+	if err != nil {
+		return err
+	}
+
+	return decodeReflectedType(model, frameworkStateRetriever(values), pluginSdkSchemaForTypedSchema(combined.Attributes, combined.Blocks), NullLogger{})
+}
+
+// EncodeFrameworkValue encodes the specified model into a Plugin Framework value (e.g. to be set into the
+// raw State), using the `tfschema` struct tags in the same manner as Encode
+func EncodeFrameworkValue(arguments TypedSchema, attributes TypedSchema, model interface{}) (*tftypes.Value, error) {
+	combined, err := combineTypedSchema(arguments, attributes)
+	if err != nil {
+		return nil, fmt.Errorf("building Typed Schema: %+v", err)
+	}
+
+	if reflect.TypeOf(model).Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("need a pointer")
+	}
+
+	if err := validateModelTypeAgainstSchema("", reflect.TypeOf(model).Elem(), pluginSdkSchemaForTypedSchema(combined.Attributes, combined.Blocks)); err != nil {
+		return nil, fmt.Errorf("validating model against the Typed Schema: %+v", err)
+	}
+
+	serialized, err := recurse(reflect.TypeOf(model).Elem(), reflect.ValueOf(model).Elem(), NullLogger{})
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := encodeFrameworkObject(combined.Attributes, combined.Blocks, serialized)
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// frameworkStateRetriever exposes the decoded Plugin Framework values as a stateRetriever
+type frameworkStateRetriever map[string]interface{}
+
+func (r frameworkStateRetriever) Get(key string) interface{} {
+	return r[key]
+}
+
+func (r frameworkStateRetriever) GetOk(key string) (interface{}, bool) {
+	v, ok := r[key]
+	if !ok || v == nil {
+		return v, false
+	}
+	return v, !reflect.ValueOf(v).IsZero()
+}
+
+func (r frameworkStateRetriever) GetOkExists(key string) (interface{}, bool) {
+	v, ok := r[key]
+	return v, ok
+}
+
+func decodeFrameworkObject(attributes map[string]Attribute, blocks map[string]Block, input tftypes.Value) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	if input.IsNull() || !input.IsKnown() {
+		return out, nil
+	}
+
+	values := make(map[string]tftypes.Value)
+	if err := input.As(&values); err != nil {
+		return nil, fmt.Errorf("converting the value to an object: %+v", err)
+	}
+
+	for k, v := range attributes {
+		value, ok := values[k]
+		if !ok || value.IsNull() || !value.IsKnown() {
+			continue
+		}
+
+		decoded, err := decodeFrameworkAttribute(v, value)
+		if err != nil {
+			return nil, fmt.Errorf("decoding %q: %+v", k, err)
+		}
+		out[k] = decoded
+	}
+
+	for k, v := range blocks {
+		value, ok := values[k]
+		if !ok || value.IsNull() || !value.IsKnown() {
+			continue
+		}
+
+		items := make([]tftypes.Value, 0)
+		if err := value.As(&items); err != nil {
+			return nil, fmt.Errorf("decoding %q: %+v", k, err)
+		}
+
+		decoded := make([]interface{}, 0, len(items))
+		for i, item := range items {
+			nested, err := decodeFrameworkObject(v.Attributes, v.Blocks, item)
+			if err != nil {
+				return nil, fmt.Errorf("decoding %q.%d: %+v", k, i, err)
+			}
+			decoded = append(decoded, nested)
+		}
+		out[k] = decoded
+	}
+
+	return out, nil
+}
+
+func decodeFrameworkAttribute(attribute Attribute, input tftypes.Value) (interface{}, error) {
+	if attribute.Type.isPrimitive() {
+		return decodeFrameworkPrimitive(attribute.Type, input)
+	}
+
+	if attribute.Type == AttributeTypeMap {
+		values := make(map[string]tftypes.Value)
+		if err := input.As(&values); err != nil {
+			return nil, err
+		}
+
+		out := make(map[string]interface{}, len(values))
+		for k, v := range values {
+			decoded, err := decodeFrameworkPrimitive(attribute.ElementType, v)
+			if err != nil {
+				return nil, fmt.Errorf("decoding the key %q: %+v", k, err)
+			}
+			out[k] = decoded
+		}
+		return out, nil
+	}
+
+	values := make([]tftypes.Value, 0)
+	if err := input.As(&values); err != nil {
+		return nil, err
+	}
+
+	out := make([]interface{}, 0, len(values))
+	for i, v := range values {
+		decoded, err := decodeFrameworkPrimitive(attribute.ElementType, v)
+		if err != nil {
+			return nil, fmt.Errorf("decoding the element %d: %+v", i, err)
+		}
+		out = append(out, decoded)
+	}
+	return out, nil
+}
+
+func decodeFrameworkPrimitive(attributeType AttributeType, input tftypes.Value) (interface{}, error) {
+	if input.IsNull() || !input.IsKnown() {
+		return nil, nil
+	}
+
+	switch attributeType {
+	case AttributeTypeBool:
+		var out bool
+		err := input.As(&out)
+		return out, err
+
+	case AttributeTypeFloat64:
+		var out big.Float
+		if err := input.As(&out); err != nil {
+			return nil, err
+		}
+		v, _ := out.Float64()
+		return v, nil
+
+	case AttributeTypeInt64:
+		var out big.Float
+		if err := input.As(&out); err != nil {
+			return nil, err
+		}
+		// the Plugin SDKv2 represents TypeInt as an int, which the models expect
+		v, _ := out.Int64()
+		return int(v), nil
+	}
+
+	var out string
+	err := input.As(&out)
+	return out, err
+}
+
+func encodeFrameworkObject(attributes map[string]Attribute, blocks map[string]Block, input map[string]interface{}) (tftypes.Value, error) {
+	objectType := frameworkObjectType(attributes, blocks).TerraformType(context.Background()).(tftypes.Object)
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for k, v := range attributes {
+		encoded, err := encodeFrameworkAttribute(v, objectType.AttributeTypes[k], input[k])
+		if err != nil {
+			return tftypes.Value{}, fmt.Errorf("encoding %q: %+v", k, err)
+		}
+		values[k] = encoded
+	}
+
+	for k, v := range blocks {
+		blockType := objectType.AttributeTypes[k]
+		items, err := interfaceToSlice(input[k])
+		if err != nil {
+			return tftypes.Value{}, fmt.Errorf("encoding %q: %+v", k, err)
+		}
+
+		encodedItems := make([]tftypes.Value, 0, len(items))
+		for i, item := range items {
+			nested, ok := item.(map[string]interface{})
+			if !ok {
+				return tftypes.Value{}, fmt.Errorf("encoding %q.%d: expected an object but got %T", k, i, item)
+			}
+			encoded, err := encodeFrameworkObject(v.Attributes, v.Blocks, nested)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("encoding %q.%d: %+v", k, i, err)
+			}
+			encodedItems = append(encodedItems, encoded)
+		}
+		values[k] = tftypes.NewValue(blockType, encodedItems)
+	}
+
+	return tftypes.NewValue(objectType, values), nil
+}
+
+func encodeFrameworkAttribute(attribute Attribute, valueType tftypes.Type, input interface{}) (tftypes.Value, error) {
+	if input == nil {
+		return tftypes.NewValue(valueType, nil), nil
+	}
+
+	if attribute.Type.isPrimitive() {
+		return encodeFrameworkPrimitive(attribute.Type, valueType, input)
+	}
+
+	var elementType tftypes.Type
+	switch t := valueType.(type) {
+	case tftypes.List:
+		elementType = t.ElementType
+	case tftypes.Map:
+		elementType = t.ElementType
+	case tftypes.Set:
+		elementType = t.ElementType
+	}
+
+	if attribute.Type == AttributeTypeMap {
+		v := reflect.ValueOf(input)
+		if v.Kind() != reflect.Map {
+			return tftypes.Value{}, fmt.Errorf("expected a map but got %T", input)
+		}
+
+		values := make(map[string]tftypes.Value, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			encoded, err := encodeFrameworkPrimitive(attribute.ElementType, elementType, iter.Value().Interface())
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("encoding the key %q: %+v", iter.Key().String(), err)
+			}
+			values[iter.Key().String()] = encoded
+		}
+		return tftypes.NewValue(valueType, values), nil
+	}
+
+	items, err := interfaceToSlice(input)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	values := make([]tftypes.Value, 0, len(items))
+	for i, item := range items {
+		encoded, err := encodeFrameworkPrimitive(attribute.ElementType, elementType, item)
+		if err != nil {
+			return tftypes.Value{}, fmt.Errorf("encoding the element %d: %+v", i, err)
+		}
+		values = append(values, encoded)
+	}
+	return tftypes.NewValue(valueType, values), nil
+}
+
+func encodeFrameworkPrimitive(attributeType AttributeType, valueType tftypes.Type, input interface{}) (tftypes.Value, error) {
+	if input == nil {
+		return tftypes.NewValue(valueType, nil), nil
+	}
+
+	v := reflect.ValueOf(input)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return tftypes.NewValue(valueType, nil), nil
+		}
+		v = v.Elem()
+	}
+
+	switch attributeType {
+	case AttributeTypeBool:
+		if v.Kind() == reflect.Bool {
+			return tftypes.NewValue(valueType, v.Bool()), nil
+		}
+
+	case AttributeTypeFloat64:
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return tftypes.NewValue(valueType, big.NewFloat(v.Float())), nil
+		}
+
+	case AttributeTypeInt64:
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return tftypes.NewValue(valueType, new(big.Float).SetInt64(v.Int())), nil
+		}
+
+	case AttributeTypeString:
+		if v.Kind() == reflect.String {
+			return tftypes.NewValue(valueType, v.String()), nil
+		}
+	}
+
+	return tftypes.Value{}, fmt.Errorf("expected a value of type %q but got %T", attributeType, input)
+}
+
+func interfaceToSlice(input interface{}) ([]interface{}, error) {
+	if input == nil {
+		return []interface{}{}, nil
+	}
+
+	v := reflect.ValueOf(input)
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("expected a slice but got %T", input)
+	}
+
+	out := make([]interface{}, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		out = append(out, v.Index(i).Interface())
+	}
+	return out, nil
+}
@@ -6,6 +6,7 @@ package sdk
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -28,7 +29,12 @@ func NewDataSourceWrapper(dataSource DataSource) DataSourceWrapper {
 
 // DataSource returns the Terraform Plugin SDK type for this DataSource implementation
 func (dw *DataSourceWrapper) DataSource() (*schema.Resource, error) {
-	resourceSchema, err := combineSchema(dw.dataSource.Arguments(), dw.dataSource.Attributes())
+	arguments, attributes, err := PluginSdkSchema(dw.dataSource)
+	if err != nil {
+		return nil, fmt.Errorf("building Schema: %+v", err)
+	}
+
+	resourceSchema, err := combineSchema(arguments, attributes)
 	if err != nil {
 		return nil, fmt.Errorf("building Schema: %+v", err)
 	}
@@ -38,6 +44,16 @@ func (dw *DataSourceWrapper) DataSource() (*schema.Resource, error) {
 		if err := ValidateModelObject(modelObj); err != nil {
 			return nil, fmt.Errorf("validating model for %q: %+v", dw.dataSource.ResourceType(), err)
 		}
+
+		if v, ok := dw.dataSource.(resourceWithTypedSchema); ok {
+			if err := validateModelObjectAgainstTypedSchema(modelObj, v); err != nil {
+				return nil, fmt.Errorf("validating model for %q: %+v", dw.dataSource.ResourceType(), err)
+			}
+		}
+
+		if err := validateModelTypeAgainstSchema("", reflect.TypeOf(modelObj).Elem(), *resourceSchema); err != nil {
+			return nil, fmt.Errorf("validating model for %q: %+v", dw.dataSource.ResourceType(), err)
+		}
 	}
 
 	d := func(duration time.Duration) *time.Duration {
@@ -47,7 +63,7 @@ func (dw *DataSourceWrapper) DataSource() (*schema.Resource, error) {
 	resource := schema.Resource{
 		Schema: *resourceSchema,
 		ReadContext: dw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
-			metaData := runArgs(d, meta, dw.logger)
+			metaData := runArgs(d, meta, *resourceSchema, dw.logger)
 			return dw.dataSource.Read().Func(ctx, metaData)
 		}),
 		Timeouts: &schema.ResourceTimeout{
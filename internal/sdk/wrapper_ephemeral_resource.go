@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+var _ ephemeral.EphemeralResourceWithConfigure = &EphemeralResourceWrapper{}
+
+// EphemeralResourceWrapper is a wrapper for converting an EphemeralResource implementation
+// into the object used by the Terraform Plugin Framework
+type EphemeralResourceWrapper struct {
+	ephemeralResource EphemeralResource
+	client            *clients.Client
+}
+
+// NewEphemeralResourceWrapper returns a function returning an EphemeralResourceWrapper for this
+// Ephemeral Resource implementation, as expected by the Plugin Framework Provider
+func NewEphemeralResourceWrapper(ephemeralResource EphemeralResource) func() ephemeral.EphemeralResource {
+	return func() ephemeral.EphemeralResource {
+		return &EphemeralResourceWrapper{
+			ephemeralResource: ephemeralResource,
+		}
+	}
+}
+
+func (ew *EphemeralResourceWrapper) Metadata(_ context.Context, _ ephemeral.MetadataRequest, response *ephemeral.MetadataResponse) {
+	response.TypeName = ew.ephemeralResource.ResourceType()
+}
+
+func (ew *EphemeralResourceWrapper) Schema(_ context.Context, _ ephemeral.SchemaRequest, response *ephemeral.SchemaResponse) {
+	modelObj := ew.ephemeralResource.ModelObject()
+	if modelObj != nil {
+		if err := ValidateModelObject(modelObj); err != nil {
+			response.Diagnostics.AddError("validating the model", fmt.Sprintf("validating model for %q: %+v", ew.ephemeralResource.ResourceType(), err))
+			return
+		}
+		if err := validateModelObjectAgainstTypedSchema(modelObj, ew.ephemeralResource); err != nil {
+			response.Diagnostics.AddError("validating the model", fmt.Sprintf("validating model for %q: %+v", ew.ephemeralResource.ResourceType(), err))
+			return
+		}
+	}
+
+	ephemeralSchema, err := FrameworkEphemeralResourceSchema(ew.ephemeralResource.TypedArguments(), ew.ephemeralResource.TypedAttributes())
+	if err != nil {
+		response.Diagnostics.AddError("building the schema", fmt.Sprintf("building Schema for %q: %+v", ew.ephemeralResource.ResourceType(), err))
+		return
+	}
+
+	response.Schema = *ephemeralSchema
+}
+
+// Configure retrieves the Azure Providers Client, which is configured by the Plugin SDKv2 Provider
+func (ew *EphemeralResourceWrapper) Configure(_ context.Context, request ephemeral.ConfigureRequest, response *ephemeral.ConfigureResponse) {
+	// the Provider Data is unavailable during validation
+	if request.ProviderData == nil {
+		return
+	}
+
+	client, ok := request.ProviderData.(*clients.Client)
+	if !ok {
+		response.Diagnostics.AddError("configuring the ephemeral resource", fmt.Sprintf("expected the Provider Data to be a *clients.Client but got %T", request.ProviderData))
+		return
+	}
+
+	ew.client = client
+}
+
+func (ew *EphemeralResourceWrapper) Open(ctx context.Context, request ephemeral.OpenRequest, response *ephemeral.OpenResponse) {
+	if ew.client == nil {
+		response.Diagnostics.AddError("opening the ephemeral resource", fmt.Sprintf("the Provider hasn't been configured for %q", ew.ephemeralResource.ResourceType()))
+		return
+	}
+
+	open := ew.ephemeralResource.Open()
+	ctx, cancel := context.WithTimeout(ctx, open.Timeout)
+	defer cancel()
+
+	logger := &DiagnosticsLogger{}
+	result := tftypes.Value{}
+	metaData := EphemeralResourceMetaData{
+		Client:     ew.client,
+		Logger:     logger,
+		arguments:  ew.ephemeralResource.TypedArguments(),
+		attributes: ew.ephemeralResource.TypedAttributes(),
+		config:     request.Config.Raw,
+		result:     &result,
+	}
+	err := open.Func(ctx, metaData)
+
+	for _, warning := range logger.diagnostics {
+		response.Diagnostics.AddWarning(warning.Summary, warning.Detail)
+	}
+
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("opening %q", ew.ephemeralResource.ResourceType()), err.Error())
+		return
+	}
+
+	if result.Type() == nil {
+		response.Diagnostics.AddError(fmt.Sprintf("opening %q", ew.ephemeralResource.ResourceType()), "the result wasn't set using `metadata.Encode`")
+		return
+	}
+
+	response.Result.Raw = result
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+type testEphemeralResource struct{}
+
+type testEphemeralResourceModel struct {
+	Name   string  `tfschema:"name"`
+	Prefix *string `tfschema:"prefix"`
+	Secret string  `tfschema:"secret"`
+}
+
+func (testEphemeralResource) ResourceType() string {
+	return "azurerm_example"
+}
+
+func (testEphemeralResource) ModelObject() interface{} {
+	return &testEphemeralResourceModel{}
+}
+
+func (testEphemeralResource) TypedArguments() TypedSchema {
+	return TypedSchema{
+		Attributes: map[string]Attribute{
+			"name": {
+				Type:     AttributeTypeString,
+				Required: true,
+			},
+			"prefix": {
+				Type:     AttributeTypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func (testEphemeralResource) TypedAttributes() TypedSchema {
+	return TypedSchema{
+		Attributes: map[string]Attribute{
+			"secret": {
+				Type:      AttributeTypeString,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func (testEphemeralResource) Open() EphemeralResourceFunc {
+	return EphemeralResourceFunc{
+		Timeout: time.Minute,
+		Func: func(ctx context.Context, metadata EphemeralResourceMetaData) error {
+			var config testEphemeralResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return err
+			}
+
+			prefix := "default"
+			if config.Prefix != nil {
+				prefix = *config.Prefix
+			}
+			config.Secret = fmt.Sprintf("%s-%s", prefix, config.Name)
+
+			return metadata.Encode(&config)
+		},
+	}
+}
+
+func TestEphemeralResourceWrapper_Open(t *testing.T) {
+	ctx := context.Background()
+
+	wrapper := NewEphemeralResourceWrapper(testEphemeralResource{})()
+
+	schemaResponse := ephemeral.SchemaResponse{}
+	wrapper.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResponse)
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("retrieving the schema: %+v", schemaResponse.Diagnostics)
+	}
+
+	configureResponse := ephemeral.ConfigureResponse{}
+	wrapper.(ephemeral.EphemeralResourceWithConfigure).Configure(ctx, ephemeral.ConfigureRequest{ProviderData: &clients.Client{}}, &configureResponse)
+	if configureResponse.Diagnostics.HasError() {
+		t.Fatalf("configuring: %+v", configureResponse.Diagnostics)
+	}
+
+	objectType := schemaResponse.Schema.Type().TerraformType(ctx)
+	config := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"name":   tftypes.NewValue(tftypes.String, "example"),
+		"prefix": tftypes.NewValue(tftypes.String, nil),
+		"secret": tftypes.NewValue(tftypes.String, nil),
+	})
+
+	openResponse := ephemeral.OpenResponse{
+		Result: tfsdk.EphemeralResultData{
+			Schema: schemaResponse.Schema,
+		},
+	}
+	wrapper.Open(ctx, ephemeral.OpenRequest{Config: tfsdk.Config{Raw: config, Schema: schemaResponse.Schema}}, &openResponse)
+	if openResponse.Diagnostics.HasError() {
+		t.Fatalf("opening: %+v", openResponse.Diagnostics)
+	}
+
+	values := make(map[string]tftypes.Value)
+	if err := openResponse.Result.Raw.As(&values); err != nil {
+		t.Fatalf("converting the result: %+v", err)
+	}
+
+	// the Optional argument which wasn't specified must remain null, rather than the zero value
+	if !values["prefix"].IsNull() {
+		t.Fatalf("expected `prefix` to be null but got %s", values["prefix"])
+	}
+
+	var secret string
+	if err := values["secret"].As(&secret); err != nil {
+		t.Fatalf("converting `secret`: %+v", err)
+	}
+	if secret != "default-example" {
+		t.Fatalf("expected `secret` to be %q but got %q", "default-example", secret)
+	}
+}
+
+func TestEphemeralResourceWrapper_OpenUnconfigured(t *testing.T) {
+	ctx := context.Background()
+
+	wrapper := NewEphemeralResourceWrapper(testEphemeralResource{})()
+
+	openResponse := ephemeral.OpenResponse{}
+	wrapper.Open(ctx, ephemeral.OpenRequest{}, &openResponse)
+	if !openResponse.Diagnostics.HasError() {
+		t.Fatalf("expected an error when the Provider hasn't been configured but didn't get one")
+	}
+}
@@ -47,12 +47,13 @@ func combineSchema(arguments map[string]*schema.Schema, attributes map[string]*s
 	return &out, nil
 }
 
-func runArgs(d *schema.ResourceData, meta interface{}, logger Logger) ResourceMetaData {
+func runArgs(d *schema.ResourceData, meta interface{}, resourceSchema map[string]*schema.Schema, logger Logger) ResourceMetaData {
 	client := meta.(*clients.Client)
 	metaData := ResourceMetaData{
 		Client:                   client,
 		Logger:                   logger,
 		ResourceData:             d,
+		resourceSchema:           resourceSchema,
 		serializationDebugLogger: NullLogger{},
 	}
 
@@ -0,0 +1,364 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var (
+	_ list.ListResourceWithConfigure    = &ListResourceWrapper{}
+	_ list.ListResourceWithRawV5Schemas = &ListResourceWrapper{}
+)
+
+// ManagedResourceServer exposes the Resources defined within the Plugin SDKv2 Provider to the List Resources, which
+// need the Schema for the Resource being listed - and which read the Resource when Terraform requests it (for
+// example to generate the configuration for the Resources being imported)
+type ManagedResourceServer struct {
+	server tfprotov5.ProviderServer
+
+	once    sync.Once
+	schemas map[string]*tfprotov5.Schema
+	err     error
+}
+
+// NewManagedResourceServer returns a ManagedResourceServer for the Plugin SDKv2 Provider Server `server`
+func NewManagedResourceServer(server tfprotov5.ProviderServer) *ManagedResourceServer {
+	return &ManagedResourceServer{
+		server: server,
+	}
+}
+
+// schemaForResource returns the Schema for the Resource `typeName`, the schemas for all Resources are retrieved
+// (and cached) the first time this is called
+func (s *ManagedResourceServer) schemaForResource(ctx context.Context, typeName string) (*tfprotov5.Schema, error) {
+	s.once.Do(func() {
+		schemas, err := s.server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+		if err != nil {
+			s.err = fmt.Errorf("retrieving the Provider Schema: %+v", err)
+			return
+		}
+		if err := diagnosticsAsError(schemas.Diagnostics); err != nil {
+			s.err = fmt.Errorf("retrieving the Provider Schema: %+v", err)
+			return
+		}
+
+		s.schemas = schemas.ResourceSchemas
+	})
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	resourceSchema, ok := s.schemas[typeName]
+	if !ok {
+		return nil, fmt.Errorf("the Resource %q was not found", typeName)
+	}
+
+	return resourceSchema, nil
+}
+
+// readResource imports and then reads the Resource `typeName` with the ID `id`, returning the State for this Resource
+func (s *ManagedResourceServer) readResource(ctx context.Context, typeName, id string) (*tfprotov5.DynamicValue, error) {
+	imported, err := s.server.ImportResourceState(ctx, &tfprotov5.ImportResourceStateRequest{
+		TypeName: typeName,
+		ID:       id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("importing: %+v", err)
+	}
+	if err := diagnosticsAsError(imported.Diagnostics); err != nil {
+		return nil, fmt.Errorf("importing: %+v", err)
+	}
+
+	var importedResource *tfprotov5.ImportedResource
+	for _, v := range imported.ImportedResources {
+		if v.TypeName == typeName {
+			importedResource = v
+			break
+		}
+	}
+	if importedResource == nil {
+		return nil, fmt.Errorf("importing: no %q was returned", typeName)
+	}
+
+	read, err := s.server.ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName:     typeName,
+		CurrentState: importedResource.State,
+		Private:      importedResource.Private,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading: %+v", err)
+	}
+	if err := diagnosticsAsError(read.Diagnostics); err != nil {
+		return nil, fmt.Errorf("reading: %+v", err)
+	}
+	if read.NewState == nil {
+		return nil, fmt.Errorf("reading: the Resource was not found")
+	}
+
+	return read.NewState, nil
+}
+
+func diagnosticsAsError(input []*tfprotov5.Diagnostic) error {
+	errors := make([]string, 0)
+	for _, v := range input {
+		if v == nil || v.Severity != tfprotov5.DiagnosticSeverityError {
+			continue
+		}
+		message := v.Summary
+		if v.Detail != "" {
+			message = fmt.Sprintf("%s: %s", v.Summary, v.Detail)
+		}
+		errors = append(errors, message)
+	}
+
+	if len(errors) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(errors, "\n"))
+}
+
+// ListResourceWrapper is a wrapper for converting a ListResource implementation
+// into the object used by the Terraform Plugin Framework
+type ListResourceWrapper struct {
+	listResource ListResource
+	server       *ManagedResourceServer
+	client       *clients.Client
+}
+
+// NewListResourceWrapper returns a function returning a ListResourceWrapper for this List Resource
+// implementation, as expected by the Plugin Framework Provider
+func NewListResourceWrapper(listResource ListResource, server *ManagedResourceServer) func() list.ListResource {
+	return func() list.ListResource {
+		return &ListResourceWrapper{
+			listResource: listResource,
+			server:       server,
+		}
+	}
+}
+
+type listResourceFilterModel struct {
+	ResourceGroupName types.String `tfsdk:"resource_group_name"`
+	Tags              types.Map    `tfsdk:"tags"`
+}
+
+func (lw *ListResourceWrapper) Metadata(_ context.Context, _ resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = lw.listResource.ResourceType()
+}
+
+func (lw *ListResourceWrapper) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, response *list.ListResourceSchemaResponse) {
+	response.Schema = listschema.Schema{
+		Attributes: map[string]listschema.Attribute{
+			"resource_group_name": listschema.StringAttribute{
+				Optional:    true,
+				Description: "The name of the Resource Group to list the Resources within. When not specified the Resources within the Subscription are listed.",
+			},
+			"tags": listschema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "A mapping of Tags which the Resources must have assigned, with the same values, to be returned.",
+			},
+		},
+	}
+}
+
+// RawV5Schemas returns the Schema for the Resource being listed, since this is defined within the Plugin
+// SDKv2 Provider - and the Resource Identity Schema built from the Resource ID type of the List Resource
+func (lw *ListResourceWrapper) RawV5Schemas(ctx context.Context, _ list.RawV5SchemaRequest, response *list.RawV5SchemaResponse) {
+	resourceSchema, err := lw.server.schemaForResource(ctx, lw.listResource.ResourceType())
+	if err != nil {
+		// the Plugin Framework raises an error when these schemas are nil, so there's no need to surface this
+		log.Printf("[ERROR] retrieving the schema for the List Resource %q: %+v", lw.listResource.ResourceType(), err)
+		return
+	}
+
+	response.ProtoV5Schema = resourceSchema
+	response.ProtoV5IdentitySchema = identitySchemaForResourceId(lw.listResource.Identity())
+}
+
+// identitySchemaForResourceId returns the Resource Identity Schema for the Resource ID type `id`
+func identitySchemaForResourceId(id resourceids.ResourceId) *tfprotov5.ResourceIdentitySchema {
+	attributes := make([]*tfprotov5.ResourceIdentitySchemaAttribute, 0)
+	for name, attribute := range pluginsdk.ResourceIdentitySchemaFromResourceId(id) {
+		attributes = append(attributes, &tfprotov5.ResourceIdentitySchemaAttribute{
+			Name:              name,
+			Type:              tftypes.String,
+			RequiredForImport: attribute.RequiredForImport,
+			OptionalForImport: attribute.OptionalForImport,
+		})
+	}
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].Name < attributes[j].Name
+	})
+
+	return &tfprotov5.ResourceIdentitySchema{
+		IdentityAttributes: attributes,
+	}
+}
+
+// Configure retrieves the Azure Providers Client, which is configured by the Plugin SDKv2 Provider
+func (lw *ListResourceWrapper) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	// the Provider Data is unavailable during validation
+	if request.ProviderData == nil {
+		return
+	}
+
+	client, ok := request.ProviderData.(*clients.Client)
+	if !ok {
+		response.Diagnostics.AddError("configuring the list resource", fmt.Sprintf("expected the Provider Data to be a *clients.Client but got %T", request.ProviderData))
+		return
+	}
+
+	lw.client = client
+}
+
+func (lw *ListResourceWrapper) List(ctx context.Context, request list.ListRequest, stream *list.ListResultsStream) {
+	resourceType := lw.listResource.ResourceType()
+	summary := fmt.Sprintf("listing %q", resourceType)
+
+	if lw.client == nil {
+		stream.Results = listResultsError(summary, fmt.Sprintf("the Provider hasn't been configured for %q", resourceType))
+		return
+	}
+
+	var filter listResourceFilterModel
+	if diags := request.Config.Get(ctx, &filter); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	tags := make(map[string]string)
+	if diags := filter.Tags.ElementsAs(ctx, &tags, false); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	listFunc := lw.listResource.List()
+	listCtx, cancel := context.WithTimeout(ctx, listFunc.Timeout)
+	defer cancel()
+
+	logger := &DiagnosticsLogger{}
+	results, err := listFunc.Func(listCtx, ListResourceMetaData{
+		Client:            lw.client,
+		Logger:            logger,
+		ResourceGroupName: filter.ResourceGroupName.ValueString(),
+	})
+	if err != nil {
+		stream.Results = listResultsError(summary, err.Error())
+		return
+	}
+
+	results = filterListResourceResults(results, tags)
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		if len(logger.diagnostics) > 0 {
+			warnings := list.ListResult{}
+			for _, warning := range logger.diagnostics {
+				warnings.Diagnostics.AddWarning(warning.Summary, warning.Detail)
+			}
+			if !push(warnings) {
+				return
+			}
+		}
+
+		for i, item := range results {
+			if request.Limit > 0 && int64(i) >= request.Limit {
+				return
+			}
+
+			result := request.NewListResult(ctx)
+
+			values, err := pluginsdk.ResourceIdentityValues(item.ID)
+			if err != nil {
+				result.Diagnostics.AddError(summary, fmt.Sprintf("building the Resource Identity for %q: %+v", item.ID.ID(), err))
+				push(result)
+				return
+			}
+			for key, value := range values {
+				result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root(key), value)...)
+			}
+
+			result.DisplayName = item.DisplayName
+			if result.DisplayName == "" {
+				result.DisplayName = values["name"]
+			}
+
+			if request.IncludeResource && !result.Diagnostics.HasError() {
+				if err := lw.readResource(ctx, item.ID.ID(), &result); err != nil {
+					result.Diagnostics.AddWarning(summary, fmt.Sprintf("retrieving %q: %+v", item.ID.ID(), err))
+				}
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// readResource populates the Resource within the result using the Read function of the Resource
+func (lw *ListResourceWrapper) readResource(ctx context.Context, id string, result *list.ListResult) error {
+	state, err := lw.server.readResource(ctx, lw.listResource.ResourceType(), id)
+	if err != nil {
+		return err
+	}
+
+	value, err := state.Unmarshal(result.Resource.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		return fmt.Errorf("decoding the State: %+v", err)
+	}
+
+	result.Resource.Raw = value
+	return nil
+}
+
+// filterListResourceResults returns the results which have all the specified tags assigned, sorted by ID
+func filterListResourceResults(input []ListResourceResult, tags map[string]string) []ListResourceResult {
+	output := make([]ListResourceResult, 0)
+	for _, item := range input {
+		if item.ID == nil {
+			continue
+		}
+
+		matches := true
+		for key, value := range tags {
+			if v, ok := item.Tags[key]; !ok || v != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			output = append(output, item)
+		}
+	}
+
+	sort.Slice(output, func(i, j int) bool {
+		return output[i].ID.ID() < output[j].ID.ID()
+	})
+
+	return output
+}
+
+func listResultsError(summary, detail string) func(push func(list.ListResult) bool) {
+	return func(push func(list.ListResult) bool) {
+		result := list.ListResult{}
+		result.Diagnostics.AddError(summary, detail)
+		push(result)
+	}
+}
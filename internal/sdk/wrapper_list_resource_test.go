@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type testListResource struct{}
+
+func (testListResource) ResourceType() string {
+	return "azurerm_example"
+}
+
+func (testListResource) Identity() resourceids.ResourceId {
+	return &commonids.ResourceGroupId{}
+}
+
+func (testListResource) List() ListResourceFunc {
+	return ListResourceFunc{
+		Timeout: time.Minute,
+		Func: func(ctx context.Context, metadata ListResourceMetaData) ([]ListResourceResult, error) {
+			subscriptionId := "12345678-1234-9876-4563-123456789012"
+			results := make([]ListResourceResult, 0)
+			for name, environment := range map[string]string{"third": "prod", "first": "prod", "second": "test"} {
+				id := commonids.NewResourceGroupID(subscriptionId, name)
+				results = append(results, ListResourceResult{
+					ID: &id,
+					Tags: map[string]string{
+						"environment": environment,
+					},
+				})
+			}
+			return results, nil
+		},
+	}
+}
+
+func testListResourceProvider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"azurerm_example": {
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+				},
+				Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+					_, err := commonids.ParseResourceGroupID(id)
+					return err
+				}),
+				Read: func(d *schema.ResourceData, _ interface{}) error {
+					id, err := commonids.ParseResourceGroupID(d.Id())
+					if err != nil {
+						return err
+					}
+					return d.Set("name", id.ResourceGroupName)
+				},
+			},
+		},
+	}
+}
+
+func TestListResourceWrapper_List(t *testing.T) {
+	ctx := context.Background()
+
+	wrapper := NewListResourceWrapper(testListResource{}, NewManagedResourceServer(schema.NewGRPCProviderServer(testListResourceProvider())))()
+
+	schemasResponse := list.RawV5SchemaResponse{}
+	wrapper.(list.ListResourceWithRawV5Schemas).RawV5Schemas(ctx, list.RawV5SchemaRequest{}, &schemasResponse)
+	if schemasResponse.ProtoV5Schema == nil || schemasResponse.ProtoV5IdentitySchema == nil {
+		t.Fatalf("expected the Schema and Resource Identity Schema to be returned")
+	}
+
+	configureResponse := resource.ConfigureResponse{}
+	wrapper.(list.ListResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: &clients.Client{}}, &configureResponse)
+	if configureResponse.Diagnostics.HasError() {
+		t.Fatalf("configuring: %+v", configureResponse.Diagnostics)
+	}
+
+	configSchemaResponse := list.ListResourceSchemaResponse{}
+	wrapper.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, &configSchemaResponse)
+	config := tftypes.NewValue(configSchemaResponse.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+		"resource_group_name": tftypes.NewValue(tftypes.String, nil),
+		"tags": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"environment": tftypes.NewValue(tftypes.String, "prod"),
+		}),
+	})
+
+	request := list.ListRequest{
+		Config: tfsdk.Config{
+			Raw:    config,
+			Schema: configSchemaResponse.Schema,
+		},
+		IncludeResource: true,
+		ResourceSchema: resourceschema.Schema{
+			Attributes: map[string]resourceschema.Attribute{
+				"id": resourceschema.StringAttribute{
+					Computed: true,
+				},
+				"name": resourceschema.StringAttribute{
+					Required: true,
+				},
+			},
+		},
+		ResourceIdentitySchema: identityschema.Schema{
+			Attributes: map[string]identityschema.Attribute{
+				"name": identityschema.StringAttribute{
+					RequiredForImport: true,
+				},
+				"subscription_id": identityschema.StringAttribute{
+					OptionalForImport: true,
+				},
+			},
+		},
+	}
+
+	stream := list.ListResultsStream{}
+	wrapper.List(ctx, request, &stream)
+
+	names := make([]string, 0)
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("listing: %+v", result.Diagnostics)
+		}
+
+		var name string
+		if diags := result.Identity.GetAttribute(ctx, path.Root("name"), &name); diags.HasError() {
+			t.Fatalf("retrieving `name` from the Resource Identity: %+v", diags)
+		}
+		if result.DisplayName != name {
+			t.Fatalf("expected the Display Name to be %q but got %q", name, result.DisplayName)
+		}
+
+		var resourceName string
+		if diags := result.Resource.GetAttribute(ctx, path.Root("name"), &resourceName); diags.HasError() {
+			t.Fatalf("retrieving `name` from the Resource: %+v", diags)
+		}
+		if resourceName != name {
+			t.Fatalf("expected the Resource `name` to be %q but got %q", name, resourceName)
+		}
+
+		names = append(names, name)
+	}
+
+	// the results should be filtered by the tags and sorted by ID
+	if len(names) != 2 || names[0] != "first" || names[1] != "third" {
+		t.Fatalf("expected the results `first` and `third` but got %+v", names)
+	}
+
+	// and then limited to the number of results requested
+	request.IncludeResource = false
+	request.Limit = 1
+	wrapper.List(ctx, request, &stream)
+	count := 0
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("listing: %+v", result.Diagnostics)
+		}
+		count++
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 result but got %d", count)
+	}
+}
+
+func TestListResourceWrapper_ListUnconfigured(t *testing.T) {
+	ctx := context.Background()
+
+	wrapper := NewListResourceWrapper(testListResource{}, NewManagedResourceServer(schema.NewGRPCProviderServer(testListResourceProvider())))()
+
+	stream := list.ListResultsStream{}
+	wrapper.List(ctx, list.ListRequest{}, &stream)
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			return
+		}
+	}
+	t.Fatalf("expected an error when the Provider hasn't been configured but didn't get one")
+}
@@ -6,12 +6,15 @@ package sdk
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"time"
 
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 )
 
 // ResourceWrapper is a wrapper for converting a Resource implementation
@@ -31,7 +34,12 @@ func NewResourceWrapper(resource Resource) ResourceWrapper {
 
 // Resource returns the Terraform Plugin SDK type for this Resource implementation
 func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
-	resourceSchema, err := combineSchema(rw.resource.Arguments(), rw.resource.Attributes())
+	arguments, attributes, err := PluginSdkSchema(rw.resource)
+	if err != nil {
+		return nil, fmt.Errorf("building Schema: %+v", err)
+	}
+
+	resourceSchema, err := combineSchema(arguments, attributes)
 	if err != nil {
 		return nil, fmt.Errorf("building Schema: %+v", err)
 	}
@@ -41,6 +49,20 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 		if err := ValidateModelObject(modelObj); err != nil {
 			return nil, fmt.Errorf("validating model for %q: %+v", rw.resource.ResourceType(), err)
 		}
+
+		if v, ok := rw.resource.(resourceWithTypedSchema); ok {
+			if err := validateModelObjectAgainstTypedSchema(modelObj, v); err != nil {
+				return nil, fmt.Errorf("validating model for %q: %+v", rw.resource.ResourceType(), err)
+			}
+		}
+
+		if err := validateModelTypeAgainstSchema("", reflect.TypeOf(modelObj).Elem(), *resourceSchema); err != nil {
+			return nil, fmt.Errorf("validating model for %q: %+v", rw.resource.ResourceType(), err)
+		}
+	}
+
+	if err := validateWriteOnlyAttributes(*resourceSchema); err != nil {
+		return nil, fmt.Errorf("validating Schema for %q: %+v", rw.resource.ResourceType(), err)
 	}
 
 	d := func(duration time.Duration) *time.Duration {
@@ -51,7 +73,7 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 		Schema: *resourceSchema,
 
 		CreateContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
-			metaData := runArgs(d, meta, rw.logger)
+			metaData := runArgs(d, meta, *resourceSchema, rw.logger)
 			err := rw.resource.Create().Func(ctx, metaData)
 			if err != nil {
 				return err
@@ -59,16 +81,16 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 			// NOTE: whilst this may look like we should use the Read
 			// functions timeout here, we're still /technically/ in the
 			// Create function so reusing that timeout should be sufficient
-			return rw.resource.Read().Func(ctx, metaData)
+			return rw.read(ctx, metaData)
 		}),
 
 		// looks like these could be reused, easiest if they're not
 		ReadContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
-			metaData := runArgs(d, meta, rw.logger)
-			return rw.resource.Read().Func(ctx, metaData)
+			metaData := runArgs(d, meta, *resourceSchema, rw.logger)
+			return rw.read(ctx, metaData)
 		}),
 		DeleteContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
-			metaData := runArgs(d, meta, rw.logger)
+			metaData := runArgs(d, meta, *resourceSchema, rw.logger)
 			return rw.resource.Delete().Func(ctx, metaData)
 		}),
 
@@ -90,15 +112,16 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 				for _, err := range errors {
 					out += err.Error()
 				}
-				return fmt.Errorf(out)
+				return fmt.Errorf("%s", out)
 			}
 
 			return nil
 		}, func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
 			if v, ok := rw.resource.(ResourceWithCustomImporter); ok {
-				metaData := runArgs(d, meta, rw.logger)
+				metaData := runArgs(d, meta, *resourceSchema, rw.logger)
 
-				ctx, cancel := context.WithTimeout(ctx, rw.resource.Read().Timeout)
+				// the Read timeout accounts for the `default_timeouts` defined within the Provider block
+				ctx, cancel := timeouts.ForRead(ctx, d)
 				defer cancel()
 				err := v.CustomImporter()(ctx, metaData)
 				if err != nil {
@@ -116,7 +139,7 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 	// implementations can opt to interface
 	if v, ok := rw.resource.(ResourceWithUpdate); ok {
 		resource.UpdateContext = rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
-			metaData := runArgs(d, meta, rw.logger)
+			metaData := runArgs(d, meta, *resourceSchema, rw.logger)
 
 			err := v.Update().Func(ctx, metaData)
 			if err != nil {
@@ -125,11 +148,16 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 			// whilst this may look like we should use the Update timeout here
 			// we're still "technically" in the update method, so reusing the
 			// Update's timeout should be fine
-			return rw.resource.Read().Func(ctx, metaData)
+			return rw.read(ctx, metaData)
 		})
 		resource.Timeouts.Update = d(v.Update().Timeout)
 	}
 
+	if v, ok := rw.resource.(ResourceWithIdentity); ok {
+		resource.Identity = pluginsdk.ResourceIdentityFromResourceId(v.Identity())
+		resource.Importer = pluginsdk.ImporterBuildingResourceIdFromIdentity(v.Identity(), resource.Importer)
+	}
+
 	if v, ok := rw.resource.(ResourceWithCustomizeDiff); ok {
 		resource.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
 			client := meta.(*clients.Client)
@@ -139,6 +167,7 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 				Client:                   client,
 				Logger:                   rw.logger,
 				ResourceDiff:             d,
+				resourceSchema:           *resourceSchema,
 				serializationDebugLogger: NullLogger{},
 			}
 
@@ -179,9 +208,42 @@ and we recommend using the %[2]q resource instead.
 	}
 	// TODO: State Migrations
 
+	// the existence check uses the Importer and Read function by default, so is applied once these are configured
+	var existenceCheck UntypedExistenceCheckFunc
+	if v, ok := rw.resource.(ResourceWithExistenceCheck); ok {
+		check := v.ExistenceCheck()
+		existenceCheck = func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) (resourceids.Id, bool, error) {
+			metaData := ResourceMetaData{
+				Client:                   meta.(*clients.Client),
+				Logger:                   rw.logger,
+				ResourceDiff:             d,
+				resourceSchema:           *resourceSchema,
+				serializationDebugLogger: NullLogger{},
+			}
+
+			return check(ctx, metaData)
+		}
+	}
+	ApplyExistenceCheck(rw.resource.ResourceType(), &resource, existenceCheck)
+
 	return &resource, nil
 }
 
+// read runs the Read function for this Resource - and then, where the Resource exposes a Resource Identity,
+// populates the Resource Identity from the Resource ID
+func (rw *ResourceWrapper) read(ctx context.Context, metaData ResourceMetaData) error {
+	if err := rw.resource.Read().Func(ctx, metaData); err != nil {
+		return err
+	}
+
+	// the Resource ID will be empty when the Resource has been marked as gone
+	if v, ok := rw.resource.(ResourceWithIdentity); ok && metaData.ResourceData.Id() != "" {
+		return pluginsdk.SetResourceIdentityDataFromString(metaData.ResourceData, v.Identity(), metaData.ResourceData.Id())
+	}
+
+	return nil
+}
+
 func (rw *ResourceWrapper) diagnosticsWrapper(in func(ctx context.Context, d *schema.ResourceData, meta interface{}) error) func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return diagnosticsWrapper(in, rw.logger)
 }
@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ ResourceWithIdentity = testIdentityResource{}
+
+type testIdentityResource struct{}
+
+type testIdentityResourceModel struct {
+	Name string `tfschema:"name"`
+}
+
+func (testIdentityResource) ResourceType() string {
+	return "azurerm_example"
+}
+
+func (testIdentityResource) ModelObject() interface{} {
+	return &testIdentityResourceModel{}
+}
+
+func (testIdentityResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+	}
+}
+
+func (testIdentityResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (testIdentityResource) Create() ResourceFunc {
+	return ResourceFunc{
+		Timeout: time.Minute,
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			return nil
+		},
+	}
+}
+
+func (testIdentityResource) Read() ResourceFunc {
+	return ResourceFunc{
+		Timeout: time.Minute,
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			id, err := commonids.ParseResourceGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			return metadata.Encode(&testIdentityResourceModel{
+				Name: id.ResourceGroupName,
+			})
+		},
+	}
+}
+
+func (testIdentityResource) Delete() ResourceFunc {
+	return ResourceFunc{
+		Timeout: time.Minute,
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			return nil
+		},
+	}
+}
+
+func (testIdentityResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return func(input interface{}, key string) (warnings []string, errors []error) {
+		if _, err := commonids.ParseResourceGroupID(input.(string)); err != nil {
+			errors = append(errors, err)
+		}
+		return
+	}
+}
+
+func (testIdentityResource) Identity() resourceids.ResourceId {
+	return &commonids.ResourceGroupId{}
+}
+
+func TestResourceWrapper_Identity(t *testing.T) {
+	ctx := context.Background()
+
+	wrapper := NewResourceWrapper(testIdentityResource{})
+	resource, err := wrapper.Resource()
+	if err != nil {
+		t.Fatalf("building Resource: %+v", err)
+	}
+	if resource.Identity == nil {
+		t.Fatalf("expected the Resource to define a Resource Identity but it didn't")
+	}
+
+	provider := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"azurerm_example": resource,
+		},
+	}
+	client := &clients.Client{
+		Account: &clients.ResourceManagerAccount{
+			SubscriptionId: "12345678-1234-9876-4563-123456789012",
+		},
+	}
+	provider.SetMeta(client)
+
+	// importing using the Resource Identity, where the `subscription_id` is taken from the Provider
+	states, err := provider.ImportStateWithIdentity(ctx, &terraform.InstanceInfo{Type: "azurerm_example"}, "", map[string]string{
+		"name": "example-resources",
+	})
+	if err != nil {
+		t.Fatalf("importing using the Resource Identity: %+v", err)
+	}
+	if len(states) != 1 {
+		t.Fatalf("expected 1 imported resource but got %d", len(states))
+	}
+
+	expected := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources"
+	if states[0].ID != expected {
+		t.Fatalf("expected the Resource ID to be %q but got %q", expected, states[0].ID)
+	}
+
+	// then once the Resource has been read the Resource Identity should be populated
+	d := resource.Data(states[0])
+	if diags := resource.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("reading: %+v", diags)
+	}
+
+	identity, err := d.Identity()
+	if err != nil {
+		t.Fatalf("retrieving the Resource Identity: %+v", err)
+	}
+	for key, value := range map[string]string{"subscription_id": "12345678-1234-9876-4563-123456789012", "name": "example-resources"} {
+		if actual := identity.Get(key).(string); actual != value {
+			t.Fatalf("expected %q within the Resource Identity to be %q but got %q", key, value, actual)
+		}
+	}
+}
@@ -7,6 +7,10 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
 // ValidateModelObject validates that the object contains the specified `tfschema` tags
@@ -21,7 +25,8 @@ func ValidateModelObject(input interface{}) error {
 		return fmt.Errorf("need a pointer to the model object")
 	}
 
-	// TODO: could we also validate that each `tfschema` tag exists in the schema?
+	// NOTE: for resources using the Typed Schema, each `tfschema` tag is also validated as existing in
+	// the schema - see validateModelObjectAgainstTypedSchema
 
 	objType := reflect.TypeOf(input).Elem()
 	objVal := reflect.ValueOf(input).Elem()
@@ -71,3 +76,153 @@ func validateModelObjectRecursively(prefix string, objType reflect.Type, objVal
 
 	return nil
 }
+
+// validateModelObjectAgainstTypedSchema validates that each `tfschema` tag within the model exists in the Typed Schema
+func validateModelObjectAgainstTypedSchema(input interface{}, resource resourceWithTypedSchema) error {
+	combined, err := combineTypedSchema(resource.TypedArguments(), resource.TypedAttributes())
+	if err != nil {
+		return fmt.Errorf("building Typed Schema: %+v", err)
+	}
+
+	return validateModelTypeAgainstTypedSchema("", reflect.TypeOf(input).Elem(), combined.Attributes, combined.Blocks)
+}
+
+func validateModelTypeAgainstTypedSchema(prefix string, objType reflect.Type, attributes map[string]Attribute, blocks map[string]Block) error {
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		structTags, err := parseStructTags(field.Tag)
+		if err != nil || structTags == nil {
+			// these are validated by ValidateModelObject
+			continue
+		}
+
+		hclPath := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, structTags.hclPath), ".")
+		if _, ok := attributes[structTags.hclPath]; ok {
+			continue
+		}
+
+		block, ok := blocks[structTags.hclPath]
+		if !ok {
+			return fmt.Errorf("the field %q has the `tfschema` tag %q which doesn't exist in the Typed Schema", field.Name, hclPath)
+		}
+
+		nestedType := field.Type
+		if nestedType.Kind() == reflect.Pointer {
+			nestedType = nestedType.Elem()
+		}
+		if nestedType.Kind() != reflect.Slice || nestedType.Elem().Kind() != reflect.Struct {
+			return fmt.Errorf("the field %q is a Block in the Typed Schema so must be a slice of a struct but got %s", field.Name, field.Type)
+		}
+
+		if err := validateModelTypeAgainstTypedSchema(hclPath, nestedType.Elem(), block.Attributes, block.Blocks); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateWriteOnlyAttributes validates that each top-level write-only attribute within the schema has a corresponding
+// `{name}_version` attribute, since the value of a write-only attribute is never persisted so changes to it can't be
+// detected
+func validateWriteOnlyAttributes(resourceSchema map[string]*schema.Schema) error {
+	for key, v := range resourceSchema {
+		if !v.WriteOnly {
+			continue
+		}
+		if _, ok := resourceSchema[key+pluginsdk.WriteOnlyVersionSuffix]; !ok {
+			return fmt.Errorf("the write-only attribute %q must have a %q attribute to trigger updates", key, key+pluginsdk.WriteOnlyVersionSuffix)
+		}
+	}
+
+	return nil
+}
+
+// validateModelTypeAgainstSchema validates that each `tfschema` tag within the model exists in the Schema
+// for this Resource and that the type of the field is compatible with the type of the Schema - this is
+// run once against the ModelObject when the Resource is built
+func validateModelTypeAgainstSchema(prefix string, objType reflect.Type, resourceSchema map[string]*schema.Schema) error {
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		structTags, err := parseStructTags(field.Tag)
+		if err != nil {
+			return fmt.Errorf("parsing struct tags for %q: %+v", field.Name, err)
+		}
+		if structTags == nil {
+			continue
+		}
+
+		hclPath := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, structTags.hclPath), ".")
+		fieldSchema, ok := resourceSchema[structTags.hclPath]
+		if !ok {
+			if structTags.removedInNextMajorVersion && features.FourPointOh() {
+				// the field has been removed from the Schema, so is skipped by Encode and Decode
+				continue
+			}
+			return fmt.Errorf("the field %q has the `tfschema` tag %q which doesn't exist in the Schema", field.Name, hclPath)
+		}
+
+		if err := validateFieldTypeAgainstSchema(hclPath, field.Type, fieldSchema); err != nil {
+			return fmt.Errorf("the field %q doesn't match the Schema: %+v", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func validateFieldTypeAgainstSchema(hclPath string, fieldType reflect.Type, fieldSchema *schema.Schema) error {
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+
+	switch fieldSchema.Type {
+	case schema.TypeBool:
+		if fieldType.Kind() != reflect.Bool {
+			return fmt.Errorf("%q is a Bool in the Schema but the field is a %s", hclPath, fieldType)
+		}
+
+	case schema.TypeInt:
+		switch fieldType.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		default:
+			return fmt.Errorf("%q is an Int in the Schema but the field is a %s", hclPath, fieldType)
+		}
+
+	case schema.TypeFloat:
+		if fieldType.Kind() != reflect.Float32 && fieldType.Kind() != reflect.Float64 {
+			return fmt.Errorf("%q is a Float in the Schema but the field is a %s", hclPath, fieldType)
+		}
+
+	case schema.TypeString:
+		if fieldType.Kind() != reflect.String {
+			return fmt.Errorf("%q is a String in the Schema but the field is a %s", hclPath, fieldType)
+		}
+
+	case schema.TypeMap:
+		if fieldType.Kind() != reflect.Map || fieldType.Key().Kind() != reflect.String {
+			return fmt.Errorf("%q is a Map in the Schema but the field is a %s", hclPath, fieldType)
+		}
+
+	case schema.TypeList, schema.TypeSet:
+		if fieldType.Kind() != reflect.Slice {
+			return fmt.Errorf("%q is a List/Set in the Schema but the field is a %s", hclPath, fieldType)
+		}
+
+		switch elem := fieldSchema.Elem.(type) {
+		case *schema.Resource:
+			nestedType := fieldType.Elem()
+			if nestedType.Kind() == reflect.Pointer {
+				nestedType = nestedType.Elem()
+			}
+			if nestedType.Kind() != reflect.Struct {
+				return fmt.Errorf("%q is a Block in the Schema so must be a slice of a struct but the field is a %s", hclPath, fieldType)
+			}
+			return validateModelTypeAgainstSchema(hclPath, nestedType, elem.Schema)
+
+		case *schema.Schema:
+			return validateFieldTypeAgainstSchema(hclPath, fieldType.Elem(), elem)
+		}
+	}
+
+	return nil
+}
@@ -3,7 +3,12 @@
 
 package sdk
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
 
 func TestValidateTopLevelObjectValid(t *testing.T) {
 	type Person struct {
@@ -69,3 +74,100 @@ func TestValidateNestedObjectInvalid(t *testing.T) {
 		t.Fatalf("expected an error but didn't get one")
 	}
 }
+
+func TestValidateWriteOnlyAttributes(t *testing.T) {
+	withVersion := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"password": {
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			WriteOnly: true,
+		},
+		"password_version": {
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+	}
+	if err := validateWriteOnlyAttributes(withVersion); err != nil {
+		t.Fatalf("error: %+v", err)
+	}
+
+	t.Log("Missing Version")
+	withoutVersion := map[string]*schema.Schema{
+		"name":     withVersion["name"],
+		"password": withVersion["password"],
+	}
+	if err := validateWriteOnlyAttributes(withoutVersion); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+}
+
+func TestValidateModelTypeAgainstSchema(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"count": {
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+		"inner": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"value": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+
+	type MatchingInner struct {
+		Value string `tfschema:"value"`
+	}
+	type Matching struct {
+		Name  string          `tfschema:"name"`
+		Count int64           `tfschema:"count"`
+		Inner []MatchingInner `tfschema:"inner"`
+	}
+	if err := validateModelTypeAgainstSchema("", reflect.TypeOf(Matching{}), resourceSchema); err != nil {
+		t.Fatalf("error: %+v", err)
+	}
+
+	t.Log("Missing From Schema")
+	type MissingFromSchema struct {
+		Name    string `tfschema:"name"`
+		Missing string `tfschema:"missing"`
+	}
+	if err := validateModelTypeAgainstSchema("", reflect.TypeOf(MissingFromSchema{}), resourceSchema); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+
+	t.Log("Wrong Type")
+	type WrongType struct {
+		Name  string `tfschema:"name"`
+		Count string `tfschema:"count"`
+	}
+	if err := validateModelTypeAgainstSchema("", reflect.TypeOf(WrongType{}), resourceSchema); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+
+	t.Log("Wrong Nested Type")
+	type WrongNestedTypeInner struct {
+		Value bool `tfschema:"value"`
+	}
+	type WrongNestedType struct {
+		Inner []WrongNestedTypeInner `tfschema:"inner"`
+	}
+	if err := validateModelTypeAgainstSchema("", reflect.TypeOf(WrongNestedType{}), resourceSchema); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+}
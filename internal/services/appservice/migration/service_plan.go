@@ -4,10 +4,9 @@
 package migration
 
 import (
-	"context"
-
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -86,14 +85,5 @@ func (s ServicePlanV0toV1) Schema() map[string]*pluginsdk.Schema {
 }
 
 func (s ServicePlanV0toV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
-	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
-		oldId := rawState["id"].(string)
-		parsedId, err := commonids.ParseAppServicePlanIDInsensitively(oldId)
-		if err != nil {
-			return nil, err
-		}
-		newId := parsedId.ID()
-		rawState["id"] = newId
-		return rawState, nil
-	}
+	return sdk.ResourceIdUpgradeFunc(&commonids.AppServicePlanId{}, nil)
 }
@@ -4,10 +4,13 @@
 package appservice
 
 import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 )
 
 var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.ServiceRegistrationWithResourceIds = Registration{}
 
 type Registration struct{}
 
@@ -61,3 +64,12 @@ func (r Registration) Resources() []sdk.Resource {
 		WindowsWebAppSlotResource{},
 	}
 }
+
+// ResourceIds returns a list of the Resource ID types used by this Service
+func (r Registration) ResourceIds() []resourceids.ResourceId {
+	return []resourceids.ResourceId{
+		&commonids.AppServiceId{},
+		&commonids.AppServiceEnvironmentId{},
+		&commonids.AppServicePlanId{},
+	}
+}
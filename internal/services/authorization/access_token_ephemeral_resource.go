@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+var _ sdk.EphemeralResource = AccessTokenEphemeralResource{}
+
+type AccessTokenEphemeralResource struct{}
+
+type AccessTokenEphemeralResourceModel struct {
+	Api       *string `tfschema:"api"`
+	Token     string  `tfschema:"token"`
+	ExpiresOn string  `tfschema:"expires_on"`
+}
+
+const (
+	accessTokenApiKeyVault        = "KeyVault"
+	accessTokenApiMicrosoftGraph  = "MicrosoftGraph"
+	accessTokenApiResourceManager = "ResourceManager"
+	accessTokenApiStorage         = "Storage"
+)
+
+func (AccessTokenEphemeralResource) ResourceType() string {
+	return "azurerm_access_token"
+}
+
+func (AccessTokenEphemeralResource) ModelObject() interface{} {
+	return &AccessTokenEphemeralResourceModel{}
+}
+
+func (AccessTokenEphemeralResource) TypedArguments() sdk.TypedSchema {
+	return sdk.TypedSchema{
+		Attributes: map[string]sdk.Attribute{
+			"api": {
+				Type:        sdk.AttributeTypeString,
+				Optional:    true,
+				Description: fmt.Sprintf("The API which the Access Token should be issued for. Defaults to `%s`.", accessTokenApiResourceManager),
+				Validation: &sdk.AttributeValidation{
+					AllowedValues: []string{
+						accessTokenApiKeyVault,
+						accessTokenApiMicrosoftGraph,
+						accessTokenApiResourceManager,
+						accessTokenApiStorage,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (AccessTokenEphemeralResource) TypedAttributes() sdk.TypedSchema {
+	return sdk.TypedSchema{
+		Attributes: map[string]sdk.Attribute{
+			"token": {
+				Type:        sdk.AttributeTypeString,
+				Sensitive:   true,
+				Description: "The Access Token, issued for the credentials the Provider is authenticated with.",
+			},
+
+			"expires_on": {
+				Type:        sdk.AttributeTypeString,
+				Description: "The date and time at which the Access Token expires, in RFC3339 format.",
+			},
+		},
+	}
+}
+
+func (AccessTokenEphemeralResource) Open() sdk.EphemeralResourceFunc {
+	return sdk.EphemeralResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.EphemeralResourceMetaData) error {
+			client := metadata.Client.Authorization
+
+			var config AccessTokenEphemeralResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			apiName := accessTokenApiResourceManager
+			if config.Api != nil && *config.Api != "" {
+				apiName = *config.Api
+			}
+
+			api := accessTokenApi(client.Environment, apiName)
+			if api == nil {
+				return fmt.Errorf("the %s API isn't available within the %q Environment", apiName, client.Environment.Name)
+			}
+
+			if client.AuthorizerFunc == nil {
+				return fmt.Errorf("an Authorizer isn't available to obtain an Access Token")
+			}
+			authorizer, err := client.AuthorizerFunc(api)
+			if err != nil {
+				return fmt.Errorf("building the Authorizer for the %s API: %+v", apiName, err)
+			}
+
+			token, err := authorizer.Token(ctx, &http.Request{})
+			if err != nil {
+				return fmt.Errorf("obtaining an Access Token for the %s API: %+v", apiName, err)
+			}
+
+			config.Token = token.AccessToken
+			if !token.Expiry.IsZero() {
+				config.ExpiresOn = token.Expiry.UTC().Format(time.RFC3339)
+			}
+
+			return metadata.Encode(&config)
+		},
+	}
+}
+
+func accessTokenApi(environment environments.Environment, apiName string) environments.Api {
+	switch apiName {
+	case accessTokenApiKeyVault:
+		return environment.KeyVault
+	case accessTokenApiMicrosoftGraph:
+		return environment.MicrosoftGraph
+	case accessTokenApiStorage:
+		return environment.Storage
+	}
+
+	return environment.ResourceManager
+}
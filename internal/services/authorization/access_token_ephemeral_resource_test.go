@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authorization_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+)
+
+type AccessTokenEphemeralResource struct{}
+
+func TestAccEphemeralAccessToken_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azurerm_access_token", "test")
+
+	data.EphemeralResourceTest(t, []acceptance.TestStep{
+		{
+			Config: AccessTokenEphemeralResource{}.basic(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("token"), knownvalue.NotNull()),
+				statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("expires_on"), knownvalue.NotNull()),
+			},
+		},
+	})
+}
+
+func TestAccEphemeralAccessToken_microsoftGraph(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azurerm_access_token", "test")
+
+	data.EphemeralResourceTest(t, []acceptance.TestStep{
+		{
+			Config: AccessTokenEphemeralResource{}.api("MicrosoftGraph"),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("api"), knownvalue.StringExact("MicrosoftGraph")),
+				statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("token"), knownvalue.NotNull()),
+			},
+		},
+	})
+}
+
+func (AccessTokenEphemeralResource) basic() string {
+	return `
+provider "azurerm" {
+  features {}
+}
+
+ephemeral "azurerm_access_token" "test" {}
+
+provider "echo" {
+  data = ephemeral.azurerm_access_token.test
+}
+
+resource "echo" "test" {}
+`
+}
+
+func (AccessTokenEphemeralResource) api(api string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+ephemeral "azurerm_access_token" "test" {
+  api = %q
+}
+
+provider "echo" {
+  data = ephemeral.azurerm_access_token.test
+}
+
+resource "echo" "test" {}
+`, api)
+}
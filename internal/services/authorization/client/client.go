@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/resource-manager/authorization/2020-10-01/roleeligibilityschedules"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/authorization/2022-04-01/roleassignments"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/authorization/2022-05-01-preview/roledefinitions"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 )
 
@@ -30,6 +31,10 @@ type Client struct {
 	RoleEligibilitySchedulesClient         *roleeligibilityschedules.RoleEligibilitySchedulesClient
 	ScopedRoleAssignmentsClient            *roleassignments.RoleAssignmentsClient
 	ScopedRoleDefinitionsClient            *roledefinitions.RoleDefinitionsClient
+
+	// AuthorizerFunc and Environment are used to obtain Access Tokens for the APIs within this Environment
+	AuthorizerFunc common.ApiAuthorizerFunc
+	Environment    environments.Environment
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
@@ -95,5 +100,8 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 		RoleEligibilitySchedulesClient:         roleEligibilitySchedulesClient,
 		ScopedRoleAssignmentsClient:            scopedRoleAssignmentsClient,
 		ScopedRoleDefinitionsClient:            scopedRoleDefinitionsClient,
+
+		AuthorizerFunc: o.Authorizers.AuthorizerFunc,
+		Environment:    o.Environment,
 	}, nil
 }
@@ -13,6 +13,7 @@ type Registration struct{}
 var (
 	_ sdk.TypedServiceRegistrationWithAGitHubLabel   = Registration{}
 	_ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+	_ sdk.ServiceRegistrationWithEphemeralResources  = Registration{}
 )
 
 func (r Registration) AssociatedGitHubLabel() string {
@@ -60,3 +61,9 @@ func (r Registration) Resources() []sdk.Resource {
 	}
 	return resources
 }
+
+func (r Registration) EphemeralResources() []sdk.EphemeralResource {
+	return []sdk.EphemeralResource{
+		AccessTokenEphemeralResource{},
+	}
+}
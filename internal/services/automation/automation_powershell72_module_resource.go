@@ -184,7 +184,7 @@ func (r PowerShell72ModuleResource) Create() sdk.ResourceFunc {
 								provisioningState = string(*props.ProvisioningState)
 							}
 							if props.Error != nil && props.Error.Message != nil && *props.Error.Message != "" {
-								return resp, provisioningState, fmt.Errorf(*props.Error.Message)
+								return resp, provisioningState, fmt.Errorf("%s", *props.Error.Message)
 							}
 							return resp, provisioningState, nil
 						}
@@ -272,7 +272,7 @@ func (r PowerShell72ModuleResource) Update() sdk.ResourceFunc {
 								provisioningState = string(*props.ProvisioningState)
 							}
 							if props.Error != nil && props.Error.Message != nil && *props.Error.Message != "" {
-								return resp, provisioningState, fmt.Errorf(*props.Error.Message)
+								return resp, provisioningState, fmt.Errorf("%s", *props.Error.Message)
 							}
 							return resp, provisioningState, nil
 						}
@@ -4,11 +4,9 @@
 package migration
 
 import (
-	"context"
-	"log"
-
 	"github.com/hashicorp/go-azure-sdk/resource-manager/automation/2015-10-31/webhook"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -73,16 +71,5 @@ func (s AutomationWebhookV0ToV1) Schema() map[string]*pluginsdk.Schema {
 }
 
 func (s AutomationWebhookV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
-	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
-		oldId := rawState["id"].(string)
-		newId, err := webhook.ParseWebHookIDInsensitively(oldId)
-		if err != nil {
-			return nil, err
-		}
-
-		log.Printf("[DEBUG] Updating ID from %q to %q", oldId, newId)
-
-		rawState["id"] = newId.ID()
-		return rawState, nil
-	}
+	return sdk.ResourceIdUpgradeFunc(&webhook.WebHookId{}, nil)
 }
@@ -7,11 +7,16 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/fakearm"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/chaosstudio"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
@@ -63,6 +68,51 @@ func TestAccChaosStudioCapability_multipleCapabilities(t *testing.T) {
 	})
 }
 
+func TestChaosStudioCapability_read(t *testing.T) {
+	server := fakearm.New(t, fakearm.Options{})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client, err := server.Client(ctx)
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	// the model of the Target was previously used as the ModelObject, as such the Resource failed to be built
+	wrapper := sdk.NewResourceWrapper(chaosstudio.ChaosStudioCapabilityResource{})
+	resource, err := wrapper.Resource()
+	if err != nil {
+		t.Fatalf("building resource: %+v", err)
+	}
+
+	virtualMachineId := commonids.NewVirtualMachineID(server.SubscriptionId, "example-resources", "example-vm")
+	targetId := commonids.NewChaosStudioTargetID(virtualMachineId.ID(), "Microsoft-VirtualMachine")
+	id := commonids.NewChaosStudioCapabilityID(targetId.Scope, targetId.TargetName, "Shutdown-1.0")
+	urn := "urn:csci:microsoft:virtualMachine:shutdown/1.0"
+	server.PutResource(id.ID(), map[string]interface{}{
+		"properties": map[string]interface{}{
+			"urn": urn,
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+	d.SetId(id.ID())
+	if diags := resource.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("reading: %+v", diags)
+	}
+
+	expected := map[string]string{
+		"capability_type":        id.CapabilityName,
+		"chaos_studio_target_id": targetId.ID(),
+		"urn":                    urn,
+	}
+	for k, v := range expected {
+		if actual := d.Get(k).(string); actual != v {
+			t.Fatalf("expected %q to be %q but got %q", k, v, actual)
+		}
+	}
+}
+
 func (r ChaosStudioCapabilityTestResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := commonids.ParseChaosStudioCapabilityID(state.ID)
 	if err != nil {
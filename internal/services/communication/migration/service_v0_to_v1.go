@@ -4,11 +4,9 @@
 package migration
 
 import (
-	"context"
-	"log"
-
 	"github.com/hashicorp/go-azure-sdk/resource-manager/communication/2023-03-31/communicationservices"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -67,15 +65,5 @@ func (ServiceV0ToV1) Schema() map[string]*pluginsdk.Schema {
 }
 
 func (ServiceV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
-	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
-		oldId := rawState["id"].(string)
-		parsedId, err := communicationservices.ParseCommunicationServiceIDInsensitively(oldId)
-		if err != nil {
-			return nil, err
-		}
-		newId := parsedId.ID()
-		log.Printf("[DEBUG] Updating ID from %q to %q..", oldId, newId)
-		rawState["id"] = newId
-		return rawState, nil
-	}
+	return sdk.ResourceIdUpgradeFunc(&communicationservices.CommunicationServiceId{}, nil)
 }
@@ -5,6 +5,7 @@ package compute_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
@@ -283,6 +284,27 @@ func TestAccLinuxVirtualMachine_scalingMachineSizeUpdate(t *testing.T) {
 	})
 }
 
+func TestAccLinuxVirtualMachine_scalingMachineSizeUnavailable(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_linux_virtual_machine", "test")
+	r := LinuxVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.scalingMachineSize(data, "Standard_F2"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			// the location is known at this point, so this is caught during the plan
+			Config:      r.scalingMachineSize(data, "Standard_DoesNotExist"),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile("available SKUs in this region are"),
+		},
+	})
+}
+
 func TestAccLinuxVirtualMachine_scalingZones(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_linux_virtual_machine", "test")
 	r := LinuxVirtualMachineResource{}
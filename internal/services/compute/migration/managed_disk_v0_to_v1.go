@@ -4,11 +4,9 @@
 package migration
 
 import (
-	"context"
-	"log"
-
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -17,18 +15,7 @@ var _ pluginsdk.StateUpgrade = ManagedDiskV0ToV1{}
 type ManagedDiskV0ToV1 struct{}
 
 func (ManagedDiskV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
-	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
-		oldIdRaw := rawState["id"].(string)
-		oldId, err := commonids.ParseManagedDiskIDInsensitively(oldIdRaw)
-		if err != nil {
-			return rawState, err
-		}
-
-		newId := oldId.ID()
-		log.Printf("[DEBUG] Updating the ID from %q to %q", oldIdRaw, newId)
-		rawState["id"] = newId
-		return rawState, nil
-	}
+	return sdk.ResourceIdUpgradeFunc(&commonids.ManagedDiskId{}, nil)
 }
 
 func (ManagedDiskV0ToV1) Schema() map[string]*pluginsdk.Schema {
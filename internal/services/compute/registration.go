@@ -4,12 +4,17 @@
 package compute
 
 import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
 type Registration struct{}
 
+var _ sdk.ServiceRegistrationWithListResources = Registration{}
+var _ sdk.ServiceRegistrationWithResourceIds = Registration{}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return "Compute"
@@ -93,3 +98,25 @@ func (r Registration) Resources() []sdk.Resource {
 		GalleryApplicationVersionResource{},
 	}
 }
+
+// ListResources returns a list of List Resources supported by this Service
+func (r Registration) ListResources() []sdk.ListResource {
+	return []sdk.ListResource{
+		LinuxVirtualMachineListResource{},
+		WindowsVirtualMachineListResource{},
+	}
+}
+
+// ResourceIds returns a list of the Resource ID types used by this Service
+func (r Registration) ResourceIds() []resourceids.ResourceId {
+	return []resourceids.ResourceId{
+		&commonids.AvailabilitySetId{},
+		&commonids.DedicatedHostId{},
+		&commonids.DedicatedHostGroupId{},
+		&commonids.DiskEncryptionSetId{},
+		&commonids.ManagedDiskId{},
+		&commonids.SharedImageGalleryId{},
+		&commonids.VirtualMachineId{},
+		&commonids.VirtualMachineScaleSetId{},
+	}
+}
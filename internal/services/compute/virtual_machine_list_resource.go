@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachines"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+var (
+	_ sdk.ListResource = LinuxVirtualMachineListResource{}
+	_ sdk.ListResource = WindowsVirtualMachineListResource{}
+)
+
+type LinuxVirtualMachineListResource struct{}
+
+func (LinuxVirtualMachineListResource) ResourceType() string {
+	return "azurerm_linux_virtual_machine"
+}
+
+func (LinuxVirtualMachineListResource) Identity() resourceids.ResourceId {
+	return &virtualmachines.VirtualMachineId{}
+}
+
+func (LinuxVirtualMachineListResource) List() sdk.ListResourceFunc {
+	return virtualMachineListResourceFunc(virtualmachines.OperatingSystemTypesLinux)
+}
+
+type WindowsVirtualMachineListResource struct{}
+
+func (WindowsVirtualMachineListResource) ResourceType() string {
+	return "azurerm_windows_virtual_machine"
+}
+
+func (WindowsVirtualMachineListResource) Identity() resourceids.ResourceId {
+	return &virtualmachines.VirtualMachineId{}
+}
+
+func (WindowsVirtualMachineListResource) List() sdk.ListResourceFunc {
+	return virtualMachineListResourceFunc(virtualmachines.OperatingSystemTypesWindows)
+}
+
+// virtualMachineListResourceFunc lists the Virtual Machines using the Operating System `osType`, since Linux and
+// Windows Virtual Machines are exposed as separate Resources
+func virtualMachineListResourceFunc(osType virtualmachines.OperatingSystemTypes) sdk.ListResourceFunc {
+	return sdk.ListResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ListResourceMetaData) ([]sdk.ListResourceResult, error) {
+			client := metadata.Client.Compute.VirtualMachinesClient
+			subscriptionId := commonids.NewSubscriptionID(metadata.Client.Account.SubscriptionId)
+
+			var items []virtualmachines.VirtualMachine
+			if metadata.ResourceGroupName != "" {
+				resourceGroupId := commonids.NewResourceGroupID(subscriptionId.SubscriptionId, metadata.ResourceGroupName)
+				resp, err := client.ListComplete(ctx, resourceGroupId, virtualmachines.DefaultListOperationOptions())
+				if err != nil {
+					return nil, fmt.Errorf("listing Virtual Machines within %s: %+v", resourceGroupId, err)
+				}
+				items = resp.Items
+			} else {
+				resp, err := client.ListAllComplete(ctx, subscriptionId, virtualmachines.DefaultListAllOperationOptions())
+				if err != nil {
+					return nil, fmt.Errorf("listing Virtual Machines within %s: %+v", subscriptionId, err)
+				}
+				items = resp.Items
+			}
+
+			results := make([]sdk.ListResourceResult, 0)
+			for _, item := range items {
+				if props := item.Properties; props == nil || props.StorageProfile == nil || props.StorageProfile.OsDisk == nil || pointer.From(props.StorageProfile.OsDisk.OsType) != osType {
+					continue
+				}
+
+				id, err := virtualmachines.ParseVirtualMachineIDInsensitively(pointer.From(item.Id))
+				if err != nil {
+					return nil, err
+				}
+
+				results = append(results, sdk.ListResourceResult{
+					ID:   id,
+					Tags: pointer.From(item.Tags),
+				})
+			}
+
+			return results, nil
+		},
+	}
+}
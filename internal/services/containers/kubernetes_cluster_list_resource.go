@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-06-02-preview/managedclusters"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+var _ sdk.ListResource = KubernetesClusterListResource{}
+
+type KubernetesClusterListResource struct{}
+
+func (KubernetesClusterListResource) ResourceType() string {
+	return "azurerm_kubernetes_cluster"
+}
+
+func (KubernetesClusterListResource) Identity() resourceids.ResourceId {
+	return &commonids.KubernetesClusterId{}
+}
+
+func (KubernetesClusterListResource) List() sdk.ListResourceFunc {
+	return sdk.ListResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ListResourceMetaData) ([]sdk.ListResourceResult, error) {
+			client := metadata.Client.Containers.KubernetesClustersClient
+			subscriptionId := commonids.NewSubscriptionID(metadata.Client.Account.SubscriptionId)
+
+			var items []managedclusters.ManagedCluster
+			if metadata.ResourceGroupName != "" {
+				resourceGroupId := commonids.NewResourceGroupID(subscriptionId.SubscriptionId, metadata.ResourceGroupName)
+				resp, err := client.ListByResourceGroupComplete(ctx, resourceGroupId)
+				if err != nil {
+					return nil, fmt.Errorf("listing Kubernetes Clusters within %s: %+v", resourceGroupId, err)
+				}
+				items = resp.Items
+			} else {
+				resp, err := client.ListComplete(ctx, subscriptionId)
+				if err != nil {
+					return nil, fmt.Errorf("listing Kubernetes Clusters within %s: %+v", subscriptionId, err)
+				}
+				items = resp.Items
+			}
+
+			results := make([]sdk.ListResourceResult, 0)
+			for _, item := range items {
+				id, err := commonids.ParseKubernetesClusterIDInsensitively(pointer.From(item.Id))
+				if err != nil {
+					return nil, err
+				}
+
+				results = append(results, sdk.ListResourceResult{
+					ID:   id,
+					Tags: pointer.From(item.Tags),
+				})
+			}
+
+			return results, nil
+		},
+	}
+}
@@ -4,6 +4,7 @@
 package containers
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"log"
@@ -26,6 +27,8 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceskus"
 	computeValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/parse"
@@ -61,6 +64,8 @@ func resourceKubernetesClusterNodePool() *pluginsdk.Resource {
 		}),
 
 		Schema: resourceKubernetesClusterNodePoolSchema(),
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceskus.ValidateSkuAvailabilityUsingLocationFunc(resourceskus.ResourceTypeVirtualMachines, "kubernetes_cluster_id", "vm_size", "zones", kubernetesClusterNodePoolLocation)),
 	}
 }
 
@@ -423,6 +428,34 @@ func resourceKubernetesClusterNodePoolSchema() map[string]*pluginsdk.Schema {
 
 	return s
 }
+
+// kubernetesClusterNodePoolLocation returns the Location of the Kubernetes Cluster which the Node Pool belongs to, which
+// has to be retrieved since it's not exposed on the Node Pool - and is used to validate the `vm_size` (and `zones`)
+func kubernetesClusterNodePoolLocation(ctx context.Context, d *pluginsdk.ResourceDiff, client *clients.Client) (string, string, error) {
+	if client.Containers == nil {
+		return "", "", nil
+	}
+
+	clusterId, err := commonids.ParseKubernetesClusterID(d.Get("kubernetes_cluster_id").(string))
+	if err != nil {
+		return "", "", err
+	}
+
+	cluster, err := client.Containers.KubernetesClustersClient.Get(ctx, *clusterId)
+	if err != nil {
+		// the Kubernetes Cluster may not exist yet, in which case the size is validated by the API during the apply
+		if !response.WasNotFound(cluster.HttpResponse) {
+			log.Printf("[WARN] unable to retrieve %s to validate the Virtual Machine Size of the Node Pool: %+v", *clusterId, err)
+		}
+		return "", "", nil
+	}
+	if cluster.Model == nil {
+		return "", "", nil
+	}
+
+	return clusterId.SubscriptionId, cluster.Model.Location, nil
+}
+
 func resourceKubernetesClusterNodePoolCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	containersClient := meta.(*clients.Client).Containers
 	clustersClient := containersClient.KubernetesClustersClient
@@ -672,6 +705,12 @@ func resourceKubernetesClusterNodePoolCreate(d *pluginsdk.ResourceData, meta int
 		Properties: &profile,
 	}
 
+	release, err := acquireKubernetesClusterNodePoolOperation(ctx, *clusterId)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	err = poolsClient.CreateOrUpdateThenPoll(ctx, id, parameters)
 	if err != nil {
 		return fmt.Errorf("creating %s: %+v", id, err)
@@ -692,6 +731,12 @@ func resourceKubernetesClusterNodePoolUpdate(d *pluginsdk.ResourceData, meta int
 		return err
 	}
 
+	release, err := acquireKubernetesClusterNodePoolOperation(ctx, commonids.NewKubernetesClusterID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName))
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	d.Partial(true)
 
 	log.Printf("[DEBUG] Retrieving existing %s..", *id)
@@ -1061,6 +1106,12 @@ func resourceKubernetesClusterNodePoolDelete(d *pluginsdk.ResourceData, meta int
 		return err
 	}
 
+	release, err := acquireKubernetesClusterNodePoolOperation(ctx, commonids.NewKubernetesClusterID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName))
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	ignorePodDisruptionBudget := true
 	err = client.DeleteThenPoll(ctx, *id, agentpools.DeleteOperationOptions{
 		IgnorePodDisruptionBudget: &ignorePodDisruptionBudget,
@@ -1072,6 +1123,16 @@ func resourceKubernetesClusterNodePoolDelete(d *pluginsdk.ResourceData, meta int
 	return nil
 }
 
+// kubernetesClusterNodePoolConcurrentOperations is the maximum number of concurrent operations against the Node Pools
+// within a Kubernetes Cluster, since further operations are rejected by the API with `AnotherOperationInProgress`
+const kubernetesClusterNodePoolConcurrentOperations = 2
+
+// acquireKubernetesClusterNodePoolOperation blocks until an operation can be performed against a Node Pool within the
+// Kubernetes Cluster, returning a function which must be called once the operation has completed
+func acquireKubernetesClusterNodePoolOperation(ctx context.Context, clusterId commonids.KubernetesClusterId) (func(), error) {
+	return locks.Acquire(ctx, fmt.Sprintf("%s/agentPools", clusterId.ID()), kubernetesClusterNodePoolConcurrentOperations)
+}
+
 func upgradeSettingsSchema() *pluginsdk.Schema {
 	if !features.FourPointOhBeta() {
 		return &pluginsdk.Schema{
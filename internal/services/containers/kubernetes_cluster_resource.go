@@ -32,6 +32,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceskus"
 	computeValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/migration"
 	containerValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
@@ -52,7 +53,7 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 		Update: resourceKubernetesClusterUpdate,
 		Delete: resourceKubernetesClusterDelete,
 
-		Importer: pluginsdk.ImporterValidatingResourceIdThen(
+		Importer: pluginsdk.ImporterBuildingResourceIdFromIdentity(&commonids.KubernetesClusterId{}, pluginsdk.ImporterValidatingResourceIdThen(
 			func(id string) error {
 				_, err := commonids.ParseKubernetesClusterID(id)
 				return err
@@ -64,7 +65,7 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 				d.Set("public_network_access_enabled", true)
 				return []*pluginsdk.ResourceData{d}, nil
 			},
-		),
+		)),
 
 		CustomizeDiff: pluginsdk.CustomDiffInSequence(
 			// Migration of `identity` to `service_principal` is not allowed, the other way around is
@@ -113,8 +114,11 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 			pluginsdk.ForceNewIfChange("custom_ca_trust_certificates_base64", func(ctx context.Context, old, new, meta interface{}) bool {
 				return len(old.([]interface{})) > 0 && len(new.([]interface{})) == 0
 			}),
+			resourceskus.ValidateSkuAvailability(resourceskus.ResourceTypeVirtualMachines, "default_node_pool.0.vm_size", "default_node_pool.0.zones"),
 		),
 
+		Identity: pluginsdk.ResourceIdentityFromResourceId(&commonids.KubernetesClusterId{}),
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(90 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
@@ -2678,6 +2682,10 @@ func resourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{})
 		return fmt.Errorf("retrieving %s: %+v", *id, err)
 	}
 
+	if err := pluginsdk.SetResourceIdentityData(d, id); err != nil {
+		return err
+	}
+
 	credentials, err := client.ListClusterUserCredentials(ctx, *id, managedclusters.ListClusterUserCredentialsOperationOptions{})
 	if err != nil {
 		return fmt.Errorf("retrieving User Credentials for %s: %+v", id, err)
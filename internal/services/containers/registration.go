@@ -4,6 +4,8 @@
 package containers
 
 import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -13,8 +15,10 @@ type Registration struct {
 }
 
 var (
-	_ sdk.TypedServiceRegistration   = Registration{}
-	_ sdk.UntypedServiceRegistration = Registration{}
+	_ sdk.TypedServiceRegistration             = Registration{}
+	_ sdk.UntypedServiceRegistration           = Registration{}
+	_ sdk.ServiceRegistrationWithListResources = Registration{}
+	_ sdk.ServiceRegistrationWithResourceIds   = Registration{}
 )
 
 // Name is the name of this Service
@@ -81,3 +85,18 @@ func (r Registration) Resources() []sdk.Resource {
 	resources = append(resources, r.autoRegistration.Resources()...)
 	return resources
 }
+
+// ListResources returns a list of List Resources supported by this Service
+func (r Registration) ListResources() []sdk.ListResource {
+	return []sdk.ListResource{
+		KubernetesClusterListResource{},
+	}
+}
+
+// ResourceIds returns a list of the Resource ID types used by this Service
+func (r Registration) ResourceIds() []resourceids.ResourceId {
+	return []resourceids.ResourceId{
+		&commonids.KubernetesClusterId{},
+		&commonids.KubernetesFleetId{},
+	}
+}
@@ -4,7 +4,10 @@
 package client
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-02-01/vaults"
+	resourcegraph "github.com/hashicorp/go-azure-sdk/resource-manager/resourcegraph/2022-10-01/resources"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	dataplane "github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
 )
@@ -20,17 +23,31 @@ type Client struct {
 	VaultsClient *vaults.VaultsClient
 
 	ManagementClient *dataplane.BaseClient
+
+	// ResourceGraphClient is used to look up the Resource ID of a Key Vault from its Data Plane URI
+	ResourceGraphClient *resourcegraph.ResourcesClient
+
+	// tenantId is the ID of the Tenant the Key Vaults exist within, which is used to key the on-disk cache
+	tenantId string
 }
 
-func NewClient(o *common.ClientOptions) *Client {
+func NewClient(o *common.ClientOptions) (*Client, error) {
 	managementClient := dataplane.New()
 	o.ConfigureClient(&managementClient.Client, o.KeyVaultAuthorizer)
 
 	vaultsClient := vaults.NewVaultsClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&vaultsClient.Client, o.ResourceManagerAuthorizer)
 
-	return &Client{
-		ManagementClient: &managementClient,
-		VaultsClient:     &vaultsClient,
+	resourceGraphClient, err := resourcegraph.NewResourcesClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building ResourceGraph client: %+v", err)
 	}
+	o.Configure(resourceGraphClient.Client, o.Authorizers.ResourceManager)
+
+	return &Client{
+		ManagementClient:    &managementClient,
+		ResourceGraphClient: resourceGraphClient,
+		VaultsClient:        &vaultsClient,
+		tenantId:            o.TenantId,
+	}, nil
 }
@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+// diskCacheLock ensures that only a single instance of the Provider within this process writes to the on-disk cache at once
+var diskCacheLock = &sync.Mutex{}
+
+// diskCacheEntry is the representation of a Key Vault within the on-disk cache
+type diskCacheEntry struct {
+	KeyVaultId       string `json:"keyVaultId"`
+	DataPlaneBaseUri string `json:"dataPlaneBaseUri"`
+}
+
+// diskCachePath returns the path to the on-disk cache for the Key Vaults within this Tenant, or nil if the on-disk cache is disabled
+func (c *Client) diskCachePath() *string {
+	directory := features.KeyVaultCacheDirectory()
+	if directory == "" || c.tenantId == "" {
+		return nil
+	}
+
+	path := filepath.Join(directory, fmt.Sprintf("azurerm-key-vaults-%s.json", strings.ToLower(c.tenantId)))
+	return &path
+}
+
+// readFromDiskCache returns the Key Vault with the specified cache key from the on-disk cache, if present
+//
+// NOTE: the on-disk cache is best-effort, as such any errors are logged rather than returned
+func (c *Client) readFromDiskCache(cacheKey string) *diskCacheEntry {
+	path := c.diskCachePath()
+	if path == nil {
+		return nil
+	}
+
+	diskCacheLock.Lock()
+	defer diskCacheLock.Unlock()
+
+	entries, err := readDiskCacheFile(*path)
+	if err != nil {
+		log.Printf("[DEBUG] reading the Key Vault cache from %q: %+v", *path, err)
+		return nil
+	}
+
+	if v, ok := entries[cacheKey]; ok {
+		return &v
+	}
+
+	return nil
+}
+
+// writeToDiskCache adds (or where `entry` is nil, removes) the Key Vault with the specified cache key to the on-disk cache
+//
+// NOTE: the on-disk cache is best-effort, as such any errors are logged rather than returned
+func (c *Client) writeToDiskCache(cacheKey string, entry *diskCacheEntry) {
+	path := c.diskCachePath()
+	if path == nil {
+		return
+	}
+
+	diskCacheLock.Lock()
+	defer diskCacheLock.Unlock()
+
+	entries, err := readDiskCacheFile(*path)
+	if err != nil {
+		log.Printf("[DEBUG] reading the Key Vault cache from %q, the cache will be recreated: %+v", *path, err)
+		entries = make(map[string]diskCacheEntry)
+	}
+
+	if existing, ok := entries[cacheKey]; ok && entry != nil && existing == *entry {
+		return
+	}
+	if entry != nil {
+		entries[cacheKey] = *entry
+	} else {
+		if _, ok := entries[cacheKey]; !ok {
+			return
+		}
+		delete(entries, cacheKey)
+	}
+
+	if err := writeDiskCacheFile(*path, entries); err != nil {
+		log.Printf("[DEBUG] writing the Key Vault cache to %q: %+v", *path, err)
+	}
+}
+
+func readDiskCacheFile(path string) (map[string]diskCacheEntry, error) {
+	entries := make(map[string]diskCacheEntry)
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return entries, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(contents, &entries); err != nil {
+		return nil, fmt.Errorf("unmarshaling: %+v", err)
+	}
+
+	return entries, nil
+}
+
+func writeDiskCacheFile(path string, entries map[string]diskCacheEntry) error {
+	contents, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("marshaling: %+v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("creating directory: %+v", err)
+	}
+
+	// the cache can be shared between multiple instances of the Provider (e.g. concurrent Terraform runs) - so
+	// the cache is written to a temporary file which is then renamed, to ensure it's never partially written
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temporary file: %+v", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(contents); err != nil {
+		file.Close()
+		return fmt.Errorf("writing temporary file: %+v", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("closing temporary file: %+v", err)
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("renaming temporary file: %+v", err)
+	}
+
+	return nil
+}
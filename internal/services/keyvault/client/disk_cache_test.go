@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+)
+
+const testTenantId = "00000000-0000-0000-0000-000000000000"
+
+// testDiskCacheClient returns a Client using an on-disk cache within a temporary directory, along with the path to the cache
+func testDiskCacheClient(t *testing.T) (*Client, string) {
+	t.Helper()
+
+	directory := t.TempDir()
+	t.Setenv("ARM_KEY_VAULT_CACHE_DIRECTORY", directory)
+
+	client := &Client{
+		tenantId: testTenantId,
+	}
+	return client, filepath.Join(directory, "azurerm-key-vaults-"+testTenantId+".json")
+}
+
+func TestReadDiskCacheFile(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Contents *string
+		Expected map[string]diskCacheEntry
+		Error    bool
+	}{
+		{
+			Name:     "Missing File",
+			Contents: nil,
+			Expected: map[string]diskCacheEntry{},
+		},
+		{
+			Name:     "Empty Cache",
+			Contents: pointer.To(`{}`),
+			Expected: map[string]diskCacheEntry{},
+		},
+		{
+			Name:     "Corrupt File",
+			Contents: pointer.To(`{"vault1":{"keyVaultId":`),
+			Error:    true,
+		},
+		{
+			Name:     "Valid File",
+			Contents: pointer.To(`{"vault1":{"keyVaultId":"/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1/providers/Microsoft.KeyVault/vaults/vault1","dataPlaneBaseUri":"https://vault1.vault.azure.net/"}}`),
+			Expected: map[string]diskCacheEntry{
+				"vault1": {
+					KeyVaultId:       "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1/providers/Microsoft.KeyVault/vaults/vault1",
+					DataPlaneBaseUri: "https://vault1.vault.azure.net/",
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		path := filepath.Join(t.TempDir(), "cache.json")
+		if v.Contents != nil {
+			if err := os.WriteFile(path, []byte(*v.Contents), 0o600); err != nil {
+				t.Fatalf("writing cache file: %+v", err)
+			}
+		}
+
+		actual, err := readDiskCacheFile(path)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.Error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestWriteDiskCacheFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "cache.json")
+	entries := map[string]diskCacheEntry{
+		"vault1": {
+			KeyVaultId:       "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1/providers/Microsoft.KeyVault/vaults/vault1",
+			DataPlaneBaseUri: "https://vault1.vault.azure.net/",
+		},
+	}
+
+	// the directory is created when it doesn't exist
+	if err := writeDiskCacheFile(path, entries); err != nil {
+		t.Fatalf("writing cache file: %+v", err)
+	}
+
+	actual, err := readDiskCacheFile(path)
+	if err != nil {
+		t.Fatalf("reading cache file: %+v", err)
+	}
+	if !reflect.DeepEqual(actual, entries) {
+		t.Fatalf("expected %+v but got %+v", entries, actual)
+	}
+
+	// the temporary file used to write the cache is removed
+	files, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("listing directory: %+v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected only the cache file to exist but got %d files", len(files))
+	}
+}
+
+func TestWriteToDiskCache(t *testing.T) {
+	existing := diskCacheEntry{
+		KeyVaultId:       "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1/providers/Microsoft.KeyVault/vaults/vault1",
+		DataPlaneBaseUri: "https://vault1.vault.azure.net/",
+	}
+	recreated := diskCacheEntry{
+		KeyVaultId:       "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group2/providers/Microsoft.KeyVault/vaults/vault1",
+		DataPlaneBaseUri: "https://vault1.vault.azure.net/",
+	}
+
+	testData := []struct {
+		Name     string
+		Contents *string
+		Entry    *diskCacheEntry
+		Expected map[string]diskCacheEntry
+	}{
+		{
+			Name:     "Missing File",
+			Contents: nil,
+			Entry:    &existing,
+			Expected: map[string]diskCacheEntry{
+				"vault1": existing,
+			},
+		},
+		{
+			Name:     "Corrupt File is Recreated",
+			Contents: pointer.To(`not-json`),
+			Entry:    &existing,
+			Expected: map[string]diskCacheEntry{
+				"vault1": existing,
+			},
+		},
+		{
+			Name:     "Stale Entry is Replaced",
+			Contents: pointer.To(`{"vault1":{"keyVaultId":"/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1/providers/Microsoft.KeyVault/vaults/vault1","dataPlaneBaseUri":"https://vault1.vault.azure.net/"}}`),
+			Entry:    &recreated,
+			Expected: map[string]diskCacheEntry{
+				"vault1": recreated,
+			},
+		},
+		{
+			Name:     "Entry is Removed",
+			Contents: pointer.To(`{"vault1":{"keyVaultId":"/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1/providers/Microsoft.KeyVault/vaults/vault1","dataPlaneBaseUri":"https://vault1.vault.azure.net/"},"vault2":{"keyVaultId":"/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1/providers/Microsoft.KeyVault/vaults/vault2","dataPlaneBaseUri":"https://vault2.vault.azure.net/"}}`),
+			Entry:    nil,
+			Expected: map[string]diskCacheEntry{
+				"vault2": {
+					KeyVaultId:       "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1/providers/Microsoft.KeyVault/vaults/vault2",
+					DataPlaneBaseUri: "https://vault2.vault.azure.net/",
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		client, path := testDiskCacheClient(t)
+		if v.Contents != nil {
+			if err := os.WriteFile(path, []byte(*v.Contents), 0o600); err != nil {
+				t.Fatalf("writing cache file: %+v", err)
+			}
+		}
+
+		client.writeToDiskCache("vault1", v.Entry)
+
+		actual, err := readDiskCacheFile(path)
+		if err != nil {
+			t.Fatalf("reading cache file: %+v", err)
+		}
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+
+		if entry := client.readFromDiskCache("vault1"); !reflect.DeepEqual(entry, v.Entry) {
+			t.Fatalf("expected the entry %+v to be read from the cache but got %+v", v.Entry, entry)
+		}
+	}
+}
+
+func TestDiskCacheDisabled(t *testing.T) {
+	t.Setenv("ARM_KEY_VAULT_CACHE_DIRECTORY", "")
+
+	client := &Client{
+		tenantId: testTenantId,
+	}
+	if path := client.diskCachePath(); path != nil {
+		t.Fatalf("expected the on-disk cache to be disabled but got %q", *path)
+	}
+
+	// these are no-ops when the on-disk cache is disabled
+	client.writeToDiskCache("vault1", &diskCacheEntry{KeyVaultId: "example"})
+	if entry := client.readFromDiskCache("vault1"); entry != nil {
+		t.Fatalf("expected no entry but got %+v", entry)
+	}
+}
@@ -6,16 +6,22 @@ package client
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/url"
+	"regexp"
 	"strings"
 	"sync"
 
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-02-01/vaults"
+	resourcegraph "github.com/hashicorp/go-azure-sdk/resource-manager/resourcegraph/2022-10-01/resources"
 )
 
 var (
+	keyVaultNameRegex = regexp.MustCompile("^[a-zA-Z0-9-]{3,24}$")
+
 	keyVaultsCache = map[string]keyVaultDetails{}
 	keysmith       = &sync.RWMutex{}
 	lock           = map[string]*sync.RWMutex{}
@@ -52,26 +58,16 @@ func (c *Client) BaseUriForKeyVault(ctx context.Context, keyVaultId commonids.Ke
 		return &v.dataPlaneBaseUri, nil
 	}
 
-	resp, err := c.VaultsClient.Get(ctx, keyVaultId)
+	vaultUri, err := c.retrieveKeyVaultUri(ctx, keyVaultId)
 	if err != nil {
-		if response.WasNotFound(resp.HttpResponse) {
-			return nil, fmt.Errorf("%s was not found", keyVaultId)
-		}
-		return nil, fmt.Errorf("retrieving %s: %+v", keyVaultId, err)
-	}
-
-	vaultUri := ""
-	if model := resp.Model; model != nil {
-		if model.Properties.VaultUri != nil {
-			vaultUri = *model.Properties.VaultUri
-		}
+		return nil, err
 	}
-	if vaultUri == "" {
-		return nil, fmt.Errorf("retrieving %s: `properties.VaultUri` was nil", keyVaultId)
+	if vaultUri == nil {
+		return nil, fmt.Errorf("%s was not found", keyVaultId)
 	}
 
-	c.AddToCache(keyVaultId, vaultUri)
-	return &vaultUri, nil
+	c.AddToCache(keyVaultId, *vaultUri)
+	return vaultUri, nil
 }
 
 func (c *Client) Exists(ctx context.Context, keyVaultId commonids.KeyVaultId) (bool, error) {
@@ -88,26 +84,40 @@ func (c *Client) Exists(ctx context.Context, keyVaultId commonids.KeyVaultId) (b
 		return true, nil
 	}
 
-	resp, err := c.VaultsClient.Get(ctx, keyVaultId)
+	vaultUri, err := c.retrieveKeyVaultUri(ctx, keyVaultId)
 	if err != nil {
-		if response.WasNotFound(resp.HttpResponse) {
-			return false, nil
-		}
-		return false, fmt.Errorf("retrieving %s: %+v", keyVaultId, err)
+		return false, err
+	}
+	if vaultUri == nil {
+		return false, nil
 	}
+	c.AddToCache(keyVaultId, *vaultUri)
 
-	vaultUri := ""
-	if model := resp.Model; model != nil {
-		if model.Properties.VaultUri != nil {
-			vaultUri = *model.Properties.VaultUri
+	return true, nil
+}
+
+// KeyVaultIDFromBaseUrlWithKnownID returns the Resource ID of the Key Vault with the Data Plane URI `keyVaultBaseUrl`.
+//
+// Where `knownKeyVaultId` is specified (e.g. the `key_vault_id` field within the state of a nested item) and refers to
+// the same Key Vault, the Key Vault is retrieved directly using that Resource ID - falling back to KeyVaultIDFromBaseUrl
+// when it's not specified, or when the Key Vault no longer exists at that Resource ID.
+func (c *Client) KeyVaultIDFromBaseUrlWithKnownID(ctx context.Context, subscriptionId commonids.SubscriptionId, keyVaultBaseUrl string, knownKeyVaultId string) (*string, error) {
+	if knownKeyVaultId != "" {
+		keyVaultId, err := commonids.ParseKeyVaultIDInsensitively(knownKeyVaultId)
+		if err != nil {
+			return nil, err
+		}
+
+		found, err := c.keyVaultIDFromKnownID(ctx, *keyVaultId, keyVaultBaseUrl)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
 		}
 	}
-	if vaultUri == "" {
-		return false, fmt.Errorf("retrieving %s: `properties.VaultUri` was nil", keyVaultId)
-	}
-	c.AddToCache(keyVaultId, vaultUri)
 
-	return true, nil
+	return c.KeyVaultIDFromBaseUrl(ctx, subscriptionId, keyVaultBaseUrl)
 }
 
 func (c *Client) KeyVaultIDFromBaseUrl(ctx context.Context, subscriptionId commonids.SubscriptionId, keyVaultBaseUrl string) (*string, error) {
@@ -130,6 +140,38 @@ func (c *Client) KeyVaultIDFromBaseUrl(ctx context.Context, subscriptionId commo
 		return &v.keyVaultId, nil
 	}
 
+	// Next check the on-disk cache (when enabled) from a previous launch of the Provider - since the Key Vault
+	// may have since been deleted (or recreated elsewhere) this is confirmed by retrieving the Key Vault
+	if v := c.readFromDiskCache(cacheKey); v != nil {
+		keyVaultId, err := c.confirmKeyVault(ctx, v.KeyVaultId, keyVaultBaseUrl)
+		if err != nil {
+			return nil, err
+		}
+		if keyVaultId != nil {
+			return keyVaultId, nil
+		}
+		c.writeToDiskCache(cacheKey, nil)
+	}
+
+	// Then look up the Key Vault using the Resource Graph, which (unlike listing the Key Vaults within the Subscription
+	// below) is a single API call and also finds Key Vaults within other Subscriptions in the Tenant.
+	//
+	// The Resource Graph is eventually consistent, as such the Key Vault is confirmed by retrieving it - and where it's
+	// not found (e.g. the Key Vault has only just been created) we fall back to listing the Key Vaults below.
+	resourceGraphKeyVaultId, err := c.keyVaultIDFromResourceGraph(ctx, *keyVaultName)
+	if err != nil {
+		log.Printf("[DEBUG] unable to look up the Key Vault %q using the Resource Graph, falling back to listing the Key Vaults within %s: %+v", *keyVaultName, subscriptionId, err)
+	}
+	if resourceGraphKeyVaultId != nil {
+		keyVaultId, err := c.confirmKeyVault(ctx, *resourceGraphKeyVaultId, keyVaultBaseUrl)
+		if err != nil {
+			return nil, err
+		}
+		if keyVaultId != nil {
+			return keyVaultId, nil
+		}
+	}
+
 	// Pull out the list of Key Vaults available within the Subscription to re-populate the cache
 	//
 	// Whilst we've historically used the Resources API to query the single Key Vault in question
@@ -177,6 +219,10 @@ func (c *Client) KeyVaultIDFromBaseUrl(ctx context.Context, subscriptionId commo
 
 	// Now that the cache has been repopulated, check if we have the key vault or not
 	if v, ok := keyVaultsCache[cacheKey]; ok {
+		c.writeToDiskCache(cacheKey, &diskCacheEntry{
+			KeyVaultId:       v.keyVaultId,
+			DataPlaneBaseUri: v.dataPlaneBaseUri,
+		})
 		return &v.keyVaultId, nil
 	}
 
@@ -193,9 +239,145 @@ func (c *Client) Purge(keyVaultId commonids.KeyVaultId) {
 	keysmith.Unlock()
 	lock[cacheKey].Lock()
 	delete(keyVaultsCache, cacheKey)
+	c.writeToDiskCache(cacheKey, nil)
 	lock[cacheKey].Unlock()
 }
 
+// keyVaultIDFromKnownID returns the Resource ID of the Key Vault `keyVaultId` when it exists with the Data Plane URI
+// `keyVaultBaseUrl`, retrieving it directly rather than looking it up - or nil if that's not the case.
+func (c *Client) keyVaultIDFromKnownID(ctx context.Context, keyVaultId commonids.KeyVaultId, keyVaultBaseUrl string) (*string, error) {
+	keyVaultName, err := c.parseNameFromBaseUrl(keyVaultBaseUrl)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(keyVaultId.VaultName, *keyVaultName) {
+		return nil, nil
+	}
+
+	cacheKey := c.cacheKeyForKeyVault(*keyVaultName)
+	keysmith.Lock()
+	if lock[cacheKey] == nil {
+		lock[cacheKey] = &sync.RWMutex{}
+	}
+	keysmith.Unlock()
+	lock[cacheKey].Lock()
+	defer lock[cacheKey].Unlock()
+
+	if v, ok := keyVaultsCache[cacheKey]; ok {
+		return &v.keyVaultId, nil
+	}
+
+	return c.confirmKeyVault(ctx, keyVaultId.ID(), keyVaultBaseUrl)
+}
+
+// confirmKeyVault retrieves the Key Vault with the Resource ID `keyVaultIdRaw`, returning the normalized Resource ID
+// and adding the Key Vault to the cache when it exists with the Data Plane URI `keyVaultBaseUrl` - or nil if it doesn't.
+//
+// NOTE: the lock for this Key Vault must be held by the caller
+func (c *Client) confirmKeyVault(ctx context.Context, keyVaultIdRaw string, keyVaultBaseUrl string) (*string, error) {
+	keyVaultId, err := commonids.ParseKeyVaultIDInsensitively(keyVaultIdRaw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q as a Key Vault ID: %+v", keyVaultIdRaw, err)
+	}
+
+	vaultUri, err := c.retrieveKeyVaultUri(ctx, *keyVaultId)
+	if err != nil {
+		return nil, err
+	}
+	if vaultUri == nil || !c.isSameDataPlaneUri(*vaultUri, keyVaultBaseUrl) {
+		return nil, nil
+	}
+
+	c.AddToCache(*keyVaultId, *vaultUri)
+	c.writeToDiskCache(c.cacheKeyForKeyVault(keyVaultId.VaultName), &diskCacheEntry{
+		KeyVaultId:       keyVaultId.ID(),
+		DataPlaneBaseUri: *vaultUri,
+	})
+
+	id := keyVaultId.ID()
+	return &id, nil
+}
+
+// keyVaultIDFromResourceGraph looks up the Resource ID of the Key Vault named `keyVaultName` across all of the
+// Subscriptions within the Tenant using the Resource Graph - returning nil if it's not found
+func (c *Client) keyVaultIDFromResourceGraph(ctx context.Context, keyVaultName string) (*string, error) {
+	if c.ResourceGraphClient == nil {
+		return nil, nil
+	}
+
+	// the name is interpolated into the query, so ensure it's a valid Key Vault name
+	if !keyVaultNameRegex.MatchString(keyVaultName) {
+		return nil, fmt.Errorf("%q is not a valid Key Vault name", keyVaultName)
+	}
+
+	request := resourcegraph.QueryRequest{
+		Query: fmt.Sprintf("Resources | where type =~ 'microsoft.keyvault/vaults' and name =~ '%s' | project id", keyVaultName),
+		Options: &resourcegraph.QueryRequestOptions{
+			ResultFormat: pointer.To(resourcegraph.ResultFormatObjectArray),
+		},
+	}
+	resp, err := c.ResourceGraphClient.Resources(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("running Resource Graph query: %+v", err)
+	}
+	if resp.Model == nil {
+		return nil, fmt.Errorf("running Resource Graph query: `model` was nil")
+	}
+
+	rows, ok := resp.Model.Data.([]interface{})
+	if !ok {
+		return nil, nil
+	}
+	for _, v := range rows {
+		row, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, ok := row["id"].(string); ok && id != "" {
+			return &id, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// retrieveKeyVaultUri retrieves the Data Plane URI for the Key Vault `keyVaultId`, returning nil if it doesn't exist
+func (c *Client) retrieveKeyVaultUri(ctx context.Context, keyVaultId commonids.KeyVaultId) (*string, error) {
+	resp, err := c.VaultsClient.Get(ctx, keyVaultId)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", keyVaultId, err)
+	}
+
+	vaultUri := ""
+	if model := resp.Model; model != nil {
+		if model.Properties.VaultUri != nil {
+			vaultUri = *model.Properties.VaultUri
+		}
+	}
+	if vaultUri == "" {
+		return nil, fmt.Errorf("retrieving %s: `properties.VaultUri` was nil", keyVaultId)
+	}
+
+	return &vaultUri, nil
+}
+
+// isSameDataPlaneUri returns whether the Data Plane URIs `first` and `second` refer to the same Key Vault
+func (c *Client) isSameDataPlaneUri(first string, second string) bool {
+	firstUri, err := url.Parse(first)
+	if err != nil {
+		return false
+	}
+	secondUri, err := url.Parse(second)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(firstUri.Host, secondUri.Host)
+}
+
 func (c *Client) cacheKeyForKeyVault(name string) string {
 	return strings.ToLower(name)
 }
@@ -0,0 +1,530 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-02-01/vaults"
+	resourcegraph "github.com/hashicorp/go-azure-sdk/resource-manager/resourcegraph/2022-10-01/resources"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"golang.org/x/oauth2"
+)
+
+const testSubscriptionId = "11111111-1111-1111-1111-111111111111"
+
+// fakeKeyVaultApi serves the Key Vaults (keyed by Resource ID) using the Key Vault and Resource Graph APIs
+type fakeKeyVaultApi struct {
+	lock sync.Mutex
+
+	// vaults maps the Resource ID of each Key Vault to its Data Plane URI
+	vaults map[string]string
+
+	// resourceGraphIds are the Resource IDs returned from the Resource Graph, which is eventually consistent
+	resourceGraphIds []string
+
+	requests []string
+}
+
+func (f *fakeKeyVaultApi) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.Method == http.MethodPost && strings.EqualFold(r.URL.Path, "/providers/Microsoft.ResourceGraph/resources"):
+		rows := make([]interface{}, 0)
+		for _, id := range f.resourceGraphIds {
+			rows = append(rows, map[string]interface{}{"id": id})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"count":           len(rows),
+			"data":            rows,
+			"resultTruncated": "false",
+			"totalRecords":    len(rows),
+		})
+
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/providers/Microsoft.KeyVault/vaults"):
+		items := make([]interface{}, 0)
+		for id, vaultUri := range f.vaults {
+			items = append(items, fakeVault(id, vaultUri))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"value": items,
+		})
+
+	case r.Method == http.MethodGet:
+		for id, vaultUri := range f.vaults {
+			if strings.EqualFold(id, r.URL.Path) {
+				_ = json.NewEncoder(w).Encode(fakeVault(id, vaultUri))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":"ResourceNotFound","message":"not found"}}`))
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeKeyVaultApi) requestCount(prefix string) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	count := 0
+	for _, v := range f.requests {
+		if strings.HasPrefix(v, prefix) {
+			count++
+		}
+	}
+	return count
+}
+
+func fakeVault(id string, vaultUri string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":   id,
+		"name": id[strings.LastIndex(id, "/")+1:],
+		"properties": map[string]interface{}{
+			"tenantId": testTenantId,
+			"vaultUri": vaultUri,
+			"sku": map[string]interface{}{
+				"family": "A",
+				"name":   "standard",
+			},
+		},
+	}
+}
+
+// testKeyVaultClient returns a Client using the fakeKeyVaultApi, with the in-memory cache reset
+func testKeyVaultClient(t *testing.T, api *fakeKeyVaultApi, withResourceGraph bool) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	keysmith.Lock()
+	keyVaultsCache = map[string]keyVaultDetails{}
+	keysmith.Unlock()
+
+	vaultsClient := vaults.NewVaultsClientWithBaseURI(server.URL)
+	client := &Client{
+		VaultsClient: &vaultsClient,
+		tenantId:     testTenantId,
+	}
+
+	if withResourceGraph {
+		resourceGraphClient, err := resourcegraph.NewResourcesClientWithBaseURI(environments.ResourceManagerAPI(server.URL))
+		if err != nil {
+			t.Fatalf("building Resource Graph client: %+v", err)
+		}
+		resourceGraphClient.Client.Authorizer = testAuthorizer{}
+		client.ResourceGraphClient = resourceGraphClient
+	}
+
+	// the on-disk cache is disabled unless enabled by the test
+	t.Setenv("ARM_KEY_VAULT_CACHE_DIRECTORY", "")
+
+	return client
+}
+
+func TestKeyVaultIDFromBaseUrlRemovesStaleDiskCacheEntry(t *testing.T) {
+	oldId := commonids.NewKeyVaultID(testSubscriptionId, "old-group", "vault1").ID()
+	newId := commonids.NewKeyVaultID(testSubscriptionId, "new-group", "vault1").ID()
+
+	testData := []struct {
+		Name     string
+		Vaults   map[string]string
+		Expected *string
+	}{
+		{
+			Name: "Key Vault Recreated in Another Resource Group",
+			Vaults: map[string]string{
+				newId: "https://vault1.vault.azure.net/",
+			},
+			Expected: &newId,
+		},
+		{
+			Name:     "Key Vault No Longer Exists",
+			Vaults:   map[string]string{},
+			Expected: nil,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		api := &fakeKeyVaultApi{
+			vaults: v.Vaults,
+		}
+		client := testKeyVaultClient(t, api, false)
+		client, path := withDiskCache(t, client)
+
+		// the on-disk cache contains the Key Vault from a previous run of the Provider, which no longer exists
+		if err := writeDiskCacheFile(path, map[string]diskCacheEntry{
+			"vault1": {
+				KeyVaultId:       oldId,
+				DataPlaneBaseUri: "https://vault1.vault.azure.net/",
+			},
+		}); err != nil {
+			t.Fatalf("writing cache file: %+v", err)
+		}
+
+		actual, err := client.KeyVaultIDFromBaseUrl(testContext(t), commonids.NewSubscriptionID(testSubscriptionId), "https://vault1.vault.azure.net/")
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if !equalIds(actual, v.Expected) {
+			t.Fatalf("expected %v but got %v", v.Expected, actual)
+		}
+
+		entries, err := readDiskCacheFile(path)
+		if err != nil {
+			t.Fatalf("reading cache file: %+v", err)
+		}
+		entry, ok := entries["vault1"]
+		if v.Expected == nil {
+			if ok {
+				t.Fatalf("expected the stale entry to be removed from the cache but got %+v", entry)
+			}
+			continue
+		}
+		if !ok || entry.KeyVaultId != *v.Expected {
+			t.Fatalf("expected the cache to contain %q but got %+v", *v.Expected, entries)
+		}
+	}
+}
+
+func TestKeyVaultIDFromBaseUrlUsesDiskCache(t *testing.T) {
+	id := commonids.NewKeyVaultID(testSubscriptionId, "group1", "vault1").ID()
+	api := &fakeKeyVaultApi{
+		vaults: map[string]string{
+			id: "https://vault1.vault.azure.net/",
+		},
+	}
+	client := testKeyVaultClient(t, api, true)
+	client, path := withDiskCache(t, client)
+
+	if err := writeDiskCacheFile(path, map[string]diskCacheEntry{
+		"vault1": {
+			KeyVaultId:       id,
+			DataPlaneBaseUri: "https://vault1.vault.azure.net/",
+		},
+	}); err != nil {
+		t.Fatalf("writing cache file: %+v", err)
+	}
+
+	actual, err := client.KeyVaultIDFromBaseUrl(testContext(t), commonids.NewSubscriptionID(testSubscriptionId), "https://vault1.vault.azure.net/")
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if !equalIds(actual, &id) {
+		t.Fatalf("expected %q but got %v", id, actual)
+	}
+
+	// the Key Vault is confirmed by retrieving it, rather than looking it up
+	if count := api.requestCount("POST"); count != 0 {
+		t.Fatalf("expected the Resource Graph not to be queried but got %d requests", count)
+	}
+	if count := api.requestCount("GET /subscriptions/" + testSubscriptionId + "/providers"); count != 0 {
+		t.Fatalf("expected the Key Vaults not to be listed but got %d requests", count)
+	}
+}
+
+func TestKeyVaultIDFromBaseUrlWithKnownID(t *testing.T) {
+	id := commonids.NewKeyVaultID(testSubscriptionId, "group1", "vault1").ID()
+	otherId := commonids.NewKeyVaultID(testSubscriptionId, "group1", "vault2").ID()
+
+	testData := []struct {
+		Name         string
+		KnownId      string
+		Vaults       map[string]string
+		Expected     *string
+		ExpectLookup bool
+	}{
+		{
+			Name:    "Known ID",
+			KnownId: id,
+			Vaults: map[string]string{
+				id: "https://vault1.vault.azure.net/",
+			},
+			Expected:     &id,
+			ExpectLookup: false,
+		},
+		{
+			Name:    "Known ID with Different Casing",
+			KnownId: strings.ToLower(id),
+			Vaults: map[string]string{
+				id: "https://vault1.vault.azure.net/",
+			},
+			Expected:     &id,
+			ExpectLookup: false,
+		},
+		{
+			// the known ID refers to a different Key Vault, so the Key Vault is looked up by its name
+			Name:    "Known ID for Different Key Vault",
+			KnownId: otherId,
+			Vaults: map[string]string{
+				id:      "https://vault1.vault.azure.net/",
+				otherId: "https://vault2.vault.azure.net/",
+			},
+			Expected:     &id,
+			ExpectLookup: true,
+		},
+		{
+			Name:         "Known ID No Longer Exists",
+			KnownId:      id,
+			Vaults:       map[string]string{},
+			Expected:     nil,
+			ExpectLookup: true,
+		},
+		{
+			Name:    "No Known ID",
+			KnownId: "",
+			Vaults: map[string]string{
+				id: "https://vault1.vault.azure.net/",
+			},
+			Expected:     &id,
+			ExpectLookup: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		api := &fakeKeyVaultApi{
+			vaults: v.Vaults,
+		}
+		client := testKeyVaultClient(t, api, false)
+
+		actual, err := client.KeyVaultIDFromBaseUrlWithKnownID(testContext(t), commonids.NewSubscriptionID(testSubscriptionId), "https://vault1.vault.azure.net/", v.KnownId)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if !equalIds(actual, v.Expected) {
+			t.Fatalf("expected %v but got %v", v.Expected, actual)
+		}
+
+		lookedUp := api.requestCount("GET /subscriptions/"+testSubscriptionId+"/providers/Microsoft.KeyVault/vaults") > 0
+		if lookedUp != v.ExpectLookup {
+			t.Fatalf("expected the Key Vaults to be listed to be %t but got %t", v.ExpectLookup, lookedUp)
+		}
+	}
+}
+
+func TestKeyVaultIDFromResourceGraph(t *testing.T) {
+	id := commonids.NewKeyVaultID(testSubscriptionId, "group1", "vault1").ID()
+
+	testData := []struct {
+		Name              string
+		KeyVaultName      string
+		ResourceGraphIds  []string
+		WithResourceGraph bool
+		Expected          *string
+		Error             bool
+	}{
+		{
+			Name:              "Found",
+			KeyVaultName:      "vault1",
+			ResourceGraphIds:  []string{id},
+			WithResourceGraph: true,
+			Expected:          &id,
+		},
+		{
+			Name:              "Not Found",
+			KeyVaultName:      "vault1",
+			ResourceGraphIds:  []string{},
+			WithResourceGraph: true,
+			Expected:          nil,
+		},
+		{
+			Name:              "No Resource Graph Client",
+			KeyVaultName:      "vault1",
+			ResourceGraphIds:  []string{id},
+			WithResourceGraph: false,
+			Expected:          nil,
+		},
+		{
+			// the name is interpolated into the query
+			Name:              "Invalid Name",
+			KeyVaultName:      "vault1' or name =~ 'vault2",
+			WithResourceGraph: true,
+			Error:             true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		api := &fakeKeyVaultApi{
+			resourceGraphIds: v.ResourceGraphIds,
+		}
+		client := testKeyVaultClient(t, api, v.WithResourceGraph)
+
+		actual, err := client.keyVaultIDFromResourceGraph(testContext(t), v.KeyVaultName)
+		if err != nil {
+			if v.Error {
+				if count := api.requestCount("POST"); count != 0 {
+					t.Fatalf("expected the Resource Graph not to be queried but got %d requests", count)
+				}
+				continue
+			}
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.Error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !equalIds(actual, v.Expected) {
+			t.Fatalf("expected %v but got %v", v.Expected, actual)
+		}
+	}
+}
+
+func TestConfirmKeyVault(t *testing.T) {
+	id := commonids.NewKeyVaultID(testSubscriptionId, "group1", "vault1").ID()
+
+	testData := []struct {
+		Name        string
+		KeyVaultId  string
+		Vaults      map[string]string
+		Expected    *string
+		ExpectCache bool
+		Error       bool
+	}{
+		{
+			Name:       "Exists",
+			KeyVaultId: id,
+			Vaults: map[string]string{
+				id: "https://vault1.vault.azure.net/",
+			},
+			Expected:    &id,
+			ExpectCache: true,
+		},
+		{
+			Name:       "Exists with Different Casing",
+			KeyVaultId: strings.Replace(id, "/resourceGroups/", "/resourcegroups/", 1),
+			Vaults: map[string]string{
+				id: "https://vault1.vault.azure.net/",
+			},
+			Expected:    &id,
+			ExpectCache: true,
+		},
+		{
+			Name:       "Different Data Plane URI",
+			KeyVaultId: id,
+			Vaults: map[string]string{
+				id: "https://vault1.vault.usgovcloudapi.net/",
+			},
+			Expected: nil,
+		},
+		{
+			Name:       "Not Found",
+			KeyVaultId: id,
+			Vaults:     map[string]string{},
+			Expected:   nil,
+		},
+		{
+			Name:       "Invalid ID",
+			KeyVaultId: "/subscriptions/" + testSubscriptionId + "/resourceGroups/group1",
+			Error:      true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		api := &fakeKeyVaultApi{
+			vaults: v.Vaults,
+		}
+		client := testKeyVaultClient(t, api, false)
+		client, path := withDiskCache(t, client)
+
+		actual, err := client.confirmKeyVault(testContext(t), v.KeyVaultId, "https://vault1.vault.azure.net/")
+		if err != nil {
+			if v.Error {
+				continue
+			}
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.Error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !equalIds(actual, v.Expected) {
+			t.Fatalf("expected %v but got %v", v.Expected, actual)
+		}
+
+		keysmith.Lock()
+		_, cached := keyVaultsCache["vault1"]
+		keysmith.Unlock()
+		if cached != v.ExpectCache {
+			t.Fatalf("expected the Key Vault to be cached to be %t but got %t", v.ExpectCache, cached)
+		}
+
+		entries, err := readDiskCacheFile(path)
+		if err != nil {
+			t.Fatalf("reading cache file: %+v", err)
+		}
+		if _, ok := entries["vault1"]; ok != v.ExpectCache {
+			t.Fatalf("expected the Key Vault to be cached on disk to be %t but got %t", v.ExpectCache, ok)
+		}
+	}
+}
+
+// withDiskCache enables the on-disk cache for the Client, returning the path to the cache
+func withDiskCache(t *testing.T, client *Client) (*Client, string) {
+	t.Helper()
+
+	directory := t.TempDir()
+	t.Setenv("ARM_KEY_VAULT_CACHE_DIRECTORY", directory)
+
+	path := client.diskCachePath()
+	if path == nil {
+		t.Fatalf("expected the on-disk cache to be enabled")
+	}
+	if _, err := os.Stat(directory); err != nil {
+		t.Fatalf("expected the cache directory to exist: %+v", err)
+	}
+
+	return client, *path
+}
+
+// testAuthorizer is an auth.Authorizer returning a static access token, since the test server doesn't validate these
+type testAuthorizer struct{}
+
+func (testAuthorizer) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	return &oauth2.Token{
+		AccessToken: "keyvault",
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(time.Hour),
+	}, nil
+}
+
+func (testAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return []*oauth2.Token{}, nil
+}
+
+// testContext returns a Context with a deadline, which is required by the SDK for polling purposes
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func equalIds(first *string, second *string) bool {
+	if first == nil || second == nil {
+		return first == nil && second == nil
+	}
+	return *first == *second
+}
@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var _ sdk.EphemeralResource = KeyVaultCertificateEphemeralResource{}
+
+type KeyVaultCertificateEphemeralResource struct{}
+
+type KeyVaultCertificateEphemeralResourceModel struct {
+	Name              string `tfschema:"name"`
+	KeyVaultId        string `tfschema:"key_vault_id"`
+	Version           string `tfschema:"version"`
+	Hex               string `tfschema:"hex"`
+	Pem               string `tfschema:"pem"`
+	Key               string `tfschema:"key"`
+	Expires           string `tfschema:"expires"`
+	NotBefore         string `tfschema:"not_before"`
+	CertificatesCount int64  `tfschema:"certificates_count"`
+}
+
+func (KeyVaultCertificateEphemeralResource) ResourceType() string {
+	return "azurerm_key_vault_certificate"
+}
+
+func (KeyVaultCertificateEphemeralResource) ModelObject() interface{} {
+	return &KeyVaultCertificateEphemeralResourceModel{}
+}
+
+func (KeyVaultCertificateEphemeralResource) TypedArguments() sdk.TypedSchema {
+	return sdk.TypedSchema{
+		Attributes: map[string]sdk.Attribute{
+			"name": {
+				Type:        sdk.AttributeTypeString,
+				Required:    true,
+				Description: "The name of the Key Vault Certificate.",
+				Validation: &sdk.AttributeValidation{
+					Pattern: "^[0-9a-zA-Z-]+$",
+				},
+			},
+
+			"key_vault_id": {
+				Type:        sdk.AttributeTypeString,
+				Required:    true,
+				Description: "The ID of the Key Vault where the Certificate exists.",
+				Validation: &sdk.AttributeValidation{
+					NotEmpty: true,
+				},
+			},
+
+			"version": {
+				Type:        sdk.AttributeTypeString,
+				Optional:    true,
+				Description: "The version of the Key Vault Certificate. Defaults to the current version.",
+			},
+		},
+	}
+}
+
+func (KeyVaultCertificateEphemeralResource) TypedAttributes() sdk.TypedSchema {
+	return sdk.TypedSchema{
+		Attributes: map[string]sdk.Attribute{
+			"hex": {
+				Type:        sdk.AttributeTypeString,
+				Description: "The raw Key Vault Certificate data represented as a hexadecimal string.",
+			},
+
+			"pem": {
+				Type:        sdk.AttributeTypeString,
+				Description: "The Key Vault Certificate in PEM format.",
+			},
+
+			"key": {
+				Type:        sdk.AttributeTypeString,
+				Sensitive:   true,
+				Description: "The Key Vault Certificate Key.",
+			},
+
+			"expires": {
+				Type:        sdk.AttributeTypeString,
+				Description: "The date and time at which the Key Vault Certificate expires.",
+			},
+
+			"not_before": {
+				Type:        sdk.AttributeTypeString,
+				Description: "The earliest date at which the Key Vault Certificate can be used.",
+			},
+
+			"certificates_count": {
+				Type:        sdk.AttributeTypeInt64,
+				Description: "The number of certificates in the chain.",
+			},
+		},
+	}
+}
+
+func (KeyVaultCertificateEphemeralResource) Open() sdk.EphemeralResourceFunc {
+	return sdk.EphemeralResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.EphemeralResourceMetaData) error {
+			keyVaultsClient := metadata.Client.KeyVault
+			client := metadata.Client.KeyVault.ManagementClient
+
+			var config KeyVaultCertificateEphemeralResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			keyVaultId, err := commonids.ParseKeyVaultID(config.KeyVaultId)
+			if err != nil {
+				return err
+			}
+
+			keyVaultBaseUri, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
+			if err != nil {
+				return fmt.Errorf("looking up Certificate %q vault url from id %q: %+v", config.Name, *keyVaultId, err)
+			}
+
+			cert, err := client.GetCertificate(ctx, *keyVaultBaseUri, config.Name, config.Version)
+			if err != nil {
+				if utils.ResponseWasNotFound(cert.Response) {
+					return fmt.Errorf("the Certificate %q was not found in Key Vault at URI %q", config.Name, *keyVaultBaseUri)
+				}
+				return fmt.Errorf("reading Key Vault Certificate: %+v", err)
+			}
+
+			if cert.ID == nil || *cert.ID == "" {
+				return fmt.Errorf("failure reading Key Vault Certificate ID for %q", config.Name)
+			}
+
+			id, err := parse.ParseNestedItemID(*cert.ID)
+			if err != nil {
+				return err
+			}
+
+			if contents := cert.Cer; contents != nil {
+				config.Hex = strings.ToUpper(hex.EncodeToString(*contents))
+			}
+			if attributes := cert.Attributes; attributes != nil {
+				if expires := attributes.Expires; expires != nil {
+					config.Expires = time.Time(*expires).Format(time.RFC3339)
+				}
+				if notBefore := attributes.NotBefore; notBefore != nil {
+					config.NotBefore = time.Time(*notBefore).Format(time.RFC3339)
+				}
+			}
+
+			pfx, err := client.GetSecret(ctx, id.KeyVaultBaseUrl, id.Name, id.Version)
+			if err != nil {
+				return fmt.Errorf("retrieving certificate %q from keyvault: %+v", id.Name, err)
+			}
+
+			certs, key, certificatesCount, err := flattenKeyVaultCertificatePEMData(id.Name, pfx)
+			if err != nil {
+				return err
+			}
+			config.Pem = certs
+			config.Key = key
+			config.CertificatesCount = int64(certificatesCount)
+
+			return metadata.Encode(&config)
+		},
+	}
+}
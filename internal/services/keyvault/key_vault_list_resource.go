@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-02-01/vaults"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+var _ sdk.ListResource = KeyVaultListResource{}
+
+type KeyVaultListResource struct{}
+
+func (KeyVaultListResource) ResourceType() string {
+	return "azurerm_key_vault"
+}
+
+func (KeyVaultListResource) Identity() resourceids.ResourceId {
+	return &commonids.KeyVaultId{}
+}
+
+func (KeyVaultListResource) List() sdk.ListResourceFunc {
+	return sdk.ListResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ListResourceMetaData) ([]sdk.ListResourceResult, error) {
+			client := metadata.Client.KeyVault.VaultsClient
+			subscriptionId := commonids.NewSubscriptionID(metadata.Client.Account.SubscriptionId)
+
+			var items []vaults.Vault
+			if metadata.ResourceGroupName != "" {
+				resourceGroupId := commonids.NewResourceGroupID(subscriptionId.SubscriptionId, metadata.ResourceGroupName)
+				resp, err := client.ListByResourceGroupComplete(ctx, resourceGroupId, vaults.DefaultListByResourceGroupOperationOptions())
+				if err != nil {
+					return nil, fmt.Errorf("listing Key Vaults within %s: %+v", resourceGroupId, err)
+				}
+				items = resp.Items
+			} else {
+				resp, err := client.ListBySubscriptionComplete(ctx, subscriptionId, vaults.DefaultListBySubscriptionOperationOptions())
+				if err != nil {
+					return nil, fmt.Errorf("listing Key Vaults within %s: %+v", subscriptionId, err)
+				}
+				items = resp.Items
+			}
+
+			results := make([]sdk.ListResourceResult, 0)
+			for _, item := range items {
+				id, err := commonids.ParseKeyVaultIDInsensitively(pointer.From(item.Id))
+				if err != nil {
+					return nil, err
+				}
+
+				results = append(results, sdk.ListResourceResult{
+					ID:   id,
+					Tags: pointer.From(item.Tags),
+				})
+			}
+
+			return results, nil
+		},
+	}
+}
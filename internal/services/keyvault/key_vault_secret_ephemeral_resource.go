@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var _ sdk.EphemeralResource = KeyVaultSecretEphemeralResource{}
+
+type KeyVaultSecretEphemeralResource struct{}
+
+type KeyVaultSecretEphemeralResourceModel struct {
+	Name           string `tfschema:"name"`
+	KeyVaultId     string `tfschema:"key_vault_id"`
+	Version        string `tfschema:"version"`
+	Value          string `tfschema:"value"`
+	ContentType    string `tfschema:"content_type"`
+	NotBeforeDate  string `tfschema:"not_before_date"`
+	ExpirationDate string `tfschema:"expiration_date"`
+}
+
+func (KeyVaultSecretEphemeralResource) ResourceType() string {
+	return "azurerm_key_vault_secret"
+}
+
+func (KeyVaultSecretEphemeralResource) ModelObject() interface{} {
+	return &KeyVaultSecretEphemeralResourceModel{}
+}
+
+func (KeyVaultSecretEphemeralResource) TypedArguments() sdk.TypedSchema {
+	return sdk.TypedSchema{
+		Attributes: map[string]sdk.Attribute{
+			"name": {
+				Type:        sdk.AttributeTypeString,
+				Required:    true,
+				Description: "The name of the Key Vault Secret.",
+				Validation: &sdk.AttributeValidation{
+					Pattern: "^[0-9a-zA-Z-]+$",
+				},
+			},
+
+			"key_vault_id": {
+				Type:        sdk.AttributeTypeString,
+				Required:    true,
+				Description: "The ID of the Key Vault where the Secret exists.",
+				Validation: &sdk.AttributeValidation{
+					NotEmpty: true,
+				},
+			},
+
+			"version": {
+				Type:        sdk.AttributeTypeString,
+				Optional:    true,
+				Description: "The version of the Key Vault Secret. Defaults to the current version.",
+			},
+		},
+	}
+}
+
+func (KeyVaultSecretEphemeralResource) TypedAttributes() sdk.TypedSchema {
+	return sdk.TypedSchema{
+		Attributes: map[string]sdk.Attribute{
+			"value": {
+				Type:        sdk.AttributeTypeString,
+				Sensitive:   true,
+				Description: "The value of the Key Vault Secret.",
+			},
+
+			"content_type": {
+				Type:        sdk.AttributeTypeString,
+				Description: "The content type of the Key Vault Secret.",
+			},
+
+			"not_before_date": {
+				Type:        sdk.AttributeTypeString,
+				Description: "The earliest date at which the Key Vault Secret can be used.",
+			},
+
+			"expiration_date": {
+				Type:        sdk.AttributeTypeString,
+				Description: "The date and time at which the Key Vault Secret expires and is no longer valid.",
+			},
+		},
+	}
+}
+
+func (KeyVaultSecretEphemeralResource) Open() sdk.EphemeralResourceFunc {
+	return sdk.EphemeralResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.EphemeralResourceMetaData) error {
+			keyVaultsClient := metadata.Client.KeyVault
+			client := metadata.Client.KeyVault.ManagementClient
+
+			var config KeyVaultSecretEphemeralResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			keyVaultId, err := commonids.ParseKeyVaultID(config.KeyVaultId)
+			if err != nil {
+				return err
+			}
+
+			keyVaultBaseUri, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
+			if err != nil {
+				return fmt.Errorf("looking up Secret %q vault url from id %q: %+v", config.Name, *keyVaultId, err)
+			}
+
+			resp, err := client.GetSecret(ctx, *keyVaultBaseUri, config.Name, config.Version)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return fmt.Errorf("KeyVault Secret %q (KeyVault URI %q) does not exist", config.Name, *keyVaultBaseUri)
+				}
+				return fmt.Errorf("retrieving KeyVault Secret %q (KeyVault URI %q): %+v", config.Name, *keyVaultBaseUri, err)
+			}
+
+			config.Value = utils.NormalizeNilableString(resp.Value)
+			config.ContentType = utils.NormalizeNilableString(resp.ContentType)
+			if attributes := resp.Attributes; attributes != nil {
+				if notBefore := attributes.NotBefore; notBefore != nil {
+					config.NotBeforeDate = time.Time(*notBefore).Format(time.RFC3339)
+				}
+				if expires := attributes.Expires; expires != nil {
+					config.ExpirationDate = time.Time(*expires).Format(time.RFC3339)
+				}
+			}
+
+			return metadata.Encode(&config)
+		},
+	}
+}
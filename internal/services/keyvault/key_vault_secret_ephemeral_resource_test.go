@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+)
+
+type KeyVaultSecretEphemeralResource struct{}
+
+func TestAccEphemeralKeyVaultSecret_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azurerm_key_vault_secret", "test")
+	r := KeyVaultSecretEphemeralResource{}
+
+	data.EphemeralResourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("value"), knownvalue.StringExact("rick-and-morty")),
+			},
+		},
+	})
+}
+
+func TestAccEphemeralKeyVaultSecret_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azurerm_key_vault_secret", "test")
+	r := KeyVaultSecretEphemeralResource{}
+
+	data.EphemeralResourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("value"), knownvalue.StringExact("<rick><morty /></rick>")),
+				statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("not_before_date"), knownvalue.StringExact("2019-01-01T01:02:03Z")),
+				statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("expiration_date"), knownvalue.StringExact("2020-01-01T01:02:03Z")),
+			},
+		},
+	})
+}
+
+func (KeyVaultSecretEphemeralResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+ephemeral "azurerm_key_vault_secret" "test" {
+  name         = azurerm_key_vault_secret.test.name
+  key_vault_id = azurerm_key_vault.test.id
+  version      = azurerm_key_vault_secret.test.version
+}
+
+provider "echo" {
+  data = ephemeral.azurerm_key_vault_secret.test
+}
+
+resource "echo" "test" {}
+`, KeyVaultSecretResource{}.basic(data))
+}
+
+func (KeyVaultSecretEphemeralResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+ephemeral "azurerm_key_vault_secret" "test" {
+  name         = azurerm_key_vault_secret.test.name
+  key_vault_id = azurerm_key_vault.test.id
+}
+
+provider "echo" {
+  data = ephemeral.azurerm_key_vault_secret.test
+}
+
+resource "echo" "test" {}
+`, KeyVaultSecretResource{}.complete(data))
+}
@@ -39,6 +39,38 @@ func TestAccKeyVaultSecret_basic(t *testing.T) {
 	})
 }
 
+func TestAccKeyVaultSecret_writeOnlyValue(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret", "test")
+	r := KeyVaultSecretResource{}
+
+	data.ResourceTestWriteOnly(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("value").HasValue("rick-and-morty"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.writeOnlyValue(data, "szechuan-sauce", 1),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("value").IsEmpty(),
+			),
+		},
+		data.ImportStep("value", "value_wo_version"),
+		{
+			Config: r.writeOnlyValue(data, "mr-meeseeks", 2),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("value").IsEmpty(),
+			),
+		},
+		data.ImportStep("value", "value_wo_version"),
+	})
+}
+
 func TestAccKeyVaultSecret_requiresImport(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret", "test")
 	r := KeyVaultSecretResource{}
@@ -328,6 +360,23 @@ resource "azurerm_key_vault_secret" "test" {
 `, r.template(data), data.RandomString)
 }
 
+func (r KeyVaultSecretResource) writeOnlyValue(data acceptance.TestData, value string, version int) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_key_vault_secret" "test" {
+  name             = "secret-%s"
+  value_wo         = "%s"
+  value_wo_version = %d
+  key_vault_id     = azurerm_key_vault.test.id
+}
+`, r.template(data), data.RandomString, value, version)
+}
+
 func (r KeyVaultSecretResource) updateTags(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
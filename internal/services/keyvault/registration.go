@@ -4,6 +4,8 @@
 package keyvault
 
 import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -13,6 +15,9 @@ type Registration struct{}
 var (
 	_ sdk.TypedServiceRegistrationWithAGitHubLabel   = Registration{}
 	_ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+	_ sdk.ServiceRegistrationWithEphemeralResources  = Registration{}
+	_ sdk.ServiceRegistrationWithListResources       = Registration{}
+	_ sdk.ServiceRegistrationWithResourceIds         = Registration{}
 )
 
 func (r Registration) AssociatedGitHubLabel() string {
@@ -71,3 +76,23 @@ func (r Registration) Resources() []sdk.Resource {
 		KeyVaultCertificateContactsResource{},
 	}
 }
+
+func (r Registration) EphemeralResources() []sdk.EphemeralResource {
+	return []sdk.EphemeralResource{
+		KeyVaultCertificateEphemeralResource{},
+		KeyVaultSecretEphemeralResource{},
+	}
+}
+
+func (r Registration) ListResources() []sdk.ListResource {
+	return []sdk.ListResource{
+		KeyVaultListResource{},
+	}
+}
+
+// ResourceIds returns a list of the Resource ID types used by this Service
+func (r Registration) ResourceIds() []resourceids.ResourceId {
+	return []resourceids.ResourceId{
+		&commonids.KeyVaultId{},
+	}
+}
@@ -4,11 +4,9 @@
 package migration
 
 import (
-	"context"
-	"log"
-
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -241,16 +239,5 @@ func (s KustoAttachedClusterV0ToV1) Schema() map[string]*pluginsdk.Schema {
 }
 
 func (s KustoAttachedClusterV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
-	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
-		oldId := rawState["id"].(string)
-		newId, err := commonids.ParseKustoClusterIDInsensitively(oldId)
-		if err != nil {
-			return nil, err
-		}
-
-		log.Printf("[DEBUG] Updating ID from %q to %q", oldId, newId)
-
-		rawState["id"] = newId.ID()
-		return rawState, nil
-	}
+	return sdk.ResourceIdUpgradeFunc(&commonids.KustoClusterId{}, nil)
 }
@@ -4,6 +4,8 @@
 package kusto
 
 import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -13,6 +15,7 @@ type Registration struct{}
 var _ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
 
 var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.ServiceRegistrationWithResourceIds = Registration{}
 
 func (r Registration) DataSources() []sdk.DataSource {
 	return []sdk.DataSource{}
@@ -64,3 +67,11 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 		"azurerm_kusto_script":                           resourceKustoDatabaseScript(),
 	}
 }
+
+// ResourceIds returns a list of the Resource ID types used by this Service
+func (r Registration) ResourceIds() []resourceids.ResourceId {
+	return []resourceids.ResourceId{
+		&commonids.KustoClusterId{},
+		&commonids.KustoDatabaseId{},
+	}
+}
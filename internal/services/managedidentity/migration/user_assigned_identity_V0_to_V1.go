@@ -4,10 +4,8 @@
 package migration
 
 import (
-	"context"
-	"log"
-
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -54,16 +52,5 @@ func (UserAssignedIdentityV0ToV1) Schema() map[string]*pluginsdk.Schema {
 }
 
 func (UserAssignedIdentityV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
-	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
-		oldId := rawState["id"].(string)
-		id, err := commonids.ParseUserAssignedIdentityIDInsensitively(oldId)
-		if err != nil {
-			return rawState, err
-		}
-
-		newId := id.ID()
-		log.Printf("Updating `id` from %q to %q", oldId, newId)
-		rawState["id"] = newId
-		return rawState, nil
-	}
+	return sdk.ResourceIdUpgradeFunc(&commonids.UserAssignedIdentityId{}, nil)
 }
@@ -4,6 +4,12 @@
 package managedidentity
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managedidentity/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -11,6 +17,7 @@ import (
 
 var _ sdk.Resource = UserAssignedIdentityResource{}
 var _ sdk.ResourceWithStateMigration = UserAssignedIdentityResource{}
+var _ sdk.ResourceWithExistenceCheck = UserAssignedIdentityResource{}
 
 func (r UserAssignedIdentityResource) StateUpgraders() sdk.StateUpgradeData {
 	return sdk.StateUpgradeData{
@@ -20,3 +27,29 @@ func (r UserAssignedIdentityResource) StateUpgraders() sdk.StateUpgradeData {
 		},
 	}
 }
+
+func (r UserAssignedIdentityResource) ExistenceCheck() sdk.ExistenceCheckFunc {
+	return func(ctx context.Context, metadata sdk.ResourceMetaData) (resourceids.Id, bool, error) {
+		client := metadata.Client.ManagedIdentity.V20230131.ManagedIdentities
+
+		if !metadata.ResourceDiff.NewValueKnown("name") || !metadata.ResourceDiff.NewValueKnown("resource_group_name") {
+			return nil, false, nil
+		}
+
+		var config UserAssignedIdentityResourceSchema
+		if err := metadata.DecodeDiff(&config); err != nil {
+			return nil, false, fmt.Errorf("decoding: %+v", err)
+		}
+
+		id := commonids.NewUserAssignedIdentityID(metadata.Client.Account.SubscriptionId, config.ResourceGroupName, config.Name)
+		existing, err := client.UserAssignedIdentitiesGet(ctx, id)
+		if err != nil {
+			if response.WasNotFound(existing.HttpResponse) {
+				return id, false, nil
+			}
+			return id, false, fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+		}
+
+		return id, true, nil
+	}
+}
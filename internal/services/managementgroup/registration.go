@@ -4,6 +4,8 @@
 package managementgroup
 
 import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -11,6 +13,7 @@ import (
 type Registration struct{}
 
 var _ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.ServiceRegistrationWithResourceIds = Registration{}
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/management-groups"
@@ -42,3 +45,10 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 		"azurerm_management_group_subscription_association": resourceManagementGroupSubscriptionAssociation(),
 	}
 }
+
+// ResourceIds returns a list of the Resource ID types used by this Service
+func (r Registration) ResourceIds() []resourceids.ResourceId {
+	return []resourceids.ResourceId{
+		&commonids.ManagementGroupId{},
+	}
+}
@@ -387,7 +387,7 @@ func buildErrorString(stub string, m map[int]float64) string {
 
 func doDTUSKUValidation(s sku) error {
 	if s.MaxAllowedGB == 0 {
-		return fmt.Errorf(getDTUCapacityErrorMsg(s))
+		return fmt.Errorf("%s", getDTUCapacityErrorMsg(s))
 	}
 
 	if strings.EqualFold(s.Name, "BasicPool") {
@@ -407,7 +407,7 @@ func doDTUSKUValidation(s sku) error {
 
 		// Check to see if the max_size_gb value is valid for this SKU type and capacity
 		if supportedDTUMaxGBValues[int(s.MaxSizeGb)] != 1 {
-			return fmt.Errorf(getDTUNotValidSizeErrorMsg(s))
+			return fmt.Errorf("%s", getDTUNotValidSizeErrorMsg(s))
 		}
 	}
 
@@ -425,7 +425,7 @@ func doDTUSKUValidation(s sku) error {
 
 func doVCoreSKUValidation(s sku) error {
 	if s.MaxAllowedGB == 0 {
-		return fmt.Errorf(getVCoreCapacityErrorMsg(s))
+		return fmt.Errorf("%s", getVCoreCapacityErrorMsg(s))
 	}
 
 	if s.MaxSizeGb > s.MaxAllowedGB {
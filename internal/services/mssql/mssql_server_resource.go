@@ -83,15 +83,31 @@ func resourceMsSqlServer() *pluginsdk.Resource {
 				Computed:     true,
 				ForceNew:     true,
 				AtLeastOneOf: []string{"administrator_login", "azuread_administrator.0.azuread_authentication_only"},
-				RequiredWith: []string{"administrator_login", "administrator_login_password"},
 			},
 
 			"administrator_login_password": {
-				Type:         pluginsdk.TypeString,
+				Type:          pluginsdk.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				AtLeastOneOf:  []string{"administrator_login_password", "administrator_login_password_wo", "azuread_administrator.0.azuread_authentication_only"},
+				ConflictsWith: []string{"administrator_login_password_wo"},
+				RequiredWith:  []string{"administrator_login"},
+			},
+
+			"administrator_login_password_wo": {
+				Type:          pluginsdk.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ValidateFunc:  validation.StringIsNotEmpty,
+				ConflictsWith: []string{"administrator_login_password"},
+				RequiredWith:  []string{"administrator_login", "administrator_login_password_wo_version"},
+			},
+
+			"administrator_login_password_wo_version": {
+				Type:         pluginsdk.TypeInt,
 				Optional:     true,
-				Sensitive:    true,
-				AtLeastOneOf: []string{"administrator_login_password", "azuread_administrator.0.azuread_authentication_only"},
-				RequiredWith: []string{"administrator_login", "administrator_login_password"},
+				RequiredWith: []string{"administrator_login_password_wo"},
 			},
 
 			"azuread_administrator": {
@@ -200,6 +216,8 @@ func resourceMsSqlServer() *pluginsdk.Resource {
 			pluginsdk.CustomizeDiffShim(msSqlMinimumTLSVersionDiff),
 
 			pluginsdk.CustomizeDiffShim(msSqlPasswordChangeWhenAADAuthOnly),
+
+			pluginsdk.CustomizeDiffShim(msSqlAdministratorLoginPasswordDiff),
 		),
 	}
 }
@@ -245,6 +263,14 @@ func resourceMsSqlServerCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 		props.Properties.AdministratorLoginPassword = utils.String(v.(string))
 	}
 
+	passwordWo, err := pluginsdk.GetWriteOnlyString(d, "administrator_login_password_wo")
+	if err != nil {
+		return err
+	}
+	if passwordWo != "" {
+		props.Properties.AdministratorLoginPassword = pointer.To(passwordWo)
+	}
+
 	// NOTE: You must set the admin before setting the values of the admin...
 	if azureADAdministrator, ok := d.GetOk("azuread_administrator"); ok {
 		props.Properties.Administrators = expandMsSqlServerAdministrators(azureADAdministrator.([]interface{}))
@@ -377,6 +403,14 @@ func resourceMsSqlServerUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 			payload.Properties.AdministratorLoginPassword = pointer.To(adminPassword)
 		}
 
+		if d.HasChange("administrator_login_password_wo_version") {
+			passwordWo, err := pluginsdk.GetWriteOnlyString(d, "administrator_login_password_wo")
+			if err != nil {
+				return err
+			}
+			payload.Properties.AdministratorLoginPassword = pointer.To(passwordWo)
+		}
+
 		if d.HasChange("minimum_tls_version") {
 			payload.Properties.MinimalTlsVersion = pointer.To(d.Get("minimum_tls_version").(string))
 		}
@@ -702,10 +736,33 @@ func msSqlMinimumTLSVersionDiff(ctx context.Context, d *pluginsdk.ResourceDiff,
 	return
 }
 
+// msSqlAdministratorLoginPasswordDiff requires that a password is specified alongside the `administrator_login`, which
+// is checked using the configuration since `administrator_login` is Computed and `administrator_login_password_wo`
+// is write-only (so neither can use `RequiredWith`)
+func msSqlAdministratorLoginPasswordDiff(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	values := config.AsValueMap()
+	if values["administrator_login"].IsNull() {
+		return nil
+	}
+	if !values["administrator_login_password"].IsNull() || !values["administrator_login_password_wo"].IsNull() {
+		return nil
+	}
+
+	return fmt.Errorf("one of `administrator_login_password` or `administrator_login_password_wo` must be specified when `administrator_login` is specified")
+}
+
 func msSqlPasswordChangeWhenAADAuthOnly(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) (err error) {
 	old, _ := d.GetChange("azuread_administrator.0.azuread_authentication_only")
 	if old.(bool) && d.HasChange("administrator_login_password") {
 		err = fmt.Errorf("`administrator_login_password` cannot be changed once `azuread_administrator.0.azuread_authentication_only = true`")
 	}
+	if old.(bool) && d.HasChange("administrator_login_password_wo_version") {
+		err = fmt.Errorf("`administrator_login_password_wo_version` cannot be changed once `azuread_administrator.0.azuread_authentication_only = true`")
+	}
 	return
 }
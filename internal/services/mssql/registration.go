@@ -4,6 +4,8 @@
 package mssql
 
 import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -13,6 +15,7 @@ type Registration struct{}
 var (
 	_ sdk.TypedServiceRegistration                   = Registration{}
 	_ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+	_ sdk.ServiceRegistrationWithResourceIds         = Registration{}
 )
 
 func (r Registration) AssociatedGitHubLabel() string {
@@ -76,3 +79,12 @@ func (r Registration) Resources() []sdk.Resource {
 		ServerDNSAliasResource{},
 	}
 }
+
+// ResourceIds returns a list of the Resource ID types used by this Service
+func (r Registration) ResourceIds() []resourceids.ResourceId {
+	return []resourceids.ResourceId{
+		&commonids.SqlDatabaseId{},
+		&commonids.SqlElasticPoolId{},
+		&commonids.SqlServerId{},
+	}
+}
@@ -4,6 +4,8 @@
 package mssqlmanagedinstance
 
 import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -13,6 +15,7 @@ type Registration struct{}
 var (
 	_ sdk.TypedServiceRegistration                   = Registration{}
 	_ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+	_ sdk.ServiceRegistrationWithResourceIds         = Registration{}
 )
 
 func (r Registration) AssociatedGitHubLabel() string {
@@ -61,3 +64,11 @@ func (r Registration) Resources() []sdk.Resource {
 		MsSqlManagedInstanceResource{},
 	}
 }
+
+// ResourceIds returns a list of the Resource ID types used by this Service
+func (r Registration) ResourceIds() []resourceids.ResourceId {
+	return []resourceids.ResourceId{
+		&commonids.SqlManagedInstanceId{},
+		&commonids.SqlManagedInstanceDatabaseId{},
+	}
+}
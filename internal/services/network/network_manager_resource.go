@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-09-01/networkmanagers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
@@ -47,6 +48,8 @@ type ManagerCrossTenantScopeModel struct {
 
 type ManagerResource struct{}
 
+var _ sdk.ResourceWithIdentity = ManagerResource{}
+
 func (r ManagerResource) ResourceType() string {
 	return "azurerm_network_manager"
 }
@@ -55,6 +58,10 @@ func (r ManagerResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
 	return networkmanagers.ValidateNetworkManagerID
 }
 
+func (r ManagerResource) Identity() resourceids.ResourceId {
+	return &networkmanagers.NetworkManagerId{}
+}
+
 func (r ManagerResource) ModelObject() interface{} {
 	return &ManagerModel{}
 }
@@ -4,6 +4,8 @@
 package network
 
 import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -13,6 +15,8 @@ type Registration struct{}
 var (
 	_ sdk.TypedServiceRegistrationWithAGitHubLabel   = Registration{}
 	_ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+	_ sdk.ServiceRegistrationWithListResources       = Registration{}
+	_ sdk.ServiceRegistrationWithResourceIds         = Registration{}
 )
 
 // Name is the name of this Service
@@ -172,3 +176,22 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 		"azurerm_web_application_firewall_policy":           resourceWebApplicationFirewallPolicy(),
 	}
 }
+
+// ListResources returns a list of List Resources supported by this Service
+func (r Registration) ListResources() []sdk.ListResource {
+	return []sdk.ListResource{
+		SubnetListResource{},
+		VirtualNetworkListResource{},
+	}
+}
+
+// ResourceIds returns a list of the Resource ID types used by this Service
+func (r Registration) ResourceIds() []resourceids.ResourceId {
+	return []resourceids.ResourceId{
+		&commonids.NetworkInterfaceId{},
+		&commonids.NetworkInterfaceIPConfigurationId{},
+		&commonids.PublicIPAddressId{},
+		&commonids.SubnetId{},
+		&commonids.VirtualNetworkId{},
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+)
+
+var _ sdk.ListResource = SubnetListResource{}
+
+type SubnetListResource struct{}
+
+func (SubnetListResource) ResourceType() string {
+	return "azurerm_subnet"
+}
+
+func (SubnetListResource) Identity() resourceids.ResourceId {
+	return &commonids.SubnetId{}
+}
+
+func (SubnetListResource) List() sdk.ListResourceFunc {
+	return sdk.ListResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ListResourceMetaData) ([]sdk.ListResourceResult, error) {
+			virtualNetworks, err := listVirtualNetworks(ctx, metadata.Client.Network.VnetClient, metadata.ResourceGroupName)
+			if err != nil {
+				return nil, err
+			}
+
+			results := make([]sdk.ListResourceResult, 0)
+			for _, virtualNetwork := range virtualNetworks {
+				props := virtualNetwork.VirtualNetworkPropertiesFormat
+				if props == nil || props.Subnets == nil {
+					continue
+				}
+
+				for _, item := range *props.Subnets {
+					if item.ID == nil {
+						continue
+					}
+
+					id, err := commonids.ParseSubnetIDInsensitively(*item.ID)
+					if err != nil {
+						return nil, err
+					}
+
+					// Subnets don't support Tags, so these are filtered using the Tags of the Virtual Network
+					results = append(results, sdk.ListResourceResult{
+						ID:          id,
+						DisplayName: id.VirtualNetworkName + "/" + id.SubnetName,
+						Tags:        tags.ToTypedObject(virtualNetwork.Tags),
+					})
+				}
+			}
+
+			return results, nil
+		},
+	}
+}
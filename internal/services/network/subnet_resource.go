@@ -100,10 +100,12 @@ func resourceSubnet() *pluginsdk.Resource {
 		Read:   resourceSubnetRead,
 		Update: resourceSubnetUpdate,
 		Delete: resourceSubnetDelete,
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+		Importer: pluginsdk.ImporterBuildingResourceIdFromIdentity(&commonids.SubnetId{}, pluginsdk.ImporterValidatingResourceId(func(id string) error {
 			_, err := commonids.ParseSubnetID(id)
 			return err
-		}),
+		})),
+
+		Identity: pluginsdk.ResourceIdentityFromResourceId(&commonids.SubnetId{}),
 
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
@@ -285,8 +287,11 @@ func resourceSubnetCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 		return tf.ImportAsExistsError("azurerm_subnet", id.ID())
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	releaseVirtualNetwork, err := acquireVirtualNetworkSubnetOperation(ctx, id.VirtualNetworkName)
+	if err != nil {
+		return err
+	}
+	defer releaseVirtualNetwork()
 
 	properties := network.SubnetPropertiesFormat{}
 	if value, ok := d.GetOk("address_prefixes"); ok {
@@ -433,11 +438,17 @@ func resourceSubnetUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	releaseVirtualNetwork, err := acquireVirtualNetworkSubnetOperation(ctx, id.VirtualNetworkName)
+	if err != nil {
+		return err
+	}
+	defer releaseVirtualNetwork()
 
-	locks.ByName(id.SubnetName, SubnetResourceName)
-	defer locks.UnlockByName(id.SubnetName, SubnetResourceName)
+	releaseSubnet, err := locks.AcquireByName(ctx, id.SubnetName, SubnetResourceName)
+	if err != nil {
+		return err
+	}
+	defer releaseSubnet()
 
 	existing, err := client.Get(ctx, id.ResourceGroupName, id.VirtualNetworkName, id.SubnetName, "")
 	if err != nil {
@@ -592,6 +603,10 @@ func resourceSubnetRead(d *pluginsdk.ResourceData, meta interface{}) error {
 		return fmt.Errorf("retrieving %s: %+v", *id, err)
 	}
 
+	if err := pluginsdk.SetResourceIdentityData(d, id); err != nil {
+		return err
+	}
+
 	d.Set("name", id.SubnetName)
 	d.Set("virtual_network_name", id.VirtualNetworkName)
 	d.Set("resource_group_name", id.ResourceGroupName)
@@ -644,11 +659,17 @@ func resourceSubnetDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	releaseVirtualNetwork, err := acquireVirtualNetworkSubnetOperation(ctx, id.VirtualNetworkName)
+	if err != nil {
+		return err
+	}
+	defer releaseVirtualNetwork()
 
-	locks.ByName(id.SubnetName, SubnetResourceName)
-	defer locks.UnlockByName(id.SubnetName, SubnetResourceName)
+	releaseSubnet, err := locks.AcquireByName(ctx, id.SubnetName, SubnetResourceName)
+	if err != nil {
+		return err
+	}
+	defer releaseSubnet()
 
 	future, err := client.Delete(ctx, id.ResourceGroupName, id.VirtualNetworkName, id.SubnetName)
 	if err != nil {
@@ -664,6 +685,17 @@ func resourceSubnetDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// virtualNetworkConcurrentSubnetOperations is the maximum number of concurrent operations against the Subnets within
+// a Virtual Network, since further operations are rejected by the API with `AnotherOperationInProgress`. Other resources
+// locking the Virtual Network using `locks.ByName` share the same key, and hold it exclusively.
+const virtualNetworkConcurrentSubnetOperations = 4
+
+// acquireVirtualNetworkSubnetOperation blocks until an operation can be performed against a Subnet within the Virtual
+// Network, returning a function which must be called once the operation has completed
+func acquireVirtualNetworkSubnetOperation(ctx context.Context, virtualNetworkName string) (func(), error) {
+	return locks.AcquireByNameWithLimit(ctx, virtualNetworkName, VirtualNetworkResourceName, virtualNetworkConcurrentSubnetOperations)
+}
+
 func expandSubnetServiceEndpoints(input []interface{}) *[]network.ServiceEndpointPropertiesFormat {
 	endpoints := make([]network.ServiceEndpointPropertiesFormat, 0)
 
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/tombuildsstuff/kermit/sdk/network/2022-07-01/network"
+)
+
+var _ sdk.ListResource = VirtualNetworkListResource{}
+
+type VirtualNetworkListResource struct{}
+
+func (VirtualNetworkListResource) ResourceType() string {
+	return VirtualNetworkResourceName
+}
+
+func (VirtualNetworkListResource) Identity() resourceids.ResourceId {
+	return &commonids.VirtualNetworkId{}
+}
+
+func (VirtualNetworkListResource) List() sdk.ListResourceFunc {
+	return sdk.ListResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ListResourceMetaData) ([]sdk.ListResourceResult, error) {
+			virtualNetworks, err := listVirtualNetworks(ctx, metadata.Client.Network.VnetClient, metadata.ResourceGroupName)
+			if err != nil {
+				return nil, err
+			}
+
+			results := make([]sdk.ListResourceResult, 0)
+			for _, item := range virtualNetworks {
+				if item.ID == nil {
+					continue
+				}
+
+				id, err := commonids.ParseVirtualNetworkIDInsensitively(*item.ID)
+				if err != nil {
+					return nil, err
+				}
+
+				results = append(results, sdk.ListResourceResult{
+					ID:   id,
+					Tags: tags.ToTypedObject(item.Tags),
+				})
+			}
+
+			return results, nil
+		},
+	}
+}
+
+// listVirtualNetworks returns the Virtual Networks within the Resource Group `resourceGroupName`, or within the
+// Subscription when this is empty
+func listVirtualNetworks(ctx context.Context, client *network.VirtualNetworksClient, resourceGroupName string) ([]network.VirtualNetwork, error) {
+	var iterator network.VirtualNetworkListResultIterator
+	var err error
+	if resourceGroupName != "" {
+		iterator, err = client.ListComplete(ctx, resourceGroupName)
+	} else {
+		iterator, err = client.ListAllComplete(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing Virtual Networks: %+v", err)
+	}
+
+	output := make([]network.VirtualNetwork, 0)
+	for iterator.NotDone() {
+		output = append(output, iterator.Value())
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("listing Virtual Networks: %+v", err)
+		}
+	}
+
+	return output, nil
+}
@@ -38,10 +38,12 @@ func resourceVirtualNetwork() *pluginsdk.Resource {
 		Read:   resourceVirtualNetworkRead,
 		Update: resourceVirtualNetworkCreateUpdate,
 		Delete: resourceVirtualNetworkDelete,
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+		Importer: pluginsdk.ImporterBuildingResourceIdFromIdentity(&commonids.VirtualNetworkId{}, pluginsdk.ImporterValidatingResourceId(func(id string) error {
 			_, err := commonids.ParseVirtualNetworkID(id)
 			return err
-		}),
+		})),
+
+		Identity: pluginsdk.ResourceIdentityFromResourceId(&commonids.VirtualNetworkId{}),
 
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
@@ -284,6 +286,10 @@ func resourceVirtualNetworkRead(d *pluginsdk.ResourceData, meta interface{}) err
 		return fmt.Errorf("retrieving %s: %+v", *id, err)
 	}
 
+	if err := pluginsdk.SetResourceIdentityData(d, id); err != nil {
+		return err
+	}
+
 	d.Set("name", id.VirtualNetworkName)
 	d.Set("resource_group_name", id.ResourceGroupName)
 
@@ -79,10 +79,27 @@ func resourcePostgresqlFlexibleServer() *pluginsdk.Resource {
 			},
 
 			"administrator_password": {
-				Type:         pluginsdk.TypeString,
+				Type:          pluginsdk.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ValidateFunc:  validation.StringIsNotEmpty,
+				ConflictsWith: []string{"administrator_password_wo"},
+			},
+
+			"administrator_password_wo": {
+				Type:          pluginsdk.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ValidateFunc:  validation.StringIsNotEmpty,
+				ConflictsWith: []string{"administrator_password"},
+				RequiredWith:  []string{"administrator_password_wo_version"},
+			},
+
+			"administrator_password_wo_version": {
+				Type:         pluginsdk.TypeInt,
 				Optional:     true,
-				Sensitive:    true,
-				ValidateFunc: validation.StringIsNotEmpty,
+				RequiredWith: []string{"administrator_password_wo"},
 			},
 
 			"authentication": {
@@ -464,9 +481,15 @@ func resourcePostgresqlFlexibleServerCreate(d *pluginsdk.ResourceData, meta inte
 		}
 	}
 
+	adminPasswordWo, err := pluginsdk.GetWriteOnlyString(d, "administrator_password_wo")
+	if err != nil {
+		return err
+	}
+
 	if createMode == "" || servers.CreateMode(createMode) == servers.CreateModeDefault {
 		_, adminLoginSet := d.GetOk("administrator_login")
 		_, adminPwdSet := d.GetOk("administrator_password")
+		adminPwdSet = adminPwdSet || adminPasswordWo != ""
 
 		pwdEnabled := true // it defaults to true
 		if authRaw, authExist := d.GetOk("authentication"); authExist {
@@ -544,6 +567,10 @@ func resourcePostgresqlFlexibleServerCreate(d *pluginsdk.ResourceData, meta inte
 		parameters.Properties.AdministratorLoginPassword = utils.String(v.(string))
 	}
 
+	if adminPasswordWo != "" {
+		parameters.Properties.AdministratorLoginPassword = pointer.To(adminPasswordWo)
+	}
+
 	if createMode != "" {
 		createModeAttr := servers.CreateMode(createMode)
 		parameters.Properties.CreateMode = &createModeAttr
@@ -735,11 +762,17 @@ func resourcePostgresqlFlexibleServerUpdate(d *pluginsdk.ResourceData, meta inte
 
 	requireUpdateOnLogin := false // it's required to call Create with `createMode` set to `Update` to update login name.
 
+	adminPasswordWo, err := pluginsdk.GetWriteOnlyString(d, "administrator_password_wo")
+	if err != nil {
+		return err
+	}
+
 	createMode := d.Get("create_mode").(string)
 	if createMode == "" || servers.CreateMode(createMode) == servers.CreateModeDefault {
 
 		_, adminLoginSet := d.GetOk("administrator_login")
 		_, adminPwdSet := d.GetOk("administrator_password")
+		adminPwdSet = adminPwdSet || adminPasswordWo != ""
 
 		pwdEnabled := true // it defaults to true
 		if authRaw, authExist := d.GetOk("authentication"); authExist {
@@ -833,6 +866,10 @@ func resourcePostgresqlFlexibleServerUpdate(d *pluginsdk.ResourceData, meta inte
 		parameters.Properties.AdministratorLoginPassword = utils.String(d.Get("administrator_password").(string))
 	}
 
+	if d.HasChange("administrator_password_wo_version") {
+		parameters.Properties.AdministratorLoginPassword = pointer.To(adminPasswordWo)
+	}
+
 	if d.HasChange("authentication") {
 		parameters.Properties.AuthConfig = expandFlexibleServerAuthConfig(d.Get("authentication").([]interface{}))
 	}
@@ -897,6 +934,11 @@ func resourcePostgresqlFlexibleServerUpdate(d *pluginsdk.ResourceData, meta inte
 	}
 
 	if requireUpdateOnLogin {
+		adminPassword := d.Get("administrator_password").(string)
+		if adminPasswordWo != "" {
+			adminPassword = adminPasswordWo
+		}
+
 		updateMode := servers.CreateModeUpdate
 		loginParameters := servers.Server{
 			Location: location.Normalize(d.Get("location").(string)),
@@ -904,7 +946,7 @@ func resourcePostgresqlFlexibleServerUpdate(d *pluginsdk.ResourceData, meta inte
 				CreateMode:                 &updateMode,
 				AuthConfig:                 expandFlexibleServerAuthConfig(d.Get("authentication").([]interface{})),
 				AdministratorLogin:         utils.String(d.Get("administrator_login").(string)),
-				AdministratorLoginPassword: utils.String(d.Get("administrator_password").(string)),
+				AdministratorLoginPassword: pointer.To(adminPassword),
 				Network:                    expandArmServerNetwork(d),
 			},
 		}
@@ -4,11 +4,18 @@
 package recoveryservices_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicessiterecovery/2022-10-01/replicationrecoveryplans"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/fakearm"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/recoveryservices"
 )
 
 type SiteRecoveryReplicationRecoveryPlanDataSource struct{}
@@ -49,6 +56,72 @@ func TestAccDataSourceSiteRecoveryReplicationRecoveryPlan_withZones(t *testing.T
 	})
 }
 
+func TestSiteRecoveryReplicationRecoveryPlanDataSource_actions(t *testing.T) {
+	server := fakearm.New(t, fakearm.Options{})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client, err := server.Client(ctx)
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	// the model previously didn't match the Schema (the model of the Resource was used, and the actions were nested
+	// as a Set of Lists) - as such the Data Source failed to be built, and the actions couldn't be set
+	wrapper := sdk.NewDataSourceWrapper(recoveryservices.SiteRecoveryReplicationRecoveryPlanDataSource{})
+	dataSource, err := wrapper.DataSource()
+	if err != nil {
+		t.Fatalf("building data source: %+v", err)
+	}
+
+	id := replicationrecoveryplans.NewReplicationRecoveryPlanID(server.SubscriptionId, "example-resources", "example-vault", "example-plan")
+	vaultId := replicationrecoveryplans.NewVaultID(id.SubscriptionId, id.ResourceGroupName, id.VaultName)
+	server.PutResource(id.ID(), map[string]interface{}{
+		"properties": map[string]interface{}{
+			"groups": []interface{}{
+				map[string]interface{}{
+					"groupType": "Boot",
+					"startGroupActions": []interface{}{
+						map[string]interface{}{
+							"actionName":         "example-pre-action",
+							"failoverDirections": []interface{}{"PrimaryToRecovery"},
+							"failoverTypes":      []interface{}{"TestFailover"},
+							"customDetails": map[string]interface{}{
+								"instanceType": "ManualActionDetails",
+								"description":  "example instruction",
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{
+		"name":              id.ReplicationRecoveryPlanName,
+		"recovery_vault_id": vaultId.ID(),
+	})
+	if diags := dataSource.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("reading: %+v", diags)
+	}
+
+	groups := d.Get("recovery_group").(*schema.Set).List()
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 recovery group but got %d", len(groups))
+	}
+	actions := groups[0].(map[string]interface{})["pre_action"].(*schema.Set).List()
+	if len(actions) != 1 {
+		t.Fatalf("expected 1 pre action but got %d", len(actions))
+	}
+	action := actions[0].(map[string]interface{})
+	if action["name"] != "example-pre-action" {
+		t.Fatalf("expected the pre action to be named %q but got %q", "example-pre-action", action["name"])
+	}
+	if action["type"] != "ManualActionDetails" || action["manual_action_instruction"] != "example instruction" {
+		t.Fatalf("expected the manual action details to be set but got %+v", action)
+	}
+}
+
 func (SiteRecoveryReplicationRecoveryPlanDataSource) basic(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -4,12 +4,11 @@
 package migration
 
 import (
-	"context"
-	"log"
 	"strings"
 
 	"github.com/hashicorp/go-azure-sdk/resource-manager/redis/2023-08-01/redis"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/suppress"
 )
@@ -325,17 +324,5 @@ func (RedisCacheV0ToV1) Schema() map[string]*pluginsdk.Schema {
 }
 
 func (RedisCacheV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
-	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
-		oldIdRaw := rawState["id"].(string)
-		oldId, err := redis.ParseRediIDInsensitively(oldIdRaw)
-		if err != nil {
-			return rawState, err
-		}
-
-		newId := oldId.ID()
-		log.Printf("[DEBUG] Updating ID from %q to %q", oldId, newId)
-		rawState["id"] = newId
-
-		return rawState, nil
-	}
+	return sdk.ResourceIdUpgradeFunc(&redis.RediId{}, nil)
 }
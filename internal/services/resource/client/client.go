@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources" // nolint: staticcheck
+	resourcegraph "github.com/hashicorp/go-azure-sdk/resource-manager/resourcegraph/2022-10-01/resources"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2020-05-01/managementlocks"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2020-05-01/privatelinkassociation"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2020-05-01/resourcemanagementprivatelink"
@@ -24,6 +25,7 @@ type Client struct {
 	FeaturesClient                      *features.FeaturesClient
 	LocksClient                         *managementlocks.ManagementLocksClient
 	PrivateLinkAssociationClient        *privatelinkassociation.PrivateLinkAssociationClient
+	ResourceGraphClient                 *resourcegraph.ResourcesClient
 	ResourceGroupsClient                *resourcegroups.ResourceGroupsClient
 	ResourceManagementPrivateLinkClient *resourcemanagementprivatelink.ResourceManagementPrivateLinkClient
 	ResourceProvidersClient             *providers.ProvidersClient
@@ -53,6 +55,12 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(featuresClient.Client, o.Authorizers.ResourceManager)
 
+	resourceGraphClient, err := resourcegraph.NewResourcesClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building ResourceGraph client: %+v", err)
+	}
+	o.Configure(resourceGraphClient.Client, o.Authorizers.ResourceManager)
+
 	resourceGroupsClient, err := resourcegroups.NewResourceGroupsClientWithBaseURI(o.Environment.ResourceManager)
 	if err != nil {
 		return nil, fmt.Errorf("building Features client: %+v", err)
@@ -110,6 +118,7 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 		FeaturesClient:                      featuresClient,
 		LocksClient:                         locksClient,
 		PrivateLinkAssociationClient:        privateLinkAssociationClient,
+		ResourceGraphClient:                 resourceGraphClient,
 		ResourceManagementPrivateLinkClient: resourceManagementPrivateLinkClient,
 		ResourceGroupsClient:                resourceGroupsClient,
 		ResourceProvidersClient:             resourceProvidersClient,
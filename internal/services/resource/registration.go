@@ -4,13 +4,17 @@
 package resource
 
 import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
 var (
-	_ sdk.TypedServiceRegistration   = Registration{}
-	_ sdk.UntypedServiceRegistration = Registration{}
+	_ sdk.TypedServiceRegistration             = Registration{}
+	_ sdk.UntypedServiceRegistration           = Registration{}
+	_ sdk.ServiceRegistrationWithListResources = Registration{}
+	_ sdk.ServiceRegistrationWithResourceIds   = Registration{}
 )
 
 type Registration struct{}
@@ -57,7 +61,9 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 
 // DataSources returns a list of Data Sources supported by this Service
 func (r Registration) DataSources() []sdk.DataSource {
-	return []sdk.DataSource{}
+	return []sdk.DataSource{
+		ResourceGraphQueryDataSource{},
+	}
 }
 
 // Resources returns a list of Resources supported by this Service
@@ -70,3 +76,18 @@ func (r Registration) Resources() []sdk.Resource {
 		ResourceDeploymentScriptAzureCliResource{},
 	}
 }
+
+// ListResources returns a list of List Resources supported by this Service
+func (r Registration) ListResources() []sdk.ListResource {
+	return []sdk.ListResource{
+		ResourceGroupListResource{},
+	}
+}
+
+// ResourceIds returns a list of the Resource ID types used by this Service
+func (r Registration) ResourceIds() []resourceids.ResourceId {
+	return []resourceids.ResourceId{
+		&commonids.SubscriptionId{},
+		&commonids.ResourceGroupId{},
+	}
+}
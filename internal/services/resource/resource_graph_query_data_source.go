@@ -0,0 +1,326 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	resourcegraph "github.com/hashicorp/go-azure-sdk/resource-manager/resourcegraph/2022-10-01/resources"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+// resourceGraphQueryPageSize is the maximum number of records which can be returned from the Resource Graph API in a single request
+const resourceGraphQueryPageSize = 1000
+
+type ResourceGraphQueryDataSource struct{}
+
+var _ sdk.DataSource = ResourceGraphQueryDataSource{}
+
+type ResourceGraphQueryDataSourceModel struct {
+	Query              string                            `tfschema:"query"`
+	SubscriptionIds    []string                          `tfschema:"subscription_ids"`
+	ManagementGroupIds []string                          `tfschema:"management_group_ids"`
+	AllowPartialScopes bool                              `tfschema:"allow_partial_scopes"`
+	MaxRecords         int64                             `tfschema:"max_records"`
+	Resources          []ResourceGraphQueryResourceModel `tfschema:"resources"`
+	ResultsJson        string                            `tfschema:"results_json"`
+	ResultTruncated    bool                              `tfschema:"result_truncated"`
+	TotalRecords       int64                             `tfschema:"total_records"`
+}
+
+type ResourceGraphQueryResourceModel struct {
+	Id                string            `tfschema:"id"`
+	Name              string            `tfschema:"name"`
+	Type              string            `tfschema:"type"`
+	Kind              string            `tfschema:"kind"`
+	Location          string            `tfschema:"location"`
+	ResourceGroupName string            `tfschema:"resource_group_name"`
+	SubscriptionId    string            `tfschema:"subscription_id"`
+	Tags              map[string]string `tfschema:"tags"`
+}
+
+func (ResourceGraphQueryDataSource) ResourceType() string {
+	return "azurerm_resource_graph_query"
+}
+
+func (ResourceGraphQueryDataSource) ModelObject() interface{} {
+	return &ResourceGraphQueryDataSourceModel{}
+}
+
+func (ResourceGraphQueryDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"query": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"subscription_ids": {
+			Type:          pluginsdk.TypeList,
+			Optional:      true,
+			ConflictsWith: []string{"management_group_ids"},
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.IsUUID,
+			},
+		},
+
+		"management_group_ids": {
+			Type:          pluginsdk.TypeList,
+			Optional:      true,
+			ConflictsWith: []string{"subscription_ids"},
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: commonids.ValidateManagementGroupID,
+			},
+		},
+
+		"allow_partial_scopes": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"max_records": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+	}
+}
+
+func (ResourceGraphQueryDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"resources": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"type": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"kind": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"location": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"resource_group_name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"subscription_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"tags": tags.SchemaDataSource(),
+				},
+			},
+		},
+
+		"results_json": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"result_truncated": {
+			Type:     pluginsdk.TypeBool,
+			Computed: true,
+		},
+
+		"total_records": {
+			Type:     pluginsdk.TypeInt,
+			Computed: true,
+		},
+	}
+}
+
+func (ResourceGraphQueryDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.ResourceGraphClient
+
+			var state ResourceGraphQueryDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			request := resourcegraph.QueryRequest{
+				Query: state.Query,
+				Options: &resourcegraph.QueryRequestOptions{
+					AllowPartialScopes: pointer.To(state.AllowPartialScopes),
+					ResultFormat:       pointer.To(resourcegraph.ResultFormatObjectArray),
+				},
+			}
+			if len(state.SubscriptionIds) > 0 {
+				request.Subscriptions = pointer.To(state.SubscriptionIds)
+			}
+			if len(state.ManagementGroupIds) > 0 {
+				managementGroups := make([]string, 0)
+				for _, v := range state.ManagementGroupIds {
+					id, err := commonids.ParseManagementGroupID(v)
+					if err != nil {
+						return err
+					}
+					managementGroups = append(managementGroups, id.GroupId)
+				}
+				request.ManagementGroups = pointer.To(managementGroups)
+			}
+
+			rows := make([]interface{}, 0)
+			for {
+				pageSize := int64(resourceGraphQueryPageSize)
+				if state.MaxRecords > 0 && state.MaxRecords-int64(len(rows)) < pageSize {
+					pageSize = state.MaxRecords - int64(len(rows))
+				}
+				request.Options.Top = pointer.To(pageSize)
+
+				resp, err := client.Resources(ctx, request)
+				if err != nil {
+					return fmt.Errorf("running Resource Graph query: %+v", err)
+				}
+				if resp.Model == nil {
+					return fmt.Errorf("running Resource Graph query: `model` was nil")
+				}
+
+				page, err := expandResourceGraphQueryRows(resp.Model.Data)
+				if err != nil {
+					return fmt.Errorf("parsing the results of the Resource Graph query: %+v", err)
+				}
+				rows = append(rows, page...)
+				state.TotalRecords = resp.Model.TotalRecords
+
+				// the results are truncated when the query doesn't project the `id` column, since Skip Tokens aren't returned
+				if resp.Model.ResultTruncated == resourcegraph.ResultTruncatedTrue {
+					log.Printf("[WARN] the results of the Resource Graph query were truncated, the `id` column must be projected to retrieve all of the results")
+					state.ResultTruncated = true
+				}
+
+				skipToken := pointer.From(resp.Model.SkipToken)
+				if skipToken == "" {
+					break
+				}
+				if state.MaxRecords > 0 && int64(len(rows)) >= state.MaxRecords {
+					state.ResultTruncated = true
+					break
+				}
+				request.Options.SkipToken = pointer.To(skipToken)
+			}
+
+			results, err := json.Marshal(rows)
+			if err != nil {
+				return fmt.Errorf("marshaling the results of the Resource Graph query: %+v", err)
+			}
+			state.ResultsJson = string(results)
+			state.Resources = flattenResourceGraphQueryResources(rows)
+
+			metadata.ResourceData.SetId(resourceGraphQueryId(state))
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func expandResourceGraphQueryRows(input interface{}) ([]interface{}, error) {
+	if input == nil {
+		return []interface{}{}, nil
+	}
+
+	rows, ok := input.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected the results to be an array but got %T", input)
+	}
+
+	return rows, nil
+}
+
+// flattenResourceGraphQueryResources returns the rows within the results of a Resource Graph query which represent a
+// resource (that is, which project the `id` column) - using the standard columns of the `Resources` table
+func flattenResourceGraphQueryResources(input []interface{}) []ResourceGraphQueryResourceModel {
+	output := make([]ResourceGraphQueryResourceModel, 0)
+	for _, v := range input {
+		row, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		id := resourceGraphQueryColumn(row, "id")
+		if id == "" {
+			continue
+		}
+
+		resource := ResourceGraphQueryResourceModel{
+			Id:                id,
+			Name:              resourceGraphQueryColumn(row, "name"),
+			Type:              resourceGraphQueryColumn(row, "type"),
+			Kind:              resourceGraphQueryColumn(row, "kind"),
+			Location:          location.Normalize(resourceGraphQueryColumn(row, "location")),
+			ResourceGroupName: resourceGraphQueryColumn(row, "resourceGroup"),
+			SubscriptionId:    resourceGraphQueryColumn(row, "subscriptionId"),
+			Tags:              make(map[string]string),
+		}
+
+		if tags, ok := row["tags"].(map[string]interface{}); ok {
+			for key, value := range tags {
+				if value == nil {
+					continue
+				}
+				if s, ok := value.(string); ok {
+					resource.Tags[key] = s
+				} else {
+					resource.Tags[key] = fmt.Sprintf("%v", value)
+				}
+			}
+		}
+
+		output = append(output, resource)
+	}
+
+	return output
+}
+
+func resourceGraphQueryColumn(row map[string]interface{}, column string) string {
+	if v, ok := row[column].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// resourceGraphQueryId returns a deterministic ID for the Resource Graph query, based on the query and the scopes it runs against
+func resourceGraphQueryId(input ResourceGraphQueryDataSourceModel) string {
+	scopes := append(append([]string{}, input.SubscriptionIds...), input.ManagementGroupIds...)
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%d", input.Query, strings.Join(scopes, ","), input.MaxRecords)))
+	return hex.EncodeToString(hash[:])
+}
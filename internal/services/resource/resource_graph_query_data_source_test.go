@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type ResourceGraphQueryDataSource struct{}
+
+func TestAccDataSourceResourceGraphQuery_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_resource_graph_query", "test")
+	r := ResourceGraphQueryDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			// the Resource Graph is eventually consistent, so the resources are provisioned first
+			Config: r.template(data),
+		},
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("resources.#").HasValue("1"),
+				check.That(data.ResourceName).Key("resources.0.name").HasValue(fmt.Sprintf("acctestRG-graph-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("resources.0.location").HasValue(data.Locations.Primary),
+				check.That(data.ResourceName).Key("resources.0.tags.%").HasValue("1"),
+				check.That(data.ResourceName).Key("resources.0.tags.environment").HasValue("resource-graph"),
+				check.That(data.ResourceName).Key("results_json").Exists(),
+				check.That(data.ResourceName).Key("result_truncated").HasValue("false"),
+				check.That(data.ResourceName).Key("total_records").HasValue("1"),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceResourceGraphQuery_projection(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_resource_graph_query", "test")
+	r := ResourceGraphQueryDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.template(data),
+		},
+		{
+			Config: r.projection(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				// the `id` column isn't projected, so these aren't returned as resources
+				check.That(data.ResourceName).Key("resources.#").HasValue("0"),
+				check.That(data.ResourceName).Key("results_json").HasValue(`[{"count_":1}]`),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceResourceGraphQuery_maxRecords(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_resource_graph_query", "test")
+	r := ResourceGraphQueryDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.maxRecords(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("resources.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func (ResourceGraphQueryDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_resource_graph_query" "test" {
+  query = <<QUERY
+ResourceContainers
+| where type =~ 'microsoft.resources/subscriptions/resourcegroups'
+| where name == '${azurerm_resource_group.test.name}'
+| project id, name, type, location, resourceGroup, subscriptionId, tags
+QUERY
+
+  subscription_ids = [
+    data.azurerm_client_config.current.subscription_id,
+  ]
+}
+`, ResourceGraphQueryDataSource{}.template(data))
+}
+
+func (ResourceGraphQueryDataSource) projection(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_resource_graph_query" "test" {
+  query = <<QUERY
+ResourceContainers
+| where type =~ 'microsoft.resources/subscriptions/resourcegroups'
+| where name == '${azurerm_resource_group.test.name}'
+| summarize count()
+QUERY
+}
+`, ResourceGraphQueryDataSource{}.template(data))
+}
+
+func (ResourceGraphQueryDataSource) maxRecords() string {
+	return `
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_resource_graph_query" "test" {
+  query       = "ResourceContainers | project id, name, type"
+  max_records = 1
+}
+`
+}
+
+func (ResourceGraphQueryDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-graph-%d"
+  location = "%s"
+
+  tags = {
+    environment = "resource-graph"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
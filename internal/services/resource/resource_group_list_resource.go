@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2023-07-01/resourcegroups"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+var _ sdk.ListResource = ResourceGroupListResource{}
+
+type ResourceGroupListResource struct{}
+
+func (ResourceGroupListResource) ResourceType() string {
+	return "azurerm_resource_group"
+}
+
+func (ResourceGroupListResource) Identity() resourceids.ResourceId {
+	return &commonids.ResourceGroupId{}
+}
+
+func (ResourceGroupListResource) List() sdk.ListResourceFunc {
+	return sdk.ListResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ListResourceMetaData) ([]sdk.ListResourceResult, error) {
+			client := metadata.Client.Resource.ResourceGroupsClient
+			subscriptionId := commonids.NewSubscriptionID(metadata.Client.Account.SubscriptionId)
+
+			items := make([]resourcegroups.ResourceGroup, 0)
+			if metadata.ResourceGroupName != "" {
+				id := commonids.NewResourceGroupID(subscriptionId.SubscriptionId, metadata.ResourceGroupName)
+				resp, err := client.Get(ctx, id)
+				if err != nil {
+					if response.WasNotFound(resp.HttpResponse) {
+						return nil, nil
+					}
+					return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+				}
+				if resp.Model != nil {
+					items = append(items, *resp.Model)
+				}
+			} else {
+				resp, err := client.ListComplete(ctx, subscriptionId, resourcegroups.DefaultListOperationOptions())
+				if err != nil {
+					return nil, fmt.Errorf("listing Resource Groups within %s: %+v", subscriptionId, err)
+				}
+				items = resp.Items
+			}
+
+			results := make([]sdk.ListResourceResult, 0)
+			for _, item := range items {
+				id, err := commonids.ParseResourceGroupIDInsensitively(pointer.From(item.Id))
+				if err != nil {
+					return nil, err
+				}
+
+				results = append(results, sdk.ListResourceResult{
+					ID:   id,
+					Tags: pointer.From(item.Tags),
+				})
+			}
+
+			return results, nil
+		},
+	}
+}
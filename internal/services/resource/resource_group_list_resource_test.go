@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+)
+
+type ResourceGroupListResource struct{}
+
+func TestAccResourceGroupListResource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group", "test")
+	r := ResourceGroupListResource{}
+
+	data.ListResourceTest(t, ResourceGroupResource{}, []acceptance.TestStep{
+		{
+			Config: ResourceGroupResource{}.withTagsConfig(data),
+		},
+		{
+			Query:  true,
+			Config: r.basicQuery(data),
+			QueryResultChecks: []querycheck.QueryResultCheck{
+				querycheck.ExpectIdentity("azurerm_resource_group.test", map[string]knownvalue.Check{
+					"name":            knownvalue.StringExact(fmt.Sprintf("acctestRG-%d", data.RandomInteger)),
+					"subscription_id": knownvalue.StringExact(data.Client().SubscriptionID),
+				}),
+			},
+		},
+		{
+			Query:  true,
+			Config: r.byTags(),
+			QueryResultChecks: []querycheck.QueryResultCheck{
+				querycheck.ExpectLengthAtLeast("azurerm_resource_group.test", 1),
+			},
+		},
+	})
+}
+
+func (ResourceGroupListResource) basicQuery(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+list "azurerm_resource_group" "test" {
+  provider = azurerm
+
+  config {
+    resource_group_name = "acctestRG-%d"
+  }
+}
+`, data.RandomInteger)
+}
+
+func (ResourceGroupListResource) byTags() string {
+	return `
+list "azurerm_resource_group" "test" {
+  provider = azurerm
+
+  config {
+    tags = {
+      environment = "Production"
+    }
+  }
+}
+`
+}
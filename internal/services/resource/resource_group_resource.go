@@ -12,6 +12,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources" // nolint: staticcheck
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
@@ -30,10 +31,12 @@ func resourceResourceGroup() *pluginsdk.Resource {
 		Read:   resourceResourceGroupRead,
 		Update: resourceResourceGroupCreateUpdate,
 		Delete: resourceResourceGroupDelete,
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+		Importer: pluginsdk.ImporterBuildingResourceIdFromIdentity(&commonids.ResourceGroupId{}, pluginsdk.ImporterValidatingResourceId(func(id string) error {
 			_, err := parse.ResourceGroupID(id)
 			return err
-		}),
+		})),
+
+		Identity: pluginsdk.ResourceIdentityFromResourceId(&commonids.ResourceGroupId{}),
 
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(90 * time.Minute),
@@ -127,6 +130,11 @@ func resourceResourceGroupRead(d *pluginsdk.ResourceData, meta interface{}) erro
 		return fmt.Errorf("reading resource group: %+v", err)
 	}
 
+	resourceGroupId := commonids.NewResourceGroupID(id.SubscriptionId, id.ResourceGroup)
+	if err := pluginsdk.SetResourceIdentityData(d, &resourceGroupId); err != nil {
+		return err
+	}
+
 	d.Set("name", resp.Name)
 	d.Set("location", location.NormalizeNilable(resp.Location))
 	d.Set("managed_by", pointer.From(resp.ManagedBy))
@@ -223,5 +231,5 @@ delete this using the Azure API directly (which will clear up any nested resourc
 More information on the 'features' block can be found in the documentation:
 https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/guides/features-block
 `, name, strings.Join(formattedResourceUris, "\n"))
-	return fmt.Errorf(strings.ReplaceAll(message, "'", "`"))
+	return fmt.Errorf("%s", strings.ReplaceAll(message, "'", "`"))
 }
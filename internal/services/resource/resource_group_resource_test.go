@@ -31,6 +31,15 @@ func TestAccResourceGroup_basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceGroup_importIdentity(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group", "test")
+	testResource := ResourceGroupResource{}
+	data.ResourceTest(t, testResource, []acceptance.TestStep{
+		data.ApplyStep(testResource.basicConfig, testResource),
+		data.ImportIdentityStep(),
+	})
+}
+
 func TestAccResourceGroup_requiresImport(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_resource_group", "test")
 	testResource := ResourceGroupResource{}
@@ -373,15 +373,14 @@ func (r ResourceProviderRegistrationResource) checkIfManagedByTerraform(name str
 		return nil
 	}
 
-	for resourceProvider := range resourceproviders.Required() {
-		if resourceProvider == name {
-			fmtStr := `The Resource Provider %q is automatically registered by Terraform.
+	if _, ok := account.ResourceProvidersToRegister[name]; ok {
+		fmtStr := `The Resource Provider %q is automatically registered by Terraform.
 
 To manage this Resource Provider Registration with Terraform you need to opt-out
-of Automatic Resource Provider Registration (by setting 'skip_provider_registration'
-to 'true' in the Provider block) to avoid conflicting with Terraform.`
-			return fmt.Errorf(fmtStr, name)
-		}
+of Automatic Resource Provider Registration for this Resource Provider (by setting
+'resource_provider_registrations' to a set which doesn't include this Resource Provider,
+such as 'none', in the Provider block) to avoid conflicting with Terraform.`
+		return fmt.Errorf(fmtStr, name)
 	}
 
 	return nil
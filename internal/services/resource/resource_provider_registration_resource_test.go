@@ -124,7 +124,7 @@ func (ResourceProviderRegistrationResource) basic(name string) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
   features {}
-  skip_provider_registration = true
+  resource_provider_registrations = "none"
 }
 
 resource "azurerm_resource_provider_registration" "test" {
@@ -202,7 +202,7 @@ func (ResourceProviderRegistrationResource) multiFeature(registered1 bool, regis
 	return fmt.Sprintf(`
 provider "azurerm" {
   features {}
-  skip_provider_registration = true
+  resource_provider_registrations = "none"
 }
 
 resource "azurerm_resource_provider_registration" "test" {
@@ -4,13 +4,20 @@
 package storage
 
 import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
 type Registration struct{}
 
-var _ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+var (
+	_ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+	_ sdk.ServiceRegistrationWithEphemeralResources  = Registration{}
+	_ sdk.ServiceRegistrationWithListResources       = Registration{}
+	_ sdk.ServiceRegistrationWithResourceIds         = Registration{}
+)
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/storage"
@@ -83,3 +90,23 @@ func (r Registration) Resources() []sdk.Resource {
 		LocalUserResource{},
 	}
 }
+
+func (r Registration) EphemeralResources() []sdk.EphemeralResource {
+	return []sdk.EphemeralResource{
+		StorageAccountBlobContainerSasEphemeralResource{},
+		StorageAccountSasEphemeralResource{},
+	}
+}
+
+func (r Registration) ListResources() []sdk.ListResource {
+	return []sdk.ListResource{
+		StorageAccountListResource{},
+	}
+}
+
+// ResourceIds returns a list of the Resource ID types used by this Service
+func (r Registration) ResourceIds() []resourceids.ResourceId {
+	return []resourceids.ResourceId{
+		&commonids.StorageAccountId{},
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/storage"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	storageValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+)
+
+var _ sdk.EphemeralResource = StorageAccountBlobContainerSasEphemeralResource{}
+
+type StorageAccountBlobContainerSasEphemeralResource struct{}
+
+type StorageAccountBlobContainerSasEphemeralResourceModel struct {
+	ConnectionString   string                                           `tfschema:"connection_string"`
+	ContainerName      string                                           `tfschema:"container_name"`
+	HttpsOnly          *bool                                            `tfschema:"https_only"`
+	IpAddress          string                                           `tfschema:"ip_address"`
+	Start              string                                           `tfschema:"start"`
+	Expiry             string                                           `tfschema:"expiry"`
+	Permissions        []StorageAccountBlobContainerSasPermissionsModel `tfschema:"permissions"`
+	CacheControl       string                                           `tfschema:"cache_control"`
+	ContentDisposition string                                           `tfschema:"content_disposition"`
+	ContentEncoding    string                                           `tfschema:"content_encoding"`
+	ContentLanguage    string                                           `tfschema:"content_language"`
+	ContentType        string                                           `tfschema:"content_type"`
+	Sas                string                                           `tfschema:"sas"`
+}
+
+type StorageAccountBlobContainerSasPermissionsModel struct {
+	Read   bool `tfschema:"read"`
+	Add    bool `tfschema:"add"`
+	Create bool `tfschema:"create"`
+	Write  bool `tfschema:"write"`
+	Delete bool `tfschema:"delete"`
+	List   bool `tfschema:"list"`
+}
+
+func (StorageAccountBlobContainerSasEphemeralResource) ResourceType() string {
+	return "azurerm_storage_account_blob_container_sas"
+}
+
+func (StorageAccountBlobContainerSasEphemeralResource) ModelObject() interface{} {
+	return &StorageAccountBlobContainerSasEphemeralResourceModel{}
+}
+
+func (StorageAccountBlobContainerSasEphemeralResource) TypedArguments() sdk.TypedSchema {
+	return sdk.TypedSchema{
+		Attributes: map[string]sdk.Attribute{
+			"connection_string": {
+				Type:        sdk.AttributeTypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The connection string for the Storage Account to which this SAS applies.",
+				Validation: &sdk.AttributeValidation{
+					NotEmpty: true,
+				},
+			},
+
+			"container_name": {
+				Type:        sdk.AttributeTypeString,
+				Required:    true,
+				Description: "The name of the Container.",
+				Validation: &sdk.AttributeValidation{
+					NotEmpty: true,
+				},
+			},
+
+			"https_only": {
+				Type:        sdk.AttributeTypeBool,
+				Optional:    true,
+				Description: "Only permit `https` access. If `false`, both `http` and `https` are permitted. Defaults to `true`.",
+			},
+
+			"ip_address": {
+				Type:        sdk.AttributeTypeString,
+				Optional:    true,
+				Description: "A single IPv4 address or range (connected with a dash) of IPv4 addresses.",
+			},
+
+			"start": {
+				Type:        sdk.AttributeTypeString,
+				Required:    true,
+				Description: "The starting time and date of validity of this SAS, in ISO-8601 format.",
+				Validation: &sdk.AttributeValidation{
+					NotEmpty: true,
+				},
+			},
+
+			"expiry": {
+				Type:        sdk.AttributeTypeString,
+				Required:    true,
+				Description: "The expiration time and date of this SAS, in ISO-8601 format.",
+				Validation: &sdk.AttributeValidation{
+					NotEmpty: true,
+				},
+			},
+
+			"cache_control": {
+				Type:        sdk.AttributeTypeString,
+				Optional:    true,
+				Description: "The `Cache-Control` response header that is sent when this SAS token is used.",
+			},
+
+			"content_disposition": {
+				Type:        sdk.AttributeTypeString,
+				Optional:    true,
+				Description: "The `Content-Disposition` response header that is sent when this SAS token is used.",
+			},
+
+			"content_encoding": {
+				Type:        sdk.AttributeTypeString,
+				Optional:    true,
+				Description: "The `Content-Encoding` response header that is sent when this SAS token is used.",
+			},
+
+			"content_language": {
+				Type:        sdk.AttributeTypeString,
+				Optional:    true,
+				Description: "The `Content-Language` response header that is sent when this SAS token is used.",
+			},
+
+			"content_type": {
+				Type:        sdk.AttributeTypeString,
+				Optional:    true,
+				Description: "The `Content-Type` response header that is sent when this SAS token is used.",
+			},
+		},
+		Blocks: map[string]sdk.Block{
+			"permissions": {
+				NestingMode: sdk.BlockNestingModeList,
+				Required:    true,
+				MinItems:    1,
+				MaxItems:    1,
+				Description: "The permissions which this SAS grants.",
+				Attributes: map[string]sdk.Attribute{
+					"read": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"add": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"create": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"write": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"delete": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"list": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (StorageAccountBlobContainerSasEphemeralResource) TypedAttributes() sdk.TypedSchema {
+	return sdk.TypedSchema{
+		Attributes: map[string]sdk.Attribute{
+			"sas": {
+				Type:        sdk.AttributeTypeString,
+				Sensitive:   true,
+				Description: "The computed Blob Container Shared Access Signature (SAS).",
+			},
+		},
+	}
+}
+
+func (StorageAccountBlobContainerSasEphemeralResource) Open() sdk.EphemeralResourceFunc {
+	return sdk.EphemeralResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.EphemeralResourceMetaData) error {
+			var config StorageAccountBlobContainerSasEphemeralResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if err := validateStorageSasDateTimes(config.Start, config.Expiry); err != nil {
+				return err
+			}
+			if config.IpAddress != "" {
+				if _, errs := storageValidate.SharedAccessSignatureIP(config.IpAddress, "ip_address"); len(errs) > 0 {
+					return errs[0]
+				}
+			}
+
+			kvp, err := storage.ParseAccountSASConnectionString(config.ConnectionString)
+			if err != nil {
+				return err
+			}
+
+			accountName := kvp[connStringAccountNameKey]
+			accountKey := kvp[connStringAccountKeyKey]
+			signedProtocol := "https,http"
+			if config.HttpsOnly == nil || *config.HttpsOnly {
+				signedProtocol = "https"
+			}
+			signedIdentifier := ""
+			signedSnapshotTime := ""
+
+			permissions := BuildContainerPermissionsString(expandStorageAccountBlobContainerSasPermissions(config.Permissions))
+
+			sasToken, err := storage.ComputeContainerSASToken(permissions, config.Start, config.Expiry, accountName, accountKey,
+				config.ContainerName, signedIdentifier, config.IpAddress, signedProtocol, signedSnapshotTime, config.CacheControl,
+				config.ContentDisposition, config.ContentEncoding, config.ContentLanguage, config.ContentType)
+			if err != nil {
+				return err
+			}
+
+			config.Sas = sasToken
+
+			return metadata.Encode(&config)
+		},
+	}
+}
+
+func expandStorageAccountBlobContainerSasPermissions(input []StorageAccountBlobContainerSasPermissionsModel) map[string]interface{} {
+	if len(input) == 0 {
+		return map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"read":   input[0].Read,
+		"add":    input[0].Add,
+		"create": input[0].Create,
+		"write":  input[0].Write,
+		"delete": input[0].Delete,
+		"list":   input[0].List,
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+var _ sdk.ListResource = StorageAccountListResource{}
+
+type StorageAccountListResource struct{}
+
+func (StorageAccountListResource) ResourceType() string {
+	return "azurerm_storage_account"
+}
+
+func (StorageAccountListResource) Identity() resourceids.ResourceId {
+	return &commonids.StorageAccountId{}
+}
+
+func (StorageAccountListResource) List() sdk.ListResourceFunc {
+	return sdk.ListResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ListResourceMetaData) ([]sdk.ListResourceResult, error) {
+			client := metadata.Client.Storage.ResourceManager.StorageAccounts
+			subscriptionId := commonids.NewSubscriptionID(metadata.Client.Account.SubscriptionId)
+
+			var items []storageaccounts.StorageAccount
+			if metadata.ResourceGroupName != "" {
+				resourceGroupId := commonids.NewResourceGroupID(subscriptionId.SubscriptionId, metadata.ResourceGroupName)
+				resp, err := client.ListByResourceGroupComplete(ctx, resourceGroupId)
+				if err != nil {
+					return nil, fmt.Errorf("listing Storage Accounts within %s: %+v", resourceGroupId, err)
+				}
+				items = resp.Items
+			} else {
+				resp, err := client.ListComplete(ctx, subscriptionId)
+				if err != nil {
+					return nil, fmt.Errorf("listing Storage Accounts within %s: %+v", subscriptionId, err)
+				}
+				items = resp.Items
+			}
+
+			results := make([]sdk.ListResourceResult, 0)
+			for _, item := range items {
+				id, err := commonids.ParseStorageAccountIDInsensitively(pointer.From(item.Id))
+				if err != nil {
+					return nil, err
+				}
+
+				results = append(results, sdk.ListResourceResult{
+					ID:   id,
+					Tags: pointer.From(item.Tags),
+				})
+			}
+
+			return results, nil
+		},
+	}
+}
@@ -113,10 +113,12 @@ func resourceStorageAccount() *pluginsdk.Resource {
 		SchemaVersion:  schemaVersion,
 		StateUpgraders: pluginsdk.StateUpgrades(upgraders),
 
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+		Importer: pluginsdk.ImporterBuildingResourceIdFromIdentity(&commonids.StorageAccountId{}, pluginsdk.ImporterValidatingResourceId(func(id string) error {
 			_, err := commonids.ParseStorageAccountID(id)
 			return err
-		}),
+		})),
+
+		Identity: pluginsdk.ResourceIdentityFromResourceId(&commonids.StorageAccountId{}),
 
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(60 * time.Minute),
@@ -2054,6 +2056,10 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 		return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
 	}
 
+	if err := pluginsdk.SetResourceIdentityData(d, id); err != nil {
+		return err
+	}
+
 	// handle the user not having permissions to list the keys
 	d.Set("primary_connection_string", "")
 	d.Set("secondary_connection_string", "")
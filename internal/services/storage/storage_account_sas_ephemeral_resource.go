@@ -0,0 +1,334 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/storage"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+var _ sdk.EphemeralResource = StorageAccountSasEphemeralResource{}
+
+// StorageAccountSasEphemeralResource generates an ACCOUNT SAS : https://docs.microsoft.com/en-us/rest/api/storageservices/Constructing-an-Account-SAS
+// not Service SAS
+type StorageAccountSasEphemeralResource struct{}
+
+type StorageAccountSasEphemeralResourceModel struct {
+	ConnectionString string                                `tfschema:"connection_string"`
+	HttpsOnly        *bool                                 `tfschema:"https_only"`
+	IpAddresses      string                                `tfschema:"ip_addresses"`
+	SignedVersion    *string                               `tfschema:"signed_version"`
+	ResourceTypes    []StorageAccountSasResourceTypesModel `tfschema:"resource_types"`
+	Services         []StorageAccountSasServicesModel      `tfschema:"services"`
+	Start            string                                `tfschema:"start"`
+	Expiry           string                                `tfschema:"expiry"`
+	Permissions      []StorageAccountSasPermissionsModel   `tfschema:"permissions"`
+	Sas              string                                `tfschema:"sas"`
+}
+
+type StorageAccountSasResourceTypesModel struct {
+	Service   bool `tfschema:"service"`
+	Container bool `tfschema:"container"`
+	Object    bool `tfschema:"object"`
+}
+
+type StorageAccountSasServicesModel struct {
+	Blob  bool `tfschema:"blob"`
+	Queue bool `tfschema:"queue"`
+	Table bool `tfschema:"table"`
+	File  bool `tfschema:"file"`
+}
+
+type StorageAccountSasPermissionsModel struct {
+	Read    bool `tfschema:"read"`
+	Write   bool `tfschema:"write"`
+	Delete  bool `tfschema:"delete"`
+	List    bool `tfschema:"list"`
+	Add     bool `tfschema:"add"`
+	Create  bool `tfschema:"create"`
+	Update  bool `tfschema:"update"`
+	Process bool `tfschema:"process"`
+	Tag     bool `tfschema:"tag"`
+	Filter  bool `tfschema:"filter"`
+}
+
+func (StorageAccountSasEphemeralResource) ResourceType() string {
+	return "azurerm_storage_account_sas"
+}
+
+func (StorageAccountSasEphemeralResource) ModelObject() interface{} {
+	return &StorageAccountSasEphemeralResourceModel{}
+}
+
+func (StorageAccountSasEphemeralResource) TypedArguments() sdk.TypedSchema {
+	return sdk.TypedSchema{
+		Attributes: map[string]sdk.Attribute{
+			"connection_string": {
+				Type:        sdk.AttributeTypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The connection string for the Storage Account to which this SAS applies.",
+			},
+
+			"https_only": {
+				Type:        sdk.AttributeTypeBool,
+				Optional:    true,
+				Description: "Only permit `https` access. If `false`, both `http` and `https` are permitted. Defaults to `true`.",
+			},
+
+			"ip_addresses": {
+				Type:        sdk.AttributeTypeString,
+				Optional:    true,
+				Description: "An IP address or a range of IP addresses from which to accept requests.",
+			},
+
+			"signed_version": {
+				Type:        sdk.AttributeTypeString,
+				Optional:    true,
+				Description: fmt.Sprintf("Specifies the signed storage service version to use to authorize requests made with this account SAS. Defaults to `%s`.", sasSignedVersion),
+			},
+
+			// Always in UTC and must be ISO-8601 format
+			"start": {
+				Type:        sdk.AttributeTypeString,
+				Required:    true,
+				Description: "The starting time and date of validity of this SAS, in ISO-8601 format.",
+				Validation: &sdk.AttributeValidation{
+					NotEmpty: true,
+				},
+			},
+
+			// Always in UTC and must be ISO-8601 format
+			"expiry": {
+				Type:        sdk.AttributeTypeString,
+				Required:    true,
+				Description: "The expiration time and date of this SAS, in ISO-8601 format.",
+				Validation: &sdk.AttributeValidation{
+					NotEmpty: true,
+				},
+			},
+		},
+		Blocks: map[string]sdk.Block{
+			"resource_types": {
+				NestingMode: sdk.BlockNestingModeList,
+				Required:    true,
+				MinItems:    1,
+				MaxItems:    1,
+				Description: "The resource types which this SAS applies to.",
+				Attributes: map[string]sdk.Attribute{
+					"service": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"container": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"object": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+				},
+			},
+
+			"services": {
+				NestingMode: sdk.BlockNestingModeList,
+				Required:    true,
+				MinItems:    1,
+				MaxItems:    1,
+				Description: "The services which this SAS applies to.",
+				Attributes: map[string]sdk.Attribute{
+					"blob": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"queue": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"table": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"file": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+				},
+			},
+
+			"permissions": {
+				NestingMode: sdk.BlockNestingModeList,
+				Required:    true,
+				MinItems:    1,
+				MaxItems:    1,
+				Description: "The permissions which this SAS grants.",
+				Attributes: map[string]sdk.Attribute{
+					"read": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"write": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"delete": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"list": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"add": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"create": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"update": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"process": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"tag": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+					"filter": {
+						Type:     sdk.AttributeTypeBool,
+						Required: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (StorageAccountSasEphemeralResource) TypedAttributes() sdk.TypedSchema {
+	return sdk.TypedSchema{
+		Attributes: map[string]sdk.Attribute{
+			"sas": {
+				Type:        sdk.AttributeTypeString,
+				Sensitive:   true,
+				Description: "The computed Account Shared Access Signature (SAS).",
+			},
+		},
+	}
+}
+
+func (StorageAccountSasEphemeralResource) Open() sdk.EphemeralResourceFunc {
+	return sdk.EphemeralResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.EphemeralResourceMetaData) error {
+			var config StorageAccountSasEphemeralResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if err := validateStorageSasDateTimes(config.Start, config.Expiry); err != nil {
+				return err
+			}
+
+			kvp, err := storage.ParseAccountSASConnectionString(config.ConnectionString)
+			if err != nil {
+				return err
+			}
+
+			accountName := kvp[connStringAccountNameKey]
+			accountKey := kvp[connStringAccountKeyKey]
+			signedProtocol := "https,http"
+			if config.HttpsOnly == nil || *config.HttpsOnly {
+				signedProtocol = "https"
+			}
+			signedVersion := sasSignedVersion
+			if config.SignedVersion != nil && *config.SignedVersion != "" {
+				signedVersion = *config.SignedVersion
+			}
+
+			// TODO: implement support for signedEncryptionScope
+			signedEncryptionScope := ""
+
+			permissions := BuildPermissionsString(expandStorageAccountSasPermissions(config.Permissions))
+			services := BuildServicesString(expandStorageAccountSasServices(config.Services))
+			resourceTypes := BuildResourceTypesString(expandStorageAccountSasResourceTypes(config.ResourceTypes))
+
+			sasToken, err := storage.ComputeAccountSASToken(accountName, accountKey, permissions, services, resourceTypes,
+				config.Start, config.Expiry, signedProtocol, config.IpAddresses, signedVersion, signedEncryptionScope)
+			if err != nil {
+				return err
+			}
+
+			config.Sas = sasToken
+
+			return metadata.Encode(&config)
+		},
+	}
+}
+
+// validateStorageSasDateTimes validates that the start and expiry of a SAS are in ISO-8601 format, since these can't
+// be validated within the Typed Schema
+func validateStorageSasDateTimes(start, expiry string) error {
+	if _, errs := validate.ISO8601DateTime(start, "start"); len(errs) > 0 {
+		return errs[0]
+	}
+	if _, errs := validate.ISO8601DateTime(expiry, "expiry"); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+func expandStorageAccountSasResourceTypes(input []StorageAccountSasResourceTypesModel) map[string]interface{} {
+	if len(input) == 0 {
+		return map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"service":   input[0].Service,
+		"container": input[0].Container,
+		"object":    input[0].Object,
+	}
+}
+
+func expandStorageAccountSasServices(input []StorageAccountSasServicesModel) map[string]interface{} {
+	if len(input) == 0 {
+		return map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"blob":  input[0].Blob,
+		"queue": input[0].Queue,
+		"table": input[0].Table,
+		"file":  input[0].File,
+	}
+}
+
+func expandStorageAccountSasPermissions(input []StorageAccountSasPermissionsModel) map[string]interface{} {
+	if len(input) == 0 {
+		return map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"read":    input[0].Read,
+		"write":   input[0].Write,
+		"delete":  input[0].Delete,
+		"list":    input[0].List,
+		"add":     input[0].Add,
+		"create":  input[0].Create,
+		"update":  input[0].Update,
+		"process": input[0].Process,
+		"tag":     input[0].Tag,
+		"filter":  input[0].Filter,
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"strings"
+)
+
+// ProviderConfig contains the tagging configuration defined in the Provider block, which is
+// applied to every Resource which exposes a `tags` field.
+type ProviderConfig struct {
+	// DefaultTags are merged into the Tags for every Resource, where any Tags defined on the
+	// Resource itself take precedence.
+	DefaultTags map[string]string
+
+	// IgnoreTags defines the Tags which should be excluded from the diff.
+	IgnoreTags IgnoreConfig
+}
+
+// IgnoreConfig defines the Tag Keys (and Key Prefixes) which should be ignored - matching is
+// performed case-insensitively, since Tag Keys are case-insensitive in Azure.
+type IgnoreConfig struct {
+	Keys        []string
+	KeyPrefixes []string
+}
+
+// Enabled returns whether any Provider-level tagging configuration has been specified.
+func (c ProviderConfig) Enabled() bool {
+	return len(c.DefaultTags) > 0 || len(c.IgnoreTags.Keys) > 0 || len(c.IgnoreTags.KeyPrefixes) > 0
+}
+
+// Enabled returns whether any Tag Keys (or Key Prefixes) should be ignored.
+func (c IgnoreConfig) Enabled() bool {
+	return len(c.Keys) > 0 || len(c.KeyPrefixes) > 0
+}
+
+// Ignored returns whether the specified Tag Key should be ignored.
+func (c IgnoreConfig) Ignored(key string) bool {
+	for _, k := range c.Keys {
+		if k != "" && strings.EqualFold(k, key) {
+			return true
+		}
+	}
+
+	for _, prefix := range c.KeyPrefixes {
+		if prefix != "" && strings.HasPrefix(strings.ToLower(key), strings.ToLower(prefix)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MergeDefaults returns the effective set of Tags for a Resource, comprised of the Default Tags
+// with the Tags defined on the Resource taking precedence.
+func (c ProviderConfig) MergeDefaults(input map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(c.DefaultTags)+len(input))
+
+	for k, v := range c.DefaultTags {
+		output[k] = v
+	}
+
+	for k, v := range input {
+		// Tag Keys are case-insensitive, so the value on the Resource should replace the Default
+		for existing := range output {
+			if strings.EqualFold(existing, k) {
+				delete(output, existing)
+			}
+		}
+
+		output[k] = v
+	}
+
+	return output
+}
+
+// MergeIgnored returns the Tags in `input` along with the ignored Tags from `remote` (the Tags currently
+// assigned to the Resource in Azure), so that the ignored Tags are retained when the Tags are replaced.
+func (c ProviderConfig) MergeIgnored(input map[string]interface{}, remote map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(input)+len(remote))
+
+	for k, v := range remote {
+		if c.IgnoreTags.Ignored(k) {
+			output[k] = v
+		}
+	}
+
+	for k, v := range input {
+		output[k] = v
+	}
+
+	return output
+}
+
+// FilterIgnored returns the Tags which aren't ignored by the Provider-level configuration.
+func (c ProviderConfig) FilterIgnored(input map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(input))
+
+	for k, v := range input {
+		if c.IgnoreTags.Ignored(k) {
+			continue
+		}
+
+		output[k] = v
+	}
+
+	return output
+}
+
+// RemoveDefaults returns the Tags which should be exposed in the `tags` field for a Resource - that
+// is, the Tags which aren't ignored and which aren't inherited from the Default Tags.
+//
+// A Tag present in `configured` (the Tags defined on the Resource) is always retained, even when it's
+// ignored, so that a Tag defined on the Resource doesn't show a diff. A Tag matching a Default Tag is
+// otherwise retained only when the value differs from the Default (e.g. when changed outside of Terraform).
+func (c ProviderConfig) RemoveDefaults(input map[string]interface{}, configured map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(input))
+
+	for k, v := range input {
+		if _, ok := configured[k]; ok {
+			output[k] = v
+			continue
+		}
+
+		if c.IgnoreTags.Ignored(k) {
+			continue
+		}
+
+		defaultValue, isDefault := c.DefaultTags[k]
+		if isDefault {
+			value, _ := TagValueToString(v)
+			if value == defaultValue {
+				continue
+			}
+		}
+
+		output[k] = v
+	}
+
+	return output
+}
@@ -0,0 +1,215 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProviderConfigMergeDefaults(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Defaults map[string]string
+		Input    map[string]interface{}
+		Expected map[string]interface{}
+	}{
+		{
+			Name:     "No Defaults",
+			Defaults: map[string]string{},
+			Input: map[string]interface{}{
+				"hello": "world",
+			},
+			Expected: map[string]interface{}{
+				"hello": "world",
+			},
+		},
+		{
+			Name: "Defaults Only",
+			Defaults: map[string]string{
+				"cost-center": "123",
+			},
+			Input: map[string]interface{}{},
+			Expected: map[string]interface{}{
+				"cost-center": "123",
+			},
+		},
+		{
+			Name: "Resource Values Win",
+			Defaults: map[string]string{
+				"cost-center": "123",
+				"owner":       "platform",
+			},
+			Input: map[string]interface{}{
+				"owner": "networking",
+			},
+			Expected: map[string]interface{}{
+				"cost-center": "123",
+				"owner":       "networking",
+			},
+		},
+		{
+			Name: "Resource Values Win Case Insensitively",
+			Defaults: map[string]string{
+				"Owner": "platform",
+			},
+			Input: map[string]interface{}{
+				"owner": "networking",
+			},
+			Expected: map[string]interface{}{
+				"owner": "networking",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		config := ProviderConfig{
+			DefaultTags: v.Defaults,
+		}
+		actual := config.MergeDefaults(v.Input)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestProviderConfigFilterIgnored(t *testing.T) {
+	config := ProviderConfig{
+		IgnoreTags: IgnoreConfig{
+			Keys:        []string{"CreatedBy"},
+			KeyPrefixes: []string{"hidden-"},
+		},
+	}
+
+	actual := config.FilterIgnored(map[string]interface{}{
+		"createdby":         "someone",
+		"hidden-link:/abc":  "Resource",
+		"Hidden-Title":      "Example",
+		"environment":       "production",
+		"not-hidden-prefix": "value",
+	})
+	expected := map[string]interface{}{
+		"environment":       "production",
+		"not-hidden-prefix": "value",
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestProviderConfigMergeIgnored(t *testing.T) {
+	config := ProviderConfig{
+		IgnoreTags: IgnoreConfig{
+			Keys:        []string{"CreatedBy"},
+			KeyPrefixes: []string{"hidden-"},
+		},
+	}
+
+	actual := config.MergeIgnored(map[string]interface{}{
+		"environment": "production",
+		"createdby":   "terraform",
+	}, map[string]interface{}{
+		"createdby":        "someone",
+		"hidden-link:/abc": "Resource",
+		"environment":      "staging",
+		"cost-center":      "1234",
+	})
+	expected := map[string]interface{}{
+		"environment":      "production",
+		"createdby":        "terraform",
+		"hidden-link:/abc": "Resource",
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestProviderConfigRemoveDefaults(t *testing.T) {
+	testData := []struct {
+		Name       string
+		Input      map[string]interface{}
+		Configured map[string]interface{}
+		Expected   map[string]interface{}
+	}{
+		{
+			Name: "Default Tags Removed",
+			Input: map[string]interface{}{
+				"cost-center": "123",
+				"environment": "production",
+			},
+			Configured: map[string]interface{}{},
+			Expected: map[string]interface{}{
+				"environment": "production",
+			},
+		},
+		{
+			Name: "Default Tag Configured on Resource",
+			Input: map[string]interface{}{
+				"cost-center": "123",
+			},
+			Configured: map[string]interface{}{
+				"cost-center": "123",
+			},
+			Expected: map[string]interface{}{
+				"cost-center": "123",
+			},
+		},
+		{
+			Name: "Default Tag Changed Outside of Terraform",
+			Input: map[string]interface{}{
+				"cost-center": "456",
+			},
+			Configured: map[string]interface{}{},
+			Expected: map[string]interface{}{
+				"cost-center": "456",
+			},
+		},
+		{
+			Name: "Ignored Tags Removed",
+			Input: map[string]interface{}{
+				"cost-center": "123",
+				"CreatedBy":   "someone",
+			},
+			Configured: map[string]interface{}{},
+			Expected:   map[string]interface{}{},
+		},
+		{
+			Name: "Ignored Tag Configured on Resource",
+			Input: map[string]interface{}{
+				"CreatedBy":   "terraform",
+				"environment": "production",
+			},
+			Configured: map[string]interface{}{
+				"CreatedBy":   "terraform",
+				"environment": "production",
+			},
+			Expected: map[string]interface{}{
+				"CreatedBy":   "terraform",
+				"environment": "production",
+			},
+		},
+	}
+
+	config := ProviderConfig{
+		DefaultTags: map[string]string{
+			"cost-center": "123",
+		},
+		IgnoreTags: IgnoreConfig{
+			Keys: []string{"createdby"},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual := config.RemoveDefaults(v.Input, v.Configured)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
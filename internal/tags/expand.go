@@ -14,3 +14,9 @@ func Expand(tagsMap map[string]interface{}) map[string]*string {
 
 	return output
 }
+
+// Expand expands the Tags defined on a Resource along with the Default Tags from the Provider block,
+// where the Tags defined on the Resource take precedence
+func (c ProviderConfig) Expand(tagsMap map[string]interface{}) map[string]*string {
+	return Expand(c.MergeDefaults(tagsMap))
+}
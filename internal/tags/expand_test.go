@@ -34,3 +34,29 @@ func TestExpand(t *testing.T) {
 		}
 	}
 }
+
+func TestProviderConfigExpand(t *testing.T) {
+	config := ProviderConfig{
+		DefaultTags: map[string]string{
+			"cost-center": "123",
+			"owner":       "platform",
+		},
+	}
+
+	expanded := config.Expand(map[string]interface{}{
+		"owner": "networking",
+	})
+
+	expected := map[string]string{
+		"cost-center": "123",
+		"owner":       "networking",
+	}
+	if len(expanded) != len(expected) {
+		t.Fatalf("Expected %d results in expanded tag map, got %d", len(expected), len(expanded))
+	}
+	for k, v := range expected {
+		if expanded[k] == nil || *expanded[k] != v {
+			t.Fatalf("Expanded value %q incorrect: expected %q, got %v", k, v, expanded[k])
+		}
+	}
+}
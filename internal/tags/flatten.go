@@ -32,3 +32,11 @@ func FlattenAndSet(d *pluginsdk.ResourceData, tagMap map[string]*string) error {
 
 	return nil
 }
+
+// Flatten flattens the Tags assigned to a Resource into the Tags which should be exposed in the `tags`
+// field (see RemoveDefaults) and the effective set of Tags exposed in the `tags_all` field, which
+// excludes any ignored Tags
+func (c ProviderConfig) Flatten(tagMap map[string]*string, configured map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	flattened := Flatten(tagMap)
+	return c.RemoveDefaults(flattened, configured), c.FilterIgnored(flattened)
+}
@@ -54,3 +54,43 @@ func TestFlatten(t *testing.T) {
 		}
 	}
 }
+
+func TestProviderConfigFlatten(t *testing.T) {
+	config := ProviderConfig{
+		DefaultTags: map[string]string{
+			"cost-center": "123",
+		},
+		IgnoreTags: IgnoreConfig{
+			KeyPrefixes: []string{"hidden-"},
+		},
+	}
+
+	input := map[string]*string{
+		"cost-center": utils.String("123"),
+		"environment": utils.String("production"),
+		"hidden-link": utils.String("Resource"),
+		"hidden-name": utils.String("example"),
+	}
+	configured := map[string]interface{}{
+		"environment": "production",
+		"hidden-name": "example",
+	}
+
+	actual, actualAll := config.Flatten(input, configured)
+
+	expected := map[string]interface{}{
+		"environment": "production",
+		"hidden-name": "example",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+
+	expectedAll := map[string]interface{}{
+		"cost-center": "123",
+		"environment": "production",
+	}
+	if !reflect.DeepEqual(actualAll, expectedAll) {
+		t.Fatalf("Expected %+v but got %+v", expectedAll, actualAll)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pluginsdk
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	IdentityData     = schema.IdentityData
+	ResourceIdentity = schema.ResourceIdentity
+)
+
+// identityAttribute maps a Segment within a Resource ID to the attribute within the Resource Identity
+type identityAttribute struct {
+	segmentName   string
+	attributeName string
+	optional      bool
+}
+
+// identityAttributesForResourceId returns the Resource Identity attributes for the Resource ID type `id`, which
+// are the Subscription ID, Resource Group Name and any User Specified/Constant/Scope segments - where the final
+// User Specified (or Resource Group, for a Resource Group) segment is exposed as `name`.
+func identityAttributesForResourceId(id resourceids.ResourceId) []identityAttribute {
+	segments := id.Segments()
+
+	nameSegmentIndex := -1
+	for i, segment := range segments {
+		if segment.Type == resourceids.UserSpecifiedSegmentType || segment.Type == resourceids.ResourceGroupSegmentType {
+			nameSegmentIndex = i
+		}
+	}
+
+	attributes := make([]identityAttribute, 0)
+	for i, segment := range segments {
+		attribute := identityAttribute{
+			segmentName: segment.Name,
+		}
+
+		switch {
+		case segment.Type == resourceids.StaticSegmentType || segment.Type == resourceids.ResourceProviderSegmentType:
+			continue
+
+		case i == nameSegmentIndex:
+			attribute.attributeName = "name"
+
+		case segment.Type == resourceids.SubscriptionIdSegmentType:
+			// the Subscription ID can be inferred from the Provider block when importing
+			attribute.attributeName = "subscription_id"
+			attribute.optional = true
+
+		case segment.Type == resourceids.ResourceGroupSegmentType:
+			attribute.attributeName = "resource_group_name"
+
+		default:
+			attribute.attributeName = identityAttributeName(segment.Name)
+		}
+
+		attributes = append(attributes, attribute)
+	}
+
+	return attributes
+}
+
+// identityAttributeName converts the camelCased name of a Resource ID Segment into a snake_cased attribute name
+func identityAttributeName(segmentName string) string {
+	var sb strings.Builder
+	runes := []rune(segmentName)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				sb.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// ResourceIdentityFromResourceId returns the Resource Identity for a Resource whose ID is represented by the
+// Resource ID type `id` - for example:
+//
+//	Identity: pluginsdk.ResourceIdentityFromResourceId(&commonids.SubnetId{}),
+//
+// The Resource must then set the Identity during the Read using SetResourceIdentityData.
+func ResourceIdentityFromResourceId(id resourceids.ResourceId) *ResourceIdentity {
+	return &ResourceIdentity{
+		Version: 0,
+		SchemaFunc: func() map[string]*Schema {
+			return ResourceIdentitySchemaFromResourceId(id)
+		},
+	}
+}
+
+// ResourceIdentitySchemaFromResourceId returns the schema for the Resource Identity of a Resource whose ID is
+// represented by the Resource ID type `id`, keyed by the attribute name within the Resource Identity.
+func ResourceIdentitySchemaFromResourceId(id resourceids.ResourceId) map[string]*Schema {
+	out := make(map[string]*Schema)
+	for _, attribute := range identityAttributesForResourceId(id) {
+		out[attribute.attributeName] = &Schema{
+			Type:              TypeString,
+			RequiredForImport: !attribute.optional,
+			OptionalForImport: attribute.optional,
+		}
+	}
+	return out
+}
+
+// ResourceIdentityValues returns the values of the Resource Identity for the Resource ID `id`, keyed by the
+// attribute name within the Resource Identity.
+func ResourceIdentityValues(id resourceids.ResourceId) (map[string]string, error) {
+	return resourceIdentityValuesFromString(id, id.ID())
+}
+
+// resourceIdentityValuesFromString parses the Resource ID `input` as the Resource ID type `id` and returns the
+// values of the Resource Identity, keyed by the attribute name within the Resource Identity.
+func resourceIdentityValuesFromString(id resourceids.ResourceId, input string) (map[string]string, error) {
+	// parsed insensitively since the Resource ID may be from the State, where older Resources may use a different casing
+	parsed, err := resourceids.NewParserFromResourceIdType(id).Parse(input, true)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	out := make(map[string]string)
+	for _, attribute := range identityAttributesForResourceId(id) {
+		value, ok := parsed.Parsed[attribute.segmentName]
+		if !ok {
+			return nil, fmt.Errorf("the segment %q was not found in %q", attribute.segmentName, input)
+		}
+		out[attribute.attributeName] = value
+	}
+
+	return out, nil
+}
+
+// SetResourceIdentityData populates the Resource Identity for the Resource using the Resource ID `id`.
+func SetResourceIdentityData(d *ResourceData, id resourceids.ResourceId) error {
+	return SetResourceIdentityDataFromString(d, id, id.ID())
+}
+
+// SetResourceIdentityDataFromString populates the Resource Identity for the Resource by parsing the Resource ID
+// `input` as the Resource ID type `id` - this is intended for use where the Resource ID is only available as a
+// string, for example when using d.Id().
+func SetResourceIdentityDataFromString(d *ResourceData, id resourceids.ResourceId, input string) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return fmt.Errorf("retrieving the Resource Identity: %+v", err)
+	}
+
+	values, err := resourceIdentityValuesFromString(id, input)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range values {
+		if err := identity.Set(key, value); err != nil {
+			return fmt.Errorf("setting %q within the Resource Identity: %+v", key, err)
+		}
+	}
+
+	return nil
+}
+
+// DefaultSubscriptionIdProvider is implemented by the Provider's Client (passed to the Importer as `meta`), and
+// returns the Subscription ID used when the `subscription_id` is omitted from the Resource Identity at import time.
+type DefaultSubscriptionIdProvider interface {
+	DefaultSubscriptionId() string
+}
+
+// ResourceIdFromIdentityData builds the Resource ID (of the Resource ID type `id`) from the values within the
+// Resource Identity `identity` - where the `subscription_id` is omitted `defaultSubscriptionId` is used instead.
+func ResourceIdFromIdentityData(identity *IdentityData, id resourceids.ResourceId, defaultSubscriptionId string) (string, error) {
+	values := make(map[string]string)
+	for _, attribute := range identityAttributesForResourceId(id) {
+		value := ""
+		if v, ok := identity.GetOk(attribute.attributeName); ok {
+			value = v.(string)
+		}
+		if value == "" && attribute.attributeName == "subscription_id" {
+			value = defaultSubscriptionId
+		}
+		if value == "" {
+			return "", fmt.Errorf("the attribute %q must be specified within the Resource Identity", attribute.attributeName)
+		}
+		values[attribute.segmentName] = value
+	}
+
+	components := make([]string, 0)
+	for _, segment := range id.Segments() {
+		switch segment.Type {
+		case resourceids.StaticSegmentType, resourceids.ResourceProviderSegmentType:
+			components = append(components, pointer.From(segment.FixedValue))
+
+		default:
+			// Scope segments are themselves Resource IDs, so the leading slash is removed
+			components = append(components, strings.TrimPrefix(values[segment.Name], "/"))
+		}
+	}
+
+	resourceId := "/" + strings.Join(components, "/")
+	if _, err := resourceids.NewParserFromResourceIdType(id).Parse(resourceId, false); err != nil {
+		return "", fmt.Errorf("building the Resource ID from the Resource Identity: %+v", err)
+	}
+
+	return resourceId, nil
+}
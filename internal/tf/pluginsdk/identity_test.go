@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pluginsdk
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+func TestResourceIdentitySchemaFromResourceId(t *testing.T) {
+	testData := []struct {
+		id       resourceids.ResourceId
+		expected map[string]bool
+	}{
+		{
+			id: &commonids.ResourceGroupId{},
+			expected: map[string]bool{
+				"subscription_id": false,
+				"name":            true,
+			},
+		},
+		{
+			id: &commonids.SubnetId{},
+			expected: map[string]bool{
+				"subscription_id":      false,
+				"resource_group_name":  true,
+				"virtual_network_name": true,
+				"name":                 true,
+			},
+		},
+		{
+			id: &commonids.KubernetesClusterId{},
+			expected: map[string]bool{
+				"subscription_id":     false,
+				"resource_group_name": true,
+				"name":                true,
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %T", v.id)
+
+		actual := make(map[string]bool)
+		for key, item := range ResourceIdentitySchemaFromResourceId(v.id) {
+			if item.RequiredForImport == item.OptionalForImport {
+				t.Fatalf("expected %q to be either RequiredForImport or OptionalForImport", key)
+			}
+			actual[key] = item.RequiredForImport
+		}
+
+		if !reflect.DeepEqual(v.expected, actual) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestResourceIdentityValues(t *testing.T) {
+	id := commonids.NewSubnetID("12345678-1234-9876-4563-123456789012", "example-resources", "example-network", "internal")
+
+	actual, err := ResourceIdentityValues(&id)
+	if err != nil {
+		t.Fatalf("building the identity values: %+v", err)
+	}
+
+	expected := map[string]string{
+		"subscription_id":      "12345678-1234-9876-4563-123456789012",
+		"resource_group_name":  "example-resources",
+		"virtual_network_name": "example-network",
+		"name":                 "internal",
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestIdentityAttributeName(t *testing.T) {
+	testData := map[string]string{
+		"subscriptionId":     "subscription_id",
+		"resourceGroupName":  "resource_group_name",
+		"virtualNetworkName": "virtual_network_name",
+		"scope":              "scope",
+		"vmSSName":           "vm_ss_name",
+	}
+
+	for input, expected := range testData {
+		if actual := identityAttributeName(input); actual != expected {
+			t.Fatalf("expected %q for %q but got %q", expected, input, actual)
+		}
+	}
+}
+
+func TestResourceIdFromIdentityData(t *testing.T) {
+	testData := []struct {
+		identity map[string]string
+		expected string
+		error    bool
+	}{
+		{
+			identity: map[string]string{
+				"subscription_id":      "12345678-1234-9876-4563-123456789012",
+				"resource_group_name":  "example-resources",
+				"virtual_network_name": "example-network",
+				"name":                 "internal",
+			},
+			expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Network/virtualNetworks/example-network/subnets/internal",
+		},
+		{
+			// the Subscription ID is optional, and defaults to the Subscription ID from the Provider
+			identity: map[string]string{
+				"resource_group_name":  "example-resources",
+				"virtual_network_name": "example-network",
+				"name":                 "internal",
+			},
+			expected: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.Network/virtualNetworks/example-network/subnets/internal",
+		},
+		{
+			identity: map[string]string{
+				"resource_group_name": "example-resources",
+				"name":                "internal",
+			},
+			error: true,
+		},
+	}
+
+	resource := &Resource{
+		Schema:   map[string]*Schema{},
+		Identity: ResourceIdentityFromResourceId(&commonids.SubnetId{}),
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %+v", v.identity)
+
+		d := resource.Data(nil)
+		identity, err := d.Identity()
+		if err != nil {
+			t.Fatalf("retrieving the Resource Identity: %+v", err)
+		}
+		for key, value := range v.identity {
+			if err := identity.Set(key, value); err != nil {
+				t.Fatalf("setting %q: %+v", key, err)
+			}
+		}
+
+		actual, err := ResourceIdFromIdentityData(identity, &commonids.SubnetId{}, "00000000-0000-0000-0000-000000000000")
+		if err != nil {
+			if v.error {
+				continue
+			}
+			t.Fatalf("building the Resource ID: %+v", err)
+		}
+		if v.error {
+			t.Fatalf("expected an error but got %q", actual)
+		}
+		if actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
@@ -5,8 +5,10 @@ package pluginsdk
 
 import (
 	"context"
+	"fmt"
 	"log"
 
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -45,3 +47,61 @@ func ImporterValidatingResourceIdThen(validateFunc IDValidationFunc, thenFunc Im
 		},
 	}
 }
+
+// ImporterValidatingIdentity validates the ID provided at import time is a valid Resource ID of the type `id` -
+// and supports importing the Resource using the Resource Identity (see ResourceIdentityFromResourceId) rather
+// than the Resource ID, for example:
+//
+//	Identity: pluginsdk.ResourceIdentityFromResourceId(&commonids.SubnetId{}),
+//	Importer: pluginsdk.ImporterValidatingIdentity(&commonids.SubnetId{}),
+func ImporterValidatingIdentity(id resourceids.ResourceId) *schema.ResourceImporter {
+	thenFunc := func(ctx context.Context, d *ResourceData, meta interface{}) ([]*ResourceData, error) {
+		return []*ResourceData{d}, nil
+	}
+	return ImporterValidatingIdentityThen(id, thenFunc)
+}
+
+// ImporterValidatingIdentityThen validates the ID provided at import time is a valid Resource ID of the type `id`
+// (building the Resource ID from the Resource Identity when the Resource is imported using the Resource Identity)
+// then runs the 'thenFunc', allowing the import to be customised.
+func ImporterValidatingIdentityThen(id resourceids.ResourceId, thenFunc ImporterFunc) *schema.ResourceImporter {
+	validateFunc := func(input string) error {
+		_, err := resourceids.NewParserFromResourceIdType(id).Parse(input, false)
+		return err
+	}
+	return ImporterBuildingResourceIdFromIdentity(id, ImporterValidatingResourceIdThen(validateFunc, thenFunc))
+}
+
+// ImporterBuildingResourceIdFromIdentity wraps the importer `importer` so that, when the Resource is imported
+// using the Resource Identity (rather than the Resource ID), the Resource ID (of the type `id`) is built from
+// the Resource Identity prior to the existing Importer being run.
+func ImporterBuildingResourceIdFromIdentity(id resourceids.ResourceId, importer *schema.ResourceImporter) *schema.ResourceImporter {
+	importer.StateContext = importerBuildingResourceIdFromIdentity(id, importer.StateContext)
+	return importer
+}
+
+func importerBuildingResourceIdFromIdentity(id resourceids.ResourceId, next schema.StateContextFunc) schema.StateContextFunc {
+	return func(ctx context.Context, d *ResourceData, meta interface{}) ([]*ResourceData, error) {
+		if d.Id() == "" {
+			identity, err := d.Identity()
+			if err != nil {
+				return nil, fmt.Errorf("retrieving the Resource Identity: %+v", err)
+			}
+
+			defaultSubscriptionId := ""
+			if v, ok := meta.(DefaultSubscriptionIdProvider); ok {
+				defaultSubscriptionId = v.DefaultSubscriptionId()
+			}
+
+			resourceId, err := ResourceIdFromIdentityData(identity, id, defaultSubscriptionId)
+			if err != nil {
+				return nil, err
+			}
+
+			log.Printf("[DEBUG] Importing Resource - built the Resource ID %q from the Resource Identity", resourceId)
+			d.SetId(resourceId)
+		}
+
+		return next(ctx, d, meta)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pluginsdk
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+// WriteOnlyVersionSuffix is the suffix of the attribute which triggers an update of a write-only attribute - since
+// the value of a write-only attribute is never persisted it's not possible to detect when the value changes, so the
+// value is sent to the API when the `{name}_version` attribute changes.
+const WriteOnlyVersionSuffix = "_version"
+
+// GetWriteOnly returns the value of the write-only attribute at key from the configuration, as the values of
+// write-only attributes are never persisted into the plan or state - and so are unavailable using `d.Get`.
+//
+// A null value is returned when the attribute isn't specified, or when the configuration isn't available (e.g.
+// during a Read, Delete or Import).
+func GetWriteOnly(d *ResourceData, key string, ty cty.Type) (cty.Value, error) {
+	if d.GetRawConfig().IsNull() {
+		return cty.NullVal(ty), nil
+	}
+
+	value, diags := d.GetRawConfigAt(cty.GetAttrPath(key))
+	if diags.HasError() {
+		return cty.NullVal(ty), fmt.Errorf("retrieving the write-only attribute %q: %+v", key, diags)
+	}
+	if !value.Type().Equals(ty) {
+		return cty.NullVal(ty), fmt.Errorf("retrieving the write-only attribute %q: expected %s but got %s", key, ty.FriendlyName(), value.Type().FriendlyName())
+	}
+
+	return value, nil
+}
+
+// GetWriteOnlyString returns the value of the write-only string attribute at key from the configuration, or an
+// empty string when this isn't specified - see GetWriteOnly
+func GetWriteOnlyString(d *ResourceData, key string) (string, error) {
+	value, err := GetWriteOnly(d, key, cty.String)
+	if err != nil {
+		return "", err
+	}
+	if value.IsNull() || !value.IsKnown() {
+		return "", nil
+	}
+
+	return value.AsString(), nil
+}
+
+// IsWriteOnlyInUse returns whether the write-only attribute at key is being used, which is determined from the
+// configuration when it's available - otherwise (e.g. during a Read or Import) from whether the `{key}_version`
+// attribute is set in the State, since the value of the write-only attribute is never persisted.
+//
+// NOTE: unlike `d.GetOk` a `{key}_version` of `0` is treated as being set.
+func IsWriteOnlyInUse(d *ResourceData, key string) bool {
+	if !d.GetRawConfig().IsNull() {
+		value, diags := d.GetRawConfigAt(cty.GetAttrPath(key))
+		return !diags.HasError() && !value.IsNull()
+	}
+
+	versionKey := key + WriteOnlyVersionSuffix
+	state := d.GetRawState()
+	if state.IsNull() || !state.IsKnown() || !state.Type().IsObjectType() || !state.Type().HasAttribute(versionKey) {
+		return false
+	}
+
+	return !state.GetAttr(versionKey).IsNull()
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pluginsdk
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestIsWriteOnlyInUse(t *testing.T) {
+	resource := &Resource{
+		Schema: map[string]*Schema{
+			"value": {
+				Type:     TypeString,
+				Optional: true,
+			},
+			"value_wo": {
+				Type:      TypeString,
+				Optional:  true,
+				WriteOnly: true,
+			},
+			"value_wo_version": {
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+	}
+	objectType := resource.CoreConfigSchema().ImpliedType()
+	object := func(value, valueWo, valueWoVersion cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"id":               cty.StringVal("example"),
+			"value":            value,
+			"value_wo":         valueWo,
+			"value_wo_version": valueWoVersion,
+		})
+	}
+
+	testData := []struct {
+		name     string
+		state    *terraform.InstanceState
+		expected bool
+	}{
+		{
+			name: "write-only value in the configuration",
+			state: &terraform.InstanceState{
+				RawConfig: object(cty.NullVal(cty.String), cty.StringVal("s3cr3t"), cty.NumberIntVal(1)),
+			},
+			expected: true,
+		},
+		{
+			name: "value in the configuration",
+			state: &terraform.InstanceState{
+				RawConfig: object(cty.StringVal("s3cr3t"), cty.NullVal(cty.String), cty.NullVal(cty.Number)),
+			},
+			expected: false,
+		},
+		{
+			name: "version in the state",
+			state: &terraform.InstanceState{
+				RawState: object(cty.NullVal(cty.String), cty.NullVal(cty.String), cty.NumberIntVal(2)),
+			},
+			expected: true,
+		},
+		{
+			name: "version of zero in the state",
+			state: &terraform.InstanceState{
+				RawState: object(cty.NullVal(cty.String), cty.NullVal(cty.String), cty.NumberIntVal(0)),
+			},
+			expected: true,
+		},
+		{
+			name: "no version in the state",
+			state: &terraform.InstanceState{
+				RawState: object(cty.StringVal("s3cr3t"), cty.NullVal(cty.String), cty.NullVal(cty.Number)),
+			},
+			expected: false,
+		},
+		{
+			name: "no state",
+			state: &terraform.InstanceState{
+				RawState: cty.NullVal(objectType),
+			},
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		d := resource.Data(v.state)
+		if actual := IsWriteOnlyInUse(d, "value_wo"); actual != v.expected {
+			t.Fatalf("expected %t but got %t", v.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"reflect"
+	"runtime"
+)
+
+// Metadata describes the values accepted by a validation function, which is exposed in the provider schema by
+// the `schema-api` tool so that linters and IDE tooling know which values are allowed for a property.
+type Metadata struct {
+	// AllowedValues is the set of values the property can be set to
+	AllowedValues []interface{}
+
+	// Min and Max are the inclusive range of values for a numeric property
+	Min *float64
+	Max *float64
+
+	// MinLength and MaxLength are the inclusive range of lengths for a string property
+	MinLength *int
+	MaxLength *int
+
+	// Pattern is the regular expression which the value of a string property must match
+	Pattern string
+}
+
+type validateFunc = func(interface{}, string) ([]string, []error)
+
+// validator is a validation function along with the Metadata describing the values which it accepts
+type validator struct {
+	validate validateFunc
+	metadata Metadata
+}
+
+// metadataRequest is passed to the validation function for a validator in place of a value, to retrieve its Metadata
+type metadataRequest struct {
+	metadata *Metadata
+}
+
+func (v validator) validateFunc(i interface{}, k string) ([]string, []error) {
+	if request, ok := i.(*metadataRequest); ok {
+		request.metadata = &v.metadata
+		return nil, nil
+	}
+
+	return v.validate(i, k)
+}
+
+// validatorFuncName is the name of the validation function returned from withMetadata, which is the same for
+// all validators - allowing these to be distinguished from other validation functions
+var validatorFuncName = functionName(validator{}.validateFunc)
+
+// withMetadata returns a validation function which calls f, and for which MetadataFor returns m
+func withMetadata(f validateFunc, m Metadata) validateFunc {
+	return validator{
+		validate: f,
+		metadata: m,
+	}.validateFunc
+}
+
+// MetadataFor returns the Metadata for the specified validation function when it's been built by this package
+// (for example using StringInSlice or IntBetween), otherwise nil is returned
+func MetadataFor(f validateFunc) *Metadata {
+	if f == nil || functionName(f) != validatorFuncName {
+		return nil
+	}
+
+	request := &metadataRequest{}
+	f(request, "")
+	return request.metadata
+}
+
+func functionName(f validateFunc) string {
+	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+}
+
+// mergeMetadata returns the combination of the Metadata from the specified validation functions, for use when
+// all of the validation functions must be satisfied
+func mergeMetadata(validators ...validateFunc) (*Metadata, bool) {
+	var result *Metadata
+	for _, v := range validators {
+		m := MetadataFor(v)
+		if m == nil {
+			continue
+		}
+		if result == nil {
+			result = &Metadata{}
+		}
+		if m.AllowedValues != nil {
+			result.AllowedValues = m.AllowedValues
+		}
+		if m.Min != nil {
+			result.Min = m.Min
+		}
+		if m.Max != nil {
+			result.Max = m.Max
+		}
+		if m.MinLength != nil {
+			result.MinLength = m.MinLength
+		}
+		if m.MaxLength != nil {
+			result.MaxLength = m.MaxLength
+		}
+		if m.Pattern != "" {
+			result.Pattern = m.Pattern
+		}
+	}
+
+	return result, result != nil
+}
+
+func stringsToValues(input []string) []interface{} {
+	output := make([]interface{}, 0, len(input))
+	for _, v := range input {
+		output = append(output, v)
+	}
+	return output
+}
+
+func intsToValues(input []int) []interface{} {
+	output := make([]interface{}, 0, len(input))
+	for _, v := range input {
+		output = append(output, v)
+	}
+	return output
+}
+
+func floatsToValues(input []float64) []interface{} {
+	output := make([]interface{}, 0, len(input))
+	for _, v := range input {
+		output = append(output, v)
+	}
+	return output
+}
+
+func float(input float64) *float64 {
+	return &input
+}
+
+func length(input int) *int {
+	return &input
+}
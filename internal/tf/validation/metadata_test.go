@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+)
+
+func TestMetadataFor(t *testing.T) {
+	cases := map[string]struct {
+		ValidateFunc func(interface{}, string) ([]string, []error)
+		Expected     *Metadata
+	}{
+		"string in slice": {
+			ValidateFunc: StringInSlice([]string{"First", "Second"}, false),
+			Expected:     &Metadata{AllowedValues: []interface{}{"First", "Second"}},
+		},
+		"different string in slice": {
+			ValidateFunc: StringInSlice([]string{"Third"}, false),
+			Expected:     &Metadata{AllowedValues: []interface{}{"Third"}},
+		},
+		"int between": {
+			ValidateFunc: IntBetween(1, 10),
+			Expected:     &Metadata{Min: pointer.To(1.0), Max: pointer.To(10.0)},
+		},
+		"string match": {
+			ValidateFunc: StringMatch(regexp.MustCompile(`^[a-z]+$`), "must be lower case"),
+			Expected:     &Metadata{Pattern: `^[a-z]+$`},
+		},
+		"named function": {
+			ValidateFunc: StringIsNotEmpty,
+			Expected:     nil,
+		},
+		"all": {
+			ValidateFunc: All(StringLenBetween(1, 5), StringMatch(regexp.MustCompile(`^[a-z]+$`), "")),
+			Expected:     &Metadata{MinLength: pointer.To(1), MaxLength: pointer.To(5), Pattern: `^[a-z]+$`},
+		},
+		"unknown": {
+			ValidateFunc: IsUUID,
+			Expected:     nil,
+		},
+		"custom": {
+			// validation functions which aren't built by this package mustn't be called
+			ValidateFunc: func(i interface{}, _ string) ([]string, []error) {
+				return nil, []error{fmt.Errorf("expected an int but got %d", i.(int))}
+			},
+			Expected: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual := MetadataFor(tc.ValidateFunc)
+			if !reflect.DeepEqual(tc.Expected, actual) {
+				t.Fatalf("expected %+v but got %+v", tc.Expected, actual)
+			}
+
+			// the validation function should still validate values
+			if _, errs := tc.ValidateFunc(1234, "example"); len(errs) == 0 {
+				t.Fatalf("expected an error for an invalid value")
+			}
+		})
+	}
+}
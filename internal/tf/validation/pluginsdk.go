@@ -20,6 +20,14 @@ import (
 // passes all provided SchemaValidateFunc
 // lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
 func All(validators ...schema.SchemaValidateFunc) schema.SchemaValidateFunc { //nolint:staticcheck
+	funcs := make([]validateFunc, 0, len(validators))
+	for _, v := range validators {
+		funcs = append(funcs, v)
+	}
+	if m, ok := mergeMetadata(funcs...); ok {
+		return withMetadata(validation.All(validators...), *m)
+	}
+
 	return validation.All(validators...)
 }
 
@@ -34,19 +42,19 @@ func Any(validators ...schema.SchemaValidateFunc) schema.SchemaValidateFunc { //
 // FloatAtLeast returns a SchemaValidateFunc which tests if the provided value
 // is of type float and is at least min (inclusive)
 func FloatAtLeast(min float64) func(interface{}, string) ([]string, []error) {
-	return validation.FloatAtLeast(min)
+	return withMetadata(validation.FloatAtLeast(min), Metadata{Min: float(min)})
 }
 
 // FloatBetween returns a SchemaValidateFunc which tests if the provided value
 // is of type float64 and is between min and max (inclusive).
 func FloatBetween(min, max float64) func(interface{}, string) ([]string, []error) {
-	return validation.FloatBetween(min, max)
+	return withMetadata(validation.FloatBetween(min, max), Metadata{Min: float(min), Max: float(max)})
 }
 
 // FloatInSlice returns a SchemaValidateFunc which tests if the provided value
 // is of type float64 and matches the value of an element in the valid slice
 func FloatInSlice(valid []float64) func(interface{}, string) ([]string, []error) {
-	return func(i interface{}, k string) (warnings []string, errors []error) {
+	return withMetadata(func(i interface{}, k string) (warnings []string, errors []error) {
 		v, ok := i.(float64)
 		if !ok {
 			errors = append(errors, fmt.Errorf("expected type of %s to be float", i))
@@ -61,7 +69,7 @@ func FloatInSlice(valid []float64) func(interface{}, string) ([]string, []error)
 
 		errors = append(errors, fmt.Errorf("expected %s to be one of %v, got %f", k, valid, v))
 		return warnings, errors
-	}
+	}, Metadata{AllowedValues: floatsToValues(valid)})
 }
 
 // IntNotInSlice returns a SchemaValidateFunc which tests if the provided value
@@ -73,19 +81,19 @@ func IntNotInSlice(valid []int) func(interface{}, string) ([]string, []error) {
 // IntAtLeast returns a SchemaValidateFunc which tests if the provided value
 // is of type int and is at least min (inclusive)
 func IntAtLeast(min int) func(interface{}, string) ([]string, []error) {
-	return validation.IntAtLeast(min)
+	return withMetadata(validation.IntAtLeast(min), Metadata{Min: float(float64(min))})
 }
 
 // IntAtMost returns a SchemaValidateFunc which tests if the provided value
 // is of type int and is at most max (inclusive)
 func IntAtMost(max int) func(interface{}, string) ([]string, []error) {
-	return validation.IntAtMost(max)
+	return withMetadata(validation.IntAtMost(max), Metadata{Max: float(float64(max))})
 }
 
 // IntBetween returns a SchemaValidateFunc which tests if the provided value
 // is of type int and is between min and max (inclusive)
 func IntBetween(min, max int) func(interface{}, string) ([]string, []error) {
-	return validation.IntBetween(min, max)
+	return withMetadata(validation.IntBetween(min, max), Metadata{Min: float(float64(min)), Max: float(float64(max))})
 }
 
 // IntDivisibleBy returns a SchemaValidateFunc which tests if the provided value
@@ -97,7 +105,7 @@ func IntDivisibleBy(divisor int) func(interface{}, string) ([]string, []error) {
 // IntInSlice returns a SchemaValidateFunc which tests if the provided value
 // is of type int and matches the value of an element in the valid slice
 func IntInSlice(valid []int) func(interface{}, string) ([]string, []error) {
-	return validation.IntInSlice(valid)
+	return withMetadata(validation.IntInSlice(valid), Metadata{AllowedValues: intsToValues(valid)})
 }
 
 func IntPositive(i interface{}, k string) (warnings []string, errors []error) {
@@ -120,7 +128,7 @@ func IsCIDR(i interface{}, k string) ([]string, []error) {
 
 // IsDayOfTheWeek id a SchemaValidateFunc which tests if the provided value is of type string and a valid english day of the week
 func IsDayOfTheWeek(ignoreCase bool) func(interface{}, string) ([]string, []error) {
-	return validation.IsDayOfTheWeek(ignoreCase)
+	return withMetadata(validation.IsDayOfTheWeek(ignoreCase), Metadata{AllowedValues: stringsToValues([]string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"})})
 }
 
 // IsIPAddress is a SchemaValidateFunc which tests if the provided value is of type string and is a single IP (v4 or v6)
@@ -145,7 +153,7 @@ func IsIPv6Address(i interface{}, k string) ([]string, []error) {
 
 // IsMonth id a SchemaValidateFunc which tests if the provided value is of type string and a valid english month
 func IsMonth(ignoreCase bool) func(interface{}, string) ([]string, []error) {
-	return validation.IsMonth(ignoreCase)
+	return withMetadata(validation.IsMonth(ignoreCase), Metadata{AllowedValues: stringsToValues([]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"})})
 }
 
 // IsPortNumber is a SchemaValidateFunc which tests if the provided value is of type string and a valid TCP Port Number
@@ -238,9 +246,9 @@ func StringDoesNotContainAny(chars string) func(interface{}, string) ([]string,
 // is of type string and matches the value of an element in the valid slice
 // will test with in lower case if ignoreCase is true
 func StringInSlice(valid []string, ignoreCase bool) func(interface{}, string) ([]string, []error) {
-	return func(i interface{}, k string) ([]string, []error) {
+	return withMetadata(func(i interface{}, k string) ([]string, []error) {
 		return validation.StringInSlice(valid, ignoreCase)(i, k)
-	}
+	}, Metadata{AllowedValues: stringsToValues(valid)})
 }
 
 // StringIsBase64 is a ValidateFunc that ensures a string can be parsed as Base64
@@ -276,14 +284,14 @@ func StringIsValidRegExp(i interface{}, k string) ([]string, []error) {
 // StringLenBetween returns a SchemaValidateFunc which tests if the provided value
 // is of type string and has length between min and max (inclusive)
 func StringLenBetween(min, max int) func(interface{}, string) ([]string, []error) {
-	return validation.StringLenBetween(min, max)
+	return withMetadata(validation.StringLenBetween(min, max), Metadata{MinLength: length(min), MaxLength: length(max)})
 }
 
 // StringMatch returns a SchemaValidateFunc which tests if the provided value
 // matches a given regexp. Optionally an error message can be provided to
 // return something friendlier than "must match some globby regexp".
 func StringMatch(r *regexp.Regexp, message string) func(interface{}, string) ([]string, []error) {
-	return validation.StringMatch(r, message)
+	return withMetadata(validation.StringMatch(r, message), Metadata{Pattern: r.String()})
 }
 
 // StringNotInSlice returns a SchemaValidateFunc which tests if the provided value
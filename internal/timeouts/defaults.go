@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package timeouts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// OperationTimeouts are the timeouts for each of the operations performed on a Resource, where nil
+// means the Resource's own default should be used
+type OperationTimeouts struct {
+	Create *time.Duration
+	Read   *time.Duration
+	Update *time.Duration
+	Delete *time.Duration
+}
+
+// ProviderDefaults are the default timeouts defined in the `default_timeouts` block within the Provider
+type ProviderDefaults struct {
+	// OperationTimeouts are the timeouts applicable to all Resources, which are only used where they're
+	// longer than the default timeout defined for the Resource
+	OperationTimeouts
+
+	// ResourceTypes are the timeouts for a specific Resource Type (e.g. `azurerm_resource_group`), which
+	// take precedence over both the timeouts applicable to all Resources and the Resource's own default
+	ResourceTypes map[string]OperationTimeouts
+}
+
+// Validate ensures that the Resource Types within the `default_timeouts` block are supported by this Provider,
+// where `resources` are the Resources supported by this Provider (keyed by Resource Type)
+func (d ProviderDefaults) Validate(resources map[string]*pluginsdk.Resource) error {
+	unknownResourceTypes := make([]string, 0)
+	for resourceType := range d.ResourceTypes {
+		if _, ok := resources[resourceType]; !ok {
+			unknownResourceTypes = append(unknownResourceTypes, resourceType)
+		}
+	}
+	if len(unknownResourceTypes) > 0 {
+		sort.Strings(unknownResourceTypes)
+		return fmt.Errorf("the `default_timeouts` block contains timeouts for Resource Types which aren't supported by this Provider: %s", strings.Join(unknownResourceTypes, ", "))
+	}
+
+	return nil
+}
+
+// Timeout returns the timeout for the operation `key` (e.g. `create`) performed on the Resource Type, where
+// `resourceDefaults` are the default timeouts defined by the Resource, `timeout` is the timeout determined from
+// the ResourceData and `configured` is whether this is defined in the `timeouts` block within the Resource.
+//
+// The timeout for an operation is (in order of precedence) that defined:
+//   - in the `timeouts` block within the Resource
+//   - for the Resource Type in the `default_timeouts` block within the Provider
+//   - for all Resources in the `default_timeouts` block within the Provider, where it's longer
+//   - by the Resource
+//
+// Where the Resource doesn't support the operation (e.g. Update) the Provider's timeouts are ignored.
+func (d ProviderDefaults) Timeout(resourceType string, resourceDefaults pluginsdk.ResourceTimeout, key string, timeout time.Duration, configured bool) time.Duration {
+	resourceDefault := OperationTimeouts{
+		Create: resourceDefaults.Create,
+		Read:   resourceDefaults.Read,
+		Update: resourceDefaults.Update,
+		Delete: resourceDefaults.Delete,
+	}.forKey(key)
+	if resourceDefault == nil || configured {
+		return timeout
+	}
+
+	if v, ok := d.ResourceTypes[resourceType]; ok {
+		if override := v.forKey(key); override != nil {
+			return *override
+		}
+	}
+
+	if provider := d.forKey(key); provider != nil && *provider > timeout {
+		return *provider
+	}
+
+	return timeout
+}
+
+func (o OperationTimeouts) forKey(key string) *time.Duration {
+	switch key {
+	case pluginsdk.TimeoutCreate:
+		return o.Create
+	case pluginsdk.TimeoutRead:
+		return o.Read
+	case pluginsdk.TimeoutUpdate:
+		return o.Update
+	case pluginsdk.TimeoutDelete:
+		return o.Delete
+	}
+
+	return nil
+}
+
+type providerDefaultsKey struct{}
+
+type providerDefaultsScope struct {
+	resourceType     string
+	resourceDefaults pluginsdk.ResourceTimeout
+	defaults         ProviderDefaults
+}
+
+// WithProviderDefaults returns a copy of the context exposing the `default_timeouts` defined within the Provider
+// block (which can differ between aliases of the Provider) for an operation performed on the Resource Type, where
+// `resourceDefaults` are the default timeouts defined by the Resource. These are used by ForCreate, ForRead etc.
+func WithProviderDefaults(ctx context.Context, resourceType string, resourceDefaults pluginsdk.ResourceTimeout, defaults ProviderDefaults) context.Context {
+	return context.WithValue(ctx, providerDefaultsKey{}, providerDefaultsScope{
+		resourceType:     resourceType,
+		resourceDefaults: resourceDefaults,
+		defaults:         defaults,
+	})
+}
+
+// determineTimeout returns the timeout for the operation `key` (e.g. `create`), accounting for the
+// `default_timeouts` defined within the Provider block when exposed by the context
+func determineTimeout(ctx context.Context, d *pluginsdk.ResourceData, key string) time.Duration {
+	timeout := d.Timeout(key)
+
+	scope, ok := ctx.Value(providerDefaultsKey{}).(providerDefaultsScope)
+	if !ok {
+		return timeout
+	}
+
+	return scope.defaults.Timeout(scope.resourceType, scope.resourceDefaults, key, timeout, timeoutConfigured(d, key))
+}
+
+// timeoutConfigured returns whether the timeout for the operation `key` is defined in the `timeouts` block within
+// the Resource. This uses the configuration where available (e.g. during Create and Update), otherwise the plan or
+// the State - which contains the `timeouts` block from the configuration (e.g. during Read and Delete).
+func timeoutConfigured(d *pluginsdk.ResourceData, key string) bool {
+	for _, raw := range []cty.Value{d.GetRawConfig(), d.GetRawPlan(), d.GetRawState()} {
+		if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsObjectType() {
+			continue
+		}
+
+		if !raw.Type().HasAttribute(schema.TimeoutsConfigKey) {
+			return false
+		}
+		block := raw.GetAttr(schema.TimeoutsConfigKey)
+		if block.IsNull() || !block.IsKnown() || !block.Type().IsObjectType() || !block.Type().HasAttribute(key) {
+			return false
+		}
+
+		return !block.GetAttr(key).IsNull()
+	}
+
+	return false
+}
@@ -0,0 +1,296 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package timeouts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var testResourceDefaults = map[string]pluginsdk.ResourceTimeout{
+	"azurerm_example": {
+		Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+		Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+		Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+		Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+	},
+	"azurerm_slow_example": {
+		Create: pluginsdk.DefaultTimeout(3 * time.Hour),
+		Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+		Delete: pluginsdk.DefaultTimeout(3 * time.Hour),
+	},
+}
+
+func TestProviderDefaultsTimeout(t *testing.T) {
+	testData := []struct {
+		Name         string
+		Defaults     ProviderDefaults
+		ResourceType string
+		Key          string
+		Timeout      time.Duration
+		Configured   bool
+		Expected     time.Duration
+	}{
+		{
+			Name:         "No Defaults",
+			Defaults:     ProviderDefaults{},
+			ResourceType: "azurerm_example",
+			Key:          pluginsdk.TimeoutCreate,
+			Timeout:      30 * time.Minute,
+			Expected:     30 * time.Minute,
+		},
+		{
+			Name: "All Resources",
+			Defaults: ProviderDefaults{
+				OperationTimeouts: OperationTimeouts{
+					Create: pointer.To(time.Hour),
+				},
+			},
+			ResourceType: "azurerm_example",
+			Key:          pluginsdk.TimeoutCreate,
+			Timeout:      30 * time.Minute,
+			Expected:     time.Hour,
+		},
+		{
+			Name: "All Resources Shorter than the Resource's Default",
+			Defaults: ProviderDefaults{
+				OperationTimeouts: OperationTimeouts{
+					Create: pointer.To(time.Hour),
+				},
+			},
+			ResourceType: "azurerm_slow_example",
+			Key:          pluginsdk.TimeoutCreate,
+			Timeout:      3 * time.Hour,
+			Expected:     3 * time.Hour,
+		},
+		{
+			Name: "All Resources Operation Not Supported",
+			Defaults: ProviderDefaults{
+				OperationTimeouts: OperationTimeouts{
+					Update: pointer.To(time.Hour),
+				},
+			},
+			ResourceType: "azurerm_slow_example",
+			Key:          pluginsdk.TimeoutUpdate,
+			Timeout:      20 * time.Minute,
+			Expected:     20 * time.Minute,
+		},
+		{
+			Name: "Resource Type",
+			Defaults: ProviderDefaults{
+				OperationTimeouts: OperationTimeouts{
+					Create: pointer.To(time.Hour),
+				},
+				ResourceTypes: map[string]OperationTimeouts{
+					"azurerm_slow_example": {
+						Create: pointer.To(2 * time.Hour),
+					},
+				},
+			},
+			ResourceType: "azurerm_slow_example",
+			Key:          pluginsdk.TimeoutCreate,
+			Timeout:      3 * time.Hour,
+			Expected:     2 * time.Hour,
+		},
+		{
+			Name: "Resource Type Operation Not Supported",
+			Defaults: ProviderDefaults{
+				ResourceTypes: map[string]OperationTimeouts{
+					"azurerm_slow_example": {
+						Update: pointer.To(2 * time.Hour),
+					},
+				},
+			},
+			ResourceType: "azurerm_slow_example",
+			Key:          pluginsdk.TimeoutUpdate,
+			Timeout:      20 * time.Minute,
+			Expected:     20 * time.Minute,
+		},
+		{
+			Name: "Timeouts Block within the Resource",
+			Defaults: ProviderDefaults{
+				OperationTimeouts: OperationTimeouts{
+					Delete: pointer.To(time.Hour),
+				},
+				ResourceTypes: map[string]OperationTimeouts{
+					"azurerm_example": {
+						Delete: pointer.To(2 * time.Hour),
+					},
+				},
+			},
+			ResourceType: "azurerm_example",
+			Key:          pluginsdk.TimeoutDelete,
+			Timeout:      10 * time.Minute,
+			Configured:   true,
+			Expected:     10 * time.Minute,
+		},
+		{
+			Name: "Timeouts Block within the Resource matching the Resource's Default",
+			Defaults: ProviderDefaults{
+				OperationTimeouts: OperationTimeouts{
+					Create: pointer.To(time.Hour),
+				},
+				ResourceTypes: map[string]OperationTimeouts{
+					"azurerm_example": {
+						Create: pointer.To(2 * time.Hour),
+					},
+				},
+			},
+			ResourceType: "azurerm_example",
+			Key:          pluginsdk.TimeoutCreate,
+			Timeout:      30 * time.Minute,
+			Configured:   true,
+			Expected:     30 * time.Minute,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := v.Defaults.Timeout(v.ResourceType, testResourceDefaults[v.ResourceType], v.Key, v.Timeout, v.Configured)
+		if actual != v.Expected {
+			t.Fatalf("expected %s but got %s", v.Expected, actual)
+		}
+	}
+}
+
+func TestProviderDefaultsValidate(t *testing.T) {
+	resources := map[string]*pluginsdk.Resource{
+		"azurerm_example": {},
+	}
+
+	valid := ProviderDefaults{
+		ResourceTypes: map[string]OperationTimeouts{
+			"azurerm_example": {
+				Create: pointer.To(time.Hour),
+			},
+		},
+	}
+	if err := valid.Validate(resources); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	unknown := ProviderDefaults{
+		ResourceTypes: map[string]OperationTimeouts{
+			"azurerm_unknown": {
+				Create: pointer.To(time.Hour),
+			},
+		},
+	}
+	if err := unknown.Validate(resources); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+}
+
+func TestForCreateWithProviderDefaults(t *testing.T) {
+	timeouts := testResourceDefaults["azurerm_example"]
+	resource := &pluginsdk.Resource{
+		Timeouts: &timeouts,
+	}
+	d := resource.Data(&terraform.InstanceState{})
+
+	// the Resources are shared by each alias of the Provider, so the timeout depends on the configured Client
+	providerDefaults := []struct {
+		Defaults ProviderDefaults
+		Expected time.Duration
+	}{
+		{
+			Defaults: ProviderDefaults{
+				ResourceTypes: map[string]OperationTimeouts{
+					"azurerm_example": {
+						Create: pointer.To(10 * time.Minute),
+					},
+				},
+			},
+			Expected: 10 * time.Minute,
+		},
+		{
+			Defaults: ProviderDefaults{
+				OperationTimeouts: OperationTimeouts{
+					Create: pointer.To(time.Hour),
+				},
+			},
+			Expected: time.Hour,
+		},
+		{
+			Defaults: ProviderDefaults{},
+			Expected: 30 * time.Minute,
+		},
+	}
+	for _, v := range providerDefaults {
+		ctx := WithProviderDefaults(context.Background(), "azurerm_example", timeouts, v.Defaults)
+		if actual := determineTimeout(ctx, d, pluginsdk.TimeoutCreate); actual != v.Expected {
+			t.Fatalf("expected %s but got %s", v.Expected, actual)
+		}
+	}
+
+	// without the `default_timeouts` the Resource's default is used
+	if actual := determineTimeout(context.Background(), d, pluginsdk.TimeoutCreate); actual != 30*time.Minute {
+		t.Fatalf("expected %s but got %s", 30*time.Minute, actual)
+	}
+
+	// the Resource's default timeouts are unchanged
+	if *resource.Timeouts.Create != 30*time.Minute {
+		t.Fatalf("expected the Resource's default timeout to be unchanged but got %s", *resource.Timeouts.Create)
+	}
+}
+
+func TestForCreateWithTimeoutsBlockMatchingTheDefault(t *testing.T) {
+	timeouts := testResourceDefaults["azurerm_example"]
+	resource := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+			},
+		},
+		Timeouts: &timeouts,
+	}
+	defaults := ProviderDefaults{
+		OperationTimeouts: OperationTimeouts{
+			Create: pointer.To(time.Hour),
+			Delete: pointer.To(time.Hour),
+		},
+	}
+	ctx := WithProviderDefaults(context.Background(), "azurerm_example", timeouts, defaults)
+
+	// `timeouts { create = "30m" }` matches the Resource's default, but is still used over the `default_timeouts`
+	timeoutsType := resource.CoreConfigSchema().ImpliedType().AttributeType(schema.TimeoutsConfigKey)
+	timeoutsBlock := map[string]cty.Value{}
+	for k := range timeoutsType.AttributeTypes() {
+		timeoutsBlock[k] = cty.NullVal(cty.String)
+	}
+	timeoutsBlock[pluginsdk.TimeoutCreate] = cty.StringVal("30m")
+	value := cty.ObjectVal(map[string]cty.Value{
+		"id":                     cty.StringVal("example"),
+		"name":                   cty.StringVal("example"),
+		schema.TimeoutsConfigKey: cty.ObjectVal(timeoutsBlock),
+	})
+
+	d := resource.Data(&terraform.InstanceState{
+		RawConfig: value,
+	})
+	if actual := determineTimeout(ctx, d, pluginsdk.TimeoutCreate); actual != 30*time.Minute {
+		t.Fatalf("expected the timeout from the `timeouts` block (%s) but got %s", 30*time.Minute, actual)
+	}
+
+	// the `timeouts` block is available from the State when the configuration isn't (e.g. during Delete), where
+	// operations which aren't defined within it use the `default_timeouts`
+	d = resource.Data(&terraform.InstanceState{
+		RawState: value,
+	})
+	if actual := determineTimeout(ctx, d, pluginsdk.TimeoutCreate); actual != 30*time.Minute {
+		t.Fatalf("expected the timeout from the `timeouts` block (%s) but got %s", 30*time.Minute, actual)
+	}
+	if actual := determineTimeout(ctx, d, pluginsdk.TimeoutDelete); actual != time.Hour {
+		t.Fatalf("expected the timeout from the `default_timeouts` (%s) but got %s", time.Hour, actual)
+	}
+}
@@ -15,7 +15,7 @@ import (
 // If the 'SupportsCustomTimeouts' feature toggle is enabled - this is wrapped with a context
 // Otherwise this returns the default context
 func ForCreate(ctx context.Context, d *pluginsdk.ResourceData) (context.Context, context.CancelFunc) {
-	return buildWithTimeout(ctx, d.Timeout(pluginsdk.TimeoutCreate))
+	return buildWithTimeout(ctx, determineTimeout(ctx, d, pluginsdk.TimeoutCreate))
 }
 
 // ForCreateUpdate returns the context wrapped with the timeout for an combined Create/Update operation
@@ -35,7 +35,7 @@ func ForCreateUpdate(ctx context.Context, d *pluginsdk.ResourceData) (context.Co
 // If the 'SupportsCustomTimeouts' feature toggle is enabled - this is wrapped with a context
 // Otherwise this returns the default context
 func ForDelete(ctx context.Context, d *pluginsdk.ResourceData) (context.Context, context.CancelFunc) {
-	return buildWithTimeout(ctx, d.Timeout(pluginsdk.TimeoutDelete))
+	return buildWithTimeout(ctx, determineTimeout(ctx, d, pluginsdk.TimeoutDelete))
 }
 
 // ForRead returns the context wrapped with the timeout for an Read operation
@@ -43,7 +43,7 @@ func ForDelete(ctx context.Context, d *pluginsdk.ResourceData) (context.Context,
 // If the 'SupportsCustomTimeouts' feature toggle is enabled - this is wrapped with a context
 // Otherwise this returns the default context
 func ForRead(ctx context.Context, d *pluginsdk.ResourceData) (context.Context, context.CancelFunc) {
-	return buildWithTimeout(ctx, d.Timeout(pluginsdk.TimeoutRead))
+	return buildWithTimeout(ctx, determineTimeout(ctx, d, pluginsdk.TimeoutRead))
 }
 
 // ForUpdate returns the context wrapped with the timeout for an Update operation
@@ -51,7 +51,7 @@ func ForRead(ctx context.Context, d *pluginsdk.ResourceData) (context.Context, c
 // If the 'SupportsCustomTimeouts' feature toggle is enabled - this is wrapped with a context
 // Otherwise this returns the default context
 func ForUpdate(ctx context.Context, d *pluginsdk.ResourceData) (context.Context, context.CancelFunc) {
-	return buildWithTimeout(ctx, d.Timeout(pluginsdk.TimeoutUpdate))
+	return buildWithTimeout(ctx, determineTimeout(ctx, d, pluginsdk.TimeoutUpdate))
 }
 
 func buildWithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type Kind string
+
+const (
+	KindDataSource Kind = "dataSource"
+	KindResource   Kind = "resource"
+)
+
+type Status string
+
+const (
+	// StatusRemovedInNextMajorVersion is used for items which are deprecated today, and so will be removed in the
+	// next major version of the provider
+	StatusRemovedInNextMajorVersion Status = "removedInNextMajorVersion"
+
+	// StatusDeprecatedInNextMajorVersion is used for items which will be deprecated in the next major version
+	// of the provider (via `features.DeprecatedInFourPointOh`), and so can continue to be used for now
+	StatusDeprecatedInNextMajorVersion Status = "deprecatedInNextMajorVersion"
+)
+
+// Deprecation describes a deprecated Data Source, Resource or property within either
+type Deprecation struct {
+	Kind Kind   `json:"kind"`
+	Name string `json:"name"`
+
+	// Property is the path to the deprecated property (e.g. `block.nested_property`), this is
+	// empty when the Data Source or Resource itself is deprecated
+	Property string `json:"property,omitempty"`
+
+	Message string `json:"message"`
+
+	// Replacement is the Data Source, Resource or property which should be used instead, where known
+	Replacement string `json:"replacement,omitempty"`
+
+	Status Status `json:"status"`
+}
+
+func (d Deprecation) key() string {
+	return fmt.Sprintf("%s/%s/%s", d.Kind, d.Name, d.Property)
+}
+
+// replacementRegex matches the common ways of referring to the replacement within a deprecation message, e.g.
+// "This property has been deprecated in favour of the `new_property` property"
+var replacementRegex = regexp.MustCompile("(?i)(?:in favou?r of|replaced by|superseded by|renamed to|use)\\s+(?:the\\s+)?[`'\"]([a-zA-Z0-9_.]+)[`'\"]")
+
+// deprecationReportBuildTag is the build tag which allows the items deprecated in the next major version to be found
+const deprecationReportBuildTag = "deprecationreport"
+
+// buildCatalogue returns every deprecation within the provider, both those which are deprecated today and those
+// which will be deprecated in the next major version
+func buildCatalogue() ([]Deprecation, error) {
+	if !nextMajorVersionDeprecationsSupported {
+		return nil, fmt.Errorf("this tool must be built with the `%s` build tag to find the items which will be deprecated in the next major version", deprecationReportBuildTag)
+	}
+
+	current := deprecationsForProvider(provider.AzureProvider(), typedReplacements(), StatusRemovedInNextMajorVersion)
+
+	next, err := nextMajorVersionDeprecations()
+	if err != nil {
+		return nil, fmt.Errorf("building the deprecations for the next major version: %+v", err)
+	}
+
+	existing := make(map[string]struct{}, len(current))
+	for _, v := range current {
+		existing[v.key()] = struct{}{}
+	}
+
+	catalogue := current
+	for _, v := range next {
+		if _, ok := existing[v.key()]; !ok {
+			catalogue = append(catalogue, v)
+		}
+	}
+	sortDeprecations(catalogue)
+
+	return catalogue, nil
+}
+
+// buildNextMajorVersionCatalogue returns every deprecation within the provider including those deprecated using
+// `features.DeprecatedInFourPointOh` - this is used (via the `-next-major-version` argument) by nextMajorVersionDeprecations
+func buildNextMajorVersionCatalogue() ([]Deprecation, error) {
+	if !nextMajorVersionDeprecationsSupported {
+		return nil, fmt.Errorf("the `-next-major-version` argument requires this tool to be built with the `%s` build tag", deprecationReportBuildTag)
+	}
+
+	enableNextMajorVersionDeprecations()
+
+	catalogue := deprecationsForProvider(provider.AzureProvider(), typedReplacements(), StatusDeprecatedInNextMajorVersion)
+	sortDeprecations(catalogue)
+
+	return catalogue, nil
+}
+
+// nextMajorVersionDeprecations returns the deprecations within the provider including those deprecated using
+// `features.DeprecatedInFourPointOh`, which only have a deprecation message once enabled. As this is determined
+// when the provider is built, this runs this tool again with the `-next-major-version` argument.
+func nextMajorVersionDeprecations() ([]Deprecation, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("determining the path to this tool: %+v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(executable, "-next-major-version", "-output-format", string(OutputFormatJSON))
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("running %q: %+v\n\n%s", executable, err, stderr.String())
+	}
+
+	var output struct {
+		Deprecations []Deprecation `json:"deprecations"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, fmt.Errorf("parsing the deprecations: %+v", err)
+	}
+
+	return output.Deprecations, nil
+}
+
+// typedReplacements returns the replacement for the typed Data Sources and Resources which are deprecated in favour
+// of another, since this isn't exposed in the schema
+func typedReplacements() map[string]string {
+	output := make(map[string]string)
+	for _, service := range provider.SupportedTypedServices() {
+		for _, ds := range service.DataSources() {
+			if v, ok := ds.(sdk.DataSourceWithDeprecationReplacedBy); ok {
+				output[fmt.Sprintf("%s/%s", KindDataSource, ds.ResourceType())] = v.DeprecatedInFavourOfDataSource()
+			}
+		}
+		for _, r := range service.Resources() {
+			if v, ok := r.(sdk.ResourceWithDeprecationReplacedBy); ok {
+				output[fmt.Sprintf("%s/%s", KindResource, r.ResourceType())] = v.DeprecatedInFavourOfResource()
+			}
+		}
+	}
+	return output
+}
+
+func deprecationsForProvider(p *schema.Provider, replacements map[string]string, status Status) []Deprecation {
+	output := make([]Deprecation, 0)
+	for name, resource := range p.DataSourcesMap {
+		output = append(output, deprecationsForResource(KindDataSource, name, resource, replacements, status)...)
+	}
+	for name, resource := range p.ResourcesMap {
+		output = append(output, deprecationsForResource(KindResource, name, resource, replacements, status)...)
+	}
+	return output
+}
+
+func deprecationsForResource(kind Kind, name string, resource *pluginsdk.Resource, replacements map[string]string, status Status) []Deprecation {
+	output := make([]Deprecation, 0)
+	if resource.DeprecationMessage != "" {
+		replacement, ok := replacements[fmt.Sprintf("%s/%s", kind, name)]
+		if !ok {
+			replacement = replacementFromMessage(resource.DeprecationMessage)
+		}
+		output = append(output, Deprecation{
+			Kind:        kind,
+			Name:        name,
+			Message:     resource.DeprecationMessage,
+			Replacement: replacement,
+			Status:      status,
+		})
+	}
+
+	for _, v := range deprecationsForSchema(resource.Schema, "") {
+		v.Kind = kind
+		v.Name = name
+		v.Status = status
+		output = append(output, v)
+	}
+
+	return output
+}
+
+func deprecationsForSchema(input map[string]*pluginsdk.Schema, prefix string) []Deprecation {
+	output := make([]Deprecation, 0)
+	for name, item := range input {
+		path := name
+		if prefix != "" {
+			path = fmt.Sprintf("%s.%s", prefix, name)
+		}
+
+		if item.Deprecated != "" {
+			output = append(output, Deprecation{
+				Property:    path,
+				Message:     item.Deprecated,
+				Replacement: replacementFromMessage(item.Deprecated),
+			})
+		}
+
+		if block, ok := item.Elem.(*pluginsdk.Resource); ok {
+			output = append(output, deprecationsForSchema(block.Schema, path)...)
+		}
+	}
+	return output
+}
+
+func replacementFromMessage(message string) string {
+	if v := replacementRegex.FindStringSubmatch(message); v != nil {
+		return v[1]
+	}
+	return ""
+}
+
+func sortDeprecations(input []Deprecation) {
+	sort.Slice(input, func(i, j int) bool {
+		if input[i].Kind != input[j].Kind {
+			return input[i].Kind < input[j].Kind
+		}
+		if input[i].Name != input[j].Name {
+			return input[i].Name < input[j].Name
+		}
+		return input[i].Property < input[j].Property
+	})
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+)
+
+func main() {
+	f := flag.NewFlagSet("deprecation-report", flag.ExitOnError)
+
+	scanDirectoryPath := f.String("scan", "", "the directory containing Terraform configurations to scan for usages of deprecated items, when omitted the catalogue of deprecations is output")
+	outputFormat := f.String("output-format", string(OutputFormatText), "the format of the output, either `text` or `json`")
+	errorOnRemoval := f.Bool("error-on-removal", false, "should scanning exit with a non-zero error code when using items which will be removed in the next major version. Defaults to `false`")
+	nextMajorVersion := f.Bool("next-major-version", false, "output the catalogue of deprecations including those deprecated in the next major version, this is used when building the catalogue")
+
+	if err := f.Parse(os.Args[1:]); err != nil {
+		log.Fatalf("error parsing args: %+v", err)
+	}
+
+	if *nextMajorVersion {
+		catalogue, err := buildNextMajorVersionCatalogue()
+		if err != nil {
+			log.Fatalf("error building the catalogue: %+v", err)
+		}
+		if err := writeCatalogue(os.Stdout, OutputFormat(*outputFormat), catalogue); err != nil {
+			log.Fatalf("error writing the catalogue: %+v", err)
+		}
+		os.Exit(0)
+	}
+
+	catalogue, err := buildCatalogue()
+	if err != nil {
+		log.Fatalf("error building the catalogue: %+v", err)
+	}
+
+	if *scanDirectoryPath == "" {
+		if err := writeCatalogue(os.Stdout, OutputFormat(*outputFormat), catalogue); err != nil {
+			log.Fatalf("error writing the catalogue: %+v", err)
+		}
+		os.Exit(0)
+	}
+
+	usages, err := scanDirectory(*scanDirectoryPath, catalogue)
+	if err != nil {
+		log.Fatalf("error scanning %q: %+v", *scanDirectoryPath, err)
+	}
+	if err := writeUsages(os.Stdout, OutputFormat(*outputFormat), usages); err != nil {
+		log.Fatalf("error writing the usages: %+v", err)
+	}
+
+	if *errorOnRemoval {
+		for _, v := range usages {
+			if v.Deprecation.Status == StatusRemovedInNextMajorVersion {
+				os.Exit(1)
+			}
+		}
+	}
+}
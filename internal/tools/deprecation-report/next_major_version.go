@@ -0,0 +1,15 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build deprecationreport
+
+package main
+
+import "github.com/hashicorp/terraform-provider-azurerm/internal/features"
+
+// nextMajorVersionDeprecationsSupported specifies whether this tool was built with the `deprecationreport` build tag
+const nextMajorVersionDeprecationsSupported = true
+
+func enableNextMajorVersionDeprecations() {
+	features.EnableNextMajorVersionDeprecations()
+}
@@ -0,0 +1,11 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !deprecationreport
+
+package main
+
+// nextMajorVersionDeprecationsSupported specifies whether this tool was built with the `deprecationreport` build tag
+const nextMajorVersionDeprecationsSupported = false
+
+func enableNextMajorVersionDeprecations() {}
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type OutputFormat string
+
+const (
+	OutputFormatJSON OutputFormat = "json"
+	OutputFormatText OutputFormat = "text"
+)
+
+func (d Deprecation) String() string {
+	item := fmt.Sprintf("the %s %q", kindDescription(d.Kind), d.Name)
+	if d.Property != "" {
+		item = fmt.Sprintf("the property %q within %s", d.Property, item)
+	}
+
+	action := "will be removed in the next major version"
+	if d.Status == StatusDeprecatedInNextMajorVersion {
+		action = "will be deprecated in the next major version"
+	}
+
+	output := fmt.Sprintf("%s %s", item, action)
+	if d.Replacement != "" {
+		output = fmt.Sprintf("%s, use %q instead", output, d.Replacement)
+	}
+	return output
+}
+
+func kindDescription(input Kind) string {
+	if input == KindDataSource {
+		return "Data Source"
+	}
+	return "Resource"
+}
+
+func writeCatalogue(w io.Writer, format OutputFormat, catalogue []Deprecation) error {
+	switch format {
+	case OutputFormatJSON:
+		return writeJSON(w, struct {
+			Deprecations []Deprecation `json:"deprecations"`
+		}{
+			Deprecations: catalogue,
+		})
+
+	case OutputFormatText:
+		for _, v := range catalogue {
+			if _, err := fmt.Fprintf(w, "%s\n    %s\n", v, indent(v.Message)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported output format %q", format)
+}
+
+func writeUsages(w io.Writer, format OutputFormat, usages []Usage) error {
+	switch format {
+	case OutputFormatJSON:
+		return writeJSON(w, struct {
+			Usages []Usage `json:"usages"`
+		}{
+			Usages: usages,
+		})
+
+	case OutputFormatText:
+		for _, v := range usages {
+			if _, err := fmt.Fprintf(w, "%s:%d: %s: %s\n", v.File, v.Line, v.Address, v.Deprecation); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported output format %q", format)
+}
+
+func writeJSON(w io.Writer, input interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(input)
+}
+
+func indent(input string) string {
+	output := make([]rune, 0, len(input))
+	for _, r := range input {
+		output = append(output, r)
+		if r == '\n' {
+			output = append(output, []rune("    ")...)
+		}
+	}
+	return string(output)
+}
@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// Usage describes a usage of a deprecated Data Source, Resource or property within a Terraform configuration
+type Usage struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+
+	// Address is the address of the Data Source or Resource within the configuration, e.g. `azurerm_resource_group.example`
+	Address string `json:"address"`
+
+	Deprecation Deprecation `json:"deprecation"`
+}
+
+// these blocks are handled by Terraform rather than the provider, so aren't part of the schema
+var metaBlocks = map[string]struct{}{
+	"connection":  {},
+	"lifecycle":   {},
+	"provisioner": {},
+	"timeouts":    {},
+}
+
+// scanDirectory parses the Terraform configuration files within the directory (and any subdirectories),
+// returning the usages of the deprecated items within the catalogue
+func scanDirectory(directory string, catalogue []Deprecation) ([]Usage, error) {
+	index := make(map[string]Deprecation, len(catalogue))
+	for _, v := range catalogue {
+		index[v.key()] = v
+	}
+
+	usages := make([]Usage, 0)
+	err := filepath.WalkDir(directory, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			// modules and providers downloaded by Terraform aren't part of this configuration
+			if entry.Name() == ".terraform" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".tf" {
+			return nil
+		}
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading %q: %+v", path, err)
+		}
+		fileUsages, err := scanFile(path, contents, index)
+		if err != nil {
+			return err
+		}
+		usages = append(usages, fileUsages...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(usages, func(i, j int) bool {
+		if usages[i].File != usages[j].File {
+			return usages[i].File < usages[j].File
+		}
+		return usages[i].Line < usages[j].Line
+	})
+
+	return usages, nil
+}
+
+func scanFile(fileName string, contents []byte, index map[string]Deprecation) ([]Usage, error) {
+	file, diags := hclsyntax.ParseConfig(contents, fileName, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("parsing %q: %s", fileName, diags.Error())
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("parsing %q: unexpected body type %T", fileName, file.Body)
+	}
+
+	usages := make([]Usage, 0)
+	for _, block := range body.Blocks {
+		var kind Kind
+		var address string
+		switch {
+		case block.Type == "resource" && len(block.Labels) == 2:
+			kind = KindResource
+			address = strings.Join(block.Labels, ".")
+		case block.Type == "data" && len(block.Labels) == 2:
+			kind = KindDataSource
+			address = fmt.Sprintf("data.%s", strings.Join(block.Labels, "."))
+		default:
+			continue
+		}
+
+		s := scanner{
+			fileName: fileName,
+			kind:     kind,
+			name:     block.Labels[0],
+			address:  address,
+			index:    index,
+		}
+		s.check("", block.DefRange())
+		s.scanBody(block.Body, "")
+		usages = append(usages, s.usages...)
+	}
+
+	return usages, nil
+}
+
+// scanner finds the usages of deprecated items within a single Data Source or Resource block
+type scanner struct {
+	fileName string
+	kind     Kind
+	name     string
+	address  string
+	index    map[string]Deprecation
+
+	usages []Usage
+}
+
+func (s *scanner) scanBody(body *hclsyntax.Body, prefix string) {
+	for name, attribute := range body.Attributes {
+		s.check(joinPath(prefix, name), attribute.SrcRange)
+	}
+
+	for _, block := range body.Blocks {
+		switch {
+		case block.Type == "dynamic" && len(block.Labels) == 1:
+			// the contents of a dynamic block is the block being generated
+			path := joinPath(prefix, block.Labels[0])
+			s.check(path, block.DefRange())
+			for _, nested := range block.Body.Blocks {
+				if nested.Type == "content" {
+					s.scanBody(nested.Body, path)
+				}
+			}
+
+		case prefix == "" && isMetaBlock(block.Type):
+			continue
+
+		default:
+			path := joinPath(prefix, block.Type)
+			s.check(path, block.DefRange())
+			s.scanBody(block.Body, path)
+		}
+	}
+}
+
+func (s *scanner) check(property string, location hcl.Range) {
+	key := Deprecation{Kind: s.kind, Name: s.name, Property: property}.key()
+	if v, ok := s.index[key]; ok {
+		s.usages = append(s.usages, Usage{
+			File:        s.fileName,
+			Line:        location.Start.Line,
+			Address:     s.address,
+			Deprecation: v,
+		})
+	}
+}
+
+func isMetaBlock(input string) bool {
+	_, ok := metaBlocks[input]
+	return ok
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return fmt.Sprintf("%s.%s", prefix, name)
+}
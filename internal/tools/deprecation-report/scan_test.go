@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestScanDirectory(t *testing.T) {
+	catalogue := []Deprecation{
+		{
+			Kind:        KindResource,
+			Name:        "azurerm_old_resource",
+			Message:     "The `azurerm_old_resource` resource has been deprecated",
+			Replacement: "azurerm_new_resource",
+			Status:      StatusRemovedInNextMajorVersion,
+		},
+		{
+			Kind:     KindResource,
+			Name:     "azurerm_example",
+			Property: "old_property",
+			Status:   StatusRemovedInNextMajorVersion,
+		},
+		{
+			Kind:     KindResource,
+			Name:     "azurerm_example",
+			Property: "block.nested_property",
+			Status:   StatusDeprecatedInNextMajorVersion,
+		},
+		{
+			Kind:     KindDataSource,
+			Name:     "azurerm_example",
+			Property: "old_property",
+			Status:   StatusRemovedInNextMajorVersion,
+		},
+	}
+
+	config := `
+resource "azurerm_old_resource" "example" {
+  name = "example"
+}
+
+resource "azurerm_example" "example" {
+  name         = "example"
+  old_property = "value"
+
+  block {
+    nested_property = "value"
+  }
+
+  dynamic "block" {
+    for_each = ["a"]
+    content {
+      nested_property = block.value
+    }
+  }
+
+  lifecycle {
+    old_property = "ignored"
+  }
+}
+
+data "azurerm_example" "example" {
+  name = "example"
+}
+
+data "azurerm_old_resource" "example" {
+  name = "example"
+}
+`
+
+	directory := t.TempDir()
+	if err := os.WriteFile(filepath.Join(directory, "main.tf"), []byte(config), 0o600); err != nil {
+		t.Fatalf("writing config: %+v", err)
+	}
+	// downloaded modules shouldn't be scanned
+	if err := os.MkdirAll(filepath.Join(directory, ".terraform"), 0o700); err != nil {
+		t.Fatalf("creating directory: %+v", err)
+	}
+	if err := os.WriteFile(filepath.Join(directory, ".terraform", "module.tf"), []byte(config), 0o600); err != nil {
+		t.Fatalf("writing config: %+v", err)
+	}
+
+	usages, err := scanDirectory(directory, catalogue)
+	if err != nil {
+		t.Fatalf("scanning: %+v", err)
+	}
+
+	type usage struct {
+		Line     int
+		Address  string
+		Property string
+	}
+	expected := []usage{
+		{Line: 2, Address: "azurerm_old_resource.example"},
+		{Line: 8, Address: "azurerm_example.example", Property: "old_property"},
+		{Line: 11, Address: "azurerm_example.example", Property: "block.nested_property"},
+		{Line: 17, Address: "azurerm_example.example", Property: "block.nested_property"},
+	}
+	actual := make([]usage, 0, len(usages))
+	for _, v := range usages {
+		actual = append(actual, usage{Line: v.Line, Address: v.Address, Property: v.Deprecation.Property})
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestReplacementFromMessage(t *testing.T) {
+	cases := map[string]string{
+		"`old_property` has been deprecated in favour of the `new_property` property and will be removed in v4.0": "new_property",
+		"This property has been superseded by `new_property`":                                                     "new_property",
+		"`old_property` will be removed in favor of 'new_property'":                                               "new_property",
+		"This property is no longer supported by the API":                                                         "",
+	}
+	for message, expected := range cases {
+		if actual := replacementFromMessage(message); actual != expected {
+			t.Errorf("expected %q for %q but got %q", expected, message, actual)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"fmt"
+	"strings"
+
+	schema2 "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/util"
+)
+
+// Data Sources document the properties which can be specified in the `Arguments Reference` section, and the
+// Computed-only properties in the `Attributes Reference` section
+
+type dataSourceSectionDiff struct {
+	checkBase
+	want model.PosType
+}
+
+func newDataSourceSectionDiff(path string, f *model.Field, want model.PosType) *dataSourceSectionDiff {
+	return &dataSourceSectionDiff{checkBase: newCheckBase(f.Line, path, f), want: want}
+}
+
+func (d dataSourceSectionDiff) String() string {
+	section := "Arguments Reference"
+	if d.want == model.PosAttr {
+		section = "Attributes Reference"
+	}
+	return fmt.Sprintf("%s should be documented in the %s section", d.checkBase.Str(), util.ItalicCode(section))
+}
+
+func (d dataSourceSectionDiff) Fix(line string) (result string, err error) {
+	// moving the property between sections cannot be fixed by line
+	return line, nil
+}
+
+var _ Checker = (*dataSourceSectionDiff)(nil)
+
+type dataSourceTimeoutDiff struct {
+	checkBase
+	Type TimeoutType
+}
+
+func newDataSourceTimeoutDiff(line int, typ TimeoutType) *dataSourceTimeoutDiff {
+	return &dataSourceTimeoutDiff{checkBase: newCheckBase(line, "timeouts."+typ.String(), nil), Type: typ}
+}
+
+func (d dataSourceTimeoutDiff) ShouldSkip() bool {
+	return false
+}
+
+func (d dataSourceTimeoutDiff) String() string {
+	return fmt.Sprintf("%s Data Sources only support the %s timeout, so this should be removed", d.checkBase.Str(), util.ItalicCode("read"))
+}
+
+func (d dataSourceTimeoutDiff) Fix(line string) (result string, err error) {
+	// cannot remove a line by line
+	return line, nil
+}
+
+var _ Checker = (*dataSourceTimeoutDiff)(nil)
+
+// isDataSourceAttribute returns whether the property is Computed-only, and so should be documented as an attribute
+func isDataSourceAttribute(s *schema2.Schema) bool {
+	return s.Computed && !s.Optional && !s.Required
+}
+
+// dataSourceField returns the field documented for the property within the section pos, the same property
+// can be documented in both the `Arguments Reference` and `Attributes Reference` sections
+func dataSourceField(doc *model.ResourceDoc, key string, pos model.PosType) *model.Field {
+	for _, f := range []*model.Field{doc.Args[key], doc.Attr[key]} {
+		for ; f != nil; f = f.SameNameAttr {
+			if f.Pos == pos {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+func crossCheckDataSourceProperty(r *schema.Resource, doc *model.ResourceDoc) (res []Checker) {
+	for key, val := range r.Schema.Schema {
+		if key == "id" {
+			continue
+		}
+
+		want, other := model.PosArgs, model.PosAttr
+		if isDataSourceAttribute(val) {
+			want, other = model.PosAttr, model.PosArgs
+		}
+
+		field := dataSourceField(doc, key, want)
+		if field == nil {
+			if f := dataSourceField(doc, key, other); f != nil {
+				res = append(res, newDataSourceSectionDiff(key, f, want))
+				continue
+			}
+		}
+
+		if want == model.PosAttr {
+			res = append(res, diffDataSourceAttribute(r.ResourceType, key, val, field)...)
+		} else {
+			res = append(res, diffDocMiss(r.ResourceType, key, val, field)...)
+		}
+	}
+
+	// exist in document but not in tf schema, or documented inconsistently with the schema
+	for key, f := range doc.AllProp() {
+		res = append(res, diffCodeMiss(r.ResourceType, key, f, r.Schema.Schema[key])...)
+	}
+	res = mergeMisspelling(res)
+	return
+}
+
+// diffDataSourceAttribute unlike `diffDocMiss` checks the nested properties of Computed blocks, which are
+// all Computed too
+func diffDataSourceAttribute(rt, path string, s *schema2.Schema, f *model.Field) (res []Checker) {
+	if shouldSkipDocProp(rt, path) || isSkipProp(rt, path) {
+		return
+	}
+
+	if f == nil {
+		if s.Deprecated == "" {
+			parts := strings.Split(path, ".")
+			f2 := &model.Field{
+				Name:    parts[len(parts)-1],
+				Path:    path,
+				Content: s.GoString(),
+			}
+			res = append(res, newMissItem(path, f2, MissInDocAttr))
+		}
+		return res
+	}
+
+	ele, ok := s.Elem.(*schema2.Resource)
+	if !ok {
+		return nil
+	}
+	if f.Subs == nil {
+		res = append(res, newMissBlockDeclare(path, f))
+		return
+	}
+	for key, val := range ele.Schema {
+		res = append(res, diffDataSourceAttribute(rt, path+"."+key, val, f.Subs[key])...)
+	}
+	return res
+}
+
+// diffDataSourceTimeout checks the `read` timeout, and that there are no other timeouts documented
+func diffDataSourceTimeout(r *schema.Resource, doc *model.ResourceDoc) (res []Checker) {
+	res = diffTimeout(r, doc)
+	if doc.Timeouts == nil {
+		return res
+	}
+
+	if line := doc.Timeouts.Create.Line; line > 0 {
+		res = append(res, newDataSourceTimeoutDiff(line, TimeoutCreate))
+	}
+	if line := doc.Timeouts.Update.Line; line > 0 {
+		res = append(res, newDataSourceTimeoutDiff(line, TimeoutUpdate))
+	}
+	if line := doc.Timeouts.Delete.Line; line > 0 {
+		res = append(res, newDataSourceTimeoutDiff(line, TimeoutDelete))
+	}
+	return res
+}
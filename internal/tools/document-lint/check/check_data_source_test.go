@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/md"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/schema"
+)
+
+const dataSourceDoc = `---
+subcategory: "Base"
+layout: "azurerm"
+page_title: "Azure Resource Manager: Data Source: azurerm_example"
+---
+
+# Data Source: azurerm_example
+
+Use this data source to access information about an existing Example.
+
+## Arguments Reference
+
+* ` + "`name`" + ` - (Required) The name of the Example.
+
+* ` + "`location`" + ` - The Azure Region where the Example exists.
+
+## Attributes Reference
+
+* ` + "`id`" + ` - The ID of the Example.
+
+* ` + "`identity`" + ` - An ` + "`identity`" + ` block as defined below.
+
+---
+
+An ` + "`identity`" + ` block exports the following:
+
+* ` + "`type`" + ` - The type of Managed Service Identity.
+
+## Timeouts
+
+* ` + "`read`" + ` - (Defaults to 5 minutes) Used when retrieving the Example.
+
+* ` + "`delete`" + ` - (Defaults to 30 minutes) Used when deleting the Example.
+`
+
+func TestDiffDataSource(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "example.html.markdown")
+	if err := os.WriteFile(file, []byte(dataSourceDoc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &schema.Resource{
+		ResourceType: "azurerm_example",
+		IsDataSource: true,
+		Schema: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+				},
+				"location": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+				"tags": {
+					Type:     pluginsdk.TypeMap,
+					Computed: true,
+					Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+				},
+				"identity": {
+					Type:     pluginsdk.TypeList,
+					Computed: true,
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"type": {
+								Type:     pluginsdk.TypeString,
+								Computed: true,
+							},
+							"principal_id": {
+								Type:     pluginsdk.TypeString,
+								Computed: true,
+							},
+						},
+					},
+				},
+			},
+			Timeouts: &pluginsdk.ResourceTimeout{
+				Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+			},
+		},
+	}
+
+	diff := NewResourceDiff(r)
+	diff.MDFile = file
+	diff.DiffAll()
+
+	expected := map[string]string{
+		"location":              "section",
+		"tags":                  "attribute",
+		"identity.principal_id": "attribute",
+		"timeouts.delete":       "timeout",
+	}
+	actual := make(map[string]string)
+	for _, item := range diff.Diffs() {
+		switch v := item.(type) {
+		case *dataSourceSectionDiff:
+			actual[v.Key()] = "section"
+		case *dataSourceTimeoutDiff:
+			actual[v.Key()] = "timeout"
+		case *propertyMissDiff:
+			if v.MissType == MissInDocAttr {
+				actual[v.Key()] = "attribute"
+				continue
+			}
+			t.Errorf("unexpected issue: %s", item.String())
+		default:
+			t.Errorf("unexpected issue: %s", item.String())
+		}
+	}
+
+	for key, kind := range expected {
+		if actual[key] != kind {
+			t.Errorf("expected a %s issue for %q but got %+v", kind, key, actual)
+		}
+	}
+	if len(actual) != len(expected) {
+		t.Errorf("expected %d issues but got %d: %+v", len(expected), len(actual), actual)
+	}
+
+	if got := md.MustNewMarkFromFile(file).ResourceType; got != "azurerm_example" {
+		t.Errorf("expected the resource type to be parsed from the title but got %q", got)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/util"
+)
+
+// documentMissing is reported for a Resource or Data Source which has no documentation page
+type documentMissing struct {
+	checkBase
+	resourceType string
+	expectedPath string
+}
+
+func newDocumentMissing(r *schema.Resource) *documentMissing {
+	dir := "website/docs/r"
+	if r.IsDataSource {
+		dir = "website/docs/d"
+	}
+	return &documentMissing{
+		checkBase:    newCheckBase(0, "", nil),
+		resourceType: r.ResourceType,
+		expectedPath: fmt.Sprintf("%s/%s.html.markdown", dir, strings.TrimPrefix(r.ResourceType, "azurerm_")),
+	}
+}
+
+// Fix implements Checker.
+func (*documentMissing) Fix(line string) (result string, err error) {
+	return line, nil
+}
+
+// ShouldSkip implements Checker.
+func (*documentMissing) ShouldSkip() bool {
+	return false
+}
+
+// String implements Checker.
+func (d *documentMissing) String() string {
+	return fmt.Sprintf("0 missing documentation for %s, expected a document at %s", util.Bold(d.resourceType), d.expectedPath)
+}
+
+var _ Checker = (*documentMissing)(nil)
@@ -0,0 +1,438 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/md"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/util"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// examples reference other Data Sources and Resources, so the schema for all of them is needed
+var providerSchema struct {
+	once        sync.Once
+	resources   map[string]*schema.Resource
+	dataSources map[string]*schema.Resource
+}
+
+func exampleSchemaFor(blockType, name string) (*schema.Resource, bool) {
+	providerSchema.once.Do(func() {
+		p := provider.AzureProvider()
+		providerSchema.resources = p.ResourcesMap
+		providerSchema.dataSources = p.DataSourcesMap
+	})
+
+	if blockType == "data" {
+		r, ok := providerSchema.dataSources[name]
+		return r, ok
+	}
+	r, ok := providerSchema.resources[name]
+	return r, ok
+}
+
+// these arguments/blocks are handled by Terraform rather than the provider, so aren't part of the schema
+var (
+	exampleMetaArguments = map[string]struct{}{
+		"count":      {},
+		"depends_on": {},
+		"for_each":   {},
+		"provider":   {},
+	}
+	exampleMetaBlocks = map[string]struct{}{
+		"connection":  {},
+		"lifecycle":   {},
+		"provisioner": {},
+		"timeouts":    {},
+	}
+)
+
+type exampleDiff struct {
+	checkBase
+	message string
+}
+
+func newExampleDiff(line int, key, message string) exampleDiff {
+	return exampleDiff{
+		checkBase: newCheckBase(line, key, nil),
+		message:   message,
+	}
+}
+
+func (e exampleDiff) ShouldSkip() bool {
+	return false
+}
+
+func (e exampleDiff) String() string {
+	return fmt.Sprintf("%s %s", e.Str(), e.message)
+}
+
+func (e exampleDiff) Fix(line string) (result string, err error) {
+	// the example has to be fixed manually
+	return line, nil
+}
+
+var _ Checker = exampleDiff{}
+
+// exampleFormatDiff is an example which isn't formatted as `terraform fmt` would
+type exampleFormatDiff struct {
+	checkBase
+	example   md.Example
+	formatted string
+}
+
+func (e exampleFormatDiff) ShouldSkip() bool {
+	return false
+}
+
+func (e exampleFormatDiff) String() string {
+	return fmt.Sprintf("%d example is not formatted, this can be fixed by running `terraform fmt` on the example", e.Line()+1)
+}
+
+func (e exampleFormatDiff) Fix(line string) (result string, err error) {
+	// cannot fix the format by line
+	return line, nil
+}
+
+// FixLines replaces the lines of the example with the formatted example, formatting only changes whitespace within
+// lines so the other line numbers within the document are unaffected
+func (e exampleFormatDiff) FixLines(lines []string) []string {
+	formatted := strings.Split(e.formatted, "\n")
+	if len(formatted) != e.example.ToLine-e.example.FromLine || len(lines) < e.example.ToLine {
+		return lines
+	}
+	copy(lines[e.example.FromLine:e.example.ToLine], formatted)
+	return lines
+}
+
+var _ Checker = exampleFormatDiff{}
+
+// checkExamples validates that the HCL examples within the document are valid, and only use Data Sources,
+// Resources and properties which exist in the provider
+func checkExamples(mark *md.Mark) (res []Checker) {
+	for _, example := range mark.Examples {
+		if example.IsHCL() {
+			res = append(res, checkExample(example)...)
+		}
+	}
+	return res
+}
+
+func checkExample(example md.Example) (res []Checker) {
+	// maps a line within the example onto the line within the document
+	lineOf := func(r hcl.Range) int {
+		return example.FromLine + r.Start.Line - 1
+	}
+
+	file, diags := hclsyntax.ParseConfig([]byte(example.Content), "example.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		for _, diag := range diags {
+			line := example.FromLine
+			if diag.Subject != nil {
+				line = lineOf(*diag.Subject)
+			}
+			res = append(res, newExampleDiff(line, "example", fmt.Sprintf("example is not valid HCL: %s", diag.Error())))
+		}
+		return res
+	}
+
+	if formatted := hclwrite.Format([]byte(example.Content)); !bytes.Equal(formatted, []byte(example.Content)) {
+		res = append(res, exampleFormatDiff{
+			checkBase: newCheckBase(example.FromLine, "example", nil),
+			example:   example,
+			formatted: string(formatted),
+		})
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return res
+	}
+
+	v := exampleValidator{
+		declared: declaredInExample(body),
+		addDiff: func(r hcl.Range, key, message string) {
+			res = append(res, newExampleDiff(lineOf(r), key, message))
+		},
+	}
+
+	for _, block := range body.Blocks {
+		if (block.Type == "resource" || block.Type == "data") && len(block.Labels) == 2 {
+			v.checkBlock(block)
+		}
+		v.checkReferences(block.Body)
+	}
+
+	return res
+}
+
+// declaredInExample returns the addresses of everything declared within the example which can be referenced
+func declaredInExample(body *hclsyntax.Body) map[string]struct{} {
+	declared := make(map[string]struct{})
+	for _, block := range body.Blocks {
+		switch {
+		case block.Type == "resource" && len(block.Labels) == 2:
+			declared[strings.Join(block.Labels, ".")] = struct{}{}
+		case block.Type == "data" && len(block.Labels) == 2:
+			declared["data."+strings.Join(block.Labels, ".")] = struct{}{}
+		case block.Type == "variable" && len(block.Labels) == 1:
+			declared["var."+block.Labels[0]] = struct{}{}
+		case block.Type == "module" && len(block.Labels) == 1:
+			declared["module."+block.Labels[0]] = struct{}{}
+		case block.Type == "locals":
+			for name := range block.Body.Attributes {
+				declared["local."+name] = struct{}{}
+			}
+		}
+	}
+	return declared
+}
+
+type exampleValidator struct {
+	declared map[string]struct{}
+	addDiff  func(r hcl.Range, key, message string)
+}
+
+func (v exampleValidator) checkBlock(block *hclsyntax.Block) {
+	resourceType, name := block.Labels[0], block.Labels[1]
+	if !strings.HasPrefix(resourceType, "azurerm_") {
+		// only the schema for this provider is available
+		return
+	}
+
+	address := resourceType + "." + name
+	kind := "Resource"
+	if block.Type == "data" {
+		address = "data." + address
+		kind = "Data Source"
+	}
+
+	resource, ok := exampleSchemaFor(block.Type, resourceType)
+	if !ok {
+		v.addDiff(block.DefRange(), address, fmt.Sprintf("the %s %s does not exist", kind, util.Bold(resourceType)))
+		return
+	}
+
+	v.checkBody(block.Body, resource.Schema, address, true)
+}
+
+func (v exampleValidator) checkBody(body *hclsyntax.Body, schemas map[string]*schema.Schema, path string, topLevel bool) {
+	for name, attribute := range body.Attributes {
+		if _, ok := exampleMetaArguments[name]; ok && topLevel {
+			continue
+		}
+
+		key := path + "." + name
+		s, ok := schemas[name]
+		if !ok {
+			v.addDiff(attribute.SrcRange, key, fmt.Sprintf("%s is not a property", util.ItalicCode(name)))
+			continue
+		}
+		if isExampleBlock(s) {
+			v.addDiff(attribute.SrcRange, key, fmt.Sprintf("%s should be specified as a block", util.ItalicCode(name)))
+			continue
+		}
+		if !s.Optional && !s.Required {
+			v.addDiff(attribute.SrcRange, key, fmt.Sprintf("%s is Computed only so cannot be specified", util.ItalicCode(name)))
+			continue
+		}
+		if message := checkExampleValueType(attribute.Expr, s); message != "" {
+			v.addDiff(attribute.SrcRange, key, fmt.Sprintf("%s %s", util.ItalicCode(name), message))
+		}
+	}
+
+	for _, block := range body.Blocks {
+		name := block.Type
+		content := block.Body
+		if block.Type == "dynamic" && len(block.Labels) == 1 {
+			// the contents of a dynamic block is the block being generated
+			name = block.Labels[0]
+			content = nil
+			for _, nested := range block.Body.Blocks {
+				if nested.Type == "content" {
+					content = nested.Body
+				}
+			}
+		} else if _, ok := exampleMetaBlocks[name]; ok && topLevel {
+			continue
+		}
+
+		key := path + "." + name
+		s, ok := schemas[name]
+		if !ok {
+			v.addDiff(block.DefRange(), key, fmt.Sprintf("%s is not a property", util.ItalicCode(name)))
+			continue
+		}
+		if !isExampleBlock(s) && !isExampleAttributeAsBlock(s) {
+			v.addDiff(block.DefRange(), key, fmt.Sprintf("%s should be specified as an attribute rather than a block", util.ItalicCode(name)))
+			continue
+		}
+
+		if content != nil {
+			v.checkBody(content, s.Elem.(*schema.Resource).Schema, key, false)
+		}
+	}
+}
+
+// checkReferences checks that references to other Data Sources, Resources, variables, locals and modules
+// are declared within the example
+func (v exampleValidator) checkReferences(body *hclsyntax.Body) {
+	_ = hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		expr, ok := node.(*hclsyntax.ScopeTraversalExpr)
+		if !ok {
+			return nil
+		}
+
+		address := referencedAddress(expr.Traversal)
+		if address == "" {
+			return nil
+		}
+		if _, ok := v.declared[address]; !ok {
+			v.addDiff(expr.SrcRange, address, fmt.Sprintf("references %s which is not declared in the example", util.Bold(address)))
+		}
+		return nil
+	})
+}
+
+// referencedAddress returns the address of the item being referenced by the traversal, or an empty string
+// when this isn't something declared within the configuration (for example `each.value` or `count.index`)
+func referencedAddress(traversal hcl.Traversal) string {
+	parts := make([]string, 0, len(traversal))
+	for _, t := range traversal {
+		switch step := t.(type) {
+		case hcl.TraverseRoot:
+			parts = append(parts, step.Name)
+		case hcl.TraverseAttr:
+			parts = append(parts, step.Name)
+		default:
+			// an index such as `[0]` ends the address
+			return addressFromParts(parts)
+		}
+	}
+	return addressFromParts(parts)
+}
+
+func addressFromParts(parts []string) string {
+	if len(parts) < 2 {
+		return ""
+	}
+
+	switch root := parts[0]; {
+	case root == "var", root == "local", root == "module":
+		return strings.Join(parts[:2], ".")
+	case root == "data" && len(parts) >= 3:
+		return strings.Join(parts[:3], ".")
+	case strings.HasPrefix(root, "azurerm_"):
+		return strings.Join(parts[:2], ".")
+	}
+
+	return ""
+}
+
+func isExampleBlock(s *schema.Schema) bool {
+	if s.Type != schema.TypeList && s.Type != schema.TypeSet {
+		return false
+	}
+	if s.ConfigMode == schema.SchemaConfigModeAttr {
+		return false
+	}
+	_, ok := s.Elem.(*schema.Resource)
+	return ok
+}
+
+// isExampleAttributeAsBlock returns whether the property uses `SchemaConfigModeAttr`, which can be specified using
+// either the block or the attribute syntax
+func isExampleAttributeAsBlock(s *schema.Schema) bool {
+	if s.ConfigMode != schema.SchemaConfigModeAttr {
+		return false
+	}
+	_, ok := s.Elem.(*schema.Resource)
+	return ok
+}
+
+// checkExampleValueType returns a message when the value of an attribute is a literal which can't be converted
+// to the type of the property, expressions are evaluated by Terraform so can't be checked
+func checkExampleValueType(expr hclsyntax.Expression, s *schema.Schema) string {
+	var value cty.Value
+	switch e := expr.(type) {
+	case *hclsyntax.LiteralValueExpr:
+		value = e.Val
+	case *hclsyntax.TemplateExpr:
+		if !e.IsStringLiteral() {
+			return ""
+		}
+		v, diags := e.Value(nil)
+		if diags.HasErrors() {
+			return ""
+		}
+		value = v
+	case *hclsyntax.TupleConsExpr:
+		if s.Type != schema.TypeList && s.Type != schema.TypeSet {
+			return fmt.Sprintf("should be a %s but is a list", exampleTypeName(s.Type))
+		}
+		return ""
+	case *hclsyntax.ObjectConsExpr:
+		if s.Type != schema.TypeMap {
+			return fmt.Sprintf("should be a %s but is a map", exampleTypeName(s.Type))
+		}
+		return ""
+	default:
+		return ""
+	}
+
+	if value.IsNull() || !value.IsKnown() {
+		return ""
+	}
+
+	switch s.Type {
+	case schema.TypeBool:
+		if value.Type() == cty.String {
+			if _, err := strconv.ParseBool(value.AsString()); err == nil {
+				return ""
+			}
+		}
+		if value.Type() != cty.Bool {
+			return "should be a bool"
+		}
+	case schema.TypeInt, schema.TypeFloat:
+		if value.Type() == cty.String {
+			if _, err := strconv.ParseFloat(value.AsString(), 64); err == nil {
+				return ""
+			}
+		}
+		if value.Type() != cty.Number {
+			return "should be a number"
+		}
+	case schema.TypeList, schema.TypeSet, schema.TypeMap:
+		return fmt.Sprintf("should be a %s", exampleTypeName(s.Type))
+	}
+
+	return ""
+}
+
+func exampleTypeName(input schema.ValueType) string {
+	switch input {
+	case schema.TypeBool:
+		return "bool"
+	case schema.TypeInt, schema.TypeFloat:
+		return "number"
+	case schema.TypeList:
+		return "list"
+	case schema.TypeSet:
+		return "set"
+	case schema.TypeMap:
+		return "map"
+	}
+	return "string"
+}
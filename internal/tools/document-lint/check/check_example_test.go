@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/md"
+)
+
+func TestCheckExample(t *testing.T) {
+	example := md.Example{
+		FromLine: 10,
+		Language: "hcl",
+		Content: `resource "azurerm_resource_group" "example" {
+  name     = "example-resources"
+  location = "West Europe"
+}
+
+resource "azurerm_virtual_network" "example" {
+  name                = "example-network"
+  resource_group_name = azurerm_resource_group.example.name
+  location            = azurerm_resource_group.missing.location
+  address_space       = "10.0.0.0/16"
+  not_a_property      = "value"
+  guid                = "computed"
+
+  subnet {
+    name           = "subnet1"
+    address_prefix = "10.0.1.0/24"
+  }
+
+  tags = var.tags
+
+  lifecycle {
+    ignore_changes = [tags]
+  }
+}
+
+data "azurerm_does_not_exist" "example" {}`,
+	}
+	example.ToLine = example.FromLine + len(strings.Split(example.Content, "\n"))
+
+	expected := map[string]int{
+		"azurerm_resource_group.missing":                 18,
+		"azurerm_virtual_network.example.address_space":  19,
+		"azurerm_virtual_network.example.not_a_property": 20,
+		"azurerm_virtual_network.example.guid":           21,
+		"var.tags":                                       28,
+		"data.azurerm_does_not_exist.example":            35,
+	}
+
+	actual := make(map[string]int)
+	for _, diff := range checkExample(example) {
+		if _, ok := diff.(exampleFormatDiff); ok {
+			t.Fatalf("expected the example to be formatted")
+		}
+		actual[diff.Key()] = diff.Line()
+	}
+
+	for key, line := range expected {
+		if actual[key] != line {
+			t.Errorf("expected an issue for %q on line %d but got %+v", key, line, actual)
+		}
+	}
+	if len(actual) != len(expected) {
+		t.Errorf("expected %d issues but got %d: %+v", len(expected), len(actual), actual)
+	}
+}
+
+func TestCheckExampleFormat(t *testing.T) {
+	example := md.Example{
+		FromLine: 5,
+		ToLine:   9,
+		Language: "hcl",
+		Content: `resource "azurerm_resource_group" "example" {
+  name = "example-resources"
+  location = "West Europe"
+}`,
+	}
+
+	diffs := checkExample(example)
+	if len(diffs) != 1 {
+		t.Fatalf("expected a single issue but got %+v", diffs)
+	}
+	format, ok := diffs[0].(exampleFormatDiff)
+	if !ok {
+		t.Fatalf("expected a format issue but got %+v", diffs[0])
+	}
+
+	lines := make([]string, 10)
+	copy(lines[5:9], strings.Split(example.Content, "\n"))
+	fixed := format.FixLines(lines)
+	if fixed[6] != `  name     = "example-resources"` {
+		t.Fatalf("expected the example to be formatted but got %q", fixed[6])
+	}
+}
+
+func TestCheckExampleInvalid(t *testing.T) {
+	example := md.Example{
+		FromLine: 5,
+		ToLine:   7,
+		Language: "hcl",
+		Content: `resource "azurerm_resource_group" "example" {
+  name = `,
+	}
+
+	diffs := checkExample(example)
+	if len(diffs) == 0 {
+		t.Fatalf("expected an issue for invalid HCL")
+	}
+}
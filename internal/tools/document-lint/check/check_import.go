@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/util"
+)
+
+type importIDDiff struct {
+	checkBase
+	id  string
+	err error
+}
+
+func newImportIDDiff(imp model.Import, err error) *importIDDiff {
+	return &importIDDiff{
+		checkBase: newCheckBase(imp.Line, "import", nil),
+		id:        imp.ResourceID,
+		err:       err,
+	}
+}
+
+func (i importIDDiff) ShouldSkip() bool {
+	return false
+}
+
+func (i importIDDiff) String() string {
+	return fmt.Sprintf("%s the ID %s doesn't match the format expected by the resource: %v", i.checkBase.Str(), util.ItalicCode(i.id), i.err)
+}
+
+func (i importIDDiff) Fix(line string) (result string, err error) {
+	// the correct ID cannot be generated from the error
+	return line, nil
+}
+
+var _ Checker = (*importIDDiff)(nil)
+
+// checkImportID validates the ID within the `terraform import` command of the `Import` section using the
+// ID validation of the resource
+func checkImportID(r *schema.Resource, doc *model.ResourceDoc) (res []Checker) {
+	imp := doc.Import
+	if imp.Line == 0 || imp.ResourceID == "" {
+		return nil
+	}
+
+	if err := validateImportID(r, imp.ResourceID); err != nil {
+		res = append(res, newImportIDDiff(imp, err))
+	}
+	return res
+}
+
+func validateImportID(r *schema.Resource, id string) error {
+	if r.SDKResource != nil {
+		_, errs := r.SDKResource.IDValidationFunc()(id, "id")
+		return errors.Join(errs...)
+	}
+
+	// untyped resources validate the ID using the function passed to `pluginsdk.ImporterValidatingResourceId` (or
+	// similar) - the ID isn't validated for resources using a custom Importer, which may require a client
+	if r.ImportIDValidationFunc != nil {
+		return r.ImportIDValidationFunc(id)
+	}
+
+	return nil
+}
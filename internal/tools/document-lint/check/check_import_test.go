@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	schema2 "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/schema"
+)
+
+func TestCheckImportID(t *testing.T) {
+	r := &schema.Resource{
+		ResourceType: "azurerm_example",
+		Schema: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+				},
+			},
+		},
+		// found by the schema package from `pluginsdk.ImporterValidatingResourceId`
+		ImportIDValidationFunc: func(id string) error {
+			_, err := commonids.ParseResourceGroupID(id)
+			return err
+		},
+	}
+
+	cases := map[string]int{
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1":                                            0,
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.KeyVault/vaults/vault1": 1,
+		"group1": 1,
+	}
+	for id, expected := range cases {
+		doc := model.NewResourceDoc()
+		doc.Import = model.Import{
+			Line:         10,
+			ResourceType: r.ResourceType,
+			ResourceID:   id,
+		}
+
+		if diffs := checkImportID(r, doc); len(diffs) != expected {
+			t.Errorf("expected %d issues for %q but got %+v", expected, id, diffs)
+		}
+	}
+}
+
+func TestCheckImportIDCustomImporter(t *testing.T) {
+	r := &schema.Resource{
+		ResourceType: "azurerm_example",
+		Schema: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+				},
+			},
+			Importer: &schema2.ResourceImporter{
+				StateContext: func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
+					// importing the resource requires a client, which isn't available when validating the ID
+					_ = meta.(*clients.Client).Account.SubscriptionId
+					return []*pluginsdk.ResourceData{d}, nil
+				},
+			},
+		},
+	}
+
+	doc := model.NewResourceDoc()
+	doc.Import = model.Import{
+		Line:         10,
+		ResourceType: r.ResourceType,
+		ResourceID:   "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1",
+	}
+	// the ID can't be validated without running the custom Importer, so this isn't reported as an issue
+	if diffs := checkImportID(r, doc); len(diffs) != 0 {
+		t.Errorf("expected no issues for a custom importer but got %+v", diffs)
+	}
+}
@@ -17,8 +17,9 @@ import (
 // logic to load schema and markdown to print the diff
 
 type ResourceDiff struct {
-	tf *schema.Resource
-	md *model.ResourceDoc
+	tf   *schema.Resource
+	md   *model.ResourceDoc
+	mark *md.Mark
 
 	SchemaFile string
 	MDFile     string
@@ -41,6 +42,9 @@ func (d *ResourceDiff) ToString() string {
 		),
 	)
 	file := d.MDFile + ":"
+	if d.MDFile == "" {
+		file = d.tf.FilePathRel() + ":"
+	}
 	if idx := strings.Index(file, "website"); idx > 0 {
 		file = "./" + file[idx:]
 	}
@@ -70,13 +74,21 @@ func NewResourceDiff(tf *schema.Resource) *ResourceDiff {
 	// try to detect Markdown path from resource
 	// can set it if not a regular MD path
 	r.MDFile = md.MDPathFor(tf.ResourceType)
+	if tf.IsDataSource {
+		r.MDFile = md.MDPathForDataSource(tf.ResourceType)
+	}
 	return r
 }
 
 func (r *ResourceDiff) DiffAll() {
 	if r.md == nil {
-		mark := md.MustNewMarkFromFile(r.MDFile)
-		r.md = mark.BuildResourceDoc()
+		// the Markdown path is empty when no document was found for the resource
+		if r.MDFile == "" {
+			r.Diff = append(r.Diff, newDocumentMissing(r.tf))
+			return
+		}
+		r.mark = md.MustNewMarkFromFile(r.MDFile)
+		r.md = r.mark.BuildResourceDoc()
 	}
 
 	if name := r.md.HasCircularRef(); name != "" {
@@ -86,9 +98,23 @@ func (r *ResourceDiff) DiffAll() {
 
 	r.Diff = checkPossibleValues(r.tf, r.md)
 
-	missDiff := crossCheckProperty(r.tf, r.md)
-	r.Diff = append(r.Diff, missDiff...)
+	if r.tf.IsDataSource {
+		missDiff := crossCheckDataSourceProperty(r.tf, r.md)
+		r.Diff = append(r.Diff, missDiff...)
+
+		timeouts := diffDataSourceTimeout(r.tf, r.md)
+		r.Diff = append(r.Diff, timeouts...)
+	} else {
+		missDiff := crossCheckProperty(r.tf, r.md)
+		r.Diff = append(r.Diff, missDiff...)
 
-	timeouts := diffTimeout(r.tf, r.md)
-	r.Diff = append(r.Diff, timeouts...)
+		timeouts := diffTimeout(r.tf, r.md)
+		r.Diff = append(r.Diff, timeouts...)
+
+		r.Diff = append(r.Diff, checkImportID(r.tf, r.md)...)
+	}
+
+	if r.mark != nil {
+		r.Diff = append(r.Diff, checkExamples(r.mark)...)
+	}
 }
@@ -125,8 +125,11 @@ func DiffAll(regs Resources, dryRun bool) *DiffResult {
 		var catName string
 
 		sch := schema.NewResource(res.schema, res.name)
+		if res.dataSource {
+			sch = schema.NewDataSource(res.schema, res.name)
+		}
 		rd := NewResourceDiff(sch)
-		if !dryRun {
+		if !dryRun && rd.MDFile != "" {
 			md.FixFileNormalize(rd.MDFile)
 		}
 		rd.DiffAll()
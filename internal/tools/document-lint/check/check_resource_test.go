@@ -4,9 +4,12 @@
 package check
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/schema"
 )
 
 func TestSliceDiff(t *testing.T) {
@@ -82,3 +85,54 @@ func TestLevenshteinDist(t *testing.T) {
 		}
 	}
 }
+
+func TestDiffAllMissingDocument(t *testing.T) {
+	for _, isDataSource := range []bool{false, true} {
+		r := &schema.Resource{
+			ResourceType: "azurerm_example_without_a_document",
+			IsDataSource: isDataSource,
+			Schema: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+					},
+				},
+			},
+		}
+
+		diff := NewResourceDiff(r)
+		if diff.MDFile != "" {
+			t.Fatalf("expected no document to be found but got %q", diff.MDFile)
+		}
+		diff.DiffAll()
+
+		if len(diff.Diffs()) != 1 {
+			t.Fatalf("expected a single issue but got %d: %+v", len(diff.Diffs()), diff.Diffs())
+		}
+		item, ok := diff.Diffs()[0].(*documentMissing)
+		if !ok {
+			t.Fatalf("expected a missing documentation issue but got %s", diff.Diffs()[0].String())
+		}
+		if item.ShouldSkip() {
+			t.Fatalf("expected the missing documentation issue not to be skipped")
+		}
+
+		expected := "website/docs/r/example_without_a_document.html.markdown"
+		if isDataSource {
+			expected = "website/docs/d/example_without_a_document.html.markdown"
+		}
+		if !strings.Contains(item.String(), expected) {
+			t.Fatalf("expected the issue to reference %q but got %q", expected, item.String())
+		}
+
+		// there's no document to fix
+		fixer := NewFixer(diff)
+		if err := fixer.TryFix(); err != nil {
+			t.Fatalf("fixing the document: %+v", err)
+		}
+		if err := fixer.WriteBack(); err != nil {
+			t.Fatalf("writing back the document: %+v", err)
+		}
+	}
+}
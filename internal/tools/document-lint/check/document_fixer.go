@@ -93,7 +93,8 @@ func tryFixTimeouts(rt string, lines []string, diffs []TimeoutDiffItem) []string
 
 func (f *Fixer) TryFix() (err error) {
 	// read file as bytes
-	if len(f.Diff) == 0 {
+	if len(f.Diff) == 0 || f.MDFile == "" {
+		// there's no document to fix when it's missing
 		return
 	}
 	content, err := os.ReadFile(f.MDFile)
@@ -110,6 +111,10 @@ func (f *Fixer) TryFix() (err error) {
 			lines = tryFixTimeouts(f.ResourceType, lines, to.TimeoutDiff)
 			continue
 		}
+		if example, ok := item.(exampleFormatDiff); ok {
+			lines = example.FixLines(lines)
+			continue
+		}
 
 		// mdField is nil for no document exists or page title mismatch
 		if item.ShouldSkip() {
@@ -10,14 +10,21 @@ import (
 )
 
 type resource struct {
-	name   string
-	schema interface{}
+	name       string
+	schema     interface{}
+	dataSource bool
 }
 
 type Resources struct {
 	resources []resource
 }
 
+// Append adds the resources from other, e.g. to check both Resources and Data Sources
+func (r Resources) Append(other Resources) Resources {
+	r.resources = append(r.resources, other.resources...)
+	return r
+}
+
 type set map[string]struct{}
 
 func (s set) Exists(key string) bool {
@@ -39,42 +46,52 @@ func newSet(val string) set {
 	return res
 }
 
-func AzurermAllResources(service, skipService string, resources, skipResources string) Resources {
-	var (
-		rps              = newSet(service)
-		skipRPs          = newSet(skipService)
-		resourcesMap     = newSet(resources)
-		skipResourcesMap = newSet(skipResources)
-	)
-
-	shouldSkipRP := func(name string) bool {
-		if len(rps) > 0 && !rps.Exists(name) {
-			return true
-		}
-		if skipRPs.Exists(name) {
-			return true
-		}
-		return false
+type filter struct {
+	rps              set
+	skipRPs          set
+	resourcesMap     set
+	skipResourcesMap set
+}
+
+func newFilter(service, skipService string, resources, skipResources string) filter {
+	return filter{
+		rps:              newSet(service),
+		skipRPs:          newSet(skipService),
+		resourcesMap:     newSet(resources),
+		skipResourcesMap: newSet(skipResources),
 	}
+}
 
-	shouldSKipResource := func(name string) bool {
-		if len(resourcesMap) > 0 && !resourcesMap.Exists(name) {
-			return true
-		}
-		if skipResourcesMap.Exists(name) {
-			return true
-		}
-		return false
+func (f filter) shouldSkipRP(name string) bool {
+	if len(f.rps) > 0 && !f.rps.Exists(name) {
+		return true
+	}
+	if f.skipRPs.Exists(name) {
+		return true
 	}
+	return false
+}
+
+func (f filter) shouldSkipResource(name string) bool {
+	if len(f.resourcesMap) > 0 && !f.resourcesMap.Exists(name) {
+		return true
+	}
+	if f.skipResourcesMap.Exists(name) {
+		return true
+	}
+	return false
+}
+
+func AzurermAllResources(service, skipService string, resources, skipResources string) Resources {
+	f := newFilter(service, skipService, resources, skipResources)
 
 	var res Resources
 	for _, r := range provider.SupportedTypedServices() {
-		name := r.Name()
-		if shouldSkipRP(name) {
+		if f.shouldSkipRP(r.Name()) {
 			continue
 		}
 		for _, svc := range r.Resources() {
-			if shouldSKipResource(svc.ResourceType()) {
+			if f.shouldSkipResource(svc.ResourceType()) {
 				continue
 			}
 			res.resources = append(res.resources, resource{
@@ -85,12 +102,11 @@ func AzurermAllResources(service, skipService string, resources, skipResources s
 	}
 
 	for _, r := range provider.SupportedUntypedServices() {
-		name := r.Name()
-		if shouldSkipRP(name) {
+		if f.shouldSkipRP(r.Name()) {
 			continue
 		}
 		for name, svc := range r.SupportedResources() {
-			if shouldSKipResource(name) {
+			if f.shouldSkipResource(name) {
 				continue
 			}
 			res.resources = append(res.resources, resource{
@@ -101,3 +117,41 @@ func AzurermAllResources(service, skipService string, resources, skipResources s
 	}
 	return res
 }
+
+func AzurermAllDataSources(service, skipService string, dataSources, skipDataSources string) Resources {
+	f := newFilter(service, skipService, dataSources, skipDataSources)
+
+	var res Resources
+	for _, r := range provider.SupportedTypedServices() {
+		if f.shouldSkipRP(r.Name()) {
+			continue
+		}
+		for _, ds := range r.DataSources() {
+			if f.shouldSkipResource(ds.ResourceType()) {
+				continue
+			}
+			res.resources = append(res.resources, resource{
+				name:       ds.ResourceType(),
+				schema:     ds,
+				dataSource: true,
+			})
+		}
+	}
+
+	for _, r := range provider.SupportedUntypedServices() {
+		if f.shouldSkipRP(r.Name()) {
+			continue
+		}
+		for name, ds := range r.SupportedDataSources() {
+			if f.shouldSkipResource(name) {
+				continue
+			}
+			res.resources = append(res.resources, resource{
+				name:       name,
+				schema:     ds,
+				dataSource: true,
+			})
+		}
+	}
+	return res
+}
@@ -24,12 +24,14 @@ OPTIONS:
 }
 
 var (
-	cmd          string
-	dryRun       = true
-	resource     string
-	service      string
-	skipResource string
-	skipService  string
+	cmd            string
+	dryRun         = true
+	resource       string
+	service        string
+	skipResource   string
+	skipService    string
+	dataSource     string
+	skipDataSource string
 )
 
 func parseArgs() {
@@ -38,6 +40,8 @@ func parseArgs() {
 	fs.StringVar(&skipResource, "skip-resource", os.Getenv("SKIP_RESOURCE"), "a list of resource names to skip the check")
 	fs.StringVar(&service, "service", os.Getenv("ONLY_SERVICE"), "a list of services names to check")
 	fs.StringVar(&skipService, "skip-service", os.Getenv("SKIP_SERVICE"), "a list of service names to skip the check")
+	fs.StringVar(&dataSource, "data-source", os.Getenv("ONLY_DATA_SOURCE"), "a list of data source names to check")
+	fs.StringVar(&skipDataSource, "skip-data-source", os.Getenv("SKIP_DATA_SOURCE"), "a list of data source names to skip the check")
 
 	fs.Usage = func() {
 		printHelp()
@@ -62,7 +66,16 @@ func parseArgs() {
 func main() {
 	parseArgs()
 
-	result := check.DiffAll(check.AzurermAllResources(service, skipService, resource, skipResource), dryRun)
+	// when only resources (or only data sources) are specified, the other kind isn't checked
+	var regs check.Resources
+	if resource != "" || dataSource == "" {
+		regs = regs.Append(check.AzurermAllResources(service, skipService, resource, skipResource))
+	}
+	if dataSource != "" || resource == "" {
+		regs = regs.Append(check.AzurermAllDataSources(service, skipService, dataSource, skipDataSource))
+	}
+
+	result := check.DiffAll(regs, dryRun)
 	if !result.HasDiff() {
 		log.Printf("document linter runs success, time costs: %v", result.CostTime())
 		return
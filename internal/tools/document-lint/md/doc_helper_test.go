@@ -30,6 +30,25 @@ func TestMDPathFor(t *testing.T) {
 	}
 }
 
+func TestMDPathForDataSource(t *testing.T) {
+	cases := [][2]string{
+		{
+			"azurerm_resource_group",
+			"d/resource_group.html.markdown",
+		},
+		{
+			"not_exists",
+			"",
+		},
+	}
+	for _, c := range cases {
+		got := md.MDPathForDataSource(c[0])
+		if !strings.Contains(got, c[1]) {
+			t.Fatalf("%s: \nwant: %s,\ngot:  %s", c[0], c[1], got)
+		}
+	}
+}
+
 func TestResourceNameReg(t *testing.T) {
 	var titleReg = regexp.MustCompile(`\npage_title:[^\n]*(azurerm_[a-zA-Z0-9_]+)"`)
 
@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package md
+
+// Example is a code block within the `## Example Usage` section of a document
+type Example struct {
+	FromLine int // line index of the first line of code, after the opening fence
+	ToLine   int // line index of the closing fence
+	Language string
+	Content  string
+}
+
+// IsHCL returns whether this example contains a Terraform configuration
+func (e Example) IsHCL() bool {
+	switch e.Language {
+	case "hcl", "terraform", "tf":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package md
+
+import (
+	"testing"
+)
+
+func TestParseExamples(t *testing.T) {
+	content := "---\n" +
+		"subcategory: \"Base\"\n" +
+		"---\n" +
+		"\n" +
+		"# azurerm_resource_group\n" +
+		"\n" +
+		"## Example Usage\n" +
+		"\n" +
+		"```hcl\n" +
+		"# a comment which isn't a header\n" +
+		"resource \"azurerm_resource_group\" \"example\" {}\n" +
+		"```\n" +
+		"\n" +
+		"### With Tags\n" +
+		"\n" +
+		"```terraform\n" +
+		"resource \"azurerm_resource_group\" \"example\" {}\n" +
+		"```\n" +
+		"\n" +
+		"## Import\n" +
+		"\n" +
+		"```shell\n" +
+		"terraform import azurerm_resource_group.example /subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1\n" +
+		"```\n"
+
+	mark := newMarkFromString(content, "resource_group.html.markdown")
+	examples := mark.Examples
+	if len(examples) != 2 {
+		t.Fatalf("expected 2 examples but got %d: %+v", len(examples), examples)
+	}
+
+	if examples[0].FromLine != 9 || examples[0].ToLine != 11 || !examples[0].IsHCL() {
+		t.Fatalf("unexpected first example: %+v", examples[0])
+	}
+	if examples[0].Content != "# a comment which isn't a header\nresource \"azurerm_resource_group\" \"example\" {}" {
+		t.Fatalf("unexpected content for the first example: %q", examples[0].Content)
+	}
+	if examples[1].Language != "terraform" || examples[1].FromLine != 16 {
+		t.Fatalf("unexpected second example: %+v", examples[1])
+	}
+
+	for _, item := range mark.Items {
+		if item.Type == ItemHeader1 && item.FromLine != 4 {
+			t.Fatalf("the comment on line %d within the example was parsed as a header", item.FromLine)
+		}
+	}
+}
@@ -96,6 +96,7 @@ type Mark struct {
 	ResourceType string // azurerm_xxx
 	Blocks       []Block
 	Fields       map[string]*model.Field
+	Examples     []Example // the code blocks within the `## Example Usage` sections
 }
 
 func (m *Mark) lastItem() *MarkItem {
@@ -138,7 +139,30 @@ func newMarkFromString(content string, filepath string) *Mark {
 		FilePath: filepath,
 		Fields:   map[string]*model.Field{},
 	}
+	var (
+		pos     model.PosType
+		inCode  bool
+		example *Example
+		code    []string
+	)
 	for idx, line := range lines {
+		// lines within a code block belong to the example, even if they look like a header or a field
+		if inCode {
+			result.lastItem().addLine(idx, line)
+			if !strings.HasPrefix(line, "```") {
+				code = append(code, line)
+				continue
+			}
+			inCode = false
+			if example != nil {
+				example.ToLine = idx
+				example.Content = strings.Join(code, "\n")
+				result.Examples = append(result.Examples, *example)
+				example = nil
+			}
+			continue
+		}
+
 		// if line starts with #, * or A Block supports, it is a special item
 		switch {
 		case strings.HasPrefix(line, "###"):
@@ -146,6 +170,7 @@ func newMarkFromString(content string, filepath string) *Mark {
 			continue
 		case strings.HasPrefix(line, "##"):
 			result.addItem(NewMarkItem(idx, line, ItemHeader2))
+			pos = headPos(line)
 			continue
 		case strings.HasPrefix(line, "#"):
 			result.addItem(NewMarkItem(idx, line, ItemHeader1))
@@ -165,6 +190,14 @@ func newMarkFromString(content string, filepath string) *Mark {
 			}
 		case strings.HasPrefix(line, "```"):
 			result.addLineOrItem(idx, line, ItemExample)
+			inCode = true
+			code = nil
+			if pos == model.PosExample {
+				example = &Example{
+					FromLine: idx + 1,
+					Language: strings.TrimSpace(strings.TrimPrefix(line, "```")),
+				}
+			}
 		case strings.HasPrefix(line, "->"), strings.HasPrefix(line, "~>"):
 			result.addItemWith(idx, line, ItemNote)
 		case isBlockHead(line):
@@ -213,6 +246,8 @@ func (m *Mark) buildField() {
 				}
 				return false
 			})
+			// data source documents are titled like `Data Source: azurerm_xxx`
+			trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "Data Source:"))
 			if !strings.Contains(trimmed, " ") {
 				m.ResourceType = trimmed
 			}
@@ -365,13 +400,43 @@ func (m *Mark) BuildResourceDoc() *model.ResourceDoc {
 	}
 
 	doc.ResourceName = m.ResourceType
+	var pos model.PosType
 	for _, item := range m.Items {
-		if item.Type == ItemExample {
+		switch item.Type {
+		case ItemHeader2:
+			pos = headPos(item.lines[0])
+		case ItemExample:
+			if pos == model.PosImport {
+				if imp, ok := importFromItem(item); ok && doc.Import.Line == 0 {
+					doc.Import = imp
+				}
+				continue
+			}
 			doc.ExampleHCL = item.content()
-		} else if item.Type == ItemTimeout {
+		case ItemTimeout:
 			doc.SetTimeout(item.FromLine, item.content())
 		}
 	}
 
 	return doc
 }
+
+// importFromItem extracts the resource type and ID from a code block like:
+// terraform import azurerm_resource_group.example /subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1
+func importFromItem(item *MarkItem) (res model.Import, ok bool) {
+	for idx, line := range item.lines {
+		parts := strings.Fields(line)
+		if len(parts) != 4 || parts[0] != "terraform" || parts[1] != "import" {
+			continue
+		}
+
+		res.Line = item.FromLine + idx
+		res.ResourceType = parts[2]
+		if dot := strings.Index(res.ResourceType, "."); dot > 0 {
+			res.ResourceType = res.ResourceType[:dot]
+		}
+		res.ResourceID = strings.Trim(parts[3], `"'`)
+		return res, true
+	}
+	return res, false
+}
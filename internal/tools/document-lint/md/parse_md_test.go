@@ -18,12 +18,13 @@ func init() {
 
 func Test_unmarshalFile(t *testing.T) {
 	args := []struct {
-		file    string
-		itemNum int
-		argsNum int
+		file       string
+		itemNum    int
+		argsNum    int
+		importLine int
 	}{
-		{"key_vault.html.markdown", 64, 16},
-		{"media_transform.html.markdown", 270, 5},
+		{"key_vault.html.markdown", 64, 16, 177},
+		{"media_transform.html.markdown", 270, 5, 887},
 	}
 	for _, arg := range args {
 		file := filepath.Join(testDir, arg.file)
@@ -35,6 +36,9 @@ func Test_unmarshalFile(t *testing.T) {
 		if gotArgs := len(doc.Args); gotArgs != arg.argsNum {
 			t.Fatalf("`%s` expect arg num: %d, got: %d", arg.file, gotArgs, arg.argsNum)
 		}
+		if doc.Import.Line != arg.importLine || doc.Import.ResourceType != doc.ResourceName {
+			t.Fatalf("`%s` expect import on line %d for %s, got: %+v", arg.file, arg.importLine, doc.ResourceName, doc.Import)
+		}
 
 	}
 }
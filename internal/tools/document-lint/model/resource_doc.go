@@ -193,6 +193,7 @@ type Timeouts struct {
 }
 
 type Import struct {
+	Line         int // line number of the `terraform import` command, 0 if there's no such line in document
 	ResourceType string
 	ResourceID   string
 }
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"sync"
+
+	gomonkey "github.com/agiledragon/gomonkey/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var (
+	// importIDValidationFuncs are the functions validating the ID provided at import time, keyed by the Importer
+	// which was built using them (see pluginsdk.ImporterValidatingResourceId and similar)
+	importIDValidationFuncs     = map[*schema.ResourceImporter]pluginsdk.IDValidationFunc{}
+	importIDValidationFuncsLock sync.Mutex
+)
+
+// patchImporterValidatingResourceIdFn records the function used to validate the ID for each Importer, since this
+// is otherwise captured within the Importer - this must be applied prior to the untyped resources being built.
+//
+// As with the other patches this requires that inlining is disabled (`-gcflags=all=-l`).
+func patchImporterValidatingResourceIdFn() {
+	gomonkey.ApplyFunc(pluginsdk.ImporterValidatingResourceIdThen,
+		func(validateFunc pluginsdk.IDValidationFunc, thenFunc pluginsdk.ImporterFunc) *schema.ResourceImporter {
+			importer := &schema.ResourceImporter{
+				StateContext: func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
+					if err := validateFunc(d.Id()); err != nil {
+						return []*pluginsdk.ResourceData{d}, err
+					}
+
+					return thenFunc(ctx, d, meta)
+				},
+			}
+
+			importIDValidationFuncsLock.Lock()
+			defer importIDValidationFuncsLock.Unlock()
+			importIDValidationFuncs[importer] = validateFunc
+
+			return importer
+		})
+}
+
+func init() {
+	patchImporterValidatingResourceIdFn()
+}
+
+// importIDValidationFunc returns the function validating the ID provided at import time for the Importer, which is
+// nil for a custom Importer
+func importIDValidationFunc(importer *schema.ResourceImporter) pluginsdk.IDValidationFunc {
+	if importer == nil {
+		return nil
+	}
+
+	importIDValidationFuncsLock.Lock()
+	defer importIDValidationFuncsLock.Unlock()
+	return importIDValidationFuncs[importer]
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/util"
 )
 
@@ -25,11 +26,18 @@ type Resource struct {
 	FilePath     string
 	ResourceType string // azurerm_xxx
 
-	// one of Schema or SDKResource must use
-	Schema      *schema.Resource `json:"-"`
-	SDKResource sdk.Resource     `json:"-"`
+	// one of Schema, SDKResource or SDKDataSource must use
+	Schema        *schema.Resource `json:"-"`
+	SDKResource   sdk.Resource     `json:"-"`
+	SDKDataSource sdk.DataSource   `json:"-"`
+
+	IsDataSource bool
 
 	PossibleValues map[string][]string // possible values for key(property path)
+
+	// ImportIDValidationFunc validates the ID provided at import time for an untyped resource, which is nil where
+	// this isn't known (for example a custom Importer) - typed resources instead expose the SDKResource's IDValidationFunc
+	ImportIDValidationFunc pluginsdk.IDValidationFunc
 }
 
 func ResourceForSDKType(res sdk.Resource) *schema.Resource {
@@ -38,6 +46,12 @@ func ResourceForSDKType(res sdk.Resource) *schema.Resource {
 	return ins
 }
 
+func DataSourceForSDKType(ds sdk.DataSource) *schema.Resource {
+	r := sdk.NewDataSourceWrapper(ds)
+	ins, _ := r.DataSource()
+	return ins
+}
+
 // NewResourceByTyped NewResource ...
 // r is Schema.Resource or Typed SDK Resource
 func NewResourceByTyped(r sdk.Resource) *Resource {
@@ -67,8 +81,30 @@ func NewResource(r interface{}, rType string) *Resource {
 	return nil
 }
 
+// NewDataSource ...
+// r is Schema.Resource or Typed SDK DataSource
+func NewDataSource(r interface{}, dsType string) *Resource {
+	s := &Resource{}
+	switch ins := r.(type) {
+	case sdk.DataSource:
+		s.SDKDataSource = ins
+		s.Schema = DataSourceForSDKType(ins)
+		s.ResourceType = ins.ResourceType()
+	case *schema.Resource:
+		s.Schema = ins
+		s.ResourceType = dsType
+	default:
+		return nil
+	}
+	s.IsDataSource = true
+	s.Init()
+	return s
+}
+
 func (r *Resource) Init() {
-	if r.SDKResource != nil {
+	if r.SDKDataSource != nil {
+		r.FilePath = FileForResource(r.SDKDataSource.Read().Func)
+	} else if r.SDKResource != nil {
 		// SDKResource is a type of interface, have to get the real
 		// vd := reflect.ValueOf(r.SDKResource).Interface()
 		// vd = reflect.ValueOf(vd).MethodByName("Arguments")
@@ -79,6 +115,9 @@ func (r *Resource) Init() {
 	}
 	r.PossibleValues = map[string][]string{}
 	r.FindAllInSlicePropByMonkey()
+	if !r.IsDataSource && r.SDKResource == nil {
+		r.ImportIDValidationFunc = importIDValidationFunc(r.Schema.Importer)
+	}
 }
 
 func (r *Resource) FilePathRel() string {
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const providerModulePath = "github.com/hashicorp/terraform-provider-azurerm"
+
+// findClient returns the path to the SDK client within `metadata.Client`, e.g. `LoadTestService.V20221201.LoadTests`
+func findClient(servicePath string, pkg *sdkPackage) (string, error) {
+	serviceClientPath := fmt.Sprintf("%s/%s/client", providerModulePath, filepath.ToSlash(filepath.Clean(servicePath)))
+	serviceField, err := findField("internal/clients", func(typ ast.Expr, imports map[string]string) bool {
+		pkgPath, name, ok := selectorType(typ, imports)
+		return ok && pkgPath == serviceClientPath && (name == "Client" || name == "AutoClient")
+	})
+	if err != nil {
+		return "", fmt.Errorf("finding the client for the service within `internal/clients`: %+v", err)
+	}
+
+	// the service client can contain either the SDK client, or the meta client for the API version
+	clientDir := filepath.Join(servicePath, "client")
+	if field, err := findField(clientDir, func(typ ast.Expr, imports map[string]string) bool {
+		pkgPath, name, ok := selectorType(typ, imports)
+		return ok && pkgPath == pkg.ImportPath && name == pkg.ClientName
+	}); err == nil {
+		return serviceField + "." + field, nil
+	}
+
+	versionField, err := findField(clientDir, func(typ ast.Expr, imports map[string]string) bool {
+		pkgPath, name, ok := selectorType(typ, imports)
+		return ok && pkgPath == pkg.VersionPackagePath() && name == "Client"
+	})
+	if err != nil {
+		return "", fmt.Errorf("neither the client %q or the client for %q were found within %q", pkg.ClientName, pkg.VersionPackagePath(), clientDir)
+	}
+
+	versionDir, err := packageDir(pkg.VersionPackagePath())
+	if err != nil {
+		return "", err
+	}
+	field, err := findField(versionDir, func(typ ast.Expr, imports map[string]string) bool {
+		pkgPath, name, ok := selectorType(typ, imports)
+		return ok && pkgPath == pkg.ImportPath && name == pkg.ClientName
+	})
+	if err != nil {
+		return "", fmt.Errorf("finding the client %q within %q: %+v", pkg.ClientName, pkg.VersionPackagePath(), err)
+	}
+	return strings.Join([]string{serviceField, versionField, field}, "."), nil
+}
+
+// findField returns the name of the first field within a struct in dir with a type matching match
+func findField(dir string, match func(typ ast.Expr, imports map[string]string) bool) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("reading %q: %+v", dir, err)
+	}
+
+	fileSet := token.NewFileSet()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fileSet, filepath.Join(dir, entry.Name()), nil, 0)
+		if err != nil {
+			return "", fmt.Errorf("parsing %q: %+v", entry.Name(), err)
+		}
+
+		imports := map[string]string{}
+		for _, spec := range file.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			name := importPath[strings.LastIndex(importPath, "/")+1:]
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			imports[name] = importPath
+		}
+
+		found := ""
+		ast.Inspect(file, func(node ast.Node) bool {
+			s, ok := node.(*ast.StructType)
+			if !ok || found != "" {
+				return found == ""
+			}
+			for _, field := range s.Fields.List {
+				if len(field.Names) == 1 && match(field.Type, imports) {
+					found = field.Names[0].Name
+					return false
+				}
+			}
+			return true
+		})
+		if found != "" {
+			return found, nil
+		}
+	}
+
+	return "", fmt.Errorf("no matching field was found within %q", dir)
+}
+
+// selectorType returns the import path and name of a type like `*loadtests.LoadTestsClient`
+func selectorType(typ ast.Expr, imports map[string]string) (pkgPath, name string, ok bool) {
+	if star, isStar := typ.(*ast.StarExpr); isStar {
+		typ = star.X
+	}
+	selector, isSelector := typ.(*ast.SelectorExpr)
+	if !isSelector {
+		return "", "", false
+	}
+	ident, isIdent := selector.X.(*ast.Ident)
+	if !isIdent {
+		return "", "", false
+	}
+	pkgPath, ok = imports[ident.Name]
+	return pkgPath, selector.Sel.Name, ok
+}
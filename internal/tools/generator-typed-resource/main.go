@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	f := flag.NewFlagSet("generator-typed-resource", flag.ExitOnError)
+
+	sdkPackage := f.String("sdk-package", "", "The go-azure-sdk package containing the API, e.g. `resource-manager/loadtestservice/2022-12-01/loadtests`")
+	resourceName := f.String("name", "", "The type of the Resource, e.g. `azurerm_load_test`")
+	servicePath := f.String("service-path", "", "The relative path to the service package, e.g. `internal/services/loadtestservice`")
+	modelName := f.String("model", "", "The model within the SDK package for the Resource, defaults to the input of the create operation")
+	attributes := f.String("attributes", "", "A comma-separated list of the properties which are Computed-only, by default this is inferred from the name")
+	brandName := f.String("brand-name", "", "The brand name of the Resource used in the documentation, defaults to the name of the Resource ID")
+	clientPath := f.String("client", "", "The path to the SDK client within `metadata.Client`, by default this is found within `internal/clients`")
+	websitePath := f.String("website-path", "", "The relative path to the website folder, when set the documentation is scaffolded using `website-scaffold`")
+
+	_ = f.Parse(os.Args[1:])
+
+	if *sdkPackage == "" {
+		log.Fatalf("the SDK Package must be specified")
+	}
+	if !strings.HasPrefix(*resourceName, "azurerm_") {
+		log.Fatalf("the Resource Name must be specified and start with `azurerm_`")
+	}
+	if *servicePath == "" {
+		log.Fatalf("the Service Path must be specified")
+	}
+
+	input := generatorInput{
+		SDKPackage:   *sdkPackage,
+		ResourceName: *resourceName,
+		ServicePath:  *servicePath,
+		ModelName:    *modelName,
+		Attributes:   map[string]struct{}{},
+		BrandName:    *brandName,
+		ClientPath:   *clientPath,
+		WebsitePath:  *websitePath,
+	}
+	for _, v := range strings.Split(*attributes, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			input.Attributes[v] = struct{}{}
+		}
+	}
+
+	if err := run(input); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type generatorInput struct {
+	SDKPackage   string
+	ResourceName string
+	ServicePath  string
+	ModelName    string
+	Attributes   map[string]struct{}
+	BrandName    string
+	ClientPath   string
+	WebsitePath  string
+}
+
+func run(input generatorInput) error {
+	importPath := input.SDKPackage
+	if !strings.HasPrefix(importPath, sdkModulePath+"/") {
+		importPath = fmt.Sprintf("%s/%s", sdkModulePath, strings.Trim(importPath, "/"))
+	}
+	dir, err := packageDir(importPath)
+	if err != nil {
+		return err
+	}
+	pkg, err := parseSDKPackage(importPath, dir)
+	if err != nil {
+		return err
+	}
+
+	def, err := newResourceDefinition(input.ResourceName, filepath.Base(input.ServicePath), pkg, input.ModelName, input.Attributes)
+	if err != nil {
+		return err
+	}
+	def.Client = input.ClientPath
+	if def.Client == "" {
+		if def.Client, err = findClient(input.ServicePath, pkg); err != nil {
+			return fmt.Errorf("%+v - the client can be specified using `-client`", err)
+		}
+	}
+
+	resourceCode, err := def.resourceFile()
+	if err != nil {
+		return fmt.Errorf("generating the Resource: %+v", err)
+	}
+	testCode, err := def.acceptanceTestFile()
+	if err != nil {
+		return fmt.Errorf("generating the acceptance tests: %+v", err)
+	}
+
+	fileName := strings.TrimPrefix(input.ResourceName, "azurerm_") + "_resource"
+	files := map[string][]byte{
+		filepath.Join(input.ServicePath, fileName+".go"):      resourceCode,
+		filepath.Join(input.ServicePath, fileName+"_test.go"): testCode,
+	}
+	for name := range files {
+		if _, err := os.Stat(name); err == nil {
+			return fmt.Errorf("the file %q already exists", name)
+		}
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, content, 0o644); err != nil {
+			return fmt.Errorf("writing %q: %+v", name, err)
+		}
+		log.Printf("[DEBUG] Generated %q", name)
+	}
+
+	registered, err := registerResource(input.ServicePath, def.TypeName)
+	if err != nil {
+		return fmt.Errorf("registering the Resource: %+v", err)
+	}
+	if !registered {
+		log.Printf("[WARN] the list of Resources wasn't found within %q, `%sResource{}` will need to be registered manually", input.ServicePath, def.TypeName)
+	}
+
+	brandName := input.BrandName
+	if brandName == "" {
+		brandName = def.ID.description()
+	}
+	scaffoldArgs := []string{
+		"run", "./internal/tools/website-scaffold",
+		"-name", input.ResourceName,
+		"-brand-name", brandName,
+		"-resource-id", def.ID.ExampleValue(),
+		"-type", "resource",
+	}
+	if input.WebsitePath == "" {
+		log.Printf("[INFO] to scaffold the documentation run: go %s -website-path website", strings.Join(quoteArgs(scaffoldArgs), " "))
+		return nil
+	}
+
+	// the documentation is generated from the schema, so the provider must be built with the new Resource
+	cmd := exec.Command("go", append(scaffoldArgs, "-website-path", input.WebsitePath)...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("scaffolding the documentation: %+v", err)
+	}
+	return nil
+}
+
+// packageDir returns the directory containing the source of the package
+func packageDir(importPath string) (string, error) {
+	out, err := exec.Command("go", "list", "-f", "{{.Dir}}", importPath).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("finding the package %q: %s", importPath, exitErr.Stderr)
+		}
+		return "", fmt.Errorf("finding the package %q: %+v", importPath, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func quoteArgs(args []string) []string {
+	out := make([]string, 0, len(args))
+	for _, arg := range args {
+		if strings.ContainsAny(arg, " \"'") {
+			arg = fmt.Sprintf("%q", arg)
+		}
+		out = append(out, arg)
+	}
+	return out
+}
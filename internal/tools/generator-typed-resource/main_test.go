@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const loadTestsPackage = sdkModulePath + "/resource-manager/loadtestservice/2022-12-01/loadtests"
+
+func TestCamelToSnake(t *testing.T) {
+	cases := map[string]string{
+		"Description":       "description",
+		"DataPlaneURI":      "data_plane_uri",
+		"ResourceGroupName": "resource_group_name",
+		"VNetId":            "v_net_id",
+		"IPAddress":         "ip_address",
+		"Sku":               "sku",
+	}
+	for input, expected := range cases {
+		if actual := camelToSnake(input); actual != expected {
+			t.Errorf("expected %q for %q but got %q", expected, input, actual)
+		}
+	}
+}
+
+func TestSplitCamelCase(t *testing.T) {
+	cases := map[string][]string{
+		"LoadTest":     {"Load", "Test"},
+		"DataPlaneURI": {"Data", "Plane", "URI"},
+		"HTTPSEnabled": {"HTTPS", "Enabled"},
+	}
+	for input, expected := range cases {
+		if actual := splitCamelCase(input); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("expected %v for %q but got %v", expected, input, actual)
+		}
+	}
+}
+
+func loadTestsDefinition(t *testing.T) *resourceDefinition {
+	dir, err := packageDir(loadTestsPackage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := parseSDKPackage(loadTestsPackage, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def, err := newResourceDefinition("azurerm_load_test", "loadtestservice", pkg, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	def.Client = "LoadTestService.V20221201.LoadTests"
+	return def
+}
+
+func TestParseSDKPackage(t *testing.T) {
+	def := loadTestsDefinition(t)
+
+	if def.SDK.ClientName != "LoadTestsClient" {
+		t.Errorf("expected the client `LoadTestsClient` but got %q", def.SDK.ClientName)
+	}
+	if !def.Create.LongRunning || def.Create.InputType != "LoadTestResource" {
+		t.Errorf("expected `CreateOrUpdate` to be a long-running operation taking `LoadTestResource` but got %+v", def.Create)
+	}
+	if expected := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resource-group/providers/Microsoft.LoadTestService/loadTests/loadTestValue"; def.ID.ExampleValue() != expected {
+		t.Errorf("expected the example Resource ID %q but got %q", expected, def.ID.ExampleValue())
+	}
+	if !reflect.DeepEqual(def.idProperties(), []string{"resource_group_name", "name"}) {
+		t.Errorf("unexpected ID properties %v", def.idProperties())
+	}
+	if !def.HasLocation || !def.HasTags || def.Identity == nil {
+		t.Errorf("expected the Resource to have a location, tags and identity")
+	}
+
+	properties := map[string]bool{}
+	for _, p := range def.Properties {
+		properties[p.Name] = p.Computed
+	}
+	if !reflect.DeepEqual(properties, map[string]bool{"description": false, "data_plane_uri": true}) {
+		t.Errorf("unexpected properties %v", properties)
+	}
+}
+
+func TestResourceFile(t *testing.T) {
+	out, err := loadTestsDefinition(t).resourceFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"var _ sdk.ResourceWithUpdate = LoadTestResource{}",
+		"return loadtests.ValidateLoadTestID",
+		`Description       string                                     ` + "`tfschema:\"description\"`",
+		`"identity": commonschema.SystemAssignedUserAssignedIdentityOptional(),`,
+		"id := loadtests.NewLoadTestID(subscriptionId, config.ResourceGroupName, config.Name)",
+		"client.CreateOrUpdateThenPoll(ctx, id, payload)",
+		"state.DataPlaneURI = pointer.From(props.DataPlaneURI)",
+		`if metadata.ResourceData.HasChange("description") {`,
+		"client.DeleteThenPoll(ctx, *id)",
+	} {
+		if !strings.Contains(string(out), expected) {
+			t.Errorf("expected the generated Resource to contain %q:\n%s", expected, out)
+		}
+	}
+}
+
+func TestAcceptanceTestFile(t *testing.T) {
+	out, err := loadTestsDefinition(t).acceptanceTestFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"func TestAccLoadTest_requiresImport(t *testing.T) {",
+		"resp, err := clients.LoadTestService.V20221201.LoadTests.Get(ctx, *id)",
+		`resource "azurerm_load_test" "import" {`,
+		`  description         = "example"`,
+	} {
+		if !strings.Contains(string(out), expected) {
+			t.Errorf("expected the generated acceptance tests to contain %q:\n%s", expected, out)
+		}
+	}
+}
+
+func TestGeneratedFilesTypeCheck(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping since type-checking the generated files requires building the Provider")
+	}
+
+	def := loadTestsDefinition(t)
+	resourceCode, err := def.resourceFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCode, err := def.acceptanceTestFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the generated files are type-checked within the Service Package (so that the `internal` packages can be
+	// imported), using an overlay for a separate directory since the Service Package already contains this
+	// Resource - meaning that nothing is written to disk
+	servicePackageDir, err := filepath.Abs(filepath.Join("..", "..", "services", "loadtestservice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(servicePackageDir, "generated")
+	config := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+		Dir:   servicePackageDir,
+		Tests: true,
+		Overlay: map[string][]byte{
+			filepath.Join(dir, "load_test_resource.go"):      resourceCode,
+			filepath.Join(dir, "load_test_resource_test.go"): testCode,
+		},
+	}
+	pkgs, err := packages.Load(config, "./generated")
+	if err != nil {
+		t.Fatalf("loading the generated files: %+v", err)
+	}
+	if len(pkgs) == 0 {
+		t.Fatalf("expected the generated files to be loaded")
+	}
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, e := range pkg.Errors {
+			t.Errorf("type-checking the generated files: %s", e)
+		}
+	})
+}
+
+func TestAddToResources(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input: `package example
+
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{}
+}
+`,
+			expected: `package example
+
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{
+		ExampleResource{},
+	}
+}
+`,
+		},
+		{
+			input: `package example
+
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{
+		OtherResource{},
+	}
+}
+`,
+			expected: `package example
+
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{
+		OtherResource{},
+		ExampleResource{},
+	}
+}
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		out, ok, err := addToResources([]byte(tc.input), "ExampleResource{}")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatalf("expected the list of Resources to be found")
+		}
+		if string(out) != tc.expected {
+			t.Errorf("expected:\n%s\nbut got:\n%s", tc.expected, out)
+		}
+	}
+
+	if _, ok, _ := addToResources([]byte("package example\n\nfunc (r Registration) Resources() []sdk.Resource {\n\treturn r.autoRegistration.Resources()\n}\n"), "ExampleResource{}"); ok {
+		t.Errorf("expected no list of Resources to be found")
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// registerResource adds the Resource to the list of Resources returned by the `Resources` function of the service
+// registration, returning false when this list wasn't found (e.g. when the service only uses generated Resources)
+func registerResource(servicePath, typeName string) (bool, error) {
+	entries, err := os.ReadDir(servicePath)
+	if err != nil {
+		return false, fmt.Errorf("reading %q: %+v", servicePath, err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || strings.HasSuffix(name, "_gen.go") {
+			continue
+		}
+
+		fileName := filepath.Join(servicePath, name)
+		src, err := os.ReadFile(fileName)
+		if err != nil {
+			return false, fmt.Errorf("reading %q: %+v", fileName, err)
+		}
+		out, ok, err := addToResources(src, typeName+"Resource{}")
+		if err != nil {
+			return false, fmt.Errorf("updating %q: %+v", fileName, err)
+		}
+		if !ok {
+			continue
+		}
+
+		if err := os.WriteFile(fileName, out, 0o644); err != nil {
+			return false, fmt.Errorf("writing %q: %+v", fileName, err)
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// addToResources appends item to the `[]sdk.Resource` returned by the `Resources` function within src
+func addToResources(src []byte, item string) ([]byte, bool, error) {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var lit *ast.CompositeLit
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != "Resources" || fn.Recv == nil || fn.Body == nil {
+			continue
+		}
+		ast.Inspect(fn.Body, func(node ast.Node) bool {
+			v, ok := node.(*ast.CompositeLit)
+			if !ok || lit != nil {
+				return lit == nil
+			}
+			if array, ok := v.Type.(*ast.ArrayType); ok {
+				if selector, ok := array.Elt.(*ast.SelectorExpr); ok && selector.Sel.Name == "Resource" {
+					lit = v
+					return false
+				}
+			}
+			return true
+		})
+	}
+	if lit == nil {
+		return nil, false, nil
+	}
+
+	for _, elt := range lit.Elts {
+		if existing, ok := elt.(*ast.CompositeLit); ok {
+			if ident, ok := existing.Type.(*ast.Ident); ok && ident.Name+"{}" == item {
+				return nil, false, fmt.Errorf("%s is already registered", item)
+			}
+		}
+	}
+
+	// the closing brace of a multi-line list is preceded by a trailing comma, whereas an empty list isn't
+	offset := fileSet.Position(lit.Rbrace).Offset
+	insert := item + ",\n"
+	if fileSet.Position(lit.Lbrace).Line == fileSet.Position(lit.Rbrace).Line {
+		if len(lit.Elts) > 0 {
+			insert = ",\n" + insert
+		} else {
+			insert = "\n" + insert
+		}
+	}
+
+	out := make([]byte, 0, len(src)+len(insert))
+	out = append(out, src[:offset]...)
+	out = append(out, insert...)
+	out = append(out, src[offset:]...)
+
+	formatted, err := format.Source(out)
+	if err != nil {
+		return nil, false, err
+	}
+	return formatted, true, nil
+}
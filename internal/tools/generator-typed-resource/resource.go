@@ -0,0 +1,391 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// readOnlySuffixes are used to guess which properties are read-only (and so Computed-only), since this isn't
+// available within the SDK - these can be overridden using `-attributes`
+var readOnlySuffixes = []string{"Uri", "URI", "Url", "URL", "Endpoint", "Fqdn", "FQDN", "State", "Status"}
+
+// ignoredFields are the fields within the top-level model which aren't exposed as properties
+var ignoredFields = map[string]struct{}{
+	"Etag":       {},
+	"Id":         {},
+	"Name":       {},
+	"SystemData": {},
+	"Type":       {},
+}
+
+// identityTypes maps the types within `go-azure-helpers/resourcemanager/identity` onto the schema and
+// expand/flatten functions for them
+var identityTypes = map[string]identityType{
+	"LegacySystemAndUserAssignedMap": {"SystemAssignedUserAssignedIdentityOptional", "ModelSystemAssignedUserAssigned", "ExpandLegacySystemAndUserAssignedMapFromModel", "FlattenLegacySystemAndUserAssignedMapToModel", false, true},
+	"SystemAndUserAssignedList":      {"SystemAssignedUserAssignedIdentityOptional", "ModelSystemAssignedUserAssigned", "ExpandSystemAndUserAssignedListFromModel", "FlattenSystemAndUserAssignedListToModel", true, true},
+	"SystemAndUserAssignedMap":       {"SystemAssignedUserAssignedIdentityOptional", "ModelSystemAssignedUserAssigned", "ExpandSystemAndUserAssignedMapFromModel", "FlattenSystemAndUserAssignedMapToModel", true, true},
+	"SystemAssigned":                 {"SystemAssignedIdentityOptional", "ModelSystemAssigned", "ExpandSystemAssignedFromModel", "FlattenSystemAssignedToModel", false, false},
+	"SystemOrUserAssignedList":       {"SystemOrUserAssignedIdentityOptional", "ModelSystemAssignedUserAssigned", "ExpandSystemOrUserAssignedListFromModel", "FlattenSystemAssignedOrUserAssignedListToModel", true, true},
+	"SystemOrUserAssignedMap":        {"SystemOrUserAssignedIdentityOptional", "ModelSystemAssignedUserAssigned", "ExpandSystemOrUserAssignedMapFromModel", "FlattenSystemOrUserAssignedMapToModel", true, true},
+	"UserAssignedList":               {"UserAssignedIdentityOptional", "ModelUserAssigned", "ExpandUserAssignedListFromModel", "FlattenUserAssignedListToModel", true, true},
+	"UserAssignedMap":                {"UserAssignedIdentityOptional", "ModelUserAssigned", "ExpandUserAssignedMapFromModel", "FlattenUserAssignedMapToModel", true, true},
+}
+
+type identityType struct {
+	Schema  string
+	Model   string
+	Expand  string
+	Flatten string
+
+	FlattenReturnsPointer bool
+	FlattenReturnsError   bool
+}
+
+// property is a property within the schema of the Resource, or of a nested block
+type property struct {
+	Name      string // e.g. `data_plane_uri`
+	FieldName string // the name of the field within the model for the schema, and the SDK model
+
+	Type     sdkType
+	Required bool
+	Computed bool
+
+	// Block is set when this property is a block, and contains the nested properties
+	Block *block
+
+	// InProperties specifies whether the field is within the `Properties` model, rather than the top-level model
+	InProperties bool
+}
+
+type block struct {
+	Name       string // the name of the block used within the expand and flatten functions, e.g. `LoadTestEncryption`
+	ModelName  string // the name of the model for the schema
+	SDKModel   string
+	Properties []property
+
+	// UsedAsList specifies whether this block is a list of models within the SDK, rather than a single model
+	UsedAsList bool
+}
+
+// resourceDefinition describes the Resource being generated
+type resourceDefinition struct {
+	ResourceType string // e.g. `azurerm_load_test`
+	TypeName     string // e.g. `LoadTest`
+	ServicePkg   string // the name of the service package within the provider
+	Client       string // the path to the client within `metadata.Client`
+
+	SDK    *sdkPackage
+	ID     sdkResourceID
+	Model  sdkModel
+	Create sdkOperation
+	Get    sdkOperation
+	Delete sdkOperation
+
+	HasLocation         bool
+	LocationIsPointer   bool
+	HasTags             bool
+	TagsIsPointer       bool
+	Identity            *identityType
+	IdentityIsPointer   bool
+	PropertiesField     *sdkField // the `Properties` field within the model, when present
+	PropertiesModelName string
+
+	Properties []property
+
+	// blocks are the nested blocks, in the order they were found
+	blocks []*block
+	enums  map[string]struct{} // the enums used within lists, which need expand/flatten functions
+}
+
+// newResourceDefinition builds the definition of the Resource from the SDK package, attributes are the names of
+// the properties which should be Computed-only, when empty this is guessed from the name of the property
+func newResourceDefinition(resourceType, servicePkg string, pkg *sdkPackage, modelName string, attributes map[string]struct{}) (*resourceDefinition, error) {
+	def := &resourceDefinition{
+		ResourceType: resourceType,
+		TypeName:     snakeToCamel(strings.TrimPrefix(resourceType, "azurerm_")),
+		ServicePkg:   servicePkg,
+		SDK:          pkg,
+		enums:        map[string]struct{}{},
+	}
+
+	var ok bool
+	if def.Get, ok = pkg.Operations["Get"]; !ok {
+		return nil, fmt.Errorf("the SDK package %q doesn't contain a `Get` operation", pkg.ImportPath)
+	}
+	for _, name := range []string{"CreateOrUpdate", "Create", "Put"} {
+		if def.Create, ok = pkg.Operations[name]; ok {
+			break
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("the SDK package %q doesn't contain a `CreateOrUpdate` or `Create` operation", pkg.ImportPath)
+	}
+	if def.Delete, ok = pkg.Operations["Delete"]; !ok {
+		return nil, fmt.Errorf("the SDK package %q doesn't contain a `Delete` operation", pkg.ImportPath)
+	}
+
+	if def.ID, ok = pkg.IDs[def.Get.IDType]; !ok {
+		return nil, fmt.Errorf("the Resource ID %q used by the `Get` operation must be defined within the SDK package", def.Get.IDType)
+	}
+
+	if modelName == "" {
+		modelName = def.Create.InputType
+	}
+	if def.Model, ok = pkg.Models[modelName]; !ok {
+		return nil, fmt.Errorf("the model %q wasn't found within the SDK package", modelName)
+	}
+	if def.Get.ResponseModel != modelName {
+		return nil, fmt.Errorf("the `Get` operation returns %q rather than %q, which isn't supported", def.Get.ResponseModel, modelName)
+	}
+
+	for _, field := range def.Model.Fields {
+		if _, ok := ignoredFields[field.Name]; ok {
+			continue
+		}
+
+		switch {
+		case field.Name == "Location" && field.Type.Kind == kindBasic:
+			def.HasLocation = true
+			def.LocationIsPointer = field.Type.Pointer
+		case field.Name == "Tags" && field.Type.Kind == kindMap:
+			def.HasTags = true
+			def.TagsIsPointer = field.Type.Pointer
+		case field.Name == "Identity" && field.Type.Kind == kindExternal && field.Type.Package == "identity":
+			v, ok := identityTypes[field.Type.Name]
+			if !ok {
+				log.Printf("[WARN] the Identity type %q isn't supported and will need to be added manually", field.Type.Name)
+				continue
+			}
+			def.Identity = &v
+			def.IdentityIsPointer = field.Type.Pointer
+		case field.Name == "Properties" && field.Type.Kind == kindModel:
+			f := field
+			def.PropertiesField = &f
+			def.PropertiesModelName = field.Type.Name
+			for _, p := range def.propertiesForModel(pkg.Models[field.Type.Name], true, attributes) {
+				p.InProperties = true
+				def.Properties = append(def.Properties, p)
+			}
+		default:
+			def.Properties = append(def.Properties, def.propertiesForModel(sdkModel{Fields: []sdkField{field}}, true, attributes)...)
+		}
+	}
+
+	// the properties within the model can conflict with those added by the generator, e.g. `name` or `location`
+	reserved := map[string]struct{}{
+		"location": {},
+		"tags":     {},
+		"identity": {},
+	}
+	for _, name := range def.idProperties() {
+		reserved[name] = struct{}{}
+	}
+	properties := make([]property, 0)
+	for _, p := range def.Properties {
+		if _, ok := reserved[p.Name]; ok {
+			log.Printf("[WARN] the property %q conflicts with a property added by the generator and has been skipped", p.Name)
+			continue
+		}
+		properties = append(properties, p)
+	}
+	def.Properties = properties
+
+	sort.SliceStable(def.Properties, func(i, j int) bool {
+		// Required properties come before Optional ones, and Computed-only ones last
+		return propertyOrder(def.Properties[i]) < propertyOrder(def.Properties[j])
+	})
+
+	return def, nil
+}
+
+func propertyOrder(p property) int {
+	switch {
+	case p.Required:
+		return 0
+	case p.Computed:
+		return 2
+	}
+	return 1
+}
+
+func (def *resourceDefinition) propertiesForModel(model sdkModel, topLevel bool, attributes map[string]struct{}) []property {
+	out := make([]property, 0)
+	for _, field := range model.Fields {
+		if field.Name == "ProvisioningState" || field.Name == "SystemData" {
+			continue
+		}
+
+		p := property{
+			Name:      camelToSnake(field.Name),
+			FieldName: field.Name,
+			Type:      field.Type,
+			Required:  !field.Type.Pointer,
+		}
+		if topLevel {
+			p.Computed = isAttribute(p, attributes)
+			if p.Computed {
+				p.Required = false
+			}
+		}
+
+		switch field.Type.Kind {
+		case kindBasic, kindEnum:
+		case kindList:
+			switch field.Type.Elem.Kind {
+			case kindBasic:
+				if field.Type.Elem.Name != "string" {
+					log.Printf("[WARN] the property %q is a list of %s which isn't supported and will need to be added manually", p.Name, field.Type.Elem.Name)
+					continue
+				}
+			case kindEnum:
+				def.enums[field.Type.Elem.Name] = struct{}{}
+			case kindModel:
+				p.Block = def.blockForModel(field.Type.Elem.Name, attributes)
+				p.Block.UsedAsList = true
+			default:
+				log.Printf("[WARN] the property %q has a type which isn't supported and will need to be added manually", p.Name)
+				continue
+			}
+		case kindMap:
+			if field.Type.Elem.Kind != kindBasic || field.Type.Elem.Name != "string" {
+				log.Printf("[WARN] the property %q is a map which isn't supported and will need to be added manually", p.Name)
+				continue
+			}
+		case kindModel:
+			p.Block = def.blockForModel(field.Type.Name, attributes)
+		default:
+			log.Printf("[WARN] the property %q has a type which isn't supported and will need to be added manually", p.Name)
+			continue
+		}
+
+		out = append(out, p)
+	}
+	return out
+}
+
+func (def *resourceDefinition) blockForModel(name string, attributes map[string]struct{}) *block {
+	for _, b := range def.blocks {
+		if b.SDKModel == name {
+			return b
+		}
+	}
+
+	b := &block{
+		Name:      def.prefixed(name),
+		ModelName: def.prefixed(name) + "Model",
+		SDKModel:  name,
+	}
+	def.blocks = append(def.blocks, b)
+	b.Properties = def.propertiesForModel(def.SDK.Models[name], false, attributes)
+	return b
+}
+
+// prefixed returns the name prefixed with the name of the Resource, since the nested models and the expand/flatten
+// functions are shared with the other Resources within the service package
+func (def resourceDefinition) prefixed(name string) string {
+	if strings.HasPrefix(name, def.TypeName) {
+		return name
+	}
+	return def.TypeName + name
+}
+
+func isAttribute(p property, attributes map[string]struct{}) bool {
+	if len(attributes) > 0 {
+		_, ok := attributes[p.Name]
+		return ok
+	}
+	if p.Block != nil || p.Type.Kind == kindModel || p.Type.Kind == kindList {
+		return false
+	}
+	for _, suffix := range readOnlySuffixes {
+		if strings.HasSuffix(p.FieldName, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseFunc is the name of the function within the SDK package to parse the Resource ID
+func (def resourceDefinition) ParseFunc() string {
+	return fmt.Sprintf("%s.Parse%sID", def.SDK.Name, strings.TrimSuffix(def.ID.Name, "Id"))
+}
+
+func (def resourceDefinition) ValidateFunc() string {
+	return fmt.Sprintf("%s.Validate%sID", def.SDK.Name, strings.TrimSuffix(def.ID.Name, "Id"))
+}
+
+func (def resourceDefinition) NewIDFunc() string {
+	return fmt.Sprintf("%s.New%sID", def.SDK.Name, strings.TrimSuffix(def.ID.Name, "Id"))
+}
+
+// idProperties returns the properties which make up the Resource ID, other than the Subscription ID
+func (def resourceDefinition) idProperties() (out []string) {
+	for i, field := range def.ID.Fields {
+		switch {
+		case field == "SubscriptionId":
+			continue
+		case i == len(def.ID.Fields)-1:
+			out = append(out, "name")
+		default:
+			out = append(out, camelToSnake(field))
+		}
+	}
+	return out
+}
+
+// schemaIDProperties returns the properties which make up the Resource ID in the order they're defined within the
+// schema, with `name` first
+func (def resourceDefinition) schemaIDProperties() []string {
+	out := []string{"name"}
+	for _, name := range def.idProperties() {
+		if name != "name" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// splitCamelCase splits a name into words, treating acronyms as a single word, e.g. `DataPlaneURI` becomes
+// `Data`, `Plane` and `URI`
+func splitCamelCase(input string) []string {
+	runes := []rune(input)
+	words := make([]string, 0)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			continue
+		}
+		prevLower := !unicode.IsUpper(runes[i-1])
+		nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if prevLower || nextLower {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(words, string(runes[start:]))
+}
+
+func camelToSnake(input string) string {
+	words := splitCamelCase(input)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, "_")
+}
+
+func snakeToCamel(input string) string {
+	out := ""
+	for _, segment := range strings.Split(input, "_") {
+		if segment == "" {
+			continue
+		}
+		out += strings.ToUpper(segment[:1]) + segment[1:]
+	}
+	return out
+}
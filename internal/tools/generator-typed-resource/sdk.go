@@ -0,0 +1,434 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const sdkModulePath = "github.com/hashicorp/go-azure-sdk"
+
+type typeKind int
+
+const (
+	kindUnsupported typeKind = iota
+	kindBasic                // string, bool, int64 or float64
+	kindEnum                 // a constant within the SDK package
+	kindModel                // a struct within the SDK package
+	kindList
+	kindMap
+	kindExternal // a type from another package, e.g. `identity.SystemAssigned`
+)
+
+// sdkType is the type of a field within a model in the SDK package
+type sdkType struct {
+	Kind    typeKind
+	Pointer bool
+
+	// Name is the name of the type, e.g. `string`, the name of the enum or model or the type within the package
+	Name string
+
+	// Package is the package containing the type when Kind is kindExternal
+	Package string
+
+	// Elem is the type of the items within a list, or of the values within a map
+	Elem *sdkType
+}
+
+type sdkField struct {
+	Name     string
+	JSONName string
+	Type     sdkType
+}
+
+type sdkModel struct {
+	Name   string
+	Fields []sdkField
+}
+
+func (m sdkModel) field(name string) *sdkField {
+	for _, f := range m.Fields {
+		if f.Name == name {
+			return &f
+		}
+	}
+	return nil
+}
+
+type sdkSegment struct {
+	Kind    string // e.g. `UserSpecified`, `ResourceGroup` or `Static`
+	Name    string
+	Example string
+}
+
+type sdkResourceID struct {
+	Name     string // e.g. `LoadTestId`
+	Fields   []string
+	Segments []sdkSegment
+}
+
+// ExampleValue returns an example of this Resource ID, using the placeholder subscription id used in the documentation
+func (id sdkResourceID) ExampleValue() string {
+	out := ""
+	for _, segment := range id.Segments {
+		value := segment.Example
+		if segment.Kind == "SubscriptionId" {
+			value = "00000000-0000-0000-0000-000000000000"
+		}
+		out += "/" + value
+	}
+	return out
+}
+
+// description returns the human-readable name of the Resource ID, e.g. `Load Test`
+func (id sdkResourceID) description() string {
+	return strings.Join(splitCamelCase(strings.TrimSuffix(id.Name, "Id")), " ")
+}
+
+type sdkOperation struct {
+	Name      string
+	IDType    string
+	InputType string
+
+	// ResponseModel is the type of the `Model` within the response, if any
+	ResponseModel string
+
+	// LongRunning specifies whether there's a `ThenPoll` variant of this operation
+	LongRunning bool
+
+	// Options specifies whether this operation accepts an `{Name}OperationOptions` argument
+	Options bool
+}
+
+// sdkPackage is the subset of a go-azure-sdk package needed to generate a Resource
+type sdkPackage struct {
+	ImportPath string
+	Name       string
+	APIVersion string
+	ClientName string
+
+	Constants  map[string][]string
+	Models     map[string]sdkModel
+	IDs        map[string]sdkResourceID
+	Operations map[string]sdkOperation
+}
+
+// VersionPackagePath is the import path of the package containing the meta client for this API version
+func (p sdkPackage) VersionPackagePath() string {
+	return p.ImportPath[:strings.LastIndex(p.ImportPath, "/")]
+}
+
+// parseSDKPackage parses the source of the go-azure-sdk package within dir
+func parseSDKPackage(importPath, dir string) (*sdkPackage, error) {
+	fileSet := token.NewFileSet()
+	files := make([]*ast.File, 0)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %+v", dir, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fileSet, filepath.Join(dir, entry.Name()), nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %q: %+v", entry.Name(), err)
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files were found in %q", dir)
+	}
+
+	segments := strings.Split(importPath, "/")
+	pkg := &sdkPackage{
+		ImportPath: importPath,
+		Name:       files[0].Name.Name,
+		Constants:  map[string][]string{},
+		Models:     map[string]sdkModel{},
+		IDs:        map[string]sdkResourceID{},
+		Operations: map[string]sdkOperation{},
+	}
+	if len(segments) >= 2 {
+		pkg.APIVersion = segments[len(segments)-2]
+	}
+
+	// the kinds of the types need to be known prior to parsing the fields of the models
+	structs := map[string]*ast.StructType{}
+	stringTypes := map[string]struct{}{}
+	funcs := make([]*ast.FuncDecl, 0)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch v := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range v.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					switch t := typeSpec.Type.(type) {
+					case *ast.StructType:
+						structs[typeSpec.Name.Name] = t
+					case *ast.Ident:
+						if t.Name == "string" {
+							stringTypes[typeSpec.Name.Name] = struct{}{}
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				funcs = append(funcs, v)
+			}
+		}
+	}
+
+	for _, fn := range funcs {
+		if fn.Recv == nil && strings.HasPrefix(fn.Name.Name, "PossibleValuesFor") {
+			name := strings.TrimPrefix(fn.Name.Name, "PossibleValuesFor")
+			if _, ok := stringTypes[name]; ok {
+				pkg.Constants[name] = make([]string, 0)
+			}
+		}
+	}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			if v, ok := decl.(*ast.GenDecl); ok && v.Tok == token.CONST {
+				parseConstants(v, pkg.Constants)
+			}
+		}
+	}
+
+	for name, s := range structs {
+		switch {
+		case strings.HasSuffix(name, "Id") && hasMethod(funcs, name, "Segments"):
+			pkg.IDs[name] = parseResourceID(name, s, funcs)
+		case strings.HasSuffix(name, "Client") && len(s.Fields.List) == 1:
+			pkg.ClientName = name
+		default:
+			pkg.Models[name] = parseModel(name, s, structs, pkg.Constants)
+		}
+	}
+	if pkg.ClientName == "" {
+		return nil, fmt.Errorf("the client wasn't found within %q", importPath)
+	}
+
+	for _, fn := range funcs {
+		if op, ok := parseOperation(fn, pkg.ClientName, structs); ok {
+			pkg.Operations[op.Name] = op
+		}
+	}
+	for name, op := range pkg.Operations {
+		if _, ok := pkg.Operations[name+"ThenPoll"]; ok {
+			op.LongRunning = true
+			pkg.Operations[name] = op
+		}
+	}
+
+	return pkg, nil
+}
+
+func parseConstants(decl *ast.GenDecl, constants map[string][]string) {
+	for _, spec := range decl.Specs {
+		valueSpec, ok := spec.(*ast.ValueSpec)
+		if !ok || valueSpec.Type == nil {
+			continue
+		}
+		typeName, ok := valueSpec.Type.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		values, ok := constants[typeName.Name]
+		if !ok {
+			continue
+		}
+		for _, value := range valueSpec.Values {
+			if lit, ok := value.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				if v, err := strconv.Unquote(lit.Value); err == nil {
+					values = append(values, v)
+				}
+			}
+		}
+		constants[typeName.Name] = values
+	}
+}
+
+func parseModel(name string, s *ast.StructType, structs map[string]*ast.StructType, constants map[string][]string) sdkModel {
+	model := sdkModel{
+		Name:   name,
+		Fields: make([]sdkField, 0),
+	}
+	for _, field := range s.Fields.List {
+		jsonName := ""
+		if field.Tag != nil {
+			if tag, err := strconv.Unquote(field.Tag.Value); err == nil {
+				jsonName = strings.Split(reflect.StructTag(tag).Get("json"), ",")[0]
+			}
+		}
+		for _, fieldName := range field.Names {
+			model.Fields = append(model.Fields, sdkField{
+				Name:     fieldName.Name,
+				JSONName: jsonName,
+				Type:     parseType(field.Type, structs, constants),
+			})
+		}
+	}
+	return model
+}
+
+func parseType(expr ast.Expr, structs map[string]*ast.StructType, constants map[string][]string) sdkType {
+	switch v := expr.(type) {
+	case *ast.StarExpr:
+		out := parseType(v.X, structs, constants)
+		out.Pointer = true
+		return out
+	case *ast.Ident:
+		switch v.Name {
+		case "string", "bool", "int64", "float64":
+			return sdkType{Kind: kindBasic, Name: v.Name}
+		}
+		if _, ok := constants[v.Name]; ok {
+			return sdkType{Kind: kindEnum, Name: v.Name}
+		}
+		if _, ok := structs[v.Name]; ok {
+			return sdkType{Kind: kindModel, Name: v.Name}
+		}
+	case *ast.ArrayType:
+		elem := parseType(v.Elt, structs, constants)
+		return sdkType{Kind: kindList, Elem: &elem}
+	case *ast.MapType:
+		if key, ok := v.Key.(*ast.Ident); ok && key.Name == "string" {
+			elem := parseType(v.Value, structs, constants)
+			return sdkType{Kind: kindMap, Elem: &elem}
+		}
+	case *ast.SelectorExpr:
+		if pkg, ok := v.X.(*ast.Ident); ok {
+			return sdkType{Kind: kindExternal, Package: pkg.Name, Name: v.Sel.Name}
+		}
+	}
+	return sdkType{Kind: kindUnsupported}
+}
+
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) != 1 {
+		return ""
+	}
+	switch v := fn.Recv.List[0].Type.(type) {
+	case *ast.Ident:
+		return v.Name
+	case *ast.StarExpr:
+		if ident, ok := v.X.(*ast.Ident); ok {
+			return ident.Name
+		}
+	}
+	return ""
+}
+
+func hasMethod(funcs []*ast.FuncDecl, typeName, method string) bool {
+	for _, fn := range funcs {
+		if fn.Name.Name == method && receiverName(fn) == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+func parseResourceID(name string, s *ast.StructType, funcs []*ast.FuncDecl) sdkResourceID {
+	id := sdkResourceID{
+		Name: name,
+	}
+	for _, field := range s.Fields.List {
+		for _, fieldName := range field.Names {
+			id.Fields = append(id.Fields, fieldName.Name)
+		}
+	}
+
+	for _, fn := range funcs {
+		if fn.Name.Name != "Segments" || receiverName(fn) != name {
+			continue
+		}
+		ast.Inspect(fn.Body, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			selector, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || !strings.HasSuffix(selector.Sel.Name, "Segment") || len(call.Args) < 2 {
+				return true
+			}
+			segment := sdkSegment{
+				Kind: strings.TrimSuffix(selector.Sel.Name, "Segment"),
+			}
+			if lit, ok := call.Args[0].(*ast.BasicLit); ok {
+				segment.Name, _ = strconv.Unquote(lit.Value)
+			}
+			if lit, ok := call.Args[len(call.Args)-1].(*ast.BasicLit); ok {
+				segment.Example, _ = strconv.Unquote(lit.Value)
+			}
+			id.Segments = append(id.Segments, segment)
+			return false
+		})
+	}
+	return id
+}
+
+// parseOperation parses a method on the client like:
+// func (c LoadTestsClient) CreateOrUpdate(ctx context.Context, id LoadTestId, input LoadTestResource) (result CreateOrUpdateOperationResponse, err error)
+func parseOperation(fn *ast.FuncDecl, clientName string, structs map[string]*ast.StructType) (op sdkOperation, ok bool) {
+	if receiverName(fn) != clientName || !fn.Name.IsExported() {
+		return op, false
+	}
+
+	op.Name = fn.Name.Name
+	params := make([]ast.Expr, 0)
+	for _, param := range fn.Type.Params.List {
+		for range param.Names {
+			params = append(params, param.Type)
+		}
+	}
+	if len(params) < 2 {
+		return op, false
+	}
+	switch v := params[1].(type) {
+	case *ast.Ident:
+		op.IDType = v.Name
+	case *ast.SelectorExpr:
+		op.IDType = fmt.Sprintf("%s.%s", v.X.(*ast.Ident).Name, v.Sel.Name)
+	}
+	for _, param := range params[2:] {
+		v, ok := param.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		if v.Name == strings.TrimSuffix(op.Name, "ThenPoll")+"OperationOptions" {
+			op.Options = true
+		} else if op.InputType == "" {
+			op.InputType = v.Name
+		}
+	}
+
+	if results := fn.Type.Results; results != nil && len(results.List) > 0 {
+		if v, ok := results.List[0].Type.(*ast.Ident); ok {
+			if response, ok := structs[v.Name]; ok {
+				for _, field := range response.Fields.List {
+					if len(field.Names) == 1 && field.Names[0].Name == "Model" {
+						if star, ok := field.Type.(*ast.StarExpr); ok {
+							if ident, ok := star.X.(*ast.Ident); ok {
+								op.ResponseModel = ident.Name
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return op, true
+}
@@ -0,0 +1,315 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// acceptanceTestFile returns the contents of the file containing the acceptance tests for the Resource
+func (def *resourceDefinition) acceptanceTestFile() ([]byte, error) {
+	getArgs := "*id"
+	if def.Get.Options {
+		getArgs += fmt.Sprintf(", %s.DefaultGetOperationOptions()", def.SDK.Name)
+	}
+
+	code := fmt.Sprintf(`// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package %[1]s_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	%[2]q
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type %[3]sResource struct{}
+
+func TestAcc%[3]s_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, %[4]q, "test")
+	r := %[3]sResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAcc%[3]s_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, %[4]q, "test")
+	r := %[3]sResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAcc%[3]s_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, %[4]q, "test")
+	r := %[3]sResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAcc%[3]s_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, %[4]q, "test")
+	r := %[3]sResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r %[3]sResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := %[5]s(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.%[6]s.Get(ctx, %[7]s)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %%s: %%+v", *id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r %[3]sResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`+"`"+`
+%%[1]s
+
+%[8]s
+`+"`"+`, r.template(data), data.RandomInteger, data.Locations.Primary)
+}
+
+func (r %[3]sResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`+"`"+`
+%%s
+
+%[9]s
+`+"`"+`, r.basic(data))
+}
+
+func (r %[3]sResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`+"`"+`
+%%[1]s
+
+%[10]s
+`+"`"+`, r.template(data), data.RandomInteger, data.Locations.Primary)
+}
+
+func (r %[3]sResource) template(data acceptance.TestData) string {
+	%[11]s
+}
+`, def.ServicePkg, def.SDK.ImportPath, def.TypeName, def.ResourceType, def.ParseFunc(), def.Client, getArgs,
+		def.hclResource("test", false), def.hclRequiresImport(), def.hclResource("test", true), def.templateReturn())
+
+	out, err := format.Source([]byte(code))
+	if err != nil {
+		return nil, fmt.Errorf("formatting the generated code: %+v\n\n%s", err, code)
+	}
+	return out, nil
+}
+
+// templateReturn returns the return statement of the `template` function within the acceptance tests
+func (def *resourceDefinition) templateReturn() string {
+	if def.hasIDProperty("resource_group_name") {
+		return fmt.Sprintf("return fmt.Sprintf(`\n%s\n`, data.RandomInteger, data.Locations.Primary)", def.hclTemplate())
+	}
+	return fmt.Sprintf("return `\n%s\n`", def.hclTemplate())
+}
+
+// hclTemplate returns the configuration of the dependencies of the Resource, the arguments to the format string
+// are the random integer and the location
+func (def *resourceDefinition) hclTemplate() string {
+	out := `provider "azurerm" {
+  features {}
+}`
+	if def.hasIDProperty("resource_group_name") {
+		out += `
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = %[2]q
+}`
+	}
+	if def.Identity != nil && !strings.Contains(def.Identity.Schema, "SystemAssigned") && def.hasIDProperty("resource_group_name") {
+		out += `
+
+resource "azurerm_user_assigned_identity" "test" {
+  name                = "acctestuai-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}`
+	}
+	return out
+}
+
+func (def *resourceDefinition) hasIDProperty(name string) bool {
+	for _, v := range def.idProperties() {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hclResource returns the configuration of the Resource, the random integer and location are the second and third
+// arguments to the format string - when complete is set all the arguments are specified, otherwise only those which are Required
+func (def *resourceDefinition) hclResource(name string, complete bool) string {
+	lines := make([]string, 0)
+	for _, prop := range def.schemaIDProperties() {
+		if prop == "resource_group_name" {
+			lines = append(lines, "resource_group_name = azurerm_resource_group.test.name")
+			continue
+		}
+		lines = append(lines, fmt.Sprintf(`%s = "acctest-%%[2]d"`, prop))
+	}
+	if def.HasLocation {
+		if def.hasIDProperty("resource_group_name") {
+			lines = append(lines, "location = azurerm_resource_group.test.location")
+		} else {
+			lines = append(lines, "location = %[3]q")
+		}
+	}
+	for _, p := range def.Properties {
+		if !p.Computed && (p.Required || complete) {
+			lines = append(lines, def.hclProperty(p, complete))
+		}
+	}
+	if def.Identity != nil && complete {
+		if strings.Contains(def.Identity.Schema, "SystemAssigned") {
+			lines = append(lines, "identity {\ntype = \"SystemAssigned\"\n}")
+		} else {
+			lines = append(lines, "identity {\ntype = \"UserAssigned\"\nidentity_ids = [azurerm_user_assigned_identity.test.id]\n}")
+		}
+	}
+	if def.HasTags && complete {
+		lines = append(lines, "tags = {\nENV = \"Test\"\n}")
+	}
+
+	return formatHCL(fmt.Sprintf("resource %q %q {\n%s\n}", def.ResourceType, name, joinStatements(lines)))
+}
+
+func (def *resourceDefinition) hclRequiresImport() string {
+	lines := make([]string, 0)
+	reference := func(prop string) string {
+		return fmt.Sprintf("%s = %s.test.%s", prop, def.ResourceType, prop)
+	}
+	for _, prop := range def.schemaIDProperties() {
+		lines = append(lines, reference(prop))
+	}
+	if def.HasLocation {
+		lines = append(lines, reference("location"))
+	}
+	for _, p := range def.Properties {
+		if p.Computed || !p.Required {
+			continue
+		}
+		if p.Block != nil {
+			// blocks can't be referenced, so these are copied from the basic configuration
+			lines = append(lines, def.hclProperty(p, false))
+		} else {
+			lines = append(lines, reference(p.Name))
+		}
+	}
+
+	return formatHCL(fmt.Sprintf("resource %q \"import\" {\n%s\n}", def.ResourceType, joinStatements(lines)))
+}
+
+func (def *resourceDefinition) hclProperty(p property, complete bool) string {
+	if p.Block == nil {
+		return fmt.Sprintf("%s = %s", p.Name, def.hclValue(p.Type))
+	}
+
+	lines := make([]string, 0)
+	for _, np := range p.Block.Properties {
+		if np.Required || complete {
+			lines = append(lines, def.hclProperty(np, complete))
+		}
+	}
+	return fmt.Sprintf("%s {\n%s\n}", p.Name, strings.Join(lines, "\n"))
+}
+
+// hclValue returns an example value for the type, these are placeholders which may need to be updated to
+// values accepted by the API
+func (def *resourceDefinition) hclValue(t sdkType) string {
+	switch t.Kind {
+	case kindBasic:
+		switch t.Name {
+		case "bool":
+			return "true"
+		case "int64":
+			return "1"
+		case "float64":
+			return "1.5"
+		}
+		return strconv.Quote("example")
+	case kindEnum:
+		if values := def.SDK.Constants[t.Name]; len(values) > 0 {
+			return strconv.Quote(values[0])
+		}
+	case kindList:
+		return fmt.Sprintf("[%s]", def.hclValue(*t.Elem))
+	case kindMap:
+		return "{\nkey = \"value\"\n}"
+	}
+	return `""`
+}
+
+func formatHCL(input string) string {
+	return strings.TrimSpace(string(hclwrite.Format([]byte(input))))
+}
@@ -0,0 +1,720 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+// resourceFile returns the contents of the file containing the Resource
+func (def *resourceDefinition) resourceFile() ([]byte, error) {
+	body := strings.Join([]string{
+		def.resourceStructCode(),
+		def.modelCode(),
+		def.schemaCode(),
+		def.createCode(),
+		def.readCode(),
+		def.updateCode(),
+		def.deleteCode(),
+		def.expandFlattenCode(),
+	}, "\n")
+
+	imports := []string{`"context"`, `"fmt"`, `"time"`, ""}
+	optional := map[string]string{
+		"commonschema.": `"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"`,
+		"identity.":     `"github.com/hashicorp/go-azure-helpers/resourcemanager/identity"`,
+		"location.":     `"github.com/hashicorp/go-azure-helpers/resourcemanager/location"`,
+		"pointer.":      `"github.com/hashicorp/go-azure-helpers/lang/pointer"`,
+		"response.":     `"github.com/hashicorp/go-azure-helpers/lang/response"`,
+		"validation.":   `"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"`,
+	}
+	thirdParty := []string{
+		fmt.Sprintf("%q", def.SDK.ImportPath),
+		`"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"`,
+		`"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"`,
+	}
+	for prefix, importPath := range optional {
+		if strings.Contains(body, prefix) {
+			thirdParty = append(thirdParty, importPath)
+		}
+	}
+	sort.Strings(thirdParty)
+	imports = append(imports, thirdParty...)
+
+	code := fmt.Sprintf(`// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package %[1]s
+
+import (
+%[2]s
+)
+
+%[3]s
+`, def.ServicePkg, strings.Join(imports, "\n"), body)
+
+	out, err := format.Source([]byte(code))
+	if err != nil {
+		return nil, fmt.Errorf("formatting the generated code: %+v\n\n%s", err, code)
+	}
+	return out, nil
+}
+
+func (def *resourceDefinition) resourceStructCode() string {
+	return fmt.Sprintf(`var _ sdk.ResourceWithUpdate = %[1]sResource{}
+
+type %[1]sResource struct{}
+
+func (r %[1]sResource) ResourceType() string {
+	return %[2]q
+}
+
+func (r %[1]sResource) ModelObject() interface{} {
+	return &%[1]sModel{}
+}
+
+func (r %[1]sResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return %[3]s
+}
+`, def.TypeName, def.ResourceType, def.ValidateFunc())
+}
+
+func (def *resourceDefinition) modelCode() string {
+	fields := make([]string, 0)
+	for _, name := range def.schemaIDProperties() {
+		fields = append(fields, fmt.Sprintf("%s string `tfschema:%q`", snakeToCamel(name), name))
+	}
+	if def.HasLocation {
+		fields = append(fields, "Location string `tfschema:\"location\"`")
+	}
+	for _, p := range def.Properties {
+		fields = append(fields, fmt.Sprintf("%s %s `tfschema:%q`", p.FieldName, goType(p), p.Name))
+	}
+	if def.Identity != nil {
+		fields = append(fields, fmt.Sprintf("Identity []identity.%s `tfschema:\"identity\"`", def.Identity.Model))
+	}
+	if def.HasTags {
+		fields = append(fields, "Tags map[string]string `tfschema:\"tags\"`")
+	}
+
+	out := fmt.Sprintf("type %sModel struct {\n%s\n}\n", def.TypeName, strings.Join(fields, "\n"))
+	for _, b := range def.blocks {
+		fields = make([]string, 0)
+		for _, p := range b.Properties {
+			fields = append(fields, fmt.Sprintf("%s %s `tfschema:%q`", p.FieldName, goType(p), p.Name))
+		}
+		out += fmt.Sprintf("\ntype %s struct {\n%s\n}\n", b.ModelName, strings.Join(fields, "\n"))
+	}
+	return out
+}
+
+func goType(p property) string {
+	switch p.Type.Kind {
+	case kindBasic:
+		return p.Type.Name
+	case kindList:
+		if p.Block != nil {
+			return "[]" + p.Block.ModelName
+		}
+		return "[]string"
+	case kindMap:
+		return "map[string]string"
+	case kindModel:
+		return "[]" + p.Block.ModelName
+	}
+	return "string"
+}
+
+func (def *resourceDefinition) schemaCode() string {
+	arguments := make([]string, 0)
+	for _, name := range def.schemaIDProperties() {
+		switch name {
+		case "resource_group_name":
+			arguments = append(arguments, `"resource_group_name": commonschema.ResourceGroupName(),`)
+		default:
+			arguments = append(arguments, fmt.Sprintf(`%q: {
+	Type:         pluginsdk.TypeString,
+	Required:     true,
+	ForceNew:     true,
+	ValidateFunc: validation.StringIsNotEmpty,
+},`, name))
+		}
+	}
+	if def.HasLocation {
+		arguments = append(arguments, `"location": commonschema.Location(),`)
+	}
+	attributes := make([]string, 0)
+	for _, p := range def.Properties {
+		if p.Computed {
+			attributes = append(attributes, def.schemaFor(p, true))
+		} else {
+			arguments = append(arguments, def.schemaFor(p, false))
+		}
+	}
+	if def.Identity != nil {
+		arguments = append(arguments, fmt.Sprintf(`"identity": commonschema.%s(),`, def.Identity.Schema))
+	}
+	if def.HasTags {
+		arguments = append(arguments, `"tags": commonschema.Tags(),`)
+	}
+
+	return fmt.Sprintf(`func (r %[1]sResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+%[2]s
+	}
+}
+
+func (r %[1]sResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+%[3]s
+	}
+}
+`, def.TypeName, strings.Join(arguments, "\n\n"), strings.Join(attributes, "\n\n"))
+}
+
+// schemaFor returns the schema for the property, the nested properties within a Computed block are also Computed
+func (def *resourceDefinition) schemaFor(p property, computed bool) string {
+	lines := make([]string, 0)
+	validate := func(t sdkType) string {
+		if computed {
+			return ""
+		}
+		switch {
+		case t.Kind == kindEnum:
+			return fmt.Sprintf("validation.StringInSlice(%s.PossibleValuesFor%s(), false)", def.SDK.Name, t.Name)
+		case t.Kind == kindBasic && t.Name == "string":
+			return "validation.StringIsNotEmpty"
+		}
+		return ""
+	}
+
+	switch p.Type.Kind {
+	case kindBasic, kindEnum, kindList, kindMap:
+		lines = append(lines, "Type: "+schemaType(p.Type))
+	case kindModel:
+		lines = append(lines, "Type: pluginsdk.TypeList,")
+	}
+
+	switch {
+	case computed:
+		lines = append(lines, "Computed: true,")
+	case p.Required:
+		lines = append(lines, "Required: true,")
+	default:
+		lines = append(lines, "Optional: true,")
+	}
+
+	if p.Type.Kind == kindModel && !computed {
+		lines = append(lines, "MaxItems: 1,")
+	}
+	if v := validate(p.Type); v != "" && (p.Type.Kind == kindBasic || p.Type.Kind == kindEnum) {
+		lines = append(lines, fmt.Sprintf("ValidateFunc: %s,", v))
+	}
+
+	switch {
+	case p.Block != nil:
+		nested := make([]string, 0)
+		for _, np := range p.Block.Properties {
+			nested = append(nested, def.schemaFor(np, computed))
+		}
+		lines = append(lines, fmt.Sprintf("Elem: &pluginsdk.Resource{\nSchema: map[string]*pluginsdk.Schema{\n%s\n},\n},", strings.Join(nested, "\n\n")))
+	case p.Type.Kind == kindList || p.Type.Kind == kindMap:
+		elem := []string{"Type: pluginsdk.TypeString,"}
+		if v := validate(*p.Type.Elem); v != "" {
+			elem = append(elem, fmt.Sprintf("ValidateFunc: %s,", v))
+		}
+		lines = append(lines, fmt.Sprintf("Elem: &pluginsdk.Schema{\n%s\n},", strings.Join(elem, "\n")))
+	}
+
+	return fmt.Sprintf("%q: {\n%s\n},", p.Name, strings.Join(lines, "\n"))
+}
+
+func schemaType(t sdkType) string {
+	switch t.Kind {
+	case kindBasic:
+		switch t.Name {
+		case "bool":
+			return "pluginsdk.TypeBool,"
+		case "int64":
+			return "pluginsdk.TypeInt,"
+		case "float64":
+			return "pluginsdk.TypeFloat,"
+		}
+	case kindList:
+		return "pluginsdk.TypeList,"
+	case kindMap:
+		return "pluginsdk.TypeMap,"
+	}
+	return "pluginsdk.TypeString,"
+}
+
+// call returns the call to the operation, using the `ThenPoll` variant for long-running operations, and whether
+// the call only returns an error
+func (def *resourceDefinition) call(op sdkOperation, args ...string) (string, bool) {
+	name := op.Name
+	if op.LongRunning {
+		name += "ThenPoll"
+		if v, ok := def.SDK.Operations[name]; ok {
+			op.Options = v.Options
+		}
+	}
+	if op.Options {
+		args = append(args, fmt.Sprintf("%s.Default%sOperationOptions()", def.SDK.Name, op.Name))
+	}
+	return fmt.Sprintf("client.%s(ctx, %s)", name, strings.Join(args, ", ")), op.LongRunning
+}
+
+func (def *resourceDefinition) ifCall(op sdkOperation, args ...string) string {
+	call, errorOnly := def.call(op, args...)
+	if errorOnly {
+		return fmt.Sprintf("if err := %s; err != nil {", call)
+	}
+	return fmt.Sprintf("if _, err := %s; err != nil {", call)
+}
+
+// newIDArgs returns the arguments for the function building the Resource ID from the model
+func (def *resourceDefinition) newIDArgs() string {
+	args := make([]string, 0)
+	names := def.idProperties()
+	for _, field := range def.ID.Fields {
+		if field == "SubscriptionId" {
+			args = append(args, "subscriptionId")
+			continue
+		}
+		args = append(args, "config."+snakeToCamel(names[0]))
+		names = names[1:]
+	}
+	return strings.Join(args, ", ")
+}
+
+// fieldPath returns the path to the field for the property within the SDK model
+func (def *resourceDefinition) fieldPath(model string, p property) string {
+	if p.InProperties {
+		return fmt.Sprintf("%s.%s.%s", model, def.PropertiesField.Name, p.FieldName)
+	}
+	return fmt.Sprintf("%s.%s", model, p.FieldName)
+}
+
+func (def *resourceDefinition) createCode() string {
+	payload := make([]string, 0)
+	if def.HasLocation {
+		if def.LocationIsPointer {
+			payload = append(payload, "Location: pointer.To(location.Normalize(config.Location)),")
+		} else {
+			payload = append(payload, "Location: location.Normalize(config.Location),")
+		}
+	}
+	if def.HasTags {
+		if def.TagsIsPointer {
+			payload = append(payload, "Tags: pointer.To(config.Tags),")
+		} else {
+			payload = append(payload, "Tags: config.Tags,")
+		}
+	}
+	if def.PropertiesField != nil && def.PropertiesField.Type.Pointer {
+		payload = append(payload, fmt.Sprintf("%s: &%s.%s{},", def.PropertiesField.Name, def.SDK.Name, def.PropertiesModelName))
+	}
+
+	expand := make([]string, 0)
+	if def.Identity != nil {
+		expand = append(expand, def.expandIdentityCode())
+	}
+	for _, p := range def.Properties {
+		if p.Computed {
+			continue
+		}
+		expand = append(expand, def.expandCode(p, "config."+p.FieldName, def.fieldPath("payload", p), !p.Required, false))
+	}
+
+	getCall, _ := def.call(def.Get, "id")
+	return fmt.Sprintf(`func (r %[1]sResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.%[2]s
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var config %[1]sModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %%+v", err)
+			}
+
+			id := %[3]s(%[4]s)
+
+			existing, err := %[5]s
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for the presence of an existing %%s: %%+v", id, err)
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			payload := %[6]s.%[7]s{
+%[8]s
+			}
+
+%[9]s
+
+			%[10]s
+				return fmt.Errorf("creating %%s: %%+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+`, def.TypeName, def.Client, def.NewIDFunc(), def.newIDArgs(), getCall, def.SDK.Name, def.Model.Name,
+		strings.Join(payload, "\n"), joinStatements(expand), def.ifCall(def.Create, "id", "payload"))
+}
+
+func (def *resourceDefinition) expandIdentityCode() string {
+	assign := "identityValue"
+	if !def.IdentityIsPointer {
+		assign = "pointer.From(identityValue)"
+	}
+	return fmt.Sprintf(`identityValue, err := identity.%s(config.Identity)
+if err != nil {
+	return fmt.Errorf("expanding `+"`identity`"+`: %%+v", err)
+}
+payload.Identity = %s`, def.Identity.Expand, assign)
+}
+
+func (def *resourceDefinition) readCode() string {
+	state := make([]string, 0)
+	names := def.idProperties()
+	for _, field := range def.ID.Fields {
+		if field == "SubscriptionId" {
+			continue
+		}
+		state = append(state, fmt.Sprintf("%s: id.%s,", snakeToCamel(names[0]), field))
+		names = names[1:]
+	}
+
+	flatten := make([]string, 0)
+	if def.HasLocation {
+		if def.LocationIsPointer {
+			flatten = append(flatten, "state.Location = location.NormalizeNilable(model.Location)")
+		} else {
+			flatten = append(flatten, "state.Location = location.Normalize(model.Location)")
+		}
+	}
+	if def.HasTags {
+		if def.TagsIsPointer {
+			flatten = append(flatten, "state.Tags = pointer.From(model.Tags)")
+		} else {
+			flatten = append(flatten, "state.Tags = model.Tags")
+		}
+	}
+	if def.Identity != nil {
+		flatten = append(flatten, def.flattenIdentityCode())
+	}
+
+	properties := make([]string, 0)
+	for _, p := range def.Properties {
+		if p.InProperties {
+			properties = append(properties, def.flattenCode(p, "props."+p.FieldName, "state."+p.FieldName))
+		} else {
+			flatten = append(flatten, def.flattenCode(p, "model."+p.FieldName, "state."+p.FieldName))
+		}
+	}
+	if len(properties) > 0 {
+		if def.PropertiesField.Type.Pointer {
+			flatten = append(flatten, fmt.Sprintf("if props := model.%s; props != nil {\n%s\n}", def.PropertiesField.Name, joinStatements(properties)))
+		} else {
+			flatten = append(flatten, fmt.Sprintf("props := model.%s\n%s", def.PropertiesField.Name, joinStatements(properties)))
+		}
+	}
+
+	getCall, _ := def.call(def.Get, "*id")
+	return fmt.Sprintf(`func (r %[1]sResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.%[2]s
+
+			id, err := %[3]s(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := %[4]s
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(*id)
+				}
+				return fmt.Errorf("retrieving %%s: %%+v", *id, err)
+			}
+
+			state := %[1]sModel{
+%[5]s
+			}
+
+			if model := resp.Model; model != nil {
+%[6]s
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+`, def.TypeName, def.Client, def.ParseFunc(), getCall, strings.Join(state, "\n"), joinStatements(flatten))
+}
+
+func (def *resourceDefinition) flattenIdentityCode() string {
+	input := "model.Identity"
+	if !def.IdentityIsPointer {
+		input = "&model.Identity"
+	}
+	value := "identityValue"
+	if def.Identity.FlattenReturnsPointer {
+		value = "pointer.From(identityValue)"
+	}
+	if !def.Identity.FlattenReturnsError {
+		return fmt.Sprintf("state.Identity = identity.%s(%s)", def.Identity.Flatten, input)
+	}
+	return fmt.Sprintf(`identityValue, err := identity.%s(%s)
+if err != nil {
+	return fmt.Errorf("flattening `+"`identity`"+`: %%+v", err)
+}
+state.Identity = %s`, def.Identity.Flatten, input, value)
+}
+
+func (def *resourceDefinition) updateCode() string {
+	changes := make([]string, 0)
+	if def.PropertiesField != nil && def.PropertiesField.Type.Pointer {
+		changes = append(changes, fmt.Sprintf("if payload.%[1]s == nil {\npayload.%[1]s = &%[2]s.%[3]s{}\n}", def.PropertiesField.Name, def.SDK.Name, def.PropertiesModelName))
+	}
+	if def.Identity != nil {
+		changes = append(changes, fmt.Sprintf("if metadata.ResourceData.HasChange(\"identity\") {\n%s\n}", def.expandIdentityCode()))
+	}
+	for _, p := range def.Properties {
+		if p.Computed {
+			continue
+		}
+		changes = append(changes, fmt.Sprintf("if metadata.ResourceData.HasChange(%q) {\n%s\n}", p.Name, def.expandCode(p, "config."+p.FieldName, def.fieldPath("payload", p), false, true)))
+	}
+	if def.HasTags {
+		tags := "config.Tags"
+		if def.TagsIsPointer {
+			tags = "pointer.To(config.Tags)"
+		}
+		changes = append(changes, fmt.Sprintf("if metadata.ResourceData.HasChange(\"tags\") {\npayload.Tags = %s\n}", tags))
+	}
+
+	getCall, _ := def.call(def.Get, "*id")
+	return fmt.Sprintf(`func (r %[1]sResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.%[2]s
+
+			id, err := %[3]s(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config %[1]sModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %%+v", err)
+			}
+
+			existing, err := %[4]s
+			if err != nil {
+				return fmt.Errorf("retrieving %%s: %%+v", *id, err)
+			}
+			if existing.Model == nil {
+				return fmt.Errorf("retrieving %%s: `+"`model`"+` was nil", *id)
+			}
+			payload := *existing.Model
+
+%[5]s
+
+			%[6]s
+				return fmt.Errorf("updating %%s: %%+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+`, def.TypeName, def.Client, def.ParseFunc(), getCall, joinStatements(changes), def.ifCall(def.Create, "*id", "payload"))
+}
+
+func (def *resourceDefinition) deleteCode() string {
+	return fmt.Sprintf(`func (r %[1]sResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.%[2]s
+
+			id, err := %[3]s(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			%[4]s
+				return fmt.Errorf("deleting %%s: %%+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+`, def.TypeName, def.Client, def.ParseFunc(), def.ifCall(def.Delete, "*id"))
+}
+
+// expandCode returns the statements to set dst within the SDK model from src within the schema model, when
+// omitEmpty is set empty strings aren't sent to the API and when update is set blocks which have been removed are
+// unset
+func (def *resourceDefinition) expandCode(p property, src, dst string, omitEmpty, update bool) string {
+	t := p.Type
+	wrap := func(value string) string {
+		if t.Pointer {
+			return fmt.Sprintf("pointer.To(%s)", value)
+		}
+		return value
+	}
+
+	switch t.Kind {
+	case kindBasic, kindEnum:
+		value := src
+		if t.Kind == kindEnum {
+			value = fmt.Sprintf("%s.%s(%s)", def.SDK.Name, t.Name, src)
+		}
+		if t.Pointer && omitEmpty && (t.Kind == kindEnum || t.Name == "string") {
+			return fmt.Sprintf("if %s != \"\" {\n%s = %s\n}", src, dst, wrap(value))
+		}
+		return fmt.Sprintf("%s = %s", dst, wrap(value))
+	case kindMap:
+		return fmt.Sprintf("%s = %s", dst, wrap(src))
+	case kindList:
+		switch {
+		case p.Block != nil:
+			return fmt.Sprintf("%s = %s", dst, wrap(fmt.Sprintf("expand%sList(%s)", p.Block.Name, src)))
+		case t.Elem.Kind == kindEnum:
+			return fmt.Sprintf("%s = %s", dst, wrap(fmt.Sprintf("expand%sList(%s)", def.prefixed(t.Elem.Name), src)))
+		}
+		return fmt.Sprintf("%s = %s", dst, wrap(src))
+	case kindModel:
+		out := fmt.Sprintf("if len(%s) > 0 {\n%s = %s\n}", src, dst, wrap(fmt.Sprintf("expand%s(%s[0])", p.Block.Name, src)))
+		if update && t.Pointer {
+			out = fmt.Sprintf("%s = nil\n%s", dst, out)
+		}
+		return out
+	}
+	return ""
+}
+
+// flattenCode returns the statements to set dst within the schema model from src within the SDK model
+func (def *resourceDefinition) flattenCode(p property, src, dst string) string {
+	t := p.Type
+	unwrap := func(value string) string {
+		if t.Pointer {
+			return fmt.Sprintf("pointer.From(%s)", value)
+		}
+		return value
+	}
+
+	switch t.Kind {
+	case kindBasic, kindMap:
+		return fmt.Sprintf("%s = %s", dst, unwrap(src))
+	case kindEnum:
+		return fmt.Sprintf("%s = string(%s)", dst, unwrap(src))
+	case kindList:
+		switch {
+		case p.Block != nil:
+			return fmt.Sprintf("%s = flatten%sList(%s)", dst, p.Block.Name, unwrap(src))
+		case t.Elem.Kind == kindEnum:
+			return fmt.Sprintf("%s = flatten%sList(%s)", dst, def.prefixed(t.Elem.Name), unwrap(src))
+		}
+		return fmt.Sprintf("%s = %s", dst, unwrap(src))
+	case kindModel:
+		if t.Pointer {
+			return fmt.Sprintf("if %s != nil {\n%s = []%s{flatten%s(*%s)}\n}", src, dst, p.Block.ModelName, p.Block.Name, src)
+		}
+		return fmt.Sprintf("%s = []%s{flatten%s(%s)}", dst, p.Block.ModelName, p.Block.Name, src)
+	}
+	return ""
+}
+
+func (def *resourceDefinition) expandFlattenCode() string {
+	funcs := make([]string, 0)
+	for _, b := range def.blocks {
+		expand := make([]string, 0)
+		flatten := make([]string, 0)
+		for _, p := range b.Properties {
+			expand = append(expand, def.expandCode(p, "input."+p.FieldName, "output."+p.FieldName, !p.Required, false))
+			flatten = append(flatten, def.flattenCode(p, "input."+p.FieldName, "output."+p.FieldName))
+		}
+
+		funcs = append(funcs, fmt.Sprintf(`func expand%[1]s(input %[2]s) %[3]s.%[4]s {
+	output := %[3]s.%[4]s{}
+%[5]s
+	return output
+}
+
+func flatten%[1]s(input %[3]s.%[4]s) %[2]s {
+	output := %[2]s{}
+%[6]s
+	return output
+}
+`, b.Name, b.ModelName, def.SDK.Name, b.SDKModel, joinStatements(expand), joinStatements(flatten)))
+
+		if b.UsedAsList {
+			funcs = append(funcs, listFuncs(b.Name, b.ModelName, fmt.Sprintf("%s.%s", def.SDK.Name, b.SDKModel),
+				fmt.Sprintf("expand%s(v)", b.Name), fmt.Sprintf("flatten%s(v)", b.Name)))
+		}
+	}
+
+	enums := make([]string, 0, len(def.enums))
+	for name := range def.enums {
+		enums = append(enums, name)
+	}
+	sort.Strings(enums)
+	for _, name := range enums {
+		funcs = append(funcs, listFuncs(def.prefixed(name), "string", fmt.Sprintf("%s.%s", def.SDK.Name, name),
+			fmt.Sprintf("%s.%s(v)", def.SDK.Name, name), "string(v)"))
+	}
+
+	return strings.Join(funcs, "\n")
+}
+
+// listFuncs returns the functions to expand and flatten a list of items
+func listFuncs(name, schemaType, sdkType, expandItem, flattenItem string) string {
+	return fmt.Sprintf(`func expand%[1]sList(input []%[2]s) []%[3]s {
+	output := make([]%[3]s, 0)
+	for _, v := range input {
+		output = append(output, %[4]s)
+	}
+	return output
+}
+
+func flatten%[1]sList(input []%[3]s) []%[2]s {
+	output := make([]%[2]s, 0)
+	for _, v := range input {
+		output = append(output, %[5]s)
+	}
+	return output
+}
+`, name, schemaType, sdkType, expandItem, flattenItem)
+}
+
+// joinStatements joins the statements, separating those spanning multiple lines with a blank line
+func joinStatements(statements []string) string {
+	out := ""
+	for i, statement := range statements {
+		if i > 0 {
+			out += "\n"
+			if strings.Contains(statement, "\n") || strings.Contains(statements[i-1], "\n") {
+				out += "\n"
+			}
+		}
+		out += statement
+	}
+	return out
+}
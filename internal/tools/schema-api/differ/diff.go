@@ -5,114 +5,152 @@ package differ
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
 	schema_rules "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/schema-rules"
 )
 
+const (
+	ruleRemovedResource   = "removedResource"
+	ruleRemovedDataSource = "removedDataSource"
+)
+
 type Differ struct {
 	base    *providerjson.ProviderWrapper
 	current *providerjson.ProviderWrapper
 }
 
-func (d *Differ) Diff(fileName string, providerName string) []string {
+// Diff compares the schema of the current provider against the base (released) schema within fileName,
+// returning any breaking changes between the two
+func (d *Differ) Diff(fileName string, providerName string) ([]Violation, error) {
 	if err := d.loadFromProvider(providerjson.LoadData(), providerName); err != nil {
-		return []string{err.Error()}
+		return nil, err
 	}
 
 	if err := d.loadFromFile(fileName); err != nil {
-		return []string{err.Error()}
+		return nil, err
 	}
 
 	if d.base.ProviderName != d.current.ProviderName {
-		return []string{fmt.Sprintf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)}
+		return nil, fmt.Errorf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)
 	}
 
-	violations := make([]string, 0)
+	return d.violations(), nil
+}
 
-	for resource, rs := range d.current.ProviderSchema.ResourcesMap {
-		_, ok := d.base.ProviderSchema.ResourcesMap[resource]
+func (d *Differ) violations() []Violation {
+	violations := make([]Violation, 0)
+
+	for _, resource := range sortedKeys(d.base.ProviderSchema.ResourcesMap) {
+		rs, ok := d.current.ProviderSchema.ResourcesMap[resource]
 		if !ok {
-			// New resource, no breaking changes to worry about
+			violations = append(violations, Violation{
+				Rule:    ruleRemovedResource,
+				Kind:    KindResource,
+				Name:    resource,
+				Message: fmt.Sprintf("Cannot remove the Resource %q", resource),
+			})
 			continue
 		}
-		for propertyName, propertySchema := range rs.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.ResourcesMap[resource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeResource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
-			}
+
+		base := d.base.ProviderSchema.ResourcesMap[resource]
+		for _, v := range compareSchemas(base.Schema, rs.Schema, "", schema_rules.BreakingChangeRules) {
+			v.Kind = KindResource
+			v.Name = resource
+			violations = append(violations, v)
 		}
 	}
 
-	for dataSource, ds := range d.current.ProviderSchema.DataSourcesMap {
-		_, ok := d.base.ProviderSchema.DataSourcesMap[dataSource]
+	for _, dataSource := range sortedKeys(d.base.ProviderSchema.DataSourcesMap) {
+		ds, ok := d.current.ProviderSchema.DataSourcesMap[dataSource]
 		if !ok {
-			// New data source, no breaking changes to worry about
+			violations = append(violations, Violation{
+				Rule:    ruleRemovedDataSource,
+				Kind:    KindDataSource,
+				Name:    dataSource,
+				Message: fmt.Sprintf("Cannot remove the Data Source %q", dataSource),
+			})
 			continue
 		}
-		for propertyName, propertySchema := range ds.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.DataSourcesMap[dataSource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeDataSource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
-			}
+
+		base := d.base.ProviderSchema.DataSourcesMap[dataSource]
+		for _, v := range compareSchemas(base.Schema, ds.Schema, "", schema_rules.BreakingChangeRulesDataSource) {
+			v.Kind = KindDataSource
+			v.Name = dataSource
+			violations = append(violations, v)
 		}
 	}
 
 	return violations
 }
 
-func compareNodeResource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeResource(newBase, newCurrent[k], k)...)
+// compareSchemas compares each property within the base and current schemas, including any nested blocks
+func compareSchemas(base map[string]providerjson.SchemaJSON, current map[string]providerjson.SchemaJSON, prefix string, rules []schema_rules.BreakingChangeRule) (violations []Violation) {
+	names := sortedKeys(base)
+	for name := range current {
+		if _, ok := base[name]; !ok {
+			names = append(names, name)
 		}
 	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		// a property missing from either side is compared against an empty schema, which the rules
+		// check for new/removed properties
+		baseItem := base[name]
+		currentItem := current[name]
+		path := name
+		if prefix != "" {
+			path = fmt.Sprintf("%s.%s", prefix, name)
+		}
+
+		for _, rule := range rules {
+			if message := rule.Check(baseItem, currentItem, path); message != nil {
+				violations = append(violations, Violation{
+					Rule:     rule.Name(),
+					Property: path,
+					Message:  *message,
+				})
+			}
+		}
 
-	for _, v := range schema_rules.BreakingChangeRules {
-		if err := v.Check(base, current, nodeName); err != nil {
-			errs = append(errs, *err)
+		// only compare nested properties when the property is a block on both sides, otherwise the
+		// change has been caught by the rules above
+		baseBlock, baseIsBlock := blockSchema(baseItem)
+		currentBlock, currentIsBlock := blockSchema(currentItem)
+		if baseIsBlock && currentIsBlock {
+			violations = append(violations, compareSchemas(baseBlock, currentBlock, path, rules)...)
 		}
 	}
 
 	return
 }
 
-func compareNodeDataSource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeDataSource(newBase, newCurrent[k], k)...)
-		}
+// blockSchema returns the nested schema for a property which is a block
+func blockSchema(input providerjson.SchemaJSON) (map[string]providerjson.SchemaJSON, bool) {
+	if input.Type != providerjson.SchemaTypeList && input.Type != providerjson.SchemaTypeSet {
+		return nil, false
 	}
 
-	for _, v := range schema_rules.BreakingChangeRulesDataSource {
-		if err := v.Check(base, current, nodeName); err != nil {
-			errs = append(errs, *err)
+	// the base schema is unmarshalled from JSON whereas the current schema comes from the provider
+	switch elem := input.Elem.(type) {
+	case providerjson.ResourceJSON:
+		return elem.Schema, true
+	case *providerjson.ResourceJSON:
+		if elem != nil {
+			return elem.Schema, true
 		}
 	}
 
-	return
+	return nil, false
 }
 
-func nodeIsBlock(input providerjson.SchemaJSON) bool {
-	if input.Type == providerjson.SchemaTypeList || input.Type == providerjson.SchemaTypeSet {
-		if _, ok := input.Elem.(providerjson.ResourceJSON); ok {
-			return true
-		}
+func sortedKeys[T any](input map[string]T) []string {
+	keys := make([]string, 0, len(input))
+	for k := range input {
+		keys = append(keys, k)
 	}
-
-	return false
+	sort.Strings(keys)
+	return keys
 }
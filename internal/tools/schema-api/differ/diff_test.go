@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestDiffer_Violations(t *testing.T) {
+	d := Differ{
+		base: &providerjson.ProviderWrapper{
+			ProviderSchema: &providerjson.ProviderSchemaJSON{
+				ResourcesMap: map[string]providerjson.ResourceJSON{
+					"azurerm_example": {
+						Schema: map[string]providerjson.SchemaJSON{
+							"name": {
+								Type:     "TypeString",
+								Required: true,
+							},
+							"removed": {
+								Type:     "TypeString",
+								Optional: true,
+							},
+							"block": {
+								Type:     providerjson.SchemaTypeList,
+								Optional: true,
+								Elem: providerjson.ResourceJSON{
+									Schema: map[string]providerjson.SchemaJSON{
+										"nested": {
+											Type:     "TypeString",
+											Optional: true,
+										},
+									},
+								},
+							},
+						},
+					},
+					"azurerm_removed": {},
+				},
+				DataSourcesMap: map[string]providerjson.ResourceJSON{
+					"azurerm_example": {
+						Schema: map[string]providerjson.SchemaJSON{
+							"value": {
+								Type:     "TypeString",
+								Computed: true,
+							},
+						},
+					},
+					"azurerm_removed": {},
+				},
+			},
+		},
+		current: &providerjson.ProviderWrapper{
+			ProviderSchema: &providerjson.ProviderSchemaJSON{
+				ResourcesMap: map[string]providerjson.ResourceJSON{
+					"azurerm_example": {
+						Schema: map[string]providerjson.SchemaJSON{
+							"name": {
+								Type:     "TypeString",
+								Required: true,
+								ForceNew: true,
+							},
+							"block": {
+								Type:     providerjson.SchemaTypeList,
+								Optional: true,
+								Elem: &providerjson.ResourceJSON{
+									Schema: map[string]providerjson.SchemaJSON{
+										"nested": {
+											Type:     "TypeString",
+											Optional: true,
+											ForceNew: true,
+										},
+									},
+								},
+							},
+						},
+					},
+					"azurerm_new": {},
+				},
+				DataSourcesMap: map[string]providerjson.ResourceJSON{
+					"azurerm_example": {
+						Schema: map[string]providerjson.SchemaJSON{
+							"value": {
+								Type:     "TypeString",
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := []string{
+		"resource.azurerm_example.block.nested: becomeForceNew",
+		"resource.azurerm_example.name: becomeForceNew",
+		"resource.azurerm_example.removed: propertyRemoved",
+		"resource.azurerm_removed: removedResource",
+		"dataSource.azurerm_example.value: dataSourceRemoveComputed",
+		"dataSource.azurerm_removed: removedDataSource",
+	}
+
+	violations := d.violations()
+	actual := make([]string, 0, len(violations))
+	for _, v := range violations {
+		actual = append(actual, v.Location()+": "+v.Rule)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := WriteReport(buf, OutputFormatSARIF, violations); err != nil {
+		t.Fatalf("writing SARIF report: %+v", err)
+	}
+	report := sarifReport{}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("parsing SARIF report: %+v", err)
+	}
+	if len(report.Runs) != 1 || len(report.Runs[0].Results) != len(violations) || len(report.Runs[0].Tool.Driver.Rules) != 5 {
+		t.Fatalf("unexpected SARIF report: %s", buf.String())
+	}
+
+	if err := WriteReport(buf, "xml", violations); err == nil {
+		t.Fatalf("expected an error for an unsupported output format")
+	}
+}
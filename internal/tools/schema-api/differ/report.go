@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type OutputFormat string
+
+const (
+	OutputFormatJSON  OutputFormat = "json"
+	OutputFormatSARIF OutputFormat = "sarif"
+	OutputFormatText  OutputFormat = "text"
+)
+
+func PossibleValuesForOutputFormat() []string {
+	return []string{
+		string(OutputFormatJSON),
+		string(OutputFormatSARIF),
+		string(OutputFormatText),
+	}
+}
+
+// WriteReport writes the violations to w in the specified format
+func WriteReport(w io.Writer, format OutputFormat, violations []Violation) error {
+	switch format {
+	case OutputFormatJSON:
+		return writeJSON(w, jsonReport{Violations: violations})
+
+	case OutputFormatSARIF:
+		return writeJSON(w, sarifReportFor(violations))
+
+	case OutputFormatText:
+		for _, v := range violations {
+			if _, err := fmt.Fprintln(w, v.String()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported output format %q, expected one of %v", format, PossibleValuesForOutputFormat())
+}
+
+func writeJSON(w io.Writer, input interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(input)
+}
+
+type jsonReport struct {
+	Violations []Violation `json:"violations"`
+}
+
+// the subset of the SARIF 2.1.0 format required to surface violations in code scanning tools
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+func sarifReportFor(violations []Violation) sarifReport {
+	ruleIds := make(map[string]struct{})
+	results := make([]sarifResult, 0, len(violations))
+	for _, v := range violations {
+		ruleIds[v.Rule] = struct{}{}
+
+		name := v.Name
+		kind := string(v.Kind)
+		if v.Property != "" {
+			name = v.Property
+			kind = "member"
+		}
+
+		results = append(results, sarifResult{
+			RuleID: v.Rule,
+			Level:  "error",
+			Message: sarifMessage{
+				Text: v.Message,
+			},
+			Locations: []sarifLocation{
+				{
+					LogicalLocations: []sarifLogicalLocation{
+						{
+							Name:               name,
+							FullyQualifiedName: v.Location(),
+							Kind:               kind,
+						},
+					},
+				},
+			},
+		})
+	}
+
+	rules := make([]sarifRule, 0, len(ruleIds))
+	for _, id := range sortedKeys(ruleIds) {
+		rules = append(rules, sarifRule{ID: id})
+	}
+
+	return sarifReport{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:  "schema-api",
+						Rules: rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+}
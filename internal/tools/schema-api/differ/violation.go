@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import "fmt"
+
+type Kind string
+
+const (
+	KindDataSource Kind = "dataSource"
+	KindResource   Kind = "resource"
+)
+
+// Violation describes a breaking change between the base (released) and current schema
+type Violation struct {
+	// Rule is the name of the rule which detected this breaking change
+	Rule string `json:"rule"`
+
+	// Kind specifies whether Name refers to a Data Source or a Resource
+	Kind Kind `json:"kind"`
+
+	// Name is the name of the Data Source or Resource, e.g. `azurerm_resource_group`
+	Name string `json:"name"`
+
+	// Property is the path to the property within the Data Source or Resource, e.g. `block.nested_property`
+	// this is empty when the Data Source or Resource itself has been removed
+	Property string `json:"property,omitempty"`
+
+	Message string `json:"message"`
+}
+
+// Location returns the fully qualified path to the Data Source/Resource and Property containing this violation
+func (v Violation) Location() string {
+	location := fmt.Sprintf("%s.%s", v.Kind, v.Name)
+	if v.Property != "" {
+		location = fmt.Sprintf("%s.%s", location, v.Property)
+	}
+	return location
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Location(), v.Message)
+}
@@ -17,3 +17,19 @@ func (p *ProviderJSON) Resources() []terraform.ResourceType {
 	s := schema.Provider(*p)
 	return s.Resources()
 }
+
+// stringsFromRaw returns the strings within a list which has been unmarshalled from JSON
+func stringsFromRaw(input interface{}) []string {
+	raw, ok := input.([]interface{})
+	if !ok || len(raw) == 0 {
+		return nil
+	}
+
+	output := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			output = append(output, s)
+		}
+	}
+	return output
+}
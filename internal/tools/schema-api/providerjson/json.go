@@ -33,6 +33,11 @@ type SchemaJSON struct {
 	Elem        interface{} `json:"elem,omitempty"`
 	MaxItems    int         `json:"maxItems,omitempty"`
 	MinItems    int         `json:"minItems,omitempty"`
+
+	ConflictsWith []string `json:"conflictsWith,omitempty"`
+	ExactlyOneOf  []string `json:"exactlyOneOf,omitempty"`
+
+	Validation *ValidationJSON `json:"validation,omitempty"`
 }
 
 func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
@@ -52,8 +57,11 @@ func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
 		b.MaxItems = int(max)
 	}
 	if min, ok := m["minItems"].(float64); ok {
-		b.MaxItems = int(min)
+		b.MinItems = int(min)
 	}
+	b.ConflictsWith = stringsFromRaw(m["conflictsWith"])
+	b.ExactlyOneOf = stringsFromRaw(m["exactlyOneOf"])
+	b.Validation = validationFromMap(m["validation"])
 
 	if def, ok := m["default"]; ok && def != nil {
 		switch def.(type) {
@@ -71,13 +79,7 @@ func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
 	}
 
 	if e, ok := m["elem"]; ok && e != nil {
-		elem := e.(map[string]interface{})
-		if schema, ok := elem["schema"]; ok {
-			b.Elem = ResourceFromMap(schema.(map[string]interface{}))
-		}
-		if t, ok := elem["type"]; ok {
-			b.Elem = t.(string)
-		}
+		b.Elem = elemFromMap(e.(map[string]interface{}))
 	}
 
 	return nil
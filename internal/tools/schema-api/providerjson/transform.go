@@ -57,6 +57,11 @@ func schemaFromRaw(input *schema.Schema) SchemaJSON {
 		Elem:        decodeElem(input.Elem),
 		MaxItems:    input.MaxItems,
 		MinItems:    input.MinItems,
+
+		ConflictsWith: input.ConflictsWith,
+		ExactlyOneOf:  input.ExactlyOneOf,
+
+		Validation: validationFromRaw(input),
 	}
 }
 
@@ -94,12 +99,12 @@ func SchemaFromMap(input map[string]interface{}) SchemaJSON {
 		result.ForceNew = t.(bool)
 	}
 
-	if t, ok := input["forceNew"]; ok {
-		result.ForceNew = t.(bool)
-	}
-
 	if t, ok := input["elem"]; ok {
-		result.Elem = decodeElem(t)
+		if elem, ok := t.(map[string]interface{}); ok {
+			result.Elem = elemFromMap(elem)
+		} else {
+			result.Elem = decodeElem(t)
+		}
 	}
 
 	if t, ok := input["minItems"]; ok {
@@ -110,9 +115,26 @@ func SchemaFromMap(input map[string]interface{}) SchemaJSON {
 		result.MaxItems = int(t.(float64))
 	}
 
+	result.ConflictsWith = stringsFromRaw(input["conflictsWith"])
+	result.ExactlyOneOf = stringsFromRaw(input["exactlyOneOf"])
+	result.Validation = validationFromMap(input["validation"])
+
 	return result
 }
 
+// elemFromMap returns the Elem for a Schema which has been unmarshalled from JSON, which is either a Resource
+// (for a block) or the type of the values within a List/Map/Set
+func elemFromMap(input map[string]interface{}) interface{} {
+	if schema, ok := input["schema"]; ok {
+		return ResourceFromMap(schema.(map[string]interface{}))
+	}
+	if t, ok := input["type"]; ok {
+		return t.(string)
+	}
+
+	return nil
+}
+
 func ResourceFromMap(input map[string]interface{}) ResourceJSON {
 	result := ResourceJSON{
 		Schema: make(map[string]SchemaJSON, 0),
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerjson
+
+import (
+	"encoding/json"
+	"reflect"
+	"runtime"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourcegroups"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkValidation "github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+// ValidationJSON describes the values accepted by a property, as determined from its ValidateFunc
+type ValidationJSON struct {
+	AllowedValues []interface{} `json:"allowedValues,omitempty"`
+	Min           *float64      `json:"min,omitempty"`
+	Max           *float64      `json:"max,omitempty"`
+	MinLength     *int          `json:"minLength,omitempty"`
+	MaxLength     *int          `json:"maxLength,omitempty"`
+	Pattern       string        `json:"pattern,omitempty"`
+}
+
+// namedValidationFuncs contains the Metadata for named validation functions (such as those used by `commonschema`),
+// which unlike the validation functions built by `internal/tf/validation` can't describe the values they accept
+var namedValidationFuncs = map[string]validation.Metadata{
+	functionName(resourcegroups.ValidateName): {
+		MinLength: pointer.To(1),
+		MaxLength: pointer.To(90),
+		Pattern:   `^[-\w._()]+$`,
+	},
+	functionName(sdkValidation.IsPortNumber):          {Min: pointer.To(1.0), Max: pointer.To(65535.0)},
+	functionName(sdkValidation.StringIsNotEmpty):      {MinLength: pointer.To(1)},
+	functionName(sdkValidation.StringIsNotWhiteSpace): {MinLength: pointer.To(1)},
+	functionName(validation.IntPositive):              {Min: pointer.To(1.0)},
+	functionName(validation.IsPortNumber):             {Min: pointer.To(1.0), Max: pointer.To(65535.0)},
+	functionName(validation.StringIsNotEmpty):         {MinLength: pointer.To(1)},
+	functionName(validation.StringIsNotWhiteSpace):    {MinLength: pointer.To(1)},
+	functionName(validate.CIDR):                       {Pattern: validate.CIDRPattern},
+	functionName(validate.PortNumber):                 {Min: pointer.To(1.0), Max: pointer.To(65535.0)},
+	functionName(validate.PortNumberOrZero):           {Min: pointer.To(0.0), Max: pointer.To(65535.0)},
+}
+
+func validationFromRaw(input *schema.Schema) *ValidationJSON {
+	if input.ValidateFunc == nil {
+		return nil
+	}
+
+	m := validation.MetadataFor(input.ValidateFunc)
+	if m == nil {
+		named, ok := namedValidationFuncs[functionName(input.ValidateFunc)]
+		if !ok {
+			return nil
+		}
+		m = &named
+	}
+
+	return &ValidationJSON{
+		AllowedValues: m.AllowedValues,
+		Min:           m.Min,
+		Max:           m.Max,
+		MinLength:     m.MinLength,
+		MaxLength:     m.MaxLength,
+		Pattern:       m.Pattern,
+	}
+}
+
+func functionName(f schema.SchemaValidateFunc) string {
+	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+}
+
+func validationFromMap(input interface{}) *ValidationJSON {
+	if input == nil {
+		return nil
+	}
+
+	// the validation has been unmarshalled from JSON into a map, so round-trip it into the typed struct
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return nil
+	}
+	output := ValidationJSON{}
+	if err := json.Unmarshal(raw, &output); err != nil {
+		return nil
+	}
+	return &output
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerjson
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourcegroups"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkValidation "github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func TestValidationFromRaw(t *testing.T) {
+	cases := map[string]struct {
+		Schema   *schema.Schema
+		Expected *ValidationJSON
+	}{
+		"no validation": {
+			Schema: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Expected: nil,
+		},
+		"named function": {
+			Schema: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: resourcegroups.ValidateName,
+			},
+			Expected: &ValidationJSON{MinLength: pointer.To(1), MaxLength: pointer.To(90), Pattern: `^[-\w._()]+$`},
+		},
+		"string in slice": {
+			Schema: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"First", "Second Value", `"Quoted"`}, false),
+			},
+			Expected: &ValidationJSON{AllowedValues: []interface{}{"First", "Second Value", `"Quoted"`}},
+		},
+		"int in slice": {
+			Schema: &schema.Schema{
+				Type:         schema.TypeInt,
+				ValidateFunc: validation.IntInSlice([]int{1, 2, 3}),
+			},
+			Expected: &ValidationJSON{AllowedValues: []interface{}{1, 2, 3}},
+		},
+		"int at least": {
+			Schema: &schema.Schema{
+				Type:         schema.TypeInt,
+				ValidateFunc: validation.IntAtLeast(5),
+			},
+			Expected: &ValidationJSON{Min: pointer.To(5.0)},
+		},
+		"float between": {
+			Schema: &schema.Schema{
+				Type:         schema.TypeFloat,
+				ValidateFunc: validation.FloatBetween(0.5, 1.5),
+			},
+			Expected: &ValidationJSON{Min: pointer.To(0.5), Max: pointer.To(1.5)},
+		},
+		"all": {
+			Schema: &schema.Schema{
+				Type: schema.TypeString,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(3, 24),
+					validation.StringMatch(regexp.MustCompile(`^[a-z0-9]+$`), ""),
+				),
+			},
+			Expected: &ValidationJSON{MinLength: pointer.To(3), MaxLength: pointer.To(24), Pattern: `^[a-z0-9]+$`},
+		},
+		"named plugin sdk function": {
+			Schema: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: sdkValidation.StringIsNotEmpty,
+			},
+			Expected: &ValidationJSON{MinLength: pointer.To(1)},
+		},
+		"plugin sdk": {
+			Schema: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: sdkValidation.StringInSlice([]string{"First"}, false),
+			},
+			Expected: nil,
+		},
+		"custom": {
+			Schema: &schema.Schema{
+				Type: schema.TypeString,
+				ValidateFunc: func(i interface{}, _ string) ([]string, []error) {
+					_ = i.(int)
+					return nil, nil
+				},
+			},
+			Expected: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual := validationFromRaw(tc.Schema)
+			if !reflect.DeepEqual(tc.Expected, actual) {
+				t.Fatalf("expected %+v but got %+v", tc.Expected, actual)
+			}
+		})
+	}
+}
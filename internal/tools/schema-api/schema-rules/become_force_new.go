@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+type becomeForceNew struct{}
+
+var _ BreakingChangeRule = becomeForceNew{}
+
+func (becomeForceNew) Name() string {
+	return "becomeForceNew"
+}
+
+// Check - Checks that an existing property isn't updated to become ForceNew, since changing it would then recreate the resource
+func (becomeForceNew) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if !isNewProperty(base) && !isRemovedProperty(current) && !base.ForceNew && current.ForceNew {
+		return pointer.To(fmt.Sprintf("Cannot change property %q to be ForceNew", propertyName))
+	}
+
+	return nil
+}
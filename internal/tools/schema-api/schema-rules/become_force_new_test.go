@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var becomeForceNewBase = providerjson.SchemaJSON{
+	Type:     "TypeString",
+	Optional: true,
+}
+
+var becomeForceNewPasses = providerjson.SchemaJSON{
+	Type:     "TypeString",
+	Optional: true,
+}
+
+var becomeForceNewViolates = providerjson.SchemaJSON{
+	Type:     "TypeString",
+	Optional: true,
+	ForceNew: true, // violation
+}
+
+func TestBecomeForceNew_Check(t *testing.T) {
+	data := becomeForceNew{}
+	if res := data.Check(becomeForceNewBase, becomeForceNewPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(becomeForceNewBase, becomeForceNewViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+	if res := data.Check(providerjson.SchemaJSON{}, becomeForceNewViolates, ""); res != nil {
+		t.Errorf("expected no violation for a new property, got %+v", res)
+	}
+}
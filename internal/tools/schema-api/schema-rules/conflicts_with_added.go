@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+type conflictsWithAdded struct{}
+
+var _ BreakingChangeRule = conflictsWithAdded{}
+
+func (conflictsWithAdded) Name() string {
+	return "conflictsWithAdded"
+}
+
+// Check - Checks that an existing property doesn't gain new ConflictsWith entries, since user configs may specify both properties
+func (conflictsWithAdded) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if isNewProperty(base) || isRemovedProperty(current) {
+		return nil
+	}
+
+	existing := make(map[string]struct{}, len(base.ConflictsWith))
+	for _, v := range base.ConflictsWith {
+		existing[v] = struct{}{}
+	}
+	for _, v := range current.ConflictsWith {
+		if _, ok := existing[v]; !ok {
+			return pointer.To(fmt.Sprintf("Cannot add %q to the ConflictsWith for property %q", v, propertyName))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var conflictsWithAddedBase = providerjson.SchemaJSON{
+	Type:          "TypeString",
+	Optional:      true,
+	ConflictsWith: []string{"foo", "bar"},
+}
+
+var conflictsWithAddedPasses = providerjson.SchemaJSON{
+	Type:          "TypeString",
+	Optional:      true,
+	ConflictsWith: []string{"bar"},
+}
+
+var conflictsWithAddedViolates = providerjson.SchemaJSON{
+	Type:          "TypeString",
+	Optional:      true,
+	ConflictsWith: []string{"foo", "bar", "baz"}, // violation
+}
+
+func TestConflictsWithAdded_Check(t *testing.T) {
+	data := conflictsWithAdded{}
+	if res := data.Check(conflictsWithAddedBase, conflictsWithAddedPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(conflictsWithAddedBase, conflictsWithAddedViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+	if res := data.Check(providerjson.SchemaJSON{}, conflictsWithAddedViolates, ""); res != nil {
+		t.Errorf("expected no violation for a new property, got %+v", res)
+	}
+}
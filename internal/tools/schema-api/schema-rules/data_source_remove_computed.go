@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+type dataSourceRemoveComputed struct{}
+
+var _ BreakingChangeRule = dataSourceRemoveComputed{}
+
+func (dataSourceRemoveComputed) Name() string {
+	return "dataSourceRemoveComputed"
+}
+
+// Check - Checks that a Computed property within a Data Source doesn't stop being Computed, since it would no longer be exported
+func (dataSourceRemoveComputed) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if !isNewProperty(base) && !isRemovedProperty(current) && base.Computed && !current.Computed {
+		return pointer.To(fmt.Sprintf("Cannot remove Computed from the Data Source property %q", propertyName))
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var dataSourceRemoveComputedBase = providerjson.SchemaJSON{
+	Type:     "TypeString",
+	Computed: true,
+}
+
+var dataSourceRemoveComputedPasses = providerjson.SchemaJSON{
+	Type:     "TypeString",
+	Optional: true,
+	Computed: true,
+}
+
+var dataSourceRemoveComputedViolates = providerjson.SchemaJSON{
+	Type:     "TypeString",
+	Optional: true, // violation
+}
+
+func TestDataSourceRemoveComputed_Check(t *testing.T) {
+	data := dataSourceRemoveComputed{}
+	if res := data.Check(dataSourceRemoveComputedBase, dataSourceRemoveComputedPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(dataSourceRemoveComputedBase, dataSourceRemoveComputedViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+	if res := data.Check(providerjson.SchemaJSON{}, dataSourceRemoveComputedViolates, ""); res != nil {
+		t.Errorf("expected no violation for a new property, got %+v", res)
+	}
+}
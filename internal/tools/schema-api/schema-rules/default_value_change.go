@@ -15,10 +15,19 @@ type defaultValueChange struct {
 
 var _ BreakingChangeRule = defaultValueChange{}
 
-// Check - Checks that an Optional or Required property is not updated to become Computed only
+func (o defaultValueChange) Name() string {
+	return "defaultValueChange"
+}
+
+// Check - Checks that the Default value of an existing property isn't changed, since this changes the value used by existing user configs
 func (o defaultValueChange) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
-	if base.Default != current.Default {
-		return pointer.To(fmt.Sprintf("Cannot change property %q to Computed only", propertyName))
+	if isNewProperty(base) || isRemovedProperty(current) {
+		return nil
+	}
+
+	// the base schema is unmarshalled from JSON, so numbers are float64's rather than the original type
+	if fmt.Sprint(base.Default) != fmt.Sprint(current.Default) {
+		return pointer.To(fmt.Sprintf("Cannot change the Default value for property %q from %v to %v", propertyName, base.Default, current.Default))
 	}
 
 	return nil
@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+type exactlyOneOfChanged struct{}
+
+var _ BreakingChangeRule = exactlyOneOfChanged{}
+
+func (exactlyOneOfChanged) Name() string {
+	return "exactlyOneOfChanged"
+}
+
+// Check - Checks that the ExactlyOneOf for an existing property isn't introduced or changed, since user configs may no longer specify exactly one of these properties
+func (exactlyOneOfChanged) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if isNewProperty(base) || isRemovedProperty(current) || len(current.ExactlyOneOf) == 0 {
+		return nil
+	}
+
+	if fmt.Sprint(sortedStrings(base.ExactlyOneOf)) != fmt.Sprint(sortedStrings(current.ExactlyOneOf)) {
+		return pointer.To(fmt.Sprintf("Cannot change the ExactlyOneOf for property %q from %v to %v", propertyName, base.ExactlyOneOf, current.ExactlyOneOf))
+	}
+
+	return nil
+}
+
+func sortedStrings(input []string) []string {
+	output := append([]string{}, input...)
+	sort.Strings(output)
+	return output
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var exactlyOneOfChangedBase = providerjson.SchemaJSON{
+	Type:         "TypeString",
+	Optional:     true,
+	ExactlyOneOf: []string{"foo", "bar"},
+}
+
+var exactlyOneOfChangedPasses = providerjson.SchemaJSON{
+	Type:         "TypeString",
+	Optional:     true,
+	ExactlyOneOf: []string{"bar", "foo"},
+}
+
+var exactlyOneOfChangedViolates = providerjson.SchemaJSON{
+	Type:         "TypeString",
+	Optional:     true,
+	ExactlyOneOf: []string{"foo", "bar", "baz"}, // violation
+}
+
+func TestExactlyOneOfChanged_Check(t *testing.T) {
+	data := exactlyOneOfChanged{}
+	if res := data.Check(exactlyOneOfChangedBase, exactlyOneOfChangedPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(exactlyOneOfChangedBase, exactlyOneOfChangedViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+	if res := data.Check(providerjson.SchemaJSON{}, exactlyOneOfChangedViolates, ""); res != nil {
+		t.Errorf("expected no violation for a new property, got %+v", res)
+	}
+	if res := data.Check(providerjson.SchemaJSON{Type: "TypeString", Optional: true}, exactlyOneOfChangedPasses, ""); res == nil {
+		t.Errorf("expected violation when introducing ExactlyOneOf, but didn't get one")
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+type maxItemsReduced struct{}
+
+var _ BreakingChangeRule = maxItemsReduced{}
+
+func (maxItemsReduced) Name() string {
+	return "maxItemsReduced"
+}
+
+// Check - Checks that the MaxItems for an existing property isn't reduced (or introduced), since user configs may contain more items
+func (maxItemsReduced) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if isNewProperty(base) || isRemovedProperty(current) || current.MaxItems == 0 {
+		return nil
+	}
+
+	if base.MaxItems == 0 || current.MaxItems < base.MaxItems {
+		return pointer.To(fmt.Sprintf("Cannot reduce the MaxItems for property %q from %d to %d", propertyName, base.MaxItems, current.MaxItems))
+	}
+
+	return nil
+}
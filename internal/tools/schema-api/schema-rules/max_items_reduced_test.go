@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var maxItemsReducedBase = providerjson.SchemaJSON{
+	Type:     "TypeList",
+	Optional: true,
+	MaxItems: 5,
+}
+
+var maxItemsReducedPasses = providerjson.SchemaJSON{
+	Type:     "TypeList",
+	Optional: true,
+	MaxItems: 10,
+}
+
+var maxItemsReducedViolates = providerjson.SchemaJSON{
+	Type:     "TypeList",
+	Optional: true,
+	MaxItems: 2, // violation
+}
+
+func TestMaxItemsReduced_Check(t *testing.T) {
+	data := maxItemsReduced{}
+	if res := data.Check(maxItemsReducedBase, maxItemsReducedPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(maxItemsReducedBase, maxItemsReducedViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+	if res := data.Check(providerjson.SchemaJSON{}, maxItemsReducedViolates, ""); res != nil {
+		t.Errorf("expected no violation for a new property, got %+v", res)
+	}
+	if res := data.Check(providerjson.SchemaJSON{Type: "TypeList", Optional: true}, maxItemsReducedPasses, ""); res == nil {
+		t.Errorf("expected violation when introducing MaxItems, but didn't get one")
+	}
+}
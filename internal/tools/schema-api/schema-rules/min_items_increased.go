@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+type minItemsIncreased struct{}
+
+var _ BreakingChangeRule = minItemsIncreased{}
+
+func (minItemsIncreased) Name() string {
+	return "minItemsIncreased"
+}
+
+// Check - Checks that the MinItems for an existing property isn't increased, since user configs may contain fewer items
+func (minItemsIncreased) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if !isNewProperty(base) && !isRemovedProperty(current) && current.MinItems > base.MinItems {
+		return pointer.To(fmt.Sprintf("Cannot increase the MinItems for property %q from %d to %d", propertyName, base.MinItems, current.MinItems))
+	}
+
+	return nil
+}
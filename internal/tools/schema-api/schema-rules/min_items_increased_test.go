@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var minItemsIncreasedBase = providerjson.SchemaJSON{
+	Type:     "TypeList",
+	Optional: true,
+	MinItems: 2,
+}
+
+var minItemsIncreasedPasses = providerjson.SchemaJSON{
+	Type:     "TypeList",
+	Optional: true,
+	MinItems: 1,
+}
+
+var minItemsIncreasedViolates = providerjson.SchemaJSON{
+	Type:     "TypeList",
+	Optional: true,
+	MinItems: 3, // violation
+}
+
+func TestMinItemsIncreased_Check(t *testing.T) {
+	data := minItemsIncreased{}
+	if res := data.Check(minItemsIncreasedBase, minItemsIncreasedPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(minItemsIncreasedBase, minItemsIncreasedViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+	if res := data.Check(providerjson.SchemaJSON{}, minItemsIncreasedViolates, ""); res != nil {
+		t.Errorf("expected no violation for a new property, got %+v", res)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+type propertyRemoved struct{}
+
+var _ BreakingChangeRule = propertyRemoved{}
+
+func (propertyRemoved) Name() string {
+	return "propertyRemoved"
+}
+
+// Check - Checks that an existing property isn't removed, since it may be used in user configs
+func (propertyRemoved) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if !isNewProperty(base) && isRemovedProperty(current) {
+		return pointer.To(fmt.Sprintf("Cannot remove property %q", propertyName))
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var propertyRemovedBase = providerjson.SchemaJSON{
+	Type:     "TypeString",
+	Optional: true,
+}
+
+var propertyRemovedPasses = providerjson.SchemaJSON{
+	Type:     "TypeString",
+	Optional: true,
+	Computed: true,
+}
+
+var propertyRemovedViolates = providerjson.SchemaJSON{}
+
+func TestPropertyRemoved_Check(t *testing.T) {
+	data := propertyRemoved{}
+	if res := data.Check(propertyRemovedBase, propertyRemovedPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(propertyRemovedBase, propertyRemovedViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+	if res := data.Check(providerjson.SchemaJSON{}, propertyRemovedViolates, ""); res != nil {
+		t.Errorf("expected no violation for a new property, got %+v", res)
+	}
+	if res := data.Check(propertyRemovedBase, providerjson.SchemaJSON{}, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+}
@@ -3,20 +3,46 @@
 
 package schema_rules
 
-import "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+import (
+	"reflect"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
 
 type BreakingChangeRule interface {
+	// Name returns the identifier for this rule, which is used in the machine-readable reports
+	Name() string
+
 	Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string
 }
 
 var BreakingChangeRules = []BreakingChangeRule{
 	becomeComputedOnly{},
+	becomeForceNew{},
+	conflictsWithAdded{},
+	defaultValueChange{},
+	exactlyOneOfChanged{},
+	maxItemsReduced{},
+	minItemsIncreased{},
 	newRequiredPropertyExistingResource{},
 	optionalRemoveComputed{},
 	optionalToRequired{},
+	propertyRemoved{},
 	propertyType{},
 }
 
 var BreakingChangeRulesDataSource = []BreakingChangeRule{
+	dataSourceRemoveComputed{},
+	propertyRemoved{},
 	propertyType{},
 }
+
+// isNewProperty returns whether the base (released) schema doesn't contain the property
+func isNewProperty(base providerjson.SchemaJSON) bool {
+	return reflect.DeepEqual(base, providerjson.SchemaJSON{})
+}
+
+// isRemovedProperty returns whether the current schema doesn't contain the property
+func isRemovedProperty(current providerjson.SchemaJSON) bool {
+	return reflect.DeepEqual(current, providerjson.SchemaJSON{})
+}
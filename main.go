@@ -7,8 +7,10 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
 )
 
@@ -21,18 +23,27 @@ func main() {
 	flag.BoolVar(&debugMode, "debuggable", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
+	ctx := context.Background()
+	providerServer, err := provider.ProtoV5ProviderServerFactory(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serveOpts []tf5server.ServeOpt
 	if debugMode {
-		//nolint:staticcheck
-		err := plugin.Debug(context.Background(), "registry.terraform.io/hashicorp/azurerm",
-			&plugin.ServeOpts{
-				ProviderFunc: provider.AzureProvider,
-			})
-		if err != nil {
-			log.Println(err.Error())
-		}
-	} else {
-		plugin.Serve(&plugin.ServeOpts{
-			ProviderFunc: provider.AzureProvider,
-		})
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
+	}
+
+	err = tf5server.Serve("registry.terraform.io/hashicorp/azurerm", providerServer, serveOpts...)
+
+	// flush any spans which have yet to be exported, now that Terraform has finished with the provider
+	shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	if shutdownErr := common.ShutdownTracing(shutdownCtx); shutdownErr != nil {
+		log.Printf("[DEBUG] Flushing spans: %+v", shutdownErr)
+	}
+	cancel()
+
+	if err != nil {
+		log.Fatal(err)
 	}
 }